@@ -0,0 +1,196 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func newAliasCommand(global *globalOptions) *cobra.Command {
+	aliasCmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage short aliases for context names",
+		Long: `Give long context names short aliases, such as p for production-us-east-1-admin.
+Aliases are kept in the configuration file as "alias => context" lines, or under
+aliases: in a YAML file, and never rename the kubeconfig entry other tools depend on.
+Commands that take a context name, such as switch, show, export and remove, accept
+an alias instead, and shell completion offers them.`,
+		Example: `  kubectx-manager alias set p production-us-east-1-admin
+  kubectx-manager switch p
+  kubectx-manager alias list
+  kubectx-manager alias remove p`,
+		Args: cobra.NoArgs,
+	}
+
+	aliasCmd.AddCommand(newAliasSetCommand(global))
+	aliasCmd.AddCommand(newAliasRemoveCommand(global))
+	aliasCmd.AddCommand(newAliasListCommand(global))
+
+	return aliasCmd
+}
+
+func newAliasSetCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	cmd := &cobra.Command{
+		Use:   "set ALIAS CONTEXT",
+		Short: "Make an alias stand for a context",
+		Long: `Make ALIAS stand for CONTEXT, replacing what it stood for before. An alias cannot
+have the name of a context of the kubeconfig, which it would hide.`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return global.completeContexts(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.setAlias(cmd.OutOrStdout(), args[0], args[1])
+		},
+	}
+	addConfigFlag(cmd, &opts.configFile)
+	return cmd
+}
+
+func newAliasRemoveCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	cmd := &cobra.Command{
+		Use:               "remove ALIAS [ALIAS...]",
+		Short:             "Remove aliases from the configuration file",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeAliases,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.editPatterns(cmd.OutOrStdout(), removeAliasEdit, args)
+		},
+	}
+	addConfigFlag(cmd, &opts.configFile)
+	return cmd
+}
+
+func newAliasListCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the aliases and the contexts they stand for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.listAliases(cmd.OutOrStdout())
+		},
+	}
+	addConfigFlag(cmd, &opts.configFile)
+	return cmd
+}
+
+// aliasResult is the structured form of an alias set run.
+type aliasResult struct {
+	Config  string `json:"config" yaml:"config"`
+	Alias   string `json:"alias" yaml:"alias"`
+	Context string `json:"context" yaml:"context"`
+	// Changed is false when the alias already stood for the context
+	Changed bool `json:"changed" yaml:"changed"`
+}
+
+var removeAliasEdit = patternEdit{command: "alias remove", apply: config.RemoveAlias,
+	changed: "Removed alias '%s' from %s", unchanged: "Alias '%s' is not in %s", warnUnchanged: true}
+
+func (o *configOptions) setAlias(out io.Writer, alias, target string) error {
+	if err := o.requireFormats("alias set", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	cfg, err := o.loadConfigFile(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// Aliases stand for context names, not for other aliases
+	target = cfg.ResolveAlias(target)
+
+	log := o.newLogger()
+	paths, err := o.kubeconfigChain("alias set")
+	if err != nil {
+		return err
+	}
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if multi.Merged.GetContext(alias) != nil {
+		return fmt.Errorf("alias '%s' would hide the context of the same name", alias)
+	}
+	if multi.Merged.GetContext(target) == nil {
+		log.Warnf("Context '%s' is not in the kubeconfig; the alias is set all the same", target)
+	}
+
+	changed, err := config.SetAlias(o.configFile, alias, target)
+	if err != nil {
+		return err
+	}
+	if changed {
+		log.Infof("Alias '%s' now stands for context '%s' in %s", alias, target, o.configFile)
+	} else {
+		log.Infof("Alias '%s' already stands for context '%s' in %s", alias, target, o.configFile)
+	}
+	return o.printResult(out, aliasResult{Config: o.configFile, Alias: alias, Context: target, Changed: changed})
+}
+
+func (o *configOptions) listAliases(out io.Writer) error {
+	if err := o.requireFormats("alias list", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	cfg, err := o.loadConfigFile(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	aliases := cfg.Aliases
+	if aliases == nil {
+		aliases = map[string]string{}
+	}
+	if o.isStructured() {
+		return o.printStructured(out, aliases)
+	}
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	slices.Sort(names)
+	for _, alias := range names {
+		fmt.Fprintf(out, "%s => %s\n", alias, aliases[alias])
+	}
+	return nil
+}
+
+// completeAliases completes the aliases of the configuration file given by
+// --config, described by their context, skipping those already among args.
+func completeAliases(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	flag := cmd.Flags().Lookup("config")
+	if flag == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.Load(flag.Value.String())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	described := make(map[string]string, len(cfg.Aliases))
+	for alias, target := range cfg.Aliases {
+		described[alias] = "alias for " + target
+	}
+	return describedCompletions(described, argSet(args)), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestAliasCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("# keep this\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--config", configPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("alias", "set", "d", "dev"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := run("alias", "set", "p", "prod"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := run("alias", "set", "prod", "dev"); err == nil {
+		t.Error("Expected an error for an alias hiding a context")
+	}
+	if out, _ := run("aliases", "ls"); out != "d => dev\np => prod\n" {
+		t.Errorf("Unexpected aliases %q", out)
+	}
+
+	// Commands resolve the alias; the context keeps its name
+	if _, err := run("switch", "d"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "dev" {
+		t.Errorf("Expected switch to resolve the alias, got %q", kConfig.CurrentContext)
+	}
+	if got := strings.Join(complete(t, "alias", "remove", "--config", configPath, ""), ","); got != "d,p" {
+		t.Errorf("Expected remove to complete the aliases, got %q", got)
+	}
+
+	var result patternEditResult
+	out, err := run("alias", "rm", "p", "missing", "-o", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out)
+	}
+	if strings.Join(result.Changed, ",") != "p" || strings.Join(result.Unchanged, ",") != "missing" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if string(data) != "# keep this\nd => dev\n" {
+		t.Errorf("Unexpected config file %q", data)
+	}
+}
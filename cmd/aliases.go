@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// commandAliases maps the path of each subcommand below the root, such as
+// "backup list", to its short aliases and plural/singular forms. Aliases are
+// kept here instead of on the individual command definitions so the whole CLI
+// vocabulary can be reviewed in one place.
+var commandAliases = map[string][]string{
+	"list":         {"ls"},
+	"remove":       {"rm", "delete"},
+	"copy":         {"cp"},
+	"normalize":    {"sort"},
+	"show":         {"ctx"},
+	"backup":       {"bk", "backups"},
+	"backup list":  {"ls"},
+	"archive":      {"archives"},
+	"archive list": {"ls"},
+	"label":        {"labels"},
+	"alias":        {"aliases"},
+	"alias list":   {"ls"},
+	"alias remove": {"rm", "delete"},
+	"version":      {"ver"},
+}
+
+// applyAliases registers the aliases from commandAliases on the descendants
+// of root. A command only gets the aliases of its own path, so a nested
+// command sharing the name of a top-level one, like backup show, does not
+// inherit them. Aliases already declared on a command are kept.
+func applyAliases(root *cobra.Command) {
+	applyAliasesBelow(root, "")
+}
+
+func applyAliasesBelow(cmd *cobra.Command, prefix string) {
+	for _, sub := range cmd.Commands() {
+		path := prefix + sub.Name()
+		for _, alias := range commandAliases[path] {
+			if !sub.HasAlias(alias) {
+				sub.Aliases = append(sub.Aliases, alias)
+			}
+		}
+		applyAliasesBelow(sub, path+" ")
+	}
+}
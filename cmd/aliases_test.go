@@ -0,0 +1,83 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyAliases(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	backup := &cobra.Command{Use: "backup", Aliases: []string{"bk"}}
+	backup.AddCommand(&cobra.Command{Use: "list"})
+	root.AddCommand(backup)
+
+	applyAliases(root)
+
+	if len(backup.Aliases) != 2 || !backup.HasAlias("bk") || !backup.HasAlias("backups") {
+		t.Errorf("Expected backup aliases [bk backups] without duplicates, got %v", backup.Aliases)
+	}
+
+	cmd, _, err := root.Find([]string{"backups", "ls"})
+	if err != nil {
+		t.Fatalf("Failed to resolve aliased command: %v", err)
+	}
+	if cmd.Name() != "list" {
+		t.Errorf("Expected 'backups ls' to resolve to list, got %s", cmd.Name())
+	}
+}
+
+func TestRootCommandAliases(t *testing.T) {
+	cmd, _, err := NewRootCommand().Find([]string{"ver"})
+	if err != nil {
+		t.Fatalf("Failed to resolve alias: %v", err)
+	}
+	if cmd.Name() != "version" {
+		t.Errorf("Expected 'ver' to resolve to version, got %s", cmd.Name())
+	}
+}
+
+func TestNestedCommandsKeepTheirAliases(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	backup := &cobra.Command{Use: "backup"}
+	show := &cobra.Command{Use: "show", Aliases: []string{"cat"}}
+	backup.AddCommand(show)
+	root.AddCommand(backup, &cobra.Command{Use: "show"})
+
+	applyAliases(root)
+
+	if !slices.Equal(show.Aliases, []string{"cat"}) {
+		t.Errorf("Expected backup show to keep only its declared aliases, got %v", show.Aliases)
+	}
+	if cmd, _, err := NewRootCommand().Find([]string{"backup", "ctx"}); err == nil && cmd.Name() == "show" {
+		t.Errorf("Expected 'backup ctx' not to resolve to backup show")
+	}
+}
+
+func TestCommandAliasTargetsExist(t *testing.T) {
+	root := NewRootCommand()
+	for path, aliases := range commandAliases {
+		words := strings.Fields(path)
+		parents := words[:len(words)-1]
+		for _, word := range append([]string{words[len(words)-1]}, aliases...) {
+			cmd, _, err := root.Find(append(slices.Clone(parents), word))
+			if err != nil || cmd.CommandPath() != root.Name()+" "+path {
+				t.Errorf("Expected %q to resolve to the %s command, got %v (%v)", word, path, cmd.CommandPath(), err)
+			}
+		}
+	}
+}
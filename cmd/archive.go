@@ -0,0 +1,242 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// archiveRestoreOptions holds the flag values for a single invocation of the archive restore command.
+type archiveRestoreOptions struct {
+	*globalOptions
+	configFile  string
+	onDuplicate string
+	keep        bool
+}
+
+func newArchiveCommand(global *globalOptions) *cobra.Command {
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Manage contexts archived by --archive",
+		Long: `Cleanups and remove run with --archive move the removed contexts, with their
+clusters and users, to an archive kubeconfig next to the kubeconfig
+(~/.kube/config.archive). List the archived contexts, or restore them into the
+kubeconfig.`,
+		Args: cobra.NoArgs,
+	}
+
+	archiveCmd.AddCommand(newArchiveListCommand(global))
+	archiveCmd.AddCommand(newArchiveRestoreCommand(global))
+
+	return archiveCmd
+}
+
+func newArchiveListCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the archived contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runArchiveList(global, cmd.OutOrStdout())
+		},
+	}
+}
+
+func runArchiveList(g *globalOptions, out io.Writer) error {
+	if err := g.requireFormats("archive list", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	kubeconfigPath, err := g.singleKubeconfig("archive list")
+	if err != nil {
+		return err
+	}
+	archive, err := kubeconfig.LoadArchive(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", err)
+	}
+
+	entries := buildInventory(archive)
+	if g.isStructured() {
+		return g.printStructured(out, entries)
+	}
+	if len(entries) == 0 {
+		g.newLogger().Infof("No archived contexts for %s", kubeconfigPath)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCLUSTER\tSERVER\tUSER\tNAMESPACE\tAUTH")
+	for i := range entries {
+		e := &entries[i]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Name, e.Cluster, e.Server, e.User, e.Namespace, e.AuthType)
+	}
+	return w.Flush()
+}
+
+func newArchiveRestoreCommand(global *globalOptions) *cobra.Command {
+	opts := &archiveRestoreOptions{globalOptions: global}
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore CONTEXT [CONTEXT...]",
+		Short: "Restore archived contexts into the kubeconfig",
+		Long: `Merge the given archived contexts, with the clusters and users they use, back
+into the kubeconfig and remove them from the archive. Each argument is a
+context name, an alias from the configuration file, or a glob pattern.
+Entries that exist with a different configuration are resolved by
+--on-duplicate, or else interactively (--yes overwrites them).`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: global.completeArchivedContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	restoreCmd.Flags().StringVar(&opts.onDuplicate, "on-duplicate", "",
+		"Resolve entries that exist in the kubeconfig with different configuration by: overwrite, keep, rename or fail")
+	restoreCmd.Flags().BoolVar(&opts.keep, "keep", false, "Keep the restored contexts in the archive")
+	_ = restoreCmd.RegisterFlagCompletionFunc("on-duplicate", completeDuplicateStrategies)
+	addConfigFlag(restoreCmd, &opts.configFile)
+
+	return restoreCmd
+}
+
+// archiveRestoreResult is the structured form of archive restore.
+type archiveRestoreResult struct {
+	Kubeconfig string                  `json:"kubeconfig" yaml:"kubeconfig"`
+	Archive    string                  `json:"archive" yaml:"archive"`
+	Contexts   []string                `json:"contexts" yaml:"contexts"`
+	Report     *kubeconfig.MergeReport `json:"report,omitempty" yaml:"report,omitempty"`
+	Backup     string                  `json:"backup,omitempty" yaml:"backup,omitempty"`
+}
+
+func (o *archiveRestoreOptions) run(out io.Writer, patterns []string) error {
+	log := o.newLogger()
+
+	var strategy kubeconfig.DuplicateStrategy
+	if o.onDuplicate != "" {
+		var err error
+		if strategy, err = kubeconfig.ParseDuplicateStrategy(o.onDuplicate); err != nil {
+			return err
+		}
+	}
+
+	kubeconfigPath, err := o.singleKubeconfig("archive restore")
+	if err != nil {
+		return err
+	}
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	archive, err := kubeconfig.LoadArchive(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", err)
+	}
+	names, _, err := selectContexts(archive, cfg, patterns)
+	if err != nil {
+		return fmt.Errorf("archive: %w", err)
+	}
+	result := archiveRestoreResult{
+		Kubeconfig: kubeconfigPath,
+		Archive:    kubeconfig.ArchivePath(kubeconfigPath),
+		Contexts:   names,
+	}
+	if len(names) == 0 {
+		log.Infof("No archived contexts match %s", strings.Join(patterns, ", "))
+		result.Contexts = []string{}
+		return o.printResult(out, result)
+	}
+
+	current, err := kubeconfig.Load(kubeconfigPath)
+	exists := err == nil
+	if errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		current = &kubeconfig.Config{APIVersion: "v1", Kind: "Config"}
+	} else if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	opts := protectingMergeOptions(o.mergeOptions(strategy), cfg, current, log)
+	report, err := kubeconfig.MergeWithOptions(current, kubeconfig.ExtractContexts(archive, names), opts)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		backupPath, err := o.createBackup(kubeconfigPath, log)
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Debugf("Created backup at: %s", backupPath)
+		result.Backup = backupPath
+	}
+	if err := kubeconfig.Save(current, kubeconfigPath); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:      "Restore archived contexts " + listSubject(names),
+		Kubeconfigs:  []string{kubeconfigPath},
+		Restored:     names,
+		RestoredFrom: result.Archive,
+		Backups:      nonEmpty(result.Backup),
+	}, log)
+	logMergeReport(report, log)
+	result.Report = report
+
+	if !o.keep {
+		if err := kubeconfig.RemoveContexts(archive, names); err != nil {
+			return err
+		}
+		// The archive empties as its contexts are restored, that is no shrink to guard against
+		if err := kubeconfig.SaveForce(archive, kubeconfig.ArchivePath(kubeconfigPath)); err != nil {
+			return fmt.Errorf("failed to update archive: %w", err)
+		}
+	}
+	log.Infof("Restored %d context(s) from %s", len(names), result.Archive)
+	return o.printResult(out, result)
+}
+
+// archiveContexts adds the named contexts of multi, which are about to be
+// removed, to the archive of the kubeconfig file that defines each.
+func archiveContexts(multi *kubeconfig.MultiConfig, names []string, log *logger.Logger) error {
+	byFile := make(map[string][]string)
+	var paths []string
+	for _, name := range names {
+		path := multi.SourceOf(name)
+		if _, ok := byFile[path]; !ok {
+			paths = append(paths, path)
+		}
+		byFile[path] = append(byFile[path], name)
+	}
+	for _, path := range paths {
+		if err := kubeconfig.ArchiveContexts(multi.Merged, byFile[path], path); err != nil {
+			return fmt.Errorf("failed to archive contexts: %w", err)
+		}
+		log.Infof("Archived %d context(s) to %s", len(byFile[path]), kubeconfig.ArchivePath(path))
+	}
+	return nil
+}
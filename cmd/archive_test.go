@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("pr*\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	run := func(out io.Writer, args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(out)
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath))
+		return root.Execute()
+	}
+	archivedNames := func() []string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := run(&out, "archive", "list", "-o", "json"); err != nil {
+			t.Fatalf("archive list failed: %v", err)
+		}
+		var entries []contextEntry
+		if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+			t.Fatalf("Invalid JSON %q: %v", out.String(), err)
+		}
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	if names := archivedNames(); len(names) != 0 {
+		t.Fatalf("Expected an empty archive, got %v", names)
+	}
+
+	// A cleanup with --archive moves dev, with its cluster and user, to the archive
+	if err := run(io.Discard, "--archive", "--config", configPath); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	archive, err := kubeconfig.Load(kubeconfig.ArchivePath(kubeconfigPath))
+	if err != nil {
+		t.Fatalf("Failed to load archive: %v", err)
+	}
+	if archive.GetContext("dev") == nil || archive.GetCluster("dev-cluster") == nil || archive.GetUser("dev-user") == nil {
+		t.Fatalf("Expected dev with its cluster and user in the archive, got %+v", archive)
+	}
+	if current, _ := kubeconfig.Load(kubeconfigPath); current.GetContext("dev") != nil {
+		t.Fatal("Expected dev to be removed from the kubeconfig")
+	}
+
+	// Restoring brings it back and empties the archive
+	if err := run(io.Discard, "archive", "restore", "dev", "--yes", "--config", configPath); err != nil {
+		t.Fatalf("archive restore failed: %v", err)
+	}
+	current, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if current.GetContext("dev") == nil || current.GetCluster("dev-cluster") == nil || current.GetUser("dev-user") == nil {
+		t.Errorf("Expected dev restored with its cluster and user, got %+v", current)
+	}
+	if names := archivedNames(); len(names) != 0 {
+		t.Errorf("Expected the restored context to leave the archive, got %v", names)
+	}
+
+	// remove --archive archives too; --keep leaves restored contexts archived
+	if err := run(io.Discard, "remove", "dev", "--archive", "--yes", "--config", configPath); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if err := run(io.Discard, "archive", "restore", "d*", "--keep", "--yes", "--config", configPath); err != nil {
+		t.Fatalf("archive restore failed: %v", err)
+	}
+	if names := archivedNames(); len(names) != 1 || names[0] != "dev" {
+		t.Errorf("Expected dev to stay archived with --keep, got %v", names)
+	}
+
+	if err := run(io.Discard, "archive", "restore", "missing", "--yes", "--config", configPath); err == nil {
+		t.Error("Expected restoring a context that is not archived to fail")
+	}
+	if err := run(io.Discard, "--archive", "--config", configPath, "--output-file", filepath.Join(tmpDir, "out")); err == nil {
+		t.Error("Expected --archive with --output-file to be rejected")
+	}
+}
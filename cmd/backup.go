@@ -0,0 +1,525 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/gitbackup"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// backupEntry is the structured form of a backup in backup list and backup show.
+type backupEntry struct {
+	Time time.Time `json:"time" yaml:"time"`
+	// Contexts is unset when the backup is encrypted or cannot be read as a kubeconfig
+	Contexts  *int   `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	Name      string `json:"name" yaml:"name"`
+	Path      string `json:"path" yaml:"path"`
+	Size      int64  `json:"size" yaml:"size"`
+	Encrypted bool   `json:"encrypted" yaml:"encrypted"`
+	// Remote is set for backups only stored on --backup-remote
+	Remote bool `json:"remote" yaml:"remote"`
+	// Manifest is unset for backups written without one
+	Manifest *kubeconfig.Manifest `json:"manifest,omitempty" yaml:"manifest,omitempty"`
+}
+
+// backupContents is the structured form of backup show.
+type backupContents struct {
+	backupEntry    `yaml:",inline"`
+	CurrentContext string   `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+	ContextNames   []string `json:"contextNames" yaml:"contextNames"`
+	Clusters       []string `json:"clusters" yaml:"clusters"`
+	Users          []string `json:"users" yaml:"users"`
+}
+
+// backupChange is the structured form of backup create, delete and prune.
+type backupChange struct {
+	// Backups lists the paths of the backups created, deleted or (with DryRun) to be pruned
+	Backups  []string `json:"backups" yaml:"backups"`
+	DryRun   bool     `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+	Canceled bool     `json:"canceled,omitempty" yaml:"canceled,omitempty"`
+}
+
+func newBackupCommand(global *globalOptions) *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Manage kubeconfig backups",
+		Long: `Manage the backups kubectx-manager creates before it modifies a kubeconfig:
+create one on demand, list and inspect them, and delete or prune old ones.`,
+		Args: cobra.NoArgs,
+	}
+
+	backupCmd.AddCommand(newBackupCreateCommand(global))
+	backupCmd.AddCommand(newBackupListCommand(global))
+	backupCmd.AddCommand(newBackupShowCommand(global))
+	backupCmd.AddCommand(newBackupDeleteCommand(global))
+	backupCmd.AddCommand(newBackupPruneCommand(global))
+	backupCmd.AddCommand(newBackupLogCommand(global))
+
+	return backupCmd
+}
+
+func newBackupCreateCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Back up the kubeconfig now",
+		Long: `Create a timestamped backup of each kubeconfig selected by --kubeconfig,
+applying --backup-retention afterwards as automatic backups do.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackupCreate(global, cmd.OutOrStdout())
+		},
+	}
+}
+
+func runBackupCreate(g *globalOptions, out io.Writer) error {
+	log := g.newLogger()
+
+	paths, err := g.kubeconfigPaths()
+	if err != nil {
+		return err
+	}
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return err
+	}
+	defer g.unlock(locks)
+
+	result := backupChange{Backups: make([]string, 0, len(paths))}
+	for _, path := range paths {
+		backupPath, err := g.createBackup(path, log)
+		if err != nil {
+			return fmt.Errorf("failed to create backup of %s: %w", path, err)
+		}
+		log.Infof("Created backup at: %s", backupPath)
+		result.Backups = append(result.Backups, backupPath)
+	}
+	return g.printResult(out, result)
+}
+
+func newBackupListCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the backups of the kubeconfig",
+		Long: `List the backups of the kubeconfig, newest first, with their creation time,
+size and number of contexts, and the command that triggered them as recorded
+in their manifests. Encrypted backups are flagged, and their contexts are
+not counted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackupList(global, cmd.OutOrStdout())
+		},
+	}
+}
+
+func runBackupList(g *globalOptions, out io.Writer) error {
+	if err := g.requireFormats("backup list", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	kubeconfigPath, err := g.singleKubeconfig("backup list")
+	if err != nil {
+		return err
+	}
+	backups, err := g.findBackups(kubeconfigPath, g.newLogger())
+	if err != nil {
+		return err
+	}
+
+	entries := make([]backupEntry, 0, len(backups))
+	for _, backup := range backups {
+		entry := newBackupEntry(backup)
+		if backup.Encrypted || backup.Remote {
+			entries = append(entries, entry)
+			continue
+		}
+		if backupConfig, err := kubeconfig.Load(backup.Path); err == nil {
+			count := len(backupConfig.Contexts)
+			entry.Contexts = &count
+		}
+		entries = append(entries, entry)
+	}
+
+	if g.isStructured() {
+		return g.printStructured(out, entries)
+	}
+	if len(entries) == 0 {
+		g.newLogger().Infof("No backups found for %s", kubeconfigPath)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCREATED\tSIZE\tCONTEXTS\tENCRYPTED\tLOCATION\tREMOVED\tCOMMAND")
+	for i := range entries {
+		e := &entries[i]
+		contexts, encrypted, location := "unreadable", "no", "local"
+		if e.Encrypted {
+			encrypted = "yes"
+		}
+		if e.Remote {
+			location = "remote"
+		}
+		switch {
+		case e.Encrypted || e.Remote:
+			contexts = "-"
+		case e.Contexts != nil:
+			contexts = fmt.Sprintf("%d", *e.Contexts)
+		}
+		removed, command := "-", "-"
+		if e.Manifest != nil {
+			removed, command = fmt.Sprintf("%d", len(e.Manifest.RemovedContexts)), commandLine(e.Manifest)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Name, backups[i].TimeStr, formatSize(e.Size), contexts, encrypted, location, removed, command)
+	}
+	return w.Flush()
+}
+
+func newBackupShowCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the contents of a backup",
+		Long: `Show the contexts, clusters and users stored in a backup, and what its
+manifest records about the command that triggered it.
+The backup is named as in backup list, or given by its path.
+Encrypted backups are decrypted with --backup-identity or a passphrase.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeFirstArgs(1, global.completeBackups),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupShow(global, cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func runBackupShow(g *globalOptions, out io.Writer, name string) error {
+	if err := g.requireFormats("backup show", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	kubeconfigPath, err := g.singleKubeconfig("backup show")
+	if err != nil {
+		return err
+	}
+	log := g.newLogger()
+	backup, err := g.findBackup(kubeconfigPath, name, log)
+	if err != nil {
+		return err
+	}
+	if err := g.pullBackup(kubeconfigPath, &backup, log); err != nil {
+		return fmt.Errorf("failed to download backup: %w", err)
+	}
+	dec, err := g.backupDecryption(backup)
+	if err != nil {
+		return err
+	}
+	backupConfig, err := loadBackup(backup.Path, dec)
+	if err != nil {
+		return err
+	}
+
+	if !g.isStructured() {
+		printBackupSummary(out, backup, backupConfig)
+		return nil
+	}
+
+	contents := backupContents{
+		backupEntry:    newBackupEntry(backup),
+		CurrentContext: backupConfig.CurrentContext,
+		ContextNames:   backupConfig.GetContextNames(),
+		Clusters:       make([]string, 0, len(backupConfig.Clusters)),
+		Users:          make([]string, 0, len(backupConfig.Users)),
+	}
+	count := len(backupConfig.Contexts)
+	contents.Contexts = &count
+	for _, cluster := range backupConfig.Clusters {
+		contents.Clusters = append(contents.Clusters, cluster.Name)
+	}
+	for _, user := range backupConfig.Users {
+		contents.Users = append(contents.Users, user.Name)
+	}
+	return g.printStructured(out, contents)
+}
+
+func newBackupDeleteCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>...",
+		Short: "Delete backups",
+		Long: `Delete the named backups of the kubeconfig, named as in backup list or given by path.
+The deletion is confirmed interactively unless --yes is given.`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: global.completeBackups,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupDelete(global, cmd.OutOrStdout(), args)
+		},
+	}
+}
+
+func runBackupDelete(g *globalOptions, out io.Writer, names []string) error {
+	log := g.newLogger()
+
+	kubeconfigPath, err := g.singleKubeconfig("backup delete")
+	if err != nil {
+		return err
+	}
+
+	// Resolve every name first, so a typo deletes nothing
+	backups := make([]kubeconfig.Backup, 0, len(names))
+	for _, name := range names {
+		backup, err := g.findBackup(kubeconfigPath, name, log)
+		if err != nil {
+			return err
+		}
+		if backup.Remote {
+			return fmt.Errorf("backup %s is only stored on %s; delete it from the bucket", backup.Name, g.backupRemote)
+		}
+		backups = append(backups, backup)
+	}
+
+	result := backupChange{Backups: make([]string, 0, len(backups))}
+	confirmed, err := confirmBackupDeletion(g.prompter(), len(backups))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		log.Infof("Operation canceled by user")
+		g.setExitCode(exitCodeCanceled)
+		result.Canceled = true
+		return g.printResult(out, result)
+	}
+
+	for _, backup := range backups {
+		if err := kubeconfig.RemoveBackup(backup.Path); err != nil {
+			return fmt.Errorf("failed to delete backup: %w", err)
+		}
+		log.Infof("Deleted backup %s", backup.Name)
+		result.Backups = append(result.Backups, backup.Path)
+	}
+	g.setExitCode(exitCodeChanged)
+	return g.printResult(out, result)
+}
+
+// backupPruneOptions holds the flag values for a single invocation of the backup prune command.
+type backupPruneOptions struct {
+	*globalOptions
+	dryRun bool
+}
+
+func newBackupPruneCommand(global *globalOptions) *cobra.Command {
+	opts := &backupPruneOptions{globalOptions: global}
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove backups beyond the retention policy",
+		Long: `Remove the backups of the kubeconfig that --backup-retention does not keep:
+those beyond the newest count, those older than the age, or both.
+
+Examples:
+  kubectx-manager backup prune --backup-retention 10
+  kubectx-manager backup prune --backup-retention 10,30d --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	pruneCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show which backups would be removed without removing them")
+
+	return pruneCmd
+}
+
+func (o *backupPruneOptions) run(out io.Writer) error {
+	log := o.newLogger()
+
+	if o.retention.IsZero() {
+		return errors.New("backup prune needs a retention policy: pass --backup-retention, e.g. 10, 30d or 10,30d")
+	}
+	kubeconfigPath, err := o.singleKubeconfig("backup prune")
+	if err != nil {
+		return err
+	}
+
+	if o.dryRun {
+		backups, err := kubeconfig.FindBackups(kubeconfigPath, o.backupDir)
+		if err != nil {
+			return fmt.Errorf("failed to find backups: %w", err)
+		}
+		expired := o.retention.Expired(backups, time.Now())
+		result := backupChange{Backups: make([]string, 0, len(expired)), DryRun: true}
+		for _, backup := range expired {
+			log.Infof("Would remove %s (%s)", backup.Path, backup.TimeStr)
+			result.Backups = append(result.Backups, backup.Path)
+		}
+		log.Infof("Dry run mode - %d of %d backup(s) would be removed", len(expired), len(backups))
+		if len(expired) > 0 {
+			o.setExitCode(exitCodeChanged)
+		}
+		return o.printResult(out, result)
+	}
+
+	removed, err := kubeconfig.PruneBackups(kubeconfigPath, o.backupDir, o.retention)
+	result := backupChange{Backups: make([]string, 0, len(removed))}
+	for _, backup := range removed {
+		log.Infof("Removed %s (%s)", backup.Path, backup.TimeStr)
+		result.Backups = append(result.Backups, backup.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prune backups: %w", err)
+	}
+	log.Infof("Removed %d backup(s)", len(removed))
+	if len(removed) > 0 {
+		o.setExitCode(exitCodeChanged)
+	}
+	return o.printResult(out, result)
+}
+
+func newBackupLogCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "log",
+		Short: "List the commits of the kubeconfig in --backup-git",
+		Long: `List the commits that changed the kubeconfig in the --backup-git repository,
+newest first. Restore any of them with restore --git-revision.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackupLog(global, cmd.OutOrStdout())
+		},
+	}
+}
+
+func runBackupLog(g *globalOptions, out io.Writer) error {
+	if err := g.requireFormats("backup log", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	kubeconfigPath, err := g.singleKubeconfig("backup log")
+	if err != nil {
+		return err
+	}
+	repo, err := g.gitRepository()
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return errors.New("backup log requires --backup-git")
+	}
+	commits, err := repo.Log(kubeconfig.BackupSource(kubeconfigPath))
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if g.isStructured() {
+		if commits == nil {
+			commits = []gitbackup.Commit{}
+		}
+		return g.printStructured(out, commits)
+	}
+	if len(commits) == 0 {
+		g.newLogger().Infof("No commits of %s in %s", kubeconfigPath, repo.Dir)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMIT\tDATE\tMESSAGE")
+	for _, commit := range commits {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", commit.ShortHash(), commit.Time.Format("2006-01-02 15:04:05"), commit.Subject)
+	}
+	return w.Flush()
+}
+
+// findBackup returns the backup of the kubeconfig with the given name, as
+// shown by backup list, or path.
+func (g *globalOptions) findBackup(kubeconfigPath, name string, log *logger.Logger) (kubeconfig.Backup, error) {
+	backups, err := g.findBackups(kubeconfigPath, log)
+	if err != nil {
+		return kubeconfig.Backup{}, err
+	}
+	for _, backup := range backups {
+		if backup.Name == name || backup.Path == name {
+			return backup, nil
+		}
+	}
+	return kubeconfig.Backup{}, fmt.Errorf("no backup named %q for %s (see backup list)", name, kubeconfigPath)
+}
+
+func newBackupEntry(backup kubeconfig.Backup) backupEntry {
+	entry := backupEntry{Name: backup.Name, Path: backup.Path, Time: backup.Time, Size: backup.Size, Encrypted: backup.Encrypted, Remote: backup.Remote}
+	if manifest, err := kubeconfig.ReadManifest(backup.Path); err == nil {
+		entry.Manifest = manifest
+	}
+	return entry
+}
+
+// commandLine renders the command line recorded in a manifest, flags sorted by name.
+func commandLine(manifest *kubeconfig.Manifest) string {
+	parts := append([]string{manifest.Command}, manifest.Args...)
+	names := make([]string, 0, len(manifest.Flags))
+	for name := range manifest.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, "--"+name+"="+manifest.Flags[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+// printManifest writes what a backup's manifest records to out.
+func printManifest(out io.Writer, manifest *kubeconfig.Manifest) {
+	fmt.Fprintf(out, "Triggered by: %s\n", commandLine(manifest))
+	if len(manifest.RemovedContexts) > 0 {
+		fmt.Fprintf(out, "Removed contexts (%d):\n", len(manifest.RemovedContexts))
+		for _, name := range manifest.RemovedContexts {
+			line := name
+			if status, ok := manifest.AuthResults[name]; ok && status.Reason != "" {
+				line += ": " + status.Reason
+			}
+			fmt.Fprintf(out, "   %s\n", line)
+		}
+	}
+	if len(manifest.MatchedPatterns) > 0 {
+		patterns := make([]string, 0, len(manifest.MatchedPatterns))
+		for pattern := range manifest.MatchedPatterns {
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+		fmt.Fprintf(out, "Matched patterns (%d):\n", len(patterns))
+		for _, pattern := range patterns {
+			fmt.Fprintf(out, "   %s: %s\n", pattern, strings.Join(manifest.MatchedPatterns[pattern], ", "))
+		}
+	}
+}
+
+// formatSize renders a byte count for humans, e.g. 1.5 KiB.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KiB", "MiB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f GiB", value)
+}
+
+func confirmBackupDeletion(p Prompter, count int) (bool, error) {
+	return p.Confirm(fmt.Sprintf("Are you sure you want to delete %d backup(s)? (y/N): ", count))
+}
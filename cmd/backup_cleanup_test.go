@@ -77,16 +77,16 @@ func TestBackupCleanupAfterRestore(t *testing.T) {
 				t.Fatalf("Backup file should exist before restore")
 			}
 
-			selectedBackup := Backup{
+			selectedBackup := kubeconfig.Backup{
 				Name: filepath.Base(testBackupPath),
 				Path: testBackupPath,
 			}
 
-			log := logger.New(false, true) // quiet logger
+			log := logger.New(logger.Options{Level: logger.LevelError}) // quiet logger
 
 			// Test the backup cleanup logic by simulating the end of runRestore
 			// First restore the backup
-			err = restoreFromBackup(selectedBackup.Path, kubeconfigPath)
+			err = kubeconfig.RestoreBackup(selectedBackup.Path, kubeconfigPath)
 			if err != nil {
 				t.Fatalf("Failed to restore from backup: %v", err)
 			}
@@ -124,7 +124,7 @@ func TestBackupCleanupErrorHandling(t *testing.T) {
 	tmpDir := t.TempDir()
 	nonExistentBackup := filepath.Join(tmpDir, "nonexistent.backup")
 
-	log := logger.New(false, true) // quiet logger
+	log := logger.New(logger.Options{Level: logger.LevelError}) // quiet logger
 
 	// Test removing a non-existent backup (should not panic)
 	err := os.Remove(nonExistentBackup)
@@ -139,7 +139,7 @@ func TestBackupCleanupErrorHandling(t *testing.T) {
 func TestKeepBackupFlagFunctionality(t *testing.T) {
 	// Test that the flag is properly initialized
 	// Smoke test for flag existence and functionality
-	cmd := restoreCmd
+	cmd := newRestoreCommand(&globalOptions{})
 	flag := cmd.Flags().Lookup("keep-backup")
 
 	if flag == nil {
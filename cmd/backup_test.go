@@ -0,0 +1,279 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// writeOldBackups writes count backups of the kubeconfig, an hour apart and at least a day old.
+func writeOldBackups(t *testing.T, kubeconfigPath string, count int) {
+	t.Helper()
+	folder, err := kubeconfig.CreateBackupFolder(kubeconfigPath, kubeconfig.DefaultBackupDir())
+	if err != nil {
+		t.Fatalf("Failed to create backup folder: %v", err)
+	}
+	start := time.Now().Add(-24 * time.Hour)
+	for i := 0; i < count; i++ {
+		name := filepath.Base(kubeconfigPath) + ".backup." + start.Add(-time.Duration(i)*time.Hour).Format(kubeconfig.BackupTimeFormat)
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(listTestKubeconfig), 0600); err != nil {
+			t.Fatalf("Failed to write backup: %v", err)
+		}
+	}
+}
+
+func TestBackupRetentionAppliedAfterBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	writeOldBackups(t, kubeconfigPath, 3)
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"remove", "dev", "--yes", "-q", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(tmpDir, "ignore"), "--backup-retention", "2"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
+	if err != nil || len(backups) != 2 {
+		t.Fatalf("Expected 2 backups to be kept, got %d (err %v)", len(backups), err)
+	}
+	if time.Since(backups[0].Time) > time.Hour {
+		t.Errorf("Expected the new backup to be kept, got %s", backups[0].TimeStr)
+	}
+}
+
+func TestBackupPruneCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	writeOldBackups(t, kubeconfigPath, 3)
+
+	countBackups := func() int {
+		backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
+		return len(backups)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"backup", "prune", "-q", "--kubeconfig", kubeconfigPath, "--backup-retention", "1", "--dry-run"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := countBackups(); n != 3 {
+		t.Errorf("Expected dry run to keep all 3 backups, got %d", n)
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"bk", "prune", "-q", "--kubeconfig", kubeconfigPath, "--backup-retention", "1"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := countBackups(); n != 1 {
+		t.Errorf("Expected 1 backup to be kept, got %d", n)
+	}
+
+	// Without a policy there is nothing to prune by
+	root = NewRootCommand()
+	root.SetArgs([]string{"backup", "prune", "-q", "--kubeconfig", kubeconfigPath})
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	if err := root.Execute(); err == nil {
+		t.Error("Expected an error without --backup-retention")
+	}
+}
+
+func TestBackupCreateListShowDelete(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("backup", "create"); err != nil {
+		t.Fatalf("backup create failed: %v", err)
+	}
+
+	out, err := run("backup", "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("backup list failed: %v", err)
+	}
+	var entries []backupEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", out, err)
+	}
+	if len(entries) != 1 || entries[0].Contexts == nil || *entries[0].Contexts != 2 || entries[0].Size != int64(len(listTestKubeconfig)) {
+		t.Fatalf("Expected one backup with 2 contexts, got %+v", entries)
+	}
+	name := entries[0].Name
+
+	out, err = run("backup", "show", name)
+	if err != nil {
+		t.Fatalf("backup show failed: %v", err)
+	}
+	if !strings.Contains(out, "Contexts (2):") || !strings.Contains(out, "prod") {
+		t.Errorf("Expected the backup's contexts, got:\n%s", out)
+	}
+
+	if _, err := run("backup", "delete", "missing", name, "--yes"); err == nil {
+		t.Error("Expected an unknown backup name to be rejected")
+	}
+	if _, err := run("backup", "delete", name, "--yes"); err != nil {
+		t.Fatalf("backup delete failed: %v", err)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 0 {
+		t.Errorf("Expected the backup to be deleted, got %d", len(backups))
+	}
+}
+
+func TestBackupCreateEncrypted(t *testing.T) {
+	t.Setenv(backupPassphraseEnv, "correct horse")
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("backup", "create", "--encrypt-backups"); err != nil {
+		t.Fatalf("backup create failed: %v", err)
+	}
+
+	out, err := run("backup", "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("backup list failed: %v", err)
+	}
+	var entries []backupEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", out, err)
+	}
+	if len(entries) != 1 || !entries[0].Encrypted || entries[0].Contexts != nil || !strings.HasSuffix(entries[0].Name, kubeconfig.PassphraseSuffix) {
+		t.Fatalf("Expected one encrypted backup, got %+v", entries)
+	}
+
+	out, err = run("backup", "show", entries[0].Name)
+	if err != nil {
+		t.Fatalf("backup show failed: %v", err)
+	}
+	if !strings.Contains(out, "Contexts (2):") {
+		t.Errorf("Expected the decrypted backup's contexts, got:\n%s", out)
+	}
+
+	if _, err := run("backup", "create", "--backup-recipient", "age1invalid"); err == nil {
+		t.Error("Expected --backup-recipient without --encrypt-backups to be rejected")
+	}
+}
+
+func TestBackupManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("pr*\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	// A cleanup records the removed contexts and the patterns that kept the others
+	root := NewRootCommand()
+	root.SetArgs([]string{"-q", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	root = NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"backup", "list", "-o", "json", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("backup list failed: %v", err)
+	}
+	var entries []backupEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", out.String(), err)
+	}
+	if len(entries) != 1 || entries[0].Manifest == nil {
+		t.Fatalf("Expected one backup with a manifest, got %+v", entries)
+	}
+	manifest := entries[0].Manifest
+	if manifest.Command != "kubectx-manager" || manifest.Flags["config"] != configPath || manifest.Kubeconfig != kubeconfigPath {
+		t.Errorf("Expected the command line to be recorded, got %+v", manifest)
+	}
+	if len(manifest.RemovedContexts) != 1 || manifest.RemovedContexts[0] != "dev" {
+		t.Errorf("Expected dev to be recorded as removed, got %v", manifest.RemovedContexts)
+	}
+	if kept := manifest.MatchedPatterns["pr*"]; len(kept) != 1 || kept[0] != "prod" {
+		t.Errorf("Expected pr* to be recorded as keeping prod, got %v", manifest.MatchedPatterns)
+	}
+
+	out.Reset()
+	root = NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"backup", "show", entries[0].Name, "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("backup show failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Triggered by: kubectx-manager --config=") || !strings.Contains(out.String(), "Removed contexts (1):") {
+		t.Errorf("Expected the manifest in backup show, got:\n%s", out.String())
+	}
+
+	// Deleting the backup deletes its manifest
+	root = NewRootCommand()
+	root.SetArgs([]string{"backup", "delete", entries[0].Name, "--yes", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("backup delete failed: %v", err)
+	}
+	if _, err := os.Stat(kubeconfig.ManifestPath(entries[0].Path)); !os.IsNotExist(err) {
+		t.Errorf("Expected the manifest to be deleted, got %v", err)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for size, want := range map[int64]string{512: "512 B", 1536: "1.5 KiB", 3 << 20: "3.0 MiB"} {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
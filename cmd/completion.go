@@ -0,0 +1,228 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// The completion command itself is provided by cobra; the functions here
+// complete the arguments of the other commands from the kubeconfig selected by
+// the flags already on the command line, read at completion time. Completion
+// must never print errors into the shell, so failures just complete nothing.
+
+// completeFunc is the signature of cobra's dynamic completion functions.
+type completeFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeFirstArgs limits completion to the first n arguments of a command.
+func completeFirstArgs(n int, complete completeFunc) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completeContexts completes the names of the contexts in the kubeconfig,
+// described by their cluster, and the aliases of the configuration file given
+// by --config, skipping those already among args.
+func (g *globalOptions) completeContexts(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	chains, err := g.kubeconfigChains()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	given := argSet(args)
+
+	described := make(map[string]string)
+	for _, chain := range chains {
+		multi, err := kubeconfig.LoadMulti(chain)
+		if err != nil {
+			continue
+		}
+		for _, ctx := range multi.Merged.Contexts {
+			described[ctx.Name] = contextCluster(ctx)
+		}
+	}
+	if flag := cmd.Flags().Lookup("config"); flag != nil {
+		if cfg, err := config.Load(flag.Value.String()); err == nil {
+			for alias, target := range cfg.Aliases {
+				if _, ok := described[alias]; !ok {
+					described[alias] = "alias for " + target
+				}
+			}
+		}
+	}
+	return describedCompletions(described, given), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeArchivedContexts completes the names of the contexts in the
+// archive of the kubeconfig, skipping those already among args.
+func (g *globalOptions) completeArchivedContexts(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	path, err := g.singleKubeconfig("completion")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	archive, err := kubeconfig.LoadArchive(path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	given := argSet(args)
+	described := make(map[string]string, len(archive.Contexts))
+	for _, ctx := range archive.Contexts {
+		described[ctx.Name] = contextCluster(ctx)
+	}
+	return describedCompletions(described, given), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePatterns completes the whitelist patterns of the configuration
+// file given by --config, skipping those already among args.
+func completePatterns(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if cmd.Name() != "remove-pattern" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeConfigList(cmd, args, func(cfg *config.Config) []string { return cfg.Whitelist })
+}
+
+// completeProtected completes the protect patterns of the configuration
+// file given by --config, skipping those already among args.
+func completeProtected(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return completeConfigList(cmd, args, func(cfg *config.Config) []string { return cfg.Protect })
+}
+
+// completeConfigList completes the entries that list returns from the
+// configuration file given by --config, skipping those already among args.
+func completeConfigList(cmd *cobra.Command, args []string, list func(cfg *config.Config) []string) ([]string, cobra.ShellCompDirective) {
+	flag := cmd.Flags().Lookup("config")
+	if flag == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.Load(flag.Value.String())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	described := make(map[string]string)
+	for _, entry := range list(cfg) {
+		described[entry] = ""
+	}
+	return describedCompletions(described, argSet(args)), cobra.ShellCompDirectiveNoFileComp
+}
+
+// contextCluster returns the cluster of ctx, if it has one.
+func contextCluster(ctx kubeconfig.NamedContext) string {
+	if ctx.Context == nil {
+		return ""
+	}
+	return ctx.Context.Cluster
+}
+
+// completeBackups completes the names of the backups of the kubeconfig,
+// described by when they were taken, skipping those already among args.
+// Only backups on disk are offered; listing --backup-remote would make
+// every tab press a network round trip.
+func (g *globalOptions) completeBackups(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	backups, err := g.localBackups()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	given := argSet(args)
+	completions := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		if !given[backup.Name] {
+			completions = append(completions, backup.Name+"\t"+backup.TimeStr)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBackupPaths completes the paths of the backups of the kubeconfig,
+// newest first, for flags that take a backup file. Other files can still be
+// completed by the shell.
+func (g *globalOptions) completeBackupPaths(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	backups, err := g.localBackups()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	completions := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		completions = append(completions, backup.Path+"\t"+backup.TimeStr)
+	}
+	return completions, cobra.ShellCompDirectiveDefault
+}
+
+// localBackups lists the backups on disk of the kubeconfig given by --kubeconfig.
+func (g *globalOptions) localBackups() ([]kubeconfig.Backup, error) {
+	path, err := g.singleKubeconfig("completion")
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfig.FindBackups(path, g.backupDir)
+}
+
+// completeValues completes a flag from a fixed list of values.
+func completeValues(values ...string) completeFunc {
+	return func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeDuplicateStrategies completes --on-duplicate.
+var completeDuplicateStrategies = completeValues(
+	string(kubeconfig.DuplicateOverwrite),
+	string(kubeconfig.DuplicateKeep),
+	string(kubeconfig.DuplicateRename),
+	string(kubeconfig.DuplicateFail),
+)
+
+// completeDuplicatePolicies completes --in-file-duplicates.
+var completeDuplicatePolicies = completeValues(
+	string(kubeconfig.DuplicatesError),
+	string(kubeconfig.DuplicatesFirst),
+	string(kubeconfig.DuplicatesLast),
+	string(kubeconfig.DuplicatesRename),
+)
+
+// argSet returns the arguments already on the command line, which are not
+// offered again.
+func argSet(args []string) map[string]bool {
+	set := make(map[string]bool, len(args))
+	for _, arg := range args {
+		set[arg] = true
+	}
+	return set
+}
+
+// describedCompletions returns the names in described not in given, sorted,
+// each with its description as cobra expects them.
+func describedCompletions(described map[string]string, given map[string]bool) []string {
+	names := make([]string, 0, len(described))
+	for name := range described {
+		if !given[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	completions := make([]string, len(names))
+	for i, name := range names {
+		completions[i] = name
+		if described[name] != "" {
+			completions[i] += "\t" + described[name]
+		}
+	}
+	return completions
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// complete asks the root command for the completions of args, as the shell
+// scripts do, and returns the candidates without their descriptions.
+func complete(t *testing.T, args ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"__complete"}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+
+	var candidates []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		// The last line is the directive
+		if strings.HasPrefix(line, ":") {
+			break
+		}
+		name, _, _ := strings.Cut(line, "\t")
+		candidates = append(candidates, name)
+	}
+	return candidates
+}
+
+func writeCompletionKubeconfig(t *testing.T) (string, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("p => prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	return kubeconfigPath, configPath
+}
+
+func TestCompleteContexts(t *testing.T) {
+	kubeconfigPath, configPath := writeCompletionKubeconfig(t)
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"switch", "--kubeconfig", kubeconfigPath, "--config", configPath, ""}, "dev,p,prod"},
+		{[]string{"switch", "--kubeconfig", kubeconfigPath, "dev", ""}, ""},
+		{[]string{"delete", "--kubeconfig", kubeconfigPath, "dev", ""}, "prod"},
+		{[]string{"rename", "--kubeconfig", kubeconfigPath, ""}, "dev,prod"},
+		{[]string{"rename", "--kubeconfig", kubeconfigPath, "dev", ""}, ""},
+		{[]string{"switch", "--kubeconfig", filepath.Join(t.TempDir(), "missing"), ""}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(complete(t, tt.args...), ","); got != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.args, tt.expected, got)
+		}
+	}
+}
+
+func TestCompleteBackups(t *testing.T) {
+	kubeconfigPath, _ := writeCompletionKubeconfig(t)
+	backupDir := t.TempDir()
+	backupPath, err := kubeconfig.CreateBackup(kubeconfigPath, backupDir)
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	names := complete(t, "backup", "show", "--kubeconfig", kubeconfigPath, "--backup-dir", backupDir, "")
+	if len(names) != 1 || names[0] != filepath.Base(backupPath) {
+		t.Errorf("Expected the backup name %s, got %v", filepath.Base(backupPath), names)
+	}
+	paths := complete(t, "restore", "--kubeconfig", kubeconfigPath, "--backup-dir", backupDir, "--from", "")
+	if len(paths) != 1 || paths[0] != backupPath {
+		t.Errorf("Expected the backup path %s, got %v", backupPath, paths)
+	}
+}
+
+func TestCompleteArchivedContexts(t *testing.T) {
+	kubeconfigPath, _ := writeCompletionKubeconfig(t)
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if err := kubeconfig.ArchiveContexts(kConfig, []string{"dev"}, kubeconfigPath); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	if got := strings.Join(complete(t, "archive", "restore", "--kubeconfig", kubeconfigPath, ""), ","); got != "dev" {
+		t.Errorf("Expected the archived dev context, got %q", got)
+	}
+}
+
+func TestCompleteFlagValues(t *testing.T) {
+	if got := strings.Join(complete(t, "list", "--output", ""), ","); got != strings.Join(outputFormats, ",") {
+		t.Errorf("Expected the output formats, got %q", got)
+	}
+	if got := strings.Join(complete(t, "restore", "--on-duplicate", ""), ","); got != "overwrite,keep,rename,fail" {
+		t.Errorf("Expected the duplicate strategies, got %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+)
+
+// configOptions holds the flag values for a single invocation of a config command.
+type configOptions struct {
+	*globalOptions
+	configFile string
+}
+
+func newConfigCommand(global *globalOptions) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the whitelist patterns of the configuration file",
+		Long: `Add, remove and list the whitelist patterns of the kubectx-manager configuration
+file without editing it by hand. The file is rewritten in place, keeping its
+comments, settings and the order of the other patterns.`,
+		Args: cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(newConfigPatternCommand(global, "add-pattern", "Add whitelist patterns to the configuration file", addPatternEdit))
+	configCmd.AddCommand(newConfigPatternCommand(global, "remove-pattern", "Remove whitelist patterns from the configuration file", removePatternEdit))
+	configCmd.AddCommand(newConfigListPatternsCommand(global))
+
+	return configCmd
+}
+
+func newConfigPatternCommand(global *globalOptions, name, short string, edit patternEdit) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	cmd := &cobra.Command{
+		Use:   name + " PATTERN [PATTERN...]",
+		Short: short,
+		Args:  cobra.MinimumNArgs(1),
+		// Offering the existing patterns only helps remove-pattern
+		ValidArgsFunction: completePatterns,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.editPatterns(cmd.OutOrStdout(), edit, args)
+		},
+	}
+	addConfigFlag(cmd, &opts.configFile)
+	return cmd
+}
+
+func newConfigListPatternsCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	cmd := &cobra.Command{
+		Use:   "list-patterns",
+		Short: "List the whitelist patterns of the configuration file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.listPatterns(cmd.OutOrStdout())
+		},
+	}
+	addConfigFlag(cmd, &opts.configFile)
+	return cmd
+}
+
+// patternEditResult is the structured form of a run of a command editing patterns.
+type patternEditResult struct {
+	Config string `json:"config" yaml:"config"`
+	// Changed lists the patterns, or aliases, added or removed
+	Changed []string `json:"changed" yaml:"changed"`
+	// Unchanged lists the entries already present, or already absent
+	Unchanged []string `json:"unchanged" yaml:"unchanged"`
+}
+
+// patternEdit is a command that adds or removes patterns of the configuration file.
+type patternEdit struct {
+	command string
+	apply   func(configPath, pattern string) (bool, error)
+	// changed and unchanged format the message for each pattern and the file
+	changed, unchanged string
+	// warnUnchanged reports the unchanged patterns as warnings
+	warnUnchanged bool
+}
+
+var (
+	addPatternEdit = patternEdit{command: "config add-pattern", apply: config.AddPattern,
+		changed: "Added pattern '%s' to %s", unchanged: "Pattern '%s' is already in %s"}
+	removePatternEdit = patternEdit{command: "config remove-pattern", apply: config.RemovePattern,
+		changed: "Removed pattern '%s' from %s", unchanged: "Pattern '%s' is not in %s", warnUnchanged: true}
+)
+
+func (o *configOptions) editPatterns(out io.Writer, edit patternEdit, patterns []string) error {
+	if err := o.requireFormats(edit.command, outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	log := o.newLogger()
+	result := patternEditResult{Config: o.configFile, Changed: []string{}, Unchanged: []string{}}
+	for _, pattern := range patterns {
+		changed, err := edit.apply(o.configFile, pattern)
+		if err != nil {
+			return err
+		}
+		switch {
+		case changed:
+			log.Infof(edit.changed, pattern, o.configFile)
+			result.Changed = append(result.Changed, pattern)
+		case edit.warnUnchanged:
+			log.Warnf(edit.unchanged, pattern, o.configFile)
+			result.Unchanged = append(result.Unchanged, pattern)
+		default:
+			log.Infof(edit.unchanged, pattern, o.configFile)
+			result.Unchanged = append(result.Unchanged, pattern)
+		}
+	}
+	return o.printResult(out, result)
+}
+
+func (o *configOptions) listPatterns(out io.Writer) error {
+	if err := o.requireFormats("config list-patterns", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	cfg, err := o.loadConfigFile(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	patterns := cfg.Whitelist
+	if patterns == nil {
+		patterns = []string{}
+	}
+	if o.isStructured() {
+		return o.printStructured(out, patterns)
+	}
+	for _, pattern := range patterns {
+		fmt.Fprintln(out, pattern)
+	}
+	return nil
+}
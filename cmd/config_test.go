@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runConfigCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs(append([]string{"config"}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("config %v failed: %v", args, err)
+	}
+	return out.String()
+}
+
+func TestConfigPatterns(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "ignore")
+	if err := os.WriteFile(configPath, []byte("# keep these\nprod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	runConfigCommand(t, "add-pattern", "--config", configPath, "-q", "dev-*", "staging")
+	runConfigCommand(t, "remove-pattern", "--config", configPath, "-q", "prod")
+
+	if got := runConfigCommand(t, "list-patterns", "--config", configPath); got != "dev-*\nstaging\n" {
+		t.Errorf("Unexpected patterns %q", got)
+	}
+	var patterns []string
+	if err := json.Unmarshal([]byte(runConfigCommand(t, "list-patterns", "--config", configPath, "-o", "json")), &patterns); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if strings.Join(patterns, ",") != "dev-*,staging" {
+		t.Errorf("Unexpected JSON patterns %v", patterns)
+	}
+
+	var result patternEditResult
+	output := runConfigCommand(t, "remove-pattern", "--config", configPath, "-o", "json", "staging", "missing")
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	if strings.Join(result.Changed, ",") != "staging" || strings.Join(result.Unchanged, ",") != "missing" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if string(data) != "# keep these\ndev-*\n" {
+		t.Errorf("Unexpected config file %q", data)
+	}
+	if got := strings.Join(complete(t, "config", "remove-pattern", "--config", configPath, ""), ","); got != "dev-*" {
+		t.Errorf("Expected remove-pattern to complete the patterns, got %q", got)
+	}
+}
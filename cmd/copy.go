@@ -0,0 +1,156 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// copyOptions holds the flag values for a single invocation of the copy command.
+type copyOptions struct {
+	*globalOptions
+	configFile string
+	cluster    string
+	user       string
+	namespace  string
+	dryRun     bool
+	showDiff   bool
+}
+
+func newCopyCommand(global *globalOptions) *cobra.Command {
+	opts := &copyOptions{globalOptions: global}
+
+	copyCmd := &cobra.Command{
+		Use:   "copy SOURCE NEW",
+		Short: "Duplicate a context, optionally changing its cluster, user or namespace",
+		Long: `Add a context named NEW with the configuration of SOURCE, a context or alias.
+--cluster, --user and --namespace replace the copied values. The copy references the existing
+cluster and user entries rather than duplicating them, so --cluster and --user must name entries
+already in the kubeconfig. A backup is created before the kubeconfig is modified.`,
+		Example: `  # An admin variant of prod in kube-system
+  kubectx-manager copy prod prod-admin --namespace kube-system --user admin-user`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFirstArgs(1, global.completeContexts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args[0], args[1])
+		},
+	}
+
+	copyCmd.Flags().StringVar(&opts.cluster, "cluster", "", "Existing cluster for the copy to use")
+	copyCmd.Flags().StringVar(&opts.user, "user", "", "Existing user for the copy to use")
+	copyCmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "Namespace for the copy to use")
+	copyCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show what would change without modifying the kubeconfig")
+	copyCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change")
+	addConfigFlag(copyCmd, &opts.configFile)
+
+	return copyCmd
+}
+
+// copyResult is the structured form of a copy run.
+type copyResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	Source     string `json:"source" yaml:"source"`
+	Context    string `json:"context" yaml:"context"`
+	Cluster    string `json:"cluster" yaml:"cluster"`
+	User       string `json:"user" yaml:"user"`
+	Namespace  string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Backup     string `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DryRun     bool   `json:"dryRun" yaml:"dryRun"`
+}
+
+func (o *copyOptions) run(out io.Writer, source, newName string) error {
+	if o.namespace != "" && (len(o.namespace) > maxNamespaceLength || !namespacePattern.MatchString(o.namespace)) {
+		return fmt.Errorf("invalid namespace '%s': must be at most %d lowercase letters, digits and '-', starting and ending with a letter or digit",
+			o.namespace, maxNamespaceLength)
+	}
+	log := o.newLogger()
+
+	kubeconfigPath, err := o.singleKubeconfig("copy")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	source = cfg.ResolveAlias(source)
+	before, err := kubeconfig.Marshal(kConfig)
+	if err != nil {
+		return err
+	}
+
+	overrides := kubeconfig.Context{Cluster: o.cluster, User: o.user, Namespace: o.namespace}
+	if err := kubeconfig.CopyContext(kConfig, source, newName, overrides); err != nil {
+		return err
+	}
+	copied := kConfig.GetContext(newName)
+	log.Infof("Copied context '%s' to '%s' (cluster '%s', user '%s')", source, newName, copied.Cluster, copied.User)
+	result := copyResult{
+		Kubeconfig: kubeconfigPath,
+		Source:     source,
+		Context:    newName,
+		Cluster:    copied.Cluster,
+		User:       copied.User,
+		Namespace:  copied.Namespace,
+		DryRun:     o.dryRun,
+	}
+
+	if o.showDiff {
+		after, err := kubeconfig.Marshal(kConfig)
+		if err != nil {
+			return err
+		}
+		printDiff(log, string(before), string(after), kubeconfigPath, kubeconfigPath+" (copied)")
+	}
+	if o.dryRun {
+		log.Infof("Dry run mode - no changes made")
+		o.setExitCode(exitCodeChanged)
+		return o.printResult(out, result)
+	}
+
+	backupPath, err := o.createBackup(kubeconfigPath, log)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	log.Debugf("Created backup at: %s", backupPath)
+	result.Backup = backupPath
+
+	if err := kubeconfig.Save(kConfig, kubeconfigPath); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Copy context %s to %s", source, newName),
+		Kubeconfigs: []string{kubeconfigPath},
+		Backups:     []string{backupPath},
+	}, log)
+	return o.printResult(out, result)
+}
@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestCopyCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	copyContext := func(args ...string) error {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(append([]string{"copy"}, args...), "-q", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore")))
+		return root.Execute()
+	}
+
+	if err := copyContext("prod", "prod-admin", "--namespace", "kube-system", "--user", "dev-user"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	ctx := kConfig.GetContext("prod-admin")
+	if ctx == nil || ctx.Cluster != "prod-cluster" || ctx.User != "dev-user" || ctx.Namespace != "kube-system" {
+		t.Fatalf("Expected a copy of prod with the overrides, got %+v", ctx)
+	}
+	if len(kConfig.Clusters) != 2 || len(kConfig.Users) != 2 || kConfig.CurrentContext != "prod" {
+		t.Error("Expected the copy to reuse the existing entries and keep current-context")
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected one backup, got %d", len(backups))
+	}
+
+	if err := copyContext("prod", "prod-admin"); err == nil {
+		t.Error("Expected an error copying onto an existing context")
+	}
+	if err := copyContext("dev", "dev-2", "--user", "missing-user"); err == nil {
+		t.Error("Expected an error for an unknown user")
+	}
+	if err := copyContext("dev", "dev-2", "--namespace", "Not_Valid"); err == nil {
+		t.Error("Expected an error for an invalid namespace")
+	}
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// defaultNamespace is the namespace kubectl uses for a context that sets none
+const defaultNamespace = "default"
+
+// currentOptions holds the flag values for a single invocation of the current command.
+type currentOptions struct {
+	*globalOptions
+}
+
+func newCurrentCommand(global *globalOptions) *cobra.Command {
+	opts := &currentOptions{globalOptions: global}
+
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Print the current context",
+		Long: `Print the name of the current context. With --output json or yaml the context's cluster,
+server, user and namespace are printed too, for shell prompts and scripts. A context that sets no
+namespace reports "default", as kubectl uses. Nothing is modified or locked.`,
+		Example: `  kubectx-manager current
+  kubectx-manager current -o json | jq -r '"\(.context):\(.namespace)"'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+}
+
+// currentResult is the structured form of a current run.
+type currentResult struct {
+	Context    string `json:"context" yaml:"context"`
+	Cluster    string `json:"cluster" yaml:"cluster"`
+	Server     string `json:"server" yaml:"server"`
+	User       string `json:"user" yaml:"user"`
+	Namespace  string `json:"namespace" yaml:"namespace"`
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+}
+
+func (o *currentOptions) run(out io.Writer) error {
+	if err := o.requireFormats("current", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+
+	paths, err := o.kubeconfigChain("current")
+	if err != nil {
+		return err
+	}
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	result, err := newCurrentResult(multi)
+	if err != nil {
+		return err
+	}
+
+	if !o.isStructured() {
+		_, err := fmt.Fprintln(out, result.Context)
+		return err
+	}
+	return o.printStructured(out, result)
+}
+
+// errNoCurrentContext is returned by newCurrentResult when no context is current
+var errNoCurrentContext = errors.New("no current context is set")
+
+// newCurrentResult describes the current context of the merged view of multi.
+func newCurrentResult(multi *kubeconfig.MultiConfig) (*currentResult, error) {
+	kConfig := multi.Merged
+	name := kConfig.CurrentContext
+	if name == "" {
+		return nil, errNoCurrentContext
+	}
+	ctx := kConfig.GetContext(name)
+	if ctx == nil {
+		return nil, fmt.Errorf("current context '%s' not found", name)
+	}
+	result := &currentResult{
+		Context:    name,
+		Cluster:    ctx.Cluster,
+		Server:     kConfig.GetServer(name),
+		User:       ctx.User,
+		Namespace:  ctx.Namespace,
+		Kubeconfig: multi.SourceOf(name),
+	}
+	if result.Namespace == "" {
+		result.Namespace = defaultNamespace
+	}
+	return result, nil
+}
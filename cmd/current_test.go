@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCurrentCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	current := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(append([]string{"current"}, args...), "--kubeconfig", kubeconfigPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	out, err := current()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "prod\n" {
+		t.Errorf("Expected only the context name, got %q", out)
+	}
+
+	if out, err = current("-o", "json"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var result currentResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	expected := currentResult{Context: "prod", Cluster: "prod-cluster", Server: "https://prod.example.com",
+		User: "prod-user", Namespace: "apps", Kubeconfig: kubeconfigPath}
+	if result != expected {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+
+	// Without a current context or with the default namespace
+	data := strings.Replace(listTestKubeconfig, "current-context: prod", "current-context: dev", 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	if out, err = current("-o", "yaml"); err != nil || !strings.Contains(out, "namespace: default") {
+		t.Errorf("Expected the default namespace, got %q (%v)", out, err)
+	}
+	data = strings.Replace(listTestKubeconfig, "current-context: prod\n", "", 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	if _, err = current(); err == nil {
+		t.Error("Expected an error without a current context")
+	}
+}
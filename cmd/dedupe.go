@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// dedupeOptions holds the flag values for a single invocation of the dedupe command.
+type dedupeOptions struct {
+	*globalOptions
+	dryRun   bool
+	showDiff bool
+}
+
+func newDedupeCommand(global *globalOptions) *cobra.Command {
+	opts := &dedupeOptions{globalOptions: global}
+
+	dedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Collapse clusters and users with identical configuration",
+		Long: `Find clusters and users that have identical configuration under different names,
+point every context at the first such entry and remove the duplicates.
+A backup is created before the kubeconfig is modified.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	dedupeCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show which entries would be collapsed without modifying the kubeconfig")
+	dedupeCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change")
+
+	return dedupeCmd
+}
+
+// dedupeResult is the structured form of a dedupe run.
+type dedupeResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	// Clusters and Users map each removed duplicate to the entry that replaced it
+	Clusters map[string]string `json:"clusters" yaml:"clusters"`
+	Users    map[string]string `json:"users" yaml:"users"`
+	Backup   string            `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DryRun   bool              `json:"dryRun" yaml:"dryRun"`
+}
+
+func (o *dedupeOptions) run(out io.Writer) error {
+	log := o.newLogger()
+
+	kubeconfigPath, err := o.singleKubeconfig("dedupe")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	before, err := kubeconfig.Marshal(kConfig)
+	if err != nil {
+		return err
+	}
+
+	report := kubeconfig.Dedupe(kConfig)
+	result := dedupeResult{
+		Kubeconfig: kubeconfigPath,
+		Clusters:   report.Clusters,
+		Users:      report.Users,
+		DryRun:     o.dryRun,
+	}
+	if report.Empty() {
+		log.Infof("No duplicate clusters or users found")
+		return o.printResult(out, result)
+	}
+	o.setExitCode(exitCodeChanged)
+	logDuplicates(log, "cluster", report.Clusters)
+	logDuplicates(log, "user", report.Users)
+
+	if o.showDiff {
+		after, err := kubeconfig.Marshal(kConfig)
+		if err != nil {
+			return err
+		}
+		printDiff(log, string(before), string(after), kubeconfigPath, kubeconfigPath+" (deduplicated)")
+	}
+	if o.dryRun {
+		log.Infof("Dry run mode - no changes made")
+		return o.printResult(out, result)
+	}
+
+	backupPath, err := o.createBackup(kubeconfigPath, log)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	log.Debugf("Created backup at: %s", backupPath)
+	result.Backup = backupPath
+
+	if err := kubeconfig.Save(kConfig, kubeconfigPath); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Remove %d duplicate clusters and %d duplicate users", len(report.Clusters), len(report.Users)),
+		Kubeconfigs: []string{kubeconfigPath},
+		Backups:     []string{backupPath},
+	}, log)
+
+	log.Infof("Removed %d duplicate clusters and %d duplicate users", len(report.Clusters), len(report.Users))
+	return o.printResult(out, result)
+}
+
+// logDuplicates prints each removed duplicate with its canonical entry, sorted by name.
+func logDuplicates(log *logger.Logger, kind string, duplicates map[string]string) {
+	names := make([]string, 0, len(duplicates))
+	for name := range duplicates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.Infof("  %s '%s' is identical to '%s'", kind, name, duplicates[name])
+	}
+}
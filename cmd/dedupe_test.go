@@ -0,0 +1,85 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+const dedupeTestKubeconfig = `apiVersion: v1
+kind: Config
+current-context: a
+contexts:
+- name: a
+  context:
+    cluster: prod
+    user: admin
+- name: b
+  context:
+    cluster: prod-imported
+    user: admin-imported
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+- name: prod-imported
+  cluster:
+    server: https://prod.example.com
+users:
+- name: admin
+  user:
+    token: secret
+- name: admin-imported
+  user:
+    token: secret
+`
+
+func TestDedupeCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(dedupeTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"dedupe", "--dry-run", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != dedupeTestKubeconfig {
+		t.Fatal("Expected dry run to leave the kubeconfig untouched")
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"dedupe", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if len(kConfig.Clusters) != 1 || len(kConfig.Users) != 1 {
+		t.Errorf("Expected one cluster and one user, got %d and %d", len(kConfig.Clusters), len(kConfig.Users))
+	}
+	if ctx := kConfig.GetContext("b"); ctx == nil || ctx.Cluster != "prod" || ctx.User != "admin" {
+		t.Errorf("Expected context b to use the canonical entries, got %+v", ctx)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected one backup, got %d", len(backups))
+	}
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// Stable error codes reported in structured error output
+const (
+	codeError              = "ERROR"
+	codeKubeconfigNotFound = "KUBECONFIG_NOT_FOUND"
+	codeInvalidPattern     = "INVALID_PATTERN"
+	codeBackupCorrupt      = "BACKUP_CORRUPT"
+	codeConflict           = "CONFLICT"
+	codeBulkFailures       = "BULK_FAILURES"
+)
+
+// Process exit codes of successful runs, so automation can tell whether a
+// command found anything to do. When several apply the highest wins.
+const (
+	exitCodeNothingToDo  = 0
+	exitCodeChanged      = 2
+	exitCodeAuthFailures = 3
+	exitCodeCanceled     = 4
+)
+
+// Process exit codes for each error code; all are 10 or above
+const (
+	exitCodeError              = 10
+	exitCodeKubeconfigNotFound = 11
+	exitCodeInvalidPattern     = 12
+	exitCodeBackupCorrupt      = 13
+	exitCodeConflict           = 14
+	// exitCodeBulkFailures is used when some kubeconfigs selected by a glob
+	// or --kubeconfig-dir could not be processed, the others having been
+	exitCodeBulkFailures = 15
+)
+
+// errBulkFailures is reported once a run over several kubeconfigs is done
+// when some of them failed, each failure having been reported on its own.
+var errBulkFailures = errors.New("some kubeconfigs could not be processed")
+
+// errorCodes maps sentinel errors to their stable code and process exit code.
+// The first entry matching with errors.Is wins.
+var errorCodes = []struct {
+	err      error
+	code     string
+	exitCode int
+}{
+	{kubeconfig.ErrKubeconfigNotFound, codeKubeconfigNotFound, exitCodeKubeconfigNotFound},
+	{config.ErrInvalidPattern, codeInvalidPattern, exitCodeInvalidPattern},
+	{kubeconfig.ErrBackupCorrupt, codeBackupCorrupt, exitCodeBackupCorrupt},
+	{kubeconfig.ErrConflict, codeConflict, exitCodeConflict},
+	{kubeconfig.ErrModifiedSinceLoad, codeConflict, exitCodeConflict},
+	{kubeconfig.ErrLocked, codeConflict, exitCodeConflict},
+	{errBulkFailures, codeBulkFailures, exitCodeBulkFailures},
+}
+
+// errorReport is the structured form of a failed command.
+type errorReport struct {
+	Error    string `json:"error" yaml:"error"`
+	Code     string `json:"code" yaml:"code"`
+	ExitCode int    `json:"exitCode" yaml:"exitCode"`
+}
+
+func newErrorReport(err error) errorReport {
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return errorReport{Error: err.Error(), Code: ec.code, ExitCode: ec.exitCode}
+		}
+	}
+	return errorReport{Error: err.Error(), Code: codeError, ExitCode: exitCodeError}
+}
+
+// commandError carries the output format selected for the failed invocation
+// so the error can be reported in the same format.
+type commandError struct {
+	err    error
+	output string
+}
+
+func (e *commandError) Error() string {
+	return e.err.Error()
+}
+
+func (e *commandError) Unwrap() error {
+	return e.err
+}
+
+// setExitCode records the outcome of a successful run. The process exits
+// with the highest code recorded.
+func (g *globalOptions) setExitCode(code int) {
+	if code > g.exitCode {
+		g.exitCode = code
+	}
+}
+
+// ReportError writes err to w and returns the exit code the process should use.
+// With a structured --output format the error is written as an object with a
+// stable code; errors from wrapped commands only propagate their exit code.
+func ReportError(w io.Writer, err error) int {
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	report := newErrorReport(err)
+
+	var cmdErr *commandError
+	if errors.As(err, &cmdErr) {
+		g := &globalOptions{output: cmdErr.output}
+		if g.isStructured() {
+			if printErr := g.printStructured(w, report); printErr == nil {
+				return report.ExitCode
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "Error: %v\n", err)
+	return report.ExitCode
+}
@@ -0,0 +1,285 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestReportError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     string
+		exitCode int
+	}{
+		{"generic", errors.New("boom"), codeError, exitCodeError},
+		{"kubeconfig not found", fmt.Errorf("failed to load kubeconfig: %w", kubeconfig.ErrKubeconfigNotFound),
+			codeKubeconfigNotFound, exitCodeKubeconfigNotFound},
+		{"invalid pattern", fmt.Errorf("%w 'x'", config.ErrInvalidPattern), codeInvalidPattern, exitCodeInvalidPattern},
+		{"backup corrupt", fmt.Errorf("%w: config.backup", kubeconfig.ErrBackupCorrupt), codeBackupCorrupt, exitCodeBackupCorrupt},
+		{"duplicate", &kubeconfig.DuplicateError{Kind: "context", Name: "prod"}, codeConflict, exitCodeConflict},
+		{"modified since load", kubeconfig.ErrModifiedSinceLoad, codeConflict, exitCodeConflict},
+		{"bulk failures", errBulkFailures, codeBulkFailures, exitCodeBulkFailures},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var text bytes.Buffer
+			if code := ReportError(&text, tt.err); code != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, code)
+			}
+			if !strings.HasPrefix(text.String(), "Error: ") {
+				t.Errorf("Expected plain error message, got %q", text.String())
+			}
+
+			var structured bytes.Buffer
+			ReportError(&structured, &commandError{err: tt.err, output: outputJSON})
+			var report errorReport
+			if err := json.Unmarshal(structured.Bytes(), &report); err != nil {
+				t.Fatalf("Expected JSON error report, got %q: %v", structured.String(), err)
+			}
+			if report.Code != tt.code || report.ExitCode != tt.exitCode || report.Error != tt.err.Error() {
+				t.Errorf("Unexpected report %+v", report)
+			}
+		})
+	}
+}
+
+func TestReportErrorExitCode(t *testing.T) {
+	var out bytes.Buffer
+	if code := ReportError(&out, &commandError{err: &ExitCodeError{Code: 3}}); code != 3 {
+		t.Errorf("Expected wrapped command exit code 3, got %d", code)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing to be printed, got %q", out.String())
+	}
+}
+
+func TestExecuteReportsMissingKubeconfig(t *testing.T) {
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	os.Args = []string{"kubectx-manager", "list", "--kubeconfig", missing, "-o", "json"}
+
+	err := Execute()
+	var out bytes.Buffer
+	if code := ReportError(&out, err); code != exitCodeKubeconfigNotFound {
+		t.Errorf("Expected exit code %d, got %d (%v)", exitCodeKubeconfigNotFound, code, err)
+	}
+	if !strings.Contains(out.String(), `"code": "KUBECONFIG_NOT_FOUND"`) {
+		t.Errorf("Expected structured error, got %q", out.String())
+	}
+}
+
+func TestExecuteExitCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		whitelist string
+		args      []string
+		exitCode  int
+	}{
+		{"nothing to remove", "*", []string{"--dry-run"}, exitCodeNothingToDo},
+		{"would remove", "prod", []string{"--dry-run"}, exitCodeChanged},
+		{"removed", "prod", []string{"--yes"}, exitCodeChanged},
+		{"dedupe finds nothing", "prod", []string{"dedupe"}, exitCodeNothingToDo},
+	}
+
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			kubeconfigPath := filepath.Join(tmpDir, "config")
+			if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+				t.Fatalf("Failed to create kubeconfig: %v", err)
+			}
+			configPath := filepath.Join(tmpDir, "ignore")
+			if err := os.WriteFile(configPath, []byte(tt.whitelist+"\n"), 0600); err != nil {
+				t.Fatalf("Failed to create config: %v", err)
+			}
+			os.Args = append([]string{"kubectx-manager", "-q", "--kubeconfig", kubeconfigPath}, tt.args...)
+			if tt.args[0] != "dedupe" {
+				os.Args = append(os.Args, "--config", configPath)
+			}
+
+			code := exitCodeNothingToDo
+			if err := Execute(); err != nil {
+				var out bytes.Buffer
+				code = ReportError(&out, err)
+			}
+			if code != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, code)
+			}
+		})
+	}
+}
+
+func TestStructuredErrorWithoutUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SilenceErrors = true
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"switch", "missing", "--no-backup", "-o", "json", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(tmpDir, "ignore")})
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("Expected switching to a missing context to fail")
+	}
+	ReportError(&stderr, &commandError{err: err, output: outputJSON})
+
+	// stderr holds the error report and nothing else
+	decoder := json.NewDecoder(&stderr)
+	var report errorReport
+	if err := decoder.Decode(&report); err != nil {
+		t.Fatalf("Expected a JSON error report on stderr: %v", err)
+	}
+	if report.Code != codeError || !strings.Contains(report.Error, "missing") {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if decoder.More() {
+		t.Errorf("Expected a single JSON object on stderr, got more after %+v", report)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected nothing on stdout, got %q", stdout.String())
+	}
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	const otherKubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- name: staging
+  context:
+    cluster: staging-cluster
+    user: staging-user
+clusters:
+- name: staging-cluster
+  cluster:
+    server: https://staging.example.com
+users:
+- name: staging-user
+  user:
+    token: staging-token
+`
+	// Each setup prepares the files the command needs in dir, next to the
+	// kubeconfig at path, and returns the arguments to run it with
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, dir, path string) []string
+		exitCode int
+	}{
+		{"switch", argsOnly("switch", "dev"), exitCodeChanged},
+		{"switch to the current context", argsOnly("switch", "prod"), exitCodeNothingToDo},
+		{"rename", argsOnly("rename", "dev", "development"), exitCodeChanged},
+		{"rename dry run", argsOnly("rename", "dev", "development", "--dry-run"), exitCodeChanged},
+		{"set-namespace", argsOnly("set-namespace", "web"), exitCodeChanged},
+		{"set-namespace unchanged", argsOnly("set-namespace", "apps"), exitCodeNothingToDo},
+		{"copy", argsOnly("copy", "dev", "dev-copy"), exitCodeChanged},
+		{"label", argsOnly("label", "dev", "team=a"), exitCodeChanged},
+		{"remove", argsOnly("remove", "dev"), exitCodeChanged},
+		{"prune-orphans", argsOnly("prune-orphans"), exitCodeNothingToDo},
+		{"normalize", argsOnly("normalize"), exitCodeChanged},
+		{"dedupe", argsOnly("dedupe"), exitCodeNothingToDo},
+		{"merge", func(t *testing.T, dir, _ string) []string {
+			return []string{"merge", writeTestFile(t, dir, "other", otherKubeconfig)}
+		}, exitCodeChanged},
+		{"merge of entries already present", func(t *testing.T, dir, _ string) []string {
+			return []string{"merge", writeTestFile(t, dir, "same", listTestKubeconfig)}
+		}, exitCodeNothingToDo},
+		{"import", func(t *testing.T, dir, _ string) []string {
+			return []string{"import", writeTestFile(t, dir, "other", otherKubeconfig)}
+		}, exitCodeChanged},
+		{"restore", func(t *testing.T, _, path string) []string {
+			createTestBackup(t, path)
+			return []string{"restore", "--latest", "--backup-choice", "none"}
+		}, exitCodeChanged},
+		{"archive restore", func(t *testing.T, _, path string) []string {
+			writeTestFile(t, filepath.Dir(path), filepath.Base(kubeconfig.ArchivePath(path)), otherKubeconfig)
+			return []string{"archive", "restore", "staging"}
+		}, exitCodeChanged},
+		{"backup create", argsOnly("backup", "create"), exitCodeNothingToDo},
+		{"backup delete", func(t *testing.T, _, path string) []string {
+			return []string{"backup", "delete", filepath.Base(createTestBackup(t, path))}
+		}, exitCodeChanged},
+		{"backup prune", func(t *testing.T, _, path string) []string {
+			createTestBackup(t, path)
+			createTestBackup(t, path)
+			return []string{"backup", "prune", "--backup-retention", "1"}
+		}, exitCodeChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			kubeconfigPath := writeTestFile(t, tmpDir, "config", listTestKubeconfig)
+			args := tt.setup(t, tmpDir, kubeconfigPath)
+
+			root, global := newRootCommand()
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(append(args, "-q", "--yes", "--kubeconfig", kubeconfigPath,
+				"--backup-dir", filepath.Join(tmpDir, "backups")))
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if global.exitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, global.exitCode)
+			}
+		})
+	}
+}
+
+// argsOnly is a TestCommandExitCodes setup that only returns args.
+func argsOnly(args ...string) func(*testing.T, string, string) []string {
+	return func(*testing.T, string, string) []string { return args }
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create %s: %v", name, err)
+	}
+	return path
+}
+
+// createTestBackup backs up the kubeconfig at path into the backups folder
+// next to it, which TestCommandExitCodes passes as --backup-dir.
+func createTestBackup(t *testing.T, path string) string {
+	t.Helper()
+	backupPath, err := kubeconfig.CreateBackup(path, filepath.Join(filepath.Dir(path), "backups"))
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	return backupPath
+}
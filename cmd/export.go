@@ -0,0 +1,155 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// exportOptions holds the flag values for a single invocation of the export command.
+type exportOptions struct {
+	*globalOptions
+	configFile    string
+	to            string
+	redactSecrets bool
+}
+
+func newExportCommand(global *globalOptions) *cobra.Command {
+	opts := &exportOptions{globalOptions: global}
+
+	exportCmd := &cobra.Command{
+		Use:   "export CONTEXT...",
+		Short: "Export contexts with their clusters and users to a new kubeconfig",
+		Long: `Write the given contexts or aliases, with the clusters and users they reference, as a new
+kubeconfig to --to, or to stdout. The first context is made current.
+
+With --redact-secrets, tokens, passwords, client keys and other secrets are replaced with
+REDACTED while the structure, servers and CA data are kept, giving a skeleton colleagues can
+fill in with their own credentials.`,
+		Example: `  # Write prod to a file of its own
+  kubectx-manager export prod --to prod.kubeconfig
+
+  # Share the clusters of a team without anyone's credentials
+  kubectx-manager export staging prod --redact-secrets > team.kubeconfig`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: global.completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	exportCmd.Flags().StringVar(&opts.to, "to", "", "File to write the kubeconfig to, which must not exist (default: stdout)")
+	exportCmd.Flags().BoolVar(&opts.redactSecrets, "redact-secrets", false,
+		"Replace tokens, passwords, client keys and other secrets with REDACTED, keeping the structure and CA data")
+	addConfigFlag(exportCmd, &opts.configFile)
+
+	return exportCmd
+}
+
+// exportResult is the structured form of an export run.
+type exportResult struct {
+	Contexts []string `json:"contexts" yaml:"contexts"`
+	// File is where the kubeconfig was written; without one, Config holds it
+	File     string                 `json:"file,omitempty" yaml:"file,omitempty"`
+	Redacted bool                   `json:"redacted" yaml:"redacted"`
+	Config   map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+func (o *exportOptions) run(out io.Writer, args []string) error {
+	if err := o.requireFormats("export", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+
+	paths, err := o.kubeconfigChain("export")
+	if err != nil {
+		return err
+	}
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	names := make([]string, len(args))
+	for i, arg := range args {
+		names[i] = cfg.ResolveAlias(arg)
+	}
+	exported, err := exportConfig(multi.Merged, names, o.redactSecrets)
+	if err != nil {
+		return err
+	}
+	result := exportResult{Contexts: names, Redacted: o.redactSecrets}
+
+	if o.to != "" {
+		path := expandHome(o.to)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+		if err := kubeconfig.Save(exported, path); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		o.newLogger().Infof("Exported %s to %s", listSubject(names), path)
+		result.File = path
+		return o.printResult(out, result)
+	}
+
+	data, err := kubeconfig.Marshal(exported)
+	if err != nil {
+		return err
+	}
+	if !o.isStructured() {
+		_, err := out.Write(data)
+		return err
+	}
+	if err := yaml.Unmarshal(data, &result.Config); err != nil {
+		return err
+	}
+	return o.printStructured(out, result)
+}
+
+// exportConfig returns a new kubeconfig holding the named contexts of
+// kConfig with the clusters and users they reference, the first context
+// being current. With redact its secrets are redacted, leaving kConfig as it is.
+func exportConfig(kConfig *kubeconfig.Config, names []string, redact bool) (*kubeconfig.Config, error) {
+	for _, name := range names {
+		if kConfig.GetContext(name) == nil {
+			return nil, fmt.Errorf("context '%s' not found", name)
+		}
+	}
+	exported := kubeconfig.ExtractContexts(kConfig, names)
+	exported.CurrentContext = names[0]
+	if !redact {
+		return exported, nil
+	}
+
+	// The extract shares its entries with kConfig; redact a copy of them
+	data, err := kubeconfig.Marshal(exported)
+	if err != nil {
+		return nil, err
+	}
+	if exported, err = kubeconfig.Parse(data); err != nil {
+		return nil, err
+	}
+	kubeconfig.Redact(exported)
+	return exported, nil
+}
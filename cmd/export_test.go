@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestExportCommand(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	export := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(append([]string{"export"}, args...), "--kubeconfig", kubeconfigPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	// A redacted skeleton on stdout
+	out, err := export("dev", "prod", "--redact-secrets")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	skeleton, err := kubeconfig.Parse([]byte(out))
+	if err != nil {
+		t.Fatalf("Failed to parse the export: %v\n%s", err, out)
+	}
+	if skeleton.CurrentContext != "dev" || len(skeleton.Contexts) != 2 || len(skeleton.Clusters) != 2 {
+		t.Errorf("Expected dev and prod with their clusters, dev current, got:\n%s", out)
+	}
+	if user := skeleton.GetUser("prod-user"); user == nil || user.Token != kubeconfig.Redacted {
+		t.Errorf("Expected the prod token to be redacted, got:\n%s", out)
+	}
+	if strings.Contains(out, "prod-token") {
+		t.Errorf("Expected no secrets in the export, got:\n%s", out)
+	}
+
+	// Unredacted to a file, which is never overwritten
+	target := filepath.Join(dir, "prod.kubeconfig")
+	if _, err := export("prod", "--to", target); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	exported, err := kubeconfig.Load(target)
+	if err != nil {
+		t.Fatalf("Failed to load the export: %v", err)
+	}
+	if len(exported.Contexts) != 1 || exported.GetUser("prod-user").Token != "prod-token" {
+		t.Errorf("Expected prod with its token, got %+v", exported)
+	}
+	if _, err := export("dev", "--to", target); err == nil {
+		t.Error("Expected an error exporting to an existing file")
+	}
+
+	if _, err := export("missing"); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Error("Expected export to leave the kubeconfig untouched")
+	}
+}
@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// Documentation formats accepted by gen-docs --format
+const (
+	docsFormatMan      = "man"
+	docsFormatMarkdown = "markdown"
+)
+
+const docsDirMode = 0755
+
+// genDocsOptions holds the flag values for a single invocation of the gen-docs command.
+type genDocsOptions struct {
+	format string
+	dir    string
+}
+
+func newGenDocsCommand() *cobra.Command {
+	opts := &genDocsOptions{}
+
+	genDocsCmd := &cobra.Command{
+		Use:    "gen-docs",
+		Short:  "Generate man pages or markdown reference documentation",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.Root())
+		},
+	}
+
+	genDocsCmd.Flags().StringVar(&opts.format, "format", docsFormatMan,
+		fmt.Sprintf("Documentation format (%s|%s)", docsFormatMan, docsFormatMarkdown))
+	genDocsCmd.Flags().StringVar(&opts.dir, "dir", "docs", "Directory to write the documentation to")
+
+	return genDocsCmd
+}
+
+func (o *genDocsOptions) run(root *cobra.Command) error {
+	if err := os.MkdirAll(o.dir, docsDirMode); err != nil {
+		return fmt.Errorf("failed to create documentation directory: %w", err)
+	}
+
+	// Generated files should not change between builds just because of the generation date
+	root.DisableAutoGenTag = true
+
+	switch o.format {
+	case docsFormatMan:
+		header := &doc.GenManHeader{
+			Title:   "KUBECTX-MANAGER",
+			Section: "1",
+			Source:  "kubectx-manager " + Version,
+			Manual:  "kubectx-manager Manual",
+		}
+		if err := doc.GenManTree(root, header, o.dir); err != nil {
+			return fmt.Errorf("failed to generate man pages: %w", err)
+		}
+	case docsFormatMarkdown:
+		if err := doc.GenMarkdownTree(root, o.dir); err != nil {
+			return fmt.Errorf("failed to generate markdown documentation: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown documentation format %q (expected %s or %s)", o.format, docsFormatMan, docsFormatMarkdown)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenDocs(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{docsFormatMan, "kubectx-manager-restore.1"},
+		{docsFormatMarkdown, "kubectx-manager_restore.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			root := NewRootCommand()
+			root.SetArgs([]string{"gen-docs", "--format", tt.format, "--dir", dir})
+
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, tt.expected))
+			if err != nil {
+				t.Fatalf("Expected %s to be generated: %v", tt.expected, err)
+			}
+			if !strings.Contains(string(data), "keep-backup") {
+				t.Errorf("Expected generated docs to document restore flags, got:\n%s", data)
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, strings.Replace(tt.expected, "restore", "gen-docs", 1))); !os.IsNotExist(err) {
+				t.Error("Hidden gen-docs command should not be documented")
+			}
+		})
+	}
+}
+
+func TestGenDocsHidden(t *testing.T) {
+	cmd, _, err := NewRootCommand().Find([]string{"gen-docs"})
+	if err != nil {
+		t.Fatalf("Failed to find gen-docs: %v", err)
+	}
+	if !cmd.Hidden {
+		t.Error("gen-docs should be hidden from help output")
+	}
+}
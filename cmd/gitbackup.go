@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/che-incubator/kubectx-manager/internal/gitbackup"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// backupGitEnv names the environment variable holding the default --backup-git
+const backupGitEnv = "KUBECTX_MANAGER_BACKUP_GIT"
+
+// gitRepository returns the --backup-git repository, or nil if none is configured.
+func (g *globalOptions) gitRepository() (*gitbackup.Repo, error) {
+	if g.backupGit == "" || g.gitRepo != nil {
+		return g.gitRepo, nil
+	}
+	repo, err := gitbackup.Open(expandHome(g.backupGit))
+	if err != nil {
+		return nil, err
+	}
+	g.gitRepo = repo
+	return repo, nil
+}
+
+// commitKubeconfig commits the kubeconfig at path to --backup-git with
+// subject as message, followed by the command line, and pushes the commit
+// if the branch has an upstream. Nothing is committed if the repository
+// already holds this version. Failures are only warned about, since the
+// kubeconfig itself was written. With --encrypt-backups nothing is
+// committed: the repository would hold, and push, the credentials that
+// the backups are encrypted to protect in plaintext.
+func (g *globalOptions) commitKubeconfig(path, subject string, log *logger.Logger) {
+	if g.backupGit != "" && g.encryptBackups {
+		if !g.gitSkipWarned {
+			log.Warnf("Not committing to %s: --encrypt-backups is set and the repository would hold the kubeconfig in plaintext", g.backupGit)
+			g.gitSkipWarned = true
+		}
+		return
+	}
+	repo, err := g.gitRepository()
+	if err != nil || repo == nil {
+		if err != nil {
+			log.Warnf("Not committing %s: %v", path, err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // User-specified kubeconfig path is intentional
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Warnf("Not committing %s: %v", path, err)
+		return
+	}
+	body := fmt.Sprintf("Kubeconfig: %s\nCommand: %s", path, commandLine(&g.invocation))
+	committed, err := repo.Commit(kubeconfig.BackupSource(path), data, subject, body)
+	if err != nil {
+		log.Warnf("Failed to commit %s to %s: %v", path, repo.Dir, err)
+		return
+	}
+	if !committed {
+		return
+	}
+	log.Debugf("Committed %s to %s: %s", path, repo.Dir, subject)
+
+	if repo.HasUpstream() {
+		if err := repo.Push(); err != nil {
+			log.Warnf("Failed to push %s: %v", repo.Dir, err)
+		}
+	}
+}
+
+// commitKubeconfigs commits each of paths, as commitKubeconfig does.
+func (g *globalOptions) commitKubeconfigs(paths []string, subject string, log *logger.Logger) {
+	for _, path := range paths {
+		g.commitKubeconfig(path, subject, log)
+	}
+}
+
+// listSubject renders names for a commit subject, eliding all but the first few.
+func listSubject(names []string) string {
+	const maxNames = 3
+	if len(names) <= maxNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxNames], ", "), len(names)-maxNames)
+}
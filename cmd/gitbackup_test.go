@@ -0,0 +1,152 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/gitbackup"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestBackupGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	repoDir := filepath.Join(tmpDir, "history")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(out io.Writer, args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(out)
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--backup-git", repoDir))
+		return root.Execute()
+	}
+	currentContext := func() string {
+		t.Helper()
+		kConfig, err := kubeconfig.Load(kubeconfigPath)
+		if err != nil {
+			t.Fatalf("Failed to load kubeconfig: %v", err)
+		}
+		return kConfig.CurrentContext
+	}
+
+	// The first change commits the state before it, then the change itself
+	if err := run(io.Discard, "switch", "dev"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+	if err := run(io.Discard, "rename", "dev", "development"); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := run(&out, "backup", "log", "-o", "json"); err != nil {
+		t.Fatalf("backup log failed: %v", err)
+	}
+	var commits []gitbackup.Commit
+	if err := json.Unmarshal(out.Bytes(), &commits); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", out.String(), err)
+	}
+	subjects := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		subjects = append(subjects, commit.Subject)
+	}
+	expected := []string{"Rename context dev to development", "Switch to context dev", "Record current config"}
+	if len(subjects) != len(expected) {
+		t.Fatalf("Expected commits %v, got %v", expected, subjects)
+	}
+	for i := range expected {
+		if subjects[i] != expected[i] {
+			t.Errorf("Commit %d: expected %q, got %q", i, expected[i], subjects[i])
+		}
+	}
+
+	// Any commit can be restored, and the restore is committed too
+	if err := run(io.Discard, "restore", "--git-revision", commits[2].Hash, "--yes", "--backup-choice", "none"); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Errorf("Expected the original kubeconfig to be restored, got:\n%s", data)
+	}
+	if currentContext() != "prod" {
+		t.Errorf("Expected current context prod, got %q", currentContext())
+	}
+	repo := &gitbackup.Repo{Dir: repoDir}
+	if latest, err := repo.Resolve("HEAD"); err != nil || latest.Subject != "Restore from config@"+commits[2].ShortHash() {
+		t.Errorf("Expected the restore to be committed, got %+v, %v", latest, err)
+	}
+
+	if err := run(io.Discard, "restore", "--git-revision", "no-such-revision", "--yes"); err == nil {
+		t.Error("Expected an unknown revision to fail")
+	}
+}
+
+func TestBackupGitRequired(t *testing.T) {
+	root := NewRootCommand()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetArgs([]string{"backup", "log", "--kubeconfig", filepath.Join(t.TempDir(), "config")})
+	if err := root.Execute(); err == nil {
+		t.Error("Expected backup log without --backup-git to fail")
+	}
+}
+
+func TestBackupGitSkippedWhenEncrypted(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv(backupPassphraseEnv, "correct horse")
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	repoDir := filepath.Join(tmpDir, "history")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	var stderr bytes.Buffer
+	root := NewRootCommand()
+	root.SilenceErrors = true
+	root.SetOut(io.Discard)
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"switch", "dev", "--encrypt-backups", "--kubeconfig", kubeconfigPath, "--backup-git", repoDir})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "--encrypt-backups is set") {
+		t.Errorf("Expected a warning that nothing is committed, got %q", stderr.String())
+	}
+
+	// No blob of the repository, if one was created at all, holds the kubeconfig in plaintext
+	if _, err := os.Stat(repoDir); os.IsNotExist(err) {
+		return
+	}
+	out, err := exec.Command("git", "-C", repoDir, "log", "--all", "-p").CombinedOutput()
+	if err != nil && !strings.Contains(string(out), "does not have any commits") {
+		t.Fatalf("git log failed: %v: %s", err, out)
+	}
+	if strings.Contains(string(out), "apiVersion:") || strings.Contains(string(out), "server:") {
+		t.Errorf("Expected no plaintext kubeconfig in the repository, got:\n%s", out)
+	}
+}
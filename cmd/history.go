@@ -0,0 +1,139 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// auditFile returns the path of the audit log every kubeconfig change is recorded in.
+func auditFile() string {
+	return audit.DefaultPath()
+}
+
+// recordChange records a change the command made to the kubeconfigs of r:
+// it commits them to --backup-git with r.Summary as subject, appends r to
+// the audit log and sets the exit code for a change. Failures are only
+// warned about, since the kubeconfigs themselves were written.
+func (g *globalOptions) recordChange(r *audit.Record, log *logger.Logger) {
+	g.setExitCode(exitCodeChanged)
+	g.commitKubeconfigs(r.Kubeconfigs, r.Summary, log)
+	g.appendAudit(r, log)
+}
+
+// appendAudit appends r, completed with the time and the command line, to
+// the audit log, warning if it cannot.
+func (g *globalOptions) appendAudit(r *audit.Record, log *logger.Logger) {
+	r.Time = time.Now().UTC()
+	r.Command, r.Args, r.Flags = g.invocation.Command, g.invocation.Args, g.invocation.Flags
+	path := auditFile()
+	if path == "" {
+		log.Warnf("Not recording %q in the audit log: cannot determine the home directory", r.Summary)
+		return
+	}
+	if err := audit.Append(path, r); err != nil {
+		log.Warnf("Failed to record %q in the audit log: %v", r.Summary, err)
+	}
+}
+
+// nonEmpty returns path as a list, empty if path is.
+func nonEmpty(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return []string{path}
+}
+
+// historyOptions holds the flag values for a single invocation of the history command.
+type historyOptions struct {
+	*globalOptions
+	limit int
+}
+
+func newHistoryCommand(global *globalOptions) *cobra.Command {
+	opts := &historyOptions{globalOptions: global}
+
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the audit log of the changes made to kubeconfigs",
+		Long: `Show the audit log, newest first: every change kubectx-manager made to a kubeconfig,
+with the command line, the contexts removed, renamed or restored and the backups taken.
+
+The log is kept in kubectx-manager/audit.log under $XDG_STATE_HOME (~/.local/state by default),
+one JSON record per line; use -o json or -o yaml for the full records.`,
+		Example: `  kubectx-manager history
+  kubectx-manager history --limit 10
+  kubectx-manager history -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	historyCmd.Flags().IntVarP(&opts.limit, "limit", "n", 0, "Show only the most recent records (0 shows all)")
+
+	return historyCmd
+}
+
+func (o *historyOptions) run(out io.Writer) error {
+	if err := o.requireFormats("history", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	if o.limit < 0 {
+		return fmt.Errorf("invalid --limit %d: must not be negative", o.limit)
+	}
+	records, err := audit.Read(auditFile())
+	if err != nil {
+		return err
+	}
+	slices.Reverse(records)
+	if o.limit > 0 && len(records) > o.limit {
+		records = records[:o.limit]
+	}
+
+	if o.isStructured() {
+		if records == nil {
+			records = []audit.Record{}
+		}
+		return o.printStructured(out, records)
+	}
+	if len(records) == 0 {
+		o.newLogger().Infof("No changes recorded in %s", auditFile())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tCOMMAND\tCHANGE\tBACKUP")
+	for _, r := range records {
+		backups := strings.Join(r.Backups, ", ")
+		if backups == "" {
+			backups = "none"
+		}
+		change := r.Summary
+		if r.DryRun {
+			change += " (dry run)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Time.Local().Format("2006-01-02 15:04:05"), r.Command, change, backups)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+)
+
+func TestHistory(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return out.String()
+	}
+
+	if output := run("history", "-o", "json"); strings.TrimSpace(output) != "[]" {
+		t.Errorf("Expected no records before any change, got %s", output)
+	}
+
+	run("rename", "dev", "development")
+	run("remove", "development", "--yes")
+	// Dry runs change nothing and are not recorded
+	run("remove", "prod", "--dry-run")
+
+	records, err := audit.Read(auditFile())
+	if err != nil {
+		t.Fatalf("Failed to read the audit log: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected a record per change, got %+v", records)
+	}
+	renamed, removed := records[0], records[1]
+	if renamed.Command != "kubectx-manager rename" || renamed.Renamed["dev"] != "development" ||
+		renamed.Kubeconfigs[0] != kubeconfigPath || len(renamed.Backups) != 1 || renamed.Time.IsZero() {
+		t.Errorf("Unexpected rename record: %+v", renamed)
+	}
+	if removed.Command != "kubectx-manager remove" || strings.Join(removed.Removed, ",") != "development" ||
+		removed.Flags["yes"] != "true" || len(removed.Backups) != 1 {
+		t.Errorf("Unexpected remove record: %+v", removed)
+	}
+
+	var newest []audit.Record
+	if err := json.Unmarshal([]byte(run("history", "--limit", "1", "-o", "json")), &newest); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(newest) != 1 || newest[0].Summary != "Remove contexts development" {
+		t.Errorf("Expected only the newest record, got %+v", newest)
+	}
+
+	lines := strings.Split(strings.TrimSpace(run("history")), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "DATE") ||
+		!strings.Contains(lines[1], "Remove contexts development") || !strings.Contains(lines[2], "Rename context dev to development") {
+		t.Errorf("Expected a table of the changes, newest first, got %q", lines)
+	}
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// importOptions holds the flag values for a single invocation of the import command.
+type importOptions struct {
+	*globalOptions
+	configFile       string
+	inFileDuplicates string
+	autoRename       bool
+	dryRun           bool
+	showDiff         bool
+}
+
+func newImportCommand(global *globalOptions) *cobra.Command {
+	opts := &importOptions{globalOptions: global}
+
+	importCmd := &cobra.Command{
+		Use:   "import FILE [FILE...]",
+		Short: "Import contexts from another kubeconfig",
+		Long: `Import the contexts, clusters and users of downloaded kubeconfig files into your kubeconfig.
+Entries that already exist with identical configuration are skipped. For each entry whose name is
+taken by a different configuration you are asked whether to overwrite, keep the existing entry,
+or add the incoming one under a new name. --auto-rename (or --yes) renames without asking.
+A file that itself defines an entry more than once is rejected unless --in-file-duplicates says which to use.
+A backup is created before the kubeconfig is modified.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	importCmd.Flags().BoolVar(&opts.autoRename, "auto-rename", false, "Add conflicting entries under a new name without prompting")
+	importCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show what would be imported without modifying the kubeconfig")
+	importCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change")
+	addInFileDuplicatesFlag(importCmd, &opts.inFileDuplicates)
+	addConfigFlag(importCmd, &opts.configFile)
+
+	return importCmd
+}
+
+func (o *importOptions) run(out io.Writer, files []string) error {
+	target, err := o.singleKubeconfig("import")
+	if err != nil {
+		return err
+	}
+
+	opts := kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateRename}
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if opts.Duplicates, err = duplicatePolicy(o.inFileDuplicates, cfg); err != nil {
+		return err
+	}
+	if !o.autoRename && !o.yes {
+		askConflicts(o.prompter(), &opts)
+	}
+
+	return withDuplicatesHint(o.mergeFiles(out, target, files, opts, o.dryRun, o.showDiff, o.newLogger()))
+}
+
+// askConflicts makes opts ask p how to resolve each conflicting entry.
+func askConflicts(p Prompter, opts *kubeconfig.MergeOptions) {
+	opts.Resolve = func(conflict kubeconfig.Conflict) kubeconfig.DuplicateStrategy {
+		return askConflictResolution(p, conflict)
+	}
+}
+
+// askConflictResolution asks how to resolve a single conflicting entry.
+// Canceling, or failing to get an answer, as when stdin is not a terminal,
+// aborts the import.
+func askConflictResolution(p Prompter, conflict kubeconfig.Conflict) kubeconfig.DuplicateStrategy {
+	for {
+		p.Printf("%s '%s' already exists with a different configuration.\n", conflict.Kind, conflict.Name)
+		response, err := p.Ask("(o)verwrite, (k)eep existing, (r)ename incoming, (c)ancel import: ", "")
+		if err != nil {
+			return kubeconfig.DuplicateFail
+		}
+
+		switch strings.ToLower(response) {
+		case "o", "overwrite":
+			return kubeconfig.DuplicateOverwrite
+		case "k", "keep":
+			return kubeconfig.DuplicateKeep
+		case "r", "rename":
+			return kubeconfig.DuplicateRename
+		case "c", choiceCancel:
+			return kubeconfig.DuplicateFail
+		default:
+			p.Printf("Please answer o, k, r or c\n")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestImportAutoRename(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "config")
+	downloaded := filepath.Join(tmpDir, "downloaded.yaml")
+	if err := os.WriteFile(target, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(downloaded, []byte(mergeIncomingKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create downloaded kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"import", downloaded, "--auto-rename", "-q", "--kubeconfig", target})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	imported, err := kubeconfig.Load(target)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if imported.GetContext("qa") == nil {
+		t.Error("Expected context qa to be imported")
+	}
+	if cluster := imported.GetCluster("prod-cluster"); cluster == nil || cluster.Server != "https://prod.example.com" {
+		t.Errorf("Expected existing prod-cluster to be kept, got %+v", cluster)
+	}
+	if cluster := imported.GetCluster("prod-cluster-1"); cluster == nil || cluster.Server != "https://prod-new.example.com" {
+		t.Errorf("Expected conflicting cluster to be imported as prod-cluster-1, got %+v", cluster)
+	}
+	if ctx := imported.GetContext("prod-1"); ctx == nil || ctx.Cluster != "prod-cluster-1" {
+		t.Errorf("Expected renamed context to reference the renamed cluster, got %+v", ctx)
+	}
+}
+
+func TestAskConflictResolution(t *testing.T) {
+	tests := []struct {
+		input    []string
+		expected kubeconfig.DuplicateStrategy
+	}{
+		{[]string{"o"}, kubeconfig.DuplicateOverwrite},
+		{[]string{"keep"}, kubeconfig.DuplicateKeep},
+		{[]string{"x", "r"}, kubeconfig.DuplicateRename},
+		{[]string{"c"}, kubeconfig.DuplicateFail},
+		{nil, kubeconfig.DuplicateFail},
+	}
+
+	for _, tt := range tests {
+		got := askConflictResolution(newFakePrompter(tt.input...), kubeconfig.Conflict{Kind: "cluster", Name: "prod"})
+		if got != tt.expected {
+			t.Errorf("Input %q: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
@@ -13,13 +13,12 @@
 package cmd
 
 import (
-	"os"
 	"strings"
 	"testing"
 )
 
 // TestAskUserAboutConflicts tests the interactive user choice functionality
-// Since this function requires user input, we test it by mocking stdin
+// with scripted answers
 func TestAskUserAboutConflicts(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -30,104 +29,79 @@ func TestAskUserAboutConflicts(t *testing.T) {
 		{
 			name:      "user chooses no backup",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "n\n",
+			input:     "n",
 			expected:  "none",
 		},
 		{
 			name:      "user chooses selective backup",
 			conflicts: []string{"context 'prod' (different configuration)", "user 'admin' (different credentials)"},
-			input:     "s\n",
+			input:     "s",
 			expected:  "selective",
 		},
 		{
 			name:      "user chooses full backup",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "f\n",
+			input:     "f",
 			expected:  "full",
 		},
 		{
 			name:      "user chooses cancel",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "c\n",
+			input:     "c",
 			expected:  "cancel",
 		},
 		{
 			name:      "user enters 'no' (long form)",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "no\n",
+			input:     "no",
 			expected:  "none",
 		},
 		{
 			name:      "user enters 'selective' (long form)",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "selective\n",
+			input:     "selective",
 			expected:  "selective",
 		},
 		{
 			name:      "user enters 'full' (long form)",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "full\n",
+			input:     "full",
 			expected:  "full",
 		},
 		{
 			name:      "user enters 'cancel' (long form)",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "cancel\n",
+			input:     "cancel",
 			expected:  "cancel",
 		},
 		{
 			name:      "user enters invalid choice",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "invalid\n",
+			input:     "invalid",
 			expected:  "cancel",
 		},
 		{
 			name:      "user enters uppercase choice",
 			conflicts: []string{"context 'prod' (different configuration)"},
-			input:     "S\n",
+			input:     "S",
 			expected:  "selective",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Save original stdin
-			oldStdin := os.Stdin
-
-			// Create a pipe to simulate user input
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			// Write the test input to the pipe
-			go func() {
-				defer w.Close()
-				w.WriteString(tt.input)
-			}()
-
-			// Capture stdout to avoid printing during tests
-			oldStdout := os.Stdout
-			r2, w2, _ := os.Pipe()
-			os.Stdout = w2
-
-			// Call the function
-			result := askUserAboutConflicts(tt.conflicts)
-
-			// Restore original stdout and stdin
-			w2.Close()
-			os.Stdout = oldStdout
-			os.Stdin = oldStdin
-
-			// Read captured output (optional, can be used for verification)
-			output := make([]byte, 1024)
-			r2.Read(output)
-			r2.Close()
+			p := newFakePrompter(tt.input)
+			result, err := askUserAboutConflicts(p, tt.conflicts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 
 			if result != tt.expected {
 				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
 			}
 
 			// Verify that conflicts were displayed in output
-			outputStr := string(output)
+			outputStr := p.output.String()
 			for _, conflict := range tt.conflicts {
 				if !strings.Contains(outputStr, conflict) {
 					t.Errorf("Expected output to contain conflict '%s', but it didn't. Output: %s", conflict, outputStr)
@@ -144,36 +118,9 @@ func TestAskUserAboutConflictsOutput(t *testing.T) {
 		"user 'admin-user' (different credentials)",
 	}
 
-	// Save original stdin and stdout
-	oldStdin := os.Stdin
-	oldStdout := os.Stdout
-
-	// Create pipes
-	r, w, _ := os.Pipe()
-	os.Stdin = r
-
-	r2, w2, _ := os.Pipe()
-	os.Stdout = w2
-
-	// Provide input
-	go func() {
-		defer w.Close()
-		w.WriteString("n\n")
-	}()
-
-	// Call function
-	askUserAboutConflicts(conflicts)
-
-	// Close write end and restore stdout
-	w2.Close()
-	os.Stdout = oldStdout
-	os.Stdin = oldStdin
-
-	// Read output
-	output := make([]byte, 2048)
-	n, _ := r2.Read(output)
-	r2.Close()
-	outputStr := string(output[:n])
+	p := newFakePrompter("n")
+	askUserAboutConflicts(p, conflicts)
+	outputStr := p.output.String()
 
 	// Verify expected content is in output
 	expectedContent := []string{
@@ -231,36 +178,9 @@ func TestConflictDisplayFormatting(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Save original stdin and stdout
-			oldStdin := os.Stdin
-			oldStdout := os.Stdout
-
-			// Create pipes
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			r2, w2, _ := os.Pipe()
-			os.Stdout = w2
-
-			// Provide input
-			go func() {
-				defer w.Close()
-				w.WriteString("c\n") // Cancel to exit quickly
-			}()
-
-			// Call function
-			askUserAboutConflicts(tt.conflicts)
-
-			// Close write end and restore stdout
-			w2.Close()
-			os.Stdout = oldStdout
-			os.Stdin = oldStdin
-
-			// Read output
-			output := make([]byte, 2048)
-			n, _ := r2.Read(output)
-			r2.Close()
-			outputStr := string(output[:n])
+			p := newFakePrompter("c") // Cancel to exit quickly
+			askUserAboutConflicts(p, tt.conflicts)
+			outputStr := p.output.String()
 
 			// Verify the item count is correct
 			if !strings.Contains(outputStr, tt.expectedItemCount) {
@@ -0,0 +1,193 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// labelOptions holds the flag values for a single invocation of the label command.
+type labelOptions struct {
+	*globalOptions
+	configFile string
+	overwrite  bool
+}
+
+func newLabelCommand(global *globalOptions) *cobra.Command {
+	opts := &labelOptions{globalOptions: global}
+
+	labelCmd := &cobra.Command{
+		Use:   "label CONTEXT [KEY=VALUE | KEY-]...",
+		Short: "Add, change or remove the labels of a context",
+		Long: `Label a context or alias to group it with others, for example by team or environment, and
+select the group with -l in list and remove. KEY=VALUE sets a label and KEY- removes it; changing
+the value of an existing label requires --overwrite. Without labels to change, the labels of the
+context are printed.
+
+Labels are kept in an extension of the context in the kubeconfig, so they move with the context
+through copy, export, merge and archive, and kubectl leaves them alone.
+A backup is created before the kubeconfig is modified.`,
+		Example: `  kubectx-manager label prod-eu team=payments env=prod
+  kubectx-manager label prod-eu env=staging --overwrite
+  kubectx-manager label prod-eu team-
+  kubectx-manager list -l env=prod`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeFirstArgs(1, global.completeContexts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args[0], args[1:])
+		},
+	}
+
+	labelCmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, "Allow changing the value of existing labels")
+	addConfigFlag(labelCmd, &opts.configFile)
+
+	return labelCmd
+}
+
+// labelResult is the structured form of a label run.
+type labelResult struct {
+	Context string            `json:"context" yaml:"context"`
+	Labels  map[string]string `json:"labels" yaml:"labels"`
+	Backups []string          `json:"backups,omitempty" yaml:"backups,omitempty"`
+}
+
+// parseLabelChanges parses KEY=VALUE and KEY- arguments into the labels to
+// set and the keys to remove.
+func parseLabelChanges(args []string) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	var remove []string
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			if err := kubeconfig.ValidateLabel(key, value); err != nil {
+				return nil, nil, err
+			}
+			set[key] = value
+			continue
+		}
+		key, ok := strings.CutSuffix(arg, "-")
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid label '%s': expected KEY=VALUE or KEY-", arg)
+		}
+		if err := kubeconfig.ValidateLabel(key, ""); err != nil {
+			return nil, nil, err
+		}
+		remove = append(remove, key)
+	}
+	return set, remove, nil
+}
+
+func (o *labelOptions) run(out io.Writer, name string, changes []string) error {
+	if err := o.requireFormats("label", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	set, remove, err := parseLabelChanges(changes)
+	if err != nil {
+		return err
+	}
+	log := o.newLogger()
+
+	paths, err := o.kubeconfigChain("label")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	target := cfg.ResolveAlias(name)
+	ctx := multi.Merged.GetContext(target)
+	if ctx == nil {
+		return fmt.Errorf("context '%s' not found", name)
+	}
+	current := ctx.Labels()
+	result := labelResult{Context: target, Labels: maps.Clone(current)}
+	if result.Labels == nil {
+		result.Labels = map[string]string{}
+	}
+
+	if len(changes) == 0 {
+		if !o.isStructured() {
+			for _, pair := range strings.Split(kubeconfig.FormatLabels(current), ",") {
+				if pair != "" {
+					fmt.Fprintln(out, pair)
+				}
+			}
+			return nil
+		}
+		return o.printStructured(out, result)
+	}
+
+	for key, value := range set {
+		if old, ok := current[key]; ok && old != value && !o.overwrite {
+			return fmt.Errorf("context '%s' already has label %s=%s; use --overwrite to change it", target, key, old)
+		}
+		result.Labels[key] = value
+	}
+	for _, key := range remove {
+		delete(result.Labels, key)
+	}
+	if maps.Equal(result.Labels, current) {
+		log.Infof("Context '%s' already has these labels", target)
+		return o.printResult(out, result)
+	}
+
+	if err := multi.SetLabels(target, result.Labels); err != nil {
+		return err
+	}
+	modified := multi.ModifiedPaths()
+	for _, path := range modified {
+		backupPath, err := o.createBackup(path, log)
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Debugf("Created backup at: %s", backupPath)
+		result.Backups = append(result.Backups, backupPath)
+	}
+
+	if err := multi.Save(); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Label context %s %s", target, strings.Join(changes, " ")),
+		Kubeconfigs: modified,
+		Backups:     result.Backups,
+	}, log)
+
+	if len(result.Labels) == 0 {
+		log.Infof("Context '%s' has no labels", target)
+	} else {
+		log.Infof("Context '%s' is labeled %s", target, kubeconfig.FormatLabels(result.Labels))
+	}
+	return o.printResult(out, result)
+}
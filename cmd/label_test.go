@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestLabelCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--config", filepath.Join(tmpDir, "ignore")))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("label", "prod", "team=payments", "env=prod"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := run("label", "dev", "team=payments", "env=scratch"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := run("label", "prod", "env=staging"); err == nil {
+		t.Error("Expected an error changing a label without --overwrite")
+	}
+	if _, err := run("label", "prod", "env=staging", "team-", "--overwrite"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out, err := run("label", "prod"); err != nil || out != "env=staging\n" {
+		t.Errorf("Expected the labels of prod, got %q (%v)", out, err)
+	}
+	if _, err := run("label", "prod", "not a label"); err == nil {
+		t.Error("Expected an error for an invalid label")
+	}
+
+	out, err := run("list", "-l", "team=payments", "-o", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var entries []contextEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || entries[0].Name != "dev" ||
+		!reflect.DeepEqual(entries[0].Labels, map[string]string{"team": "payments", "env": "scratch"}) {
+		t.Errorf("Expected only dev with its labels, got %+v", entries)
+	}
+
+	if _, err := run("remove", "-l", "env=scratch", "--yes"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.GetContext("dev") != nil || kConfig.GetContext("prod") == nil {
+		t.Errorf("Expected only dev to be removed, got %v", kConfig.GetContextNames())
+	}
+	if _, err := run("remove"); err == nil {
+		t.Error("Expected an error without contexts or a selector")
+	}
+}
@@ -0,0 +1,374 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+// contextEntry is one row of the context inventory.
+type contextEntry struct {
+	Expiry   *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	LastUsed *time.Time `json:"lastUsed,omitempty" yaml:"lastUsed,omitempty"`
+	// AuthValid and AuthState are only set when --auth-check is given;
+	// contexts exempt from it have only the AuthState "skipped"
+	AuthValid   *bool                `json:"authValid,omitempty" yaml:"authValid,omitempty"`
+	AuthState   kubeconfig.AuthState `json:"authState,omitempty" yaml:"authState,omitempty"`
+	File        string               `json:"file,omitempty" yaml:"file,omitempty"`
+	Name        string               `json:"name" yaml:"name"`
+	Cluster     string               `json:"cluster" yaml:"cluster"`
+	Server      string               `json:"server" yaml:"server"`
+	User        string               `json:"user" yaml:"user"`
+	Namespace   string               `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	AuthType    string               `json:"authType" yaml:"authType"`
+	Aliases     []string             `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Current     bool                 `json:"current" yaml:"current"`
+	Whitelisted bool                 `json:"whitelisted" yaml:"whitelisted"`
+	// Labels are the labels set by the label command
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// info describes the context for matching configuration rules.
+func (e *contextEntry) info() config.ContextInfo {
+	return config.ContextInfo{Name: e.Name, Cluster: e.Cluster, User: e.User, Server: e.Server, Namespace: e.Namespace, AuthType: e.AuthType}
+}
+
+// csvHeader lists the columns written by list -o csv.
+var csvHeader = []string{"context", "cluster", "server", "user", "namespace", "auth_type", "expiry", "last_used", "current", "whitelisted", "auth_valid", "auth_state", "file"}
+
+// listOptions holds the flag values for a single invocation of the list command.
+type listOptions struct {
+	*globalOptions
+	configFile string
+	auth       authCheckFlags
+	authCheck  bool
+	selector   string
+	showLabels bool
+}
+
+func newListCommand(global *globalOptions) *cobra.Command {
+	opts := &listOptions{globalOptions: global}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all contexts in the kubeconfig",
+		Long: `List every context in the kubeconfig together with its cluster, server, user and authentication details,
+and whether it matches a whitelist pattern (and is therefore kept by cleanup).
+Use --auth-check to also test whether each context's credentials are valid and its cluster reachable.
+Use -l to list only the contexts whose labels match a selector, such as env=dev or team,!scratch.
+Use -o csv to export the inventory for spreadsheets or asset-management systems.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	listCmd.Flags().BoolVarP(&opts.authCheck, "auth-check", "a", false, "Check whether each context's authentication is valid and its cluster reachable")
+	listCmd.Flags().StringVarP(&opts.selector, "selector", "l", "",
+		"Only list contexts whose labels match this selector (e.g. env=dev,team!=payments)")
+	listCmd.Flags().BoolVar(&opts.showLabels, "show-labels", false, "Add a column with the labels of each context")
+	opts.auth.addFlags(listCmd)
+	addConfigFlag(listCmd, &opts.configFile)
+
+	return listCmd
+}
+
+func (o *listOptions) run(out io.Writer) error {
+	var selector *kubeconfig.Selector
+	if o.selector != "" {
+		var err error
+		if selector, err = kubeconfig.ParseSelector(o.selector); err != nil {
+			return err
+		}
+	}
+
+	chains, err := o.kubeconfigChains()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	authOpts, err := o.auth.options(cfg)
+	if err != nil {
+		return err
+	}
+
+	usageStore, err := usage.Load(usageFile())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var observed []string
+	var entries []contextEntry
+	for _, paths := range chains {
+		multi, err := kubeconfig.LoadMulti(paths)
+		if err != nil && o.isBulk() {
+			o.newLogger().Errorf("Skipping %s: %v", strings.Join(paths, string(filepath.ListSeparator)), err)
+			o.setExitCode(exitCodeBulkFailures)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load kubeconfig %s: %w", strings.Join(paths, string(filepath.ListSeparator)), err)
+		}
+		kConfig := multi.Merged
+		if cfg.TrackCurrentContext && kConfig.CurrentContext != "" {
+			usageStore.Record(kConfig.CurrentContext, now)
+			observed = append(observed, kConfig.CurrentContext)
+		}
+		fileEntries := buildInventory(kConfig)
+		if selector != nil {
+			fileEntries = slices.DeleteFunc(fileEntries, func(e contextEntry) bool { return !selector.Matches(e.Labels) })
+		}
+		for i := range fileEntries {
+			e := &fileEntries[i]
+			e.Whitelisted = cfg.MatchesContext(e.info())
+			e.Aliases = cfg.AliasesFor(e.Name)
+			if _, skip := cfg.SkipsAuthCheck(e.info()); o.authCheck && skip {
+				e.AuthState = authSkipped
+			} else if o.authCheck {
+				status := kubeconfig.CheckAuth(kConfig, e.Name, authOpts)
+				e.AuthValid, e.AuthState = &status.Valid, status.State
+				if !status.Valid {
+					o.setExitCode(exitCodeAuthFailures)
+				}
+			}
+			if lastUsed, ok := usageStore.Get(e.Name); ok {
+				e.LastUsed = &lastUsed
+			}
+			if len(chains) > 1 || len(multi.Files) > 1 {
+				e.File = multi.SourceOf(e.Name)
+			}
+		}
+		entries = append(entries, fileEntries...)
+	}
+	saveAuthCache(authOpts, o.newLogger())
+	if len(observed) > 0 {
+		// Like all usage recording this is best effort: list must still work
+		err := usage.Update(usageFile(), func(store *usage.Store) {
+			for _, name := range observed {
+				store.Record(name, now)
+			}
+		})
+		if err != nil {
+			o.newLogger().Debugf("Failed to record usage of the current context: %v", err)
+		}
+	}
+
+	switch o.output {
+	case outputCSV:
+		return writeInventoryCSV(out, entries)
+	case outputJSON, outputYAML:
+		return o.printStructured(out, entries)
+	default:
+		return writeInventoryTable(out, entries, o.authCheck, o.showLabels, o.colorOutput(), now)
+	}
+}
+
+// buildInventory collects one entry per context, sorted by name.
+func buildInventory(kConfig *kubeconfig.Config) []contextEntry {
+	names := kConfig.GetContextNames()
+	sort.Strings(names)
+
+	entries := make([]contextEntry, 0, len(names))
+	for _, name := range names {
+		ctx := kConfig.GetContext(name)
+		entry := contextEntry{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.User,
+			Namespace: ctx.Namespace,
+			Current:   kConfig.CurrentContext == name,
+			Labels:    ctx.Labels(),
+		}
+		if cluster := kConfig.GetCluster(ctx.Cluster); cluster != nil {
+			entry.Server = cluster.Server
+		}
+		user := kConfig.GetUser(ctx.User)
+		entry.AuthType = kubeconfig.AuthType(user)
+		if expiry, ok := kubeconfig.CredentialExpiry(user); ok {
+			entry.Expiry = &expiry
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func writeInventoryCSV(out io.Writer, entries []contextEntry) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range entries {
+		e := &entries[i]
+		record := []string{
+			e.Name, e.Cluster, e.Server, e.User, e.Namespace, e.AuthType,
+			formatOptionalTime(e.Expiry), formatOptionalTime(e.LastUsed), fmt.Sprintf("%t", e.Current),
+			fmt.Sprintf("%t", e.Whitelisted), formatOptionalBool(e.AuthValid), string(e.AuthState), e.File,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeInventoryTable writes entries as a table, with a LABELS column if
+// showLabels is set. With color, whitelisted contexts are green and, with
+// authCheck, those failing it red.
+func writeInventoryTable(out io.Writer, entries []contextEntry, authCheck, showLabels, color bool, now time.Time) error {
+	multiFile := len(entries) > 0 && entries[0].File != ""
+
+	// Rows are painted once aligned, as tabwriter would count the escape
+	// codes as part of the cells
+	var table bytes.Buffer
+	w := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	header := "CURRENT\tNAME\tCLUSTER\tSERVER\tUSER\tNAMESPACE\tAUTH\tLAST USED\tWHITELISTED"
+	if authCheck {
+		header += "\tAUTH VALID"
+	}
+	if showLabels {
+		header += "\tLABELS"
+	}
+	if multiFile {
+		header += "\tFILE"
+	}
+	fmt.Fprintln(w, header)
+	for i := range entries {
+		e := &entries[i]
+		current := ""
+		if e.Current {
+			current = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", current, e.Name, e.Cluster, e.Server, e.User, e.Namespace, e.AuthType,
+			formatLastUsed(e.LastUsed, now), yesNo(e.Whitelisted))
+		if authCheck {
+			fmt.Fprintf(w, "\t%s", formatAuthValid(e))
+		}
+		if showLabels {
+			fmt.Fprintf(w, "\t%s", kubeconfig.FormatLabels(e.Labels))
+		}
+		if multiFile {
+			fmt.Fprintf(w, "\t%s", e.File)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	rows := strings.SplitAfter(table.String(), "\n")
+	for i := range entries {
+		e := &entries[i]
+		switch {
+		case authCheck && e.AuthState != authSkipped && (e.AuthValid == nil || !*e.AuthValid):
+			rows[i+1] = paintRow(color, logger.Red, rows[i+1])
+		case e.Whitelisted:
+			rows[i+1] = paintRow(color, logger.Green, rows[i+1])
+		}
+	}
+	_, err := io.WriteString(out, strings.Join(rows, ""))
+	return err
+}
+
+// authSkipped is the auth state of contexts a skip-auth-check pattern
+// exempts from the auth check
+const authSkipped kubeconfig.AuthState = "skipped"
+
+// formatAuthValid says whether the context passed the auth check and, when
+// it failed, in which state: "no (expired)", or "yes (unreachable)" when
+// the failure is kept by policy. Contexts exempt from it are "skipped".
+func formatAuthValid(e *contextEntry) string {
+	if e.AuthState == authSkipped {
+		return string(authSkipped)
+	}
+	valid := yesNo(e.AuthValid != nil && *e.AuthValid)
+	if e.AuthState != "" && e.AuthState != kubeconfig.AuthOK {
+		valid += " (" + string(e.AuthState) + ")"
+	}
+	return valid
+}
+
+// paintRow paints a table row, leaving its line break unpainted.
+func paintRow(enabled bool, c logger.Color, row string) string {
+	text := strings.TrimSuffix(row, "\n")
+	return logger.Paint(enabled, c, text) + row[len(text):]
+}
+
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprintf("%t", *b)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// formatLastUsed tells how long ago a context was last used, in the largest
+// whole unit: "just now", "5 minutes ago", "3 hours ago", "94 days ago".
+func formatLastUsed(t *time.Time, now time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	elapsed := now.Sub(*t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return unitsAgo(int(elapsed/time.Minute), "minute")
+	case elapsed < 24*time.Hour:
+		return unitsAgo(int(elapsed/time.Hour), "hour")
+	default:
+		return unitsAgo(int(elapsed/(24*time.Hour)), "day")
+	}
+}
+
+func unitsAgo(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
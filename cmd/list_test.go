@@ -0,0 +1,328 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+const listTestKubeconfig = `apiVersion: v1
+kind: Config
+current-context: prod
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+    namespace: apps
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+users:
+- name: prod-user
+  user:
+    token: prod-token
+- name: dev-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: aws
+`
+
+func runListCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs(append([]string{"list", "--kubeconfig", kubeconfigPath, "--config", configPath}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return out.String()
+}
+
+func TestListCSV(t *testing.T) {
+	records, err := csv.NewReader(strings.NewReader(runListCommand(t, "-o", "csv"))).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+
+	// Rows are sorted by context name
+	if records[1][0] != "dev" || records[1][5] != "exec:aws" || records[1][8] != "false" {
+		t.Errorf("Unexpected dev row: %v", records[1])
+	}
+	if records[2][0] != "prod" || records[2][2] != "https://prod.example.com" || records[2][4] != "apps" ||
+		records[2][5] != "token" || records[2][8] != "true" {
+		t.Errorf("Unexpected prod row: %v", records[2])
+	}
+
+	// Whitelist status comes from the configuration file; auth is only checked on request
+	if records[1][9] != "false" || records[2][9] != "true" || records[2][10] != "" {
+		t.Errorf("Unexpected whitelist/auth columns: %v / %v", records[1], records[2])
+	}
+}
+
+func TestListTable(t *testing.T) {
+	output := runListCommand(t)
+	if !strings.Contains(output, "NAME") || !strings.Contains(output, "WHITELISTED") ||
+		!strings.Contains(output, "https://dev.example.com") {
+		t.Errorf("Unexpected table output:\n%s", output)
+	}
+}
+
+func TestListColor(t *testing.T) {
+	saved := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return true }
+	t.Cleanup(func() { stdoutIsTerminal = saved })
+
+	output := runListCommand(t)
+	lines := strings.Split(output, "\n")
+	if len(lines) < 3 || !strings.HasPrefix(lines[2], "\x1b[32m*") || !strings.HasSuffix(lines[2], "yes\x1b[0m") {
+		t.Errorf("Expected the whitelisted prod row to be green:\n%q", output)
+	}
+	if strings.Contains(lines[0]+lines[1], "\x1b[") {
+		t.Errorf("Expected the header and dev row to be plain:\n%q", output)
+	}
+	// Painting whole rows keeps the columns aligned
+	plain := strings.ReplaceAll(strings.ReplaceAll(output, "\x1b[32m", ""), "\x1b[0m", "")
+	if plain != runListCommand(t, "--no-color") {
+		t.Errorf("Expected --no-color to give the same table without colors:\n%q", output)
+	}
+
+	t.Setenv("NO_COLOR", "")
+	if output := runListCommand(t); strings.Contains(output, "\x1b[") {
+		t.Errorf("Expected NO_COLOR to disable colors:\n%q", output)
+	}
+}
+
+func TestListLastUsed(t *testing.T) {
+	_ = os.Remove(usageFile())
+	t.Cleanup(func() { _ = os.Remove(usageFile()) })
+	if err := recordUsage("dev", time.Now().Add(-94*24*time.Hour)); err != nil {
+		t.Fatalf("Failed to record usage: %v", err)
+	}
+
+	output := runListCommand(t)
+	if !strings.Contains(output, "LAST USED") || !strings.Contains(output, "94 days ago") || !strings.Contains(output, "never") {
+		t.Errorf("Expected dev last used 94 days ago and prod never:\n%s", output)
+	}
+}
+
+func TestListTracksCurrentContext(t *testing.T) {
+	_ = os.Remove(usageFile())
+	t.Cleanup(func() { _ = os.Remove(usageFile()) })
+
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("set track-current-context = true\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"list", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	store, err := usage.Load(usageFile())
+	if err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+	if _, ok := store.Get("prod"); !ok {
+		t.Errorf("Expected the current context prod to be recorded as used")
+	}
+	if _, ok := store.Get("dev"); ok {
+		t.Errorf("Expected dev not to be recorded")
+	}
+}
+
+func TestFormatLastUsed(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{30 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{5 * time.Minute, "5 minutes ago"},
+		{3 * time.Hour, "3 hours ago"},
+		{94*24*time.Hour + time.Hour, "94 days ago"},
+	}
+
+	for _, tt := range tests {
+		at := now.Add(-tt.ago)
+		if got := formatLastUsed(&at, now); got != tt.expected {
+			t.Errorf("%v: expected %q, got %q", tt.ago, tt.expected, got)
+		}
+	}
+	if got := formatLastUsed(nil, now); got != "never" {
+		t.Errorf("Expected never, got %q", got)
+	}
+}
+
+func TestListAuthCheckCache(t *testing.T) {
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		probes.Add(1)
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	content := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", server.URL)
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	defer func(old func() string) { authCacheFile = old }(authCacheFile)
+	authCacheFile = func() string { return filepath.Join(tmpDir, "auth-cache.json") }
+
+	list := func(args ...string) {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"list", "--auth-check", "-q", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore")}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("list %v failed: %v", args, err)
+		}
+	}
+	list()
+	list()
+	if got := probes.Load(); got != 1 {
+		t.Errorf("Expected the second run to reuse the probe, got %d probes", got)
+	}
+	list("--no-cache")
+	if got := probes.Load(); got != 2 {
+		t.Errorf("Expected --no-cache to probe again, got %d probes", got)
+	}
+}
+
+func TestListAuthCheckRemoveOn(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	content := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://127.0.0.1:1")
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	list := func(args ...string) (map[string]contextEntry, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"list", "--auth-check", "-o", "json", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore"), "--auth-timeout", "1s"}, args...))
+		if err := root.Execute(); err != nil {
+			return nil, err
+		}
+		var entries []contextEntry
+		if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+			t.Fatalf("Failed to parse list output: %v", err)
+		}
+		byName := make(map[string]contextEntry)
+		for _, e := range entries {
+			byName[e.Name] = e
+		}
+		return byName, nil
+	}
+
+	entries, err := list()
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if prod := entries["prod"]; prod.AuthValid == nil || *prod.AuthValid || prod.AuthState != kubeconfig.AuthUnreachable {
+		t.Errorf("Expected the unreachable prod context to fail, got %+v", prod)
+	}
+
+	entries, err = list("--remove-on", "unauthorized,expired")
+	if err != nil {
+		t.Fatalf("list --remove-on failed: %v", err)
+	}
+	if prod := entries["prod"]; prod.AuthValid == nil || !*prod.AuthValid || prod.AuthState != kubeconfig.AuthUnreachable {
+		t.Errorf("Expected --remove-on unauthorized,expired to keep the unreachable prod context, got %+v", prod)
+	}
+
+	if _, err := list("--remove-on", "offline"); err == nil || !strings.Contains(err.Error(), "--remove-on") {
+		t.Errorf("Expected an unknown auth state to be rejected, got %v", err)
+	}
+}
+
+func TestListUnreachableGrace(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	content := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://127.0.0.1:1")
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	defer func(old func() string) { outageLogFile = old }(outageLogFile)
+	outageLogFile = func() string { return filepath.Join(tmpDir, "outages.json") }
+
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"list", "--auth-check", "--unreachable-grace", "7d", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(tmpDir, "ignore"), "--auth-timeout", "1s"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "yes (unreachable)") {
+		t.Errorf("Expected the unreachable prod context to be kept by the grace period, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "outages.json")); err != nil {
+		t.Errorf("Expected the outage to be recorded: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// mergeOptions holds the flag values for a single invocation of the merge command.
+type mergeOptions struct {
+	*globalOptions
+	configFile       string
+	into             string
+	renameSuffix     string
+	inFileDuplicates string
+	preferExisting   bool
+	preferIncoming   bool
+	dryRun           bool
+	showDiff         bool
+}
+
+func newMergeCommand(global *globalOptions) *cobra.Command {
+	opts := &mergeOptions{globalOptions: global}
+
+	mergeCmd := &cobra.Command{
+		Use:   "merge FILE [FILE...]",
+		Short: "Merge kubeconfig files into one",
+		Long: `Merge the contexts, clusters and users of one or more kubeconfig files into a target kubeconfig
+(--into, defaulting to --kubeconfig). Entries that already exist with identical configuration are skipped.
+Entries with the same name but different configuration are conflicts; choose how to resolve them with
+--prefer-existing, --prefer-incoming or --rename-suffix. Without one of these the merge stops at the first conflict.
+A file that itself defines an entry more than once is rejected unless --in-file-duplicates says which to use.
+A backup of the target is created before it is modified.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	mergeCmd.Flags().StringVar(&opts.into, "into", "", "Kubeconfig to merge into (default: --kubeconfig)")
+	mergeCmd.Flags().BoolVar(&opts.preferExisting, "prefer-existing", false, "Keep the target's entry when a name conflicts")
+	mergeCmd.Flags().BoolVar(&opts.preferIncoming, "prefer-incoming", false, "Replace the target's entry when a name conflicts")
+	mergeCmd.Flags().StringVar(&opts.renameSuffix, "rename-suffix", "", "Add conflicting incoming entries under their name plus this suffix")
+	mergeCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show what would be merged without modifying the target")
+	mergeCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the target kubeconfig change")
+	addInFileDuplicatesFlag(mergeCmd, &opts.inFileDuplicates)
+	addConfigFlag(mergeCmd, &opts.configFile)
+	mergeCmd.MarkFlagsMutuallyExclusive("prefer-existing", "prefer-incoming", "rename-suffix")
+
+	return mergeCmd
+}
+
+// strategy returns the merge options selected by the conflict flags.
+func (o *mergeOptions) strategy() kubeconfig.MergeOptions {
+	switch {
+	case o.preferExisting:
+		return kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateKeep}
+	case o.preferIncoming:
+		return kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateOverwrite}
+	case o.renameSuffix != "":
+		return kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateRename, RenameSuffix: o.renameSuffix}
+	default:
+		return kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateFail}
+	}
+}
+
+func (o *mergeOptions) run(out io.Writer, files []string) error {
+	var err error
+	target := expandHome(o.into)
+	if target == "" {
+		if target, err = o.singleKubeconfig("merge"); err != nil {
+			return err
+		}
+	}
+	opts := o.strategy()
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if opts.Duplicates, err = duplicatePolicy(o.inFileDuplicates, cfg); err != nil {
+		return err
+	}
+	err = withDuplicatesHint(o.mergeFiles(out, target, files, opts, o.dryRun, o.showDiff, o.newLogger()))
+	var dupErr *kubeconfig.DuplicateError
+	if errors.As(err, &dupErr) {
+		return fmt.Errorf("%w (use --prefer-existing, --prefer-incoming or --rename-suffix)", err)
+	}
+	return err
+}
+
+// mergeResult is the structured form of a merge or import run.
+type mergeResult struct {
+	Target string            `json:"target" yaml:"target"`
+	Files  []mergeFileResult `json:"files" yaml:"files"`
+	Backup string            `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DryRun bool              `json:"dryRun" yaml:"dryRun"`
+}
+
+// mergeFileResult describes what was merged from a single file.
+type mergeFileResult struct {
+	File   string                  `json:"file" yaml:"file"`
+	Report *kubeconfig.MergeReport `json:"report" yaml:"report"`
+}
+
+// mergeFiles merges each file into the target kubeconfig, creating it if needed,
+// and prints the result to out with a structured --output format.
+// The target is backed up before it is overwritten.
+func (g *globalOptions) mergeFiles(out io.Writer, target string, files []string, opts kubeconfig.MergeOptions, dryRun, showDiff bool, log *logger.Logger) error {
+	locks, err := kubeconfig.LockAll([]string{target})
+	if err != nil {
+		return err
+	}
+	defer g.unlock(locks)
+
+	targetConfig, err := kubeconfig.Load(target)
+	targetExists := err == nil
+	if errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		log.Debugf("Target %s does not exist, it will be created", target)
+		targetConfig = &kubeconfig.Config{}
+	} else if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	before, err := kubeconfig.Marshal(targetConfig)
+	if err != nil {
+		return err
+	}
+
+	result := mergeResult{Target: target, Files: make([]mergeFileResult, 0, len(files)), DryRun: dryRun}
+	for _, file := range files {
+		incoming, err := kubeconfig.Load(expandHome(file))
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", file, err)
+		}
+		report, err := kubeconfig.MergeWithOptions(targetConfig, incoming, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		log.Infof("==> %s", file)
+		logMergeReport(report, log)
+		result.Files = append(result.Files, mergeFileResult{File: file, Report: report})
+	}
+
+	after, err := kubeconfig.Marshal(targetConfig)
+	if err != nil {
+		return err
+	}
+	if showDiff {
+		printDiff(log, string(before), string(after), target, target+" (merged)")
+	}
+	if targetExists && bytes.Equal(before, after) {
+		log.Infof("Nothing to merge: %s already holds every entry", target)
+		return g.printResult(out, result)
+	}
+	if dryRun {
+		log.Infof("Dry run mode - no changes made")
+		g.setExitCode(exitCodeChanged)
+		return g.printResult(out, result)
+	}
+
+	if targetExists {
+		backupPath, err := g.createBackup(target, log)
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Infof("Created backup at: %s", backupPath)
+		result.Backup = backupPath
+	}
+
+	if err := kubeconfig.Save(targetConfig, target); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	g.recordChange(&audit.Record{Summary: "Merge " + listSubject(names), Kubeconfigs: []string{target}, Backups: nonEmpty(result.Backup)}, log)
+	log.Infof("Merged %d file(s) into %s", len(files), target)
+	return g.printResult(out, result)
+}
@@ -187,7 +187,7 @@ func TestAnalyzeRestoreConflicts(t *testing.T) {
 				t.Fatalf("Failed to load backup config: %v", err)
 			}
 
-			log := logger.New(false, true) // quiet logger for tests
+			log := logger.New(logger.Options{Level: logger.LevelError}) // quiet logger for tests
 			conflicts := analyzeRestoreConflicts(currentConfig, backupConfig, log)
 
 			if len(conflicts) != len(tt.expectedConflicts) {
@@ -212,207 +212,6 @@ func TestAnalyzeRestoreConflicts(t *testing.T) {
 	}
 }
 
-func TestContextsEqual(t *testing.T) {
-	tests := []struct {
-		a        *kubeconfig.Context
-		b        *kubeconfig.Context
-		name     string
-		expected bool
-	}{
-		{
-			name:     "identical contexts",
-			a:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
-			b:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
-			expected: true,
-		},
-		{
-			name:     "different cluster",
-			a:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
-			b:        &kubeconfig.Context{Cluster: "c2", User: "u1", Namespace: "ns1"},
-			expected: false,
-		},
-		{
-			name:     "different user",
-			a:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
-			b:        &kubeconfig.Context{Cluster: "c1", User: "u2", Namespace: "ns1"},
-			expected: false,
-		},
-		{
-			name:     "different namespace",
-			a:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
-			b:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "ns2"},
-			expected: false,
-		},
-		{
-			name:     "empty namespace vs set namespace",
-			a:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: ""},
-			b:        &kubeconfig.Context{Cluster: "c1", User: "u1", Namespace: "default"},
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := contextsEqual(tt.a, tt.b)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
-func TestClustersEqual(t *testing.T) {
-	tests := []struct {
-		a        *kubeconfig.Cluster
-		b        *kubeconfig.Cluster
-		name     string
-		expected bool
-	}{
-		{
-			name: "identical clusters",
-			a: &kubeconfig.Cluster{
-				Server:                   "https://api.example.com",
-				CertificateAuthorityData: "cert-data",
-				InsecureSkipTLSVerify:    false,
-			},
-			b: &kubeconfig.Cluster{
-				Server:                   "https://api.example.com",
-				CertificateAuthorityData: "cert-data",
-				InsecureSkipTLSVerify:    false,
-			},
-			expected: true,
-		},
-		{
-			name: "different server",
-			a: &kubeconfig.Cluster{
-				Server: "https://api1.example.com",
-			},
-			b: &kubeconfig.Cluster{
-				Server: "https://api2.example.com",
-			},
-			expected: false,
-		},
-		{
-			name: "different certificate data",
-			a: &kubeconfig.Cluster{
-				Server:                   "https://api.example.com",
-				CertificateAuthorityData: "cert-data-1",
-			},
-			b: &kubeconfig.Cluster{
-				Server:                   "https://api.example.com",
-				CertificateAuthorityData: "cert-data-2",
-			},
-			expected: false,
-		},
-		{
-			name: "different insecure skip TLS",
-			a: &kubeconfig.Cluster{
-				Server:                "https://api.example.com",
-				InsecureSkipTLSVerify: true,
-			},
-			b: &kubeconfig.Cluster{
-				Server:                "https://api.example.com",
-				InsecureSkipTLSVerify: false,
-			},
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := clustersEqual(tt.a, tt.b)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
-func TestUsersEqual(t *testing.T) {
-	tests := []struct {
-		a        *kubeconfig.User
-		b        *kubeconfig.User
-		name     string
-		expected bool
-	}{
-		{
-			name: "identical token users",
-			a: &kubeconfig.User{
-				Token: "abc123",
-			},
-			b: &kubeconfig.User{
-				Token: "abc123",
-			},
-			expected: true,
-		},
-		{
-			name: "different tokens",
-			a: &kubeconfig.User{
-				Token: "abc123",
-			},
-			b: &kubeconfig.User{
-				Token: "def456",
-			},
-			expected: false,
-		},
-		{
-			name: "identical cert users",
-			a: &kubeconfig.User{
-				ClientCertificateData: "cert-data",
-				ClientKeyData:         "key-data",
-			},
-			b: &kubeconfig.User{
-				ClientCertificateData: "cert-data",
-				ClientKeyData:         "key-data",
-			},
-			expected: true,
-		},
-		{
-			name: "different cert data",
-			a: &kubeconfig.User{
-				ClientCertificateData: "cert-data-1",
-			},
-			b: &kubeconfig.User{
-				ClientCertificateData: "cert-data-2",
-			},
-			expected: false,
-		},
-		{
-			name: "identical basic auth users",
-			a: &kubeconfig.User{
-				Username: "admin",
-				Password: "secret",
-			},
-			b: &kubeconfig.User{
-				Username: "admin",
-				Password: "secret",
-			},
-			expected: true,
-		},
-		{
-			name: "different passwords",
-			a: &kubeconfig.User{
-				Username: "admin",
-				Password: "secret1",
-			},
-			b: &kubeconfig.User{
-				Username: "admin",
-				Password: "secret2",
-			},
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := usersEqual(tt.a, tt.b)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
 func TestExtractNameFromConflict(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -534,8 +333,8 @@ func TestCreateSelectiveBackup(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			log := logger.New(false, true) // quiet logger
-			backupPath, err := createSelectiveBackup(kubeconfigPath, tt.conflicts, log)
+			log := logger.New(logger.Options{Level: logger.LevelError}) // quiet logger
+			backupPath, err := createSelectiveBackup(kubeconfigPath, "", kubeconfig.Encryption{}, tt.conflicts, log)
 			if err != nil {
 				t.Errorf("Unexpected error: %v", err)
 				return
@@ -684,12 +483,12 @@ func TestShouldCreateBackupBeforeRestore(t *testing.T) {
 				t.Fatalf("Failed to save backup config: %v", err)
 			}
 
-			selectedBackup := Backup{
+			selectedBackup := kubeconfig.Backup{
 				Name: "test-backup",
 				Path: backupPath,
 			}
 
-			log := logger.New(false, true) // quiet logger
+			log := logger.New(logger.Options{Level: logger.LevelError}) // quiet logger
 
 			// Mock the user interaction for conflicts
 			// We'll need to test this function in parts due to the interactive nature
@@ -713,7 +512,7 @@ func TestShouldCreateBackupBeforeRestore(t *testing.T) {
 
 			// For the no-conflict case, we can test the full function
 			if tt.expectedConflictCount == 0 {
-				shouldBackup, reason, conflictList := shouldCreateBackupBeforeRestore(currentPath, []Backup{}, selectedBackup, log)
+				shouldBackup, reason, conflictList, _ := shouldCreateBackupBeforeRestore(newFakePrompter(), currentPath, kubeconfig.Decryption{}, selectedBackup, "", log)
 
 				if shouldBackup != tt.expectedShouldBackup {
 					t.Errorf("Expected shouldBackup=%v, got %v", tt.expectedShouldBackup, shouldBackup)
@@ -733,7 +532,7 @@ func TestShouldCreateBackupBeforeRestore(t *testing.T) {
 
 func TestShouldCreateBackupBeforeRestoreErrorCases(t *testing.T) {
 	tmpDir := t.TempDir()
-	log := logger.New(false, true)
+	log := logger.New(logger.Options{Level: logger.LevelError})
 
 	tests := []struct {
 		name           string
@@ -766,11 +565,11 @@ func TestShouldCreateBackupBeforeRestoreErrorCases(t *testing.T) {
 				kubeconfig.Save(config, tt.kubeconfigPath)
 			}
 
-			selectedBackup := Backup{
+			selectedBackup := kubeconfig.Backup{
 				Path: tt.backupPath,
 			}
 
-			shouldBackup, reason, conflicts := shouldCreateBackupBeforeRestore(tt.kubeconfigPath, []Backup{}, selectedBackup, log)
+			shouldBackup, reason, conflicts, _ := shouldCreateBackupBeforeRestore(newFakePrompter(), tt.kubeconfigPath, kubeconfig.Decryption{}, selectedBackup, "", log)
 
 			if tt.expectedError {
 				if shouldBackup != true {
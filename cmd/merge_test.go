@@ -0,0 +1,204 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+const mergeIncomingKubeconfig = `apiVersion: v1
+kind: Config
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+- name: qa
+  context:
+    cluster: qa-cluster
+    user: qa-user
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod-new.example.com
+- name: qa-cluster
+  cluster:
+    server: https://qa.example.com
+users:
+- name: prod-user
+  user:
+    token: prod-token
+- name: qa-user
+  user:
+    token: qa-token
+`
+
+func runMergeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "config")
+	incoming := filepath.Join(tmpDir, "incoming")
+	if err := os.WriteFile(target, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(incoming, []byte(mergeIncomingKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create incoming kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetArgs(append([]string{"merge", incoming, "--into", target, "-q"}, args...))
+	return target, root.Execute()
+}
+
+func TestMergeCommandStrategies(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedServer string
+		renamedCluster string
+	}{
+		{"prefer existing", []string{"--prefer-existing"}, "https://prod.example.com", ""},
+		{"prefer incoming", []string{"--prefer-incoming"}, "https://prod-new.example.com", ""},
+		{"rename suffix", []string{"--rename-suffix", "-new"}, "https://prod.example.com", "prod-cluster-new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := runMergeCommand(t, tt.args...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			merged, err := kubeconfig.Load(target)
+			if err != nil {
+				t.Fatalf("Failed to load merged kubeconfig: %v", err)
+			}
+			if merged.GetContext("qa") == nil {
+				t.Error("Expected new context qa to be merged")
+			}
+			if cluster := merged.GetCluster("prod-cluster"); cluster == nil || cluster.Server != tt.expectedServer {
+				t.Errorf("Expected prod-cluster server %s, got %+v", tt.expectedServer, cluster)
+			}
+			if tt.renamedCluster != "" && merged.GetCluster(tt.renamedCluster) == nil {
+				t.Errorf("Expected conflicting cluster to be added as %s", tt.renamedCluster)
+			}
+			if backups, _ := kubeconfig.FindBackups(target, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+				t.Errorf("Expected one backup of the target, got %d", len(backups))
+			}
+		})
+	}
+}
+
+func TestMergeCommandConflictWithoutStrategy(t *testing.T) {
+	target, err := runMergeCommand(t)
+	if !errors.Is(err, kubeconfig.ErrConflict) || !strings.Contains(err.Error(), "--prefer-existing") {
+		t.Fatalf("Expected a conflict error suggesting a strategy, got %v", err)
+	}
+
+	data, readErr := os.ReadFile(target)
+	if readErr != nil || string(data) != listTestKubeconfig {
+		t.Errorf("Expected target to be unchanged after a failed merge (err %v)", readErr)
+	}
+}
+
+func TestMergeCommandInFileDuplicates(t *testing.T) {
+	const incomingWithDuplicates = `apiVersion: v1
+kind: Config
+contexts:
+- name: qa
+  context:
+    cluster: qa-cluster
+    user: qa-user
+clusters:
+- name: qa-cluster
+  cluster:
+    server: https://qa-first.example.com
+- name: qa-cluster
+  cluster:
+    server: https://qa-last.example.com
+users:
+- name: qa-user
+  user:
+    token: qa-token
+`
+	tests := []struct {
+		name           string
+		args           []string
+		setting        string
+		expectedServer string
+	}{
+		{"rejected by default", nil, "", ""},
+		{"first", []string{"--in-file-duplicates", "first"}, "", "https://qa-first.example.com"},
+		{"last from the configuration file", nil, "last", "https://qa-last.example.com"},
+		{"flag overrides the configuration file", []string{"--in-file-duplicates", "first"}, "last", "https://qa-first.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			target := filepath.Join(tmpDir, "config")
+			incoming := filepath.Join(tmpDir, "incoming")
+			configFile := filepath.Join(tmpDir, "ignore")
+			if err := os.WriteFile(target, []byte(listTestKubeconfig), 0600); err != nil {
+				t.Fatalf("Failed to create kubeconfig: %v", err)
+			}
+			if err := os.WriteFile(incoming, []byte(incomingWithDuplicates), 0600); err != nil {
+				t.Fatalf("Failed to create incoming kubeconfig: %v", err)
+			}
+			setting := ""
+			if tt.setting != "" {
+				setting = "set in-file-duplicates = " + tt.setting + "\n"
+			}
+			if err := os.WriteFile(configFile, []byte(setting), 0600); err != nil {
+				t.Fatalf("Failed to create config: %v", err)
+			}
+
+			root := NewRootCommand()
+			root.SilenceErrors = true
+			root.SilenceUsage = true
+			root.SetArgs(append([]string{"merge", incoming, "--into", target, "--config", configFile, "-q"}, tt.args...))
+			err := root.Execute()
+
+			if tt.expectedServer == "" {
+				if !errors.Is(err, kubeconfig.ErrConflict) || !strings.Contains(err.Error(), "--in-file-duplicates") {
+					t.Fatalf("Expected a duplicate error suggesting --in-file-duplicates, got %v", err)
+				}
+				if data, _ := os.ReadFile(target); string(data) != listTestKubeconfig {
+					t.Error("Expected target to be unchanged after a failed merge")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			merged, err := kubeconfig.Load(target)
+			if err != nil {
+				t.Fatalf("Failed to load merged kubeconfig: %v", err)
+			}
+			if cluster := merged.GetCluster("qa-cluster"); cluster == nil || cluster.Server != tt.expectedServer {
+				t.Errorf("Expected qa-cluster server %s, got %+v", tt.expectedServer, cluster)
+			}
+			if n := len(merged.Clusters); n != 3 {
+				t.Errorf("Expected a single qa-cluster to be added, got %d clusters", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// namespacePattern matches valid namespace names, which are DNS labels
+var namespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// maxNamespaceLength is the longest a DNS label, and so a namespace, may be
+const maxNamespaceLength = 63
+
+// setNamespaceOptions holds the flag values for a single invocation of the set-namespace command.
+type setNamespaceOptions struct {
+	*globalOptions
+	configFile string
+	context    string
+}
+
+func newSetNamespaceCommand(global *globalOptions) *cobra.Command {
+	opts := &setNamespaceOptions{globalOptions: global}
+
+	setNamespaceCmd := &cobra.Command{
+		Use:   "set-namespace NAMESPACE",
+		Short: "Change the namespace of a context",
+		Long: `Set the default namespace of the current context, or of the context or alias given
+with --context, in the file that defines it. A backup is created before the kubeconfig is modified.`,
+		Example: `  # Use the team-a namespace in the current context
+  kubectx-manager set-namespace team-a
+
+  # Change another context
+  kubectx-manager set-namespace monitoring --context prod`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	setNamespaceCmd.Flags().StringVar(&opts.context, "context", "", "Context or alias to change instead of the current context")
+	_ = setNamespaceCmd.RegisterFlagCompletionFunc("context", global.completeContexts)
+	addConfigFlag(setNamespaceCmd, &opts.configFile)
+
+	return setNamespaceCmd
+}
+
+// setNamespaceResult is the structured form of a set-namespace run.
+type setNamespaceResult struct {
+	Context   string   `json:"context" yaml:"context"`
+	Previous  string   `json:"previous" yaml:"previous"`
+	Namespace string   `json:"namespace" yaml:"namespace"`
+	Backups   []string `json:"backups,omitempty" yaml:"backups,omitempty"`
+}
+
+func (o *setNamespaceOptions) run(out io.Writer, namespace string) error {
+	if len(namespace) > maxNamespaceLength || !namespacePattern.MatchString(namespace) {
+		return fmt.Errorf("invalid namespace '%s': must be at most %d lowercase letters, digits and '-', starting and ending with a letter or digit",
+			namespace, maxNamespaceLength)
+	}
+	log := o.newLogger()
+
+	paths, err := o.kubeconfigChain("set-namespace")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+
+	target := kConfig.CurrentContext
+	if o.context != "" {
+		target = cfg.ResolveAlias(o.context)
+	} else if target == "" {
+		return fmt.Errorf("no current context is set; name the context with --context")
+	}
+	ctx := kConfig.GetContext(target)
+	if ctx == nil {
+		return fmt.Errorf("context '%s' not found", target)
+	}
+	result := setNamespaceResult{Context: target, Previous: ctx.Namespace, Namespace: namespace}
+
+	if ctx.Namespace == namespace {
+		log.Infof("Context '%s' already uses namespace '%s'", target, namespace)
+		return o.printResult(out, result)
+	}
+
+	if err := multi.SetNamespace(target, namespace); err != nil {
+		return err
+	}
+	modified := multi.ModifiedPaths()
+	for _, path := range modified {
+		backupPath, err := o.createBackup(path, log)
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Debugf("Created backup at: %s", backupPath)
+		result.Backups = append(result.Backups, backupPath)
+	}
+
+	if err := multi.Save(); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Set namespace of context %s to %s", target, namespace),
+		Kubeconfigs: modified,
+		Backups:     result.Backups,
+	}, log)
+
+	log.Infof("Context '%s' now uses namespace '%s'", target, namespace)
+	return o.printResult(out, result)
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestSetNamespaceCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	// The current context by default, another with --context
+	for _, args := range [][]string{
+		{"set-namespace", "monitoring"},
+		{"set-namespace", "team-a", "--context", "dev"},
+	} {
+		root := NewRootCommand()
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Unexpected error for %v: %v", args, err)
+		}
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if ns := kConfig.GetContext("prod").Namespace; ns != "monitoring" {
+		t.Errorf("Expected prod to use namespace monitoring, got %q", ns)
+	}
+	if ns := kConfig.GetContext("dev").Namespace; ns != "team-a" {
+		t.Errorf("Expected dev to use namespace team-a, got %q", ns)
+	}
+	if kConfig.CurrentContext != "prod" {
+		t.Errorf("Expected the current context to stay prod, got %q", kConfig.CurrentContext)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) == 0 {
+		t.Error("Expected the kubeconfig to be backed up")
+	}
+
+	for _, args := range [][]string{
+		{"set-namespace", "Team_A"},
+		{"set-namespace", "team-a", "--context", "missing"},
+	} {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "--kubeconfig", kubeconfigPath))
+		if err := root.Execute(); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
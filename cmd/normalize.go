@@ -0,0 +1,173 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// sortOnSaveEnv names the environment variable holding the default --sort-on-save
+const sortOnSaveEnv = "KUBECTX_MANAGER_SORT_ON_SAVE"
+
+// normalizeOptions holds the flag values for a single invocation of the normalize command.
+type normalizeOptions struct {
+	*globalOptions
+	dryRun   bool
+	showDiff bool
+}
+
+func newNormalizeCommand(global *globalOptions) *cobra.Command {
+	opts := &normalizeOptions{globalOptions: global}
+
+	normalizeCmd := &cobra.Command{
+		Use:   "normalize",
+		Short: "Sort kubeconfig entries by name and rewrite them in a canonical format",
+		Long: `Order the contexts, clusters and users of every kubeconfig by name and rewrite it in the
+format kubectx-manager writes, with keys sorted and consistent indentation, so kubeconfigs kept in
+a dotfile repository produce readable diffs. Each file of a kubeconfig list is normalized on its own.
+A backup of every modified file is created first.
+
+Use --sort-on-save to keep entries sorted whenever another command writes a kubeconfig.`,
+		Example: `  # Show what would change
+  kubectx-manager normalize --dry-run --diff
+
+  # Normalize every kubeconfig of a directory
+  kubectx-manager normalize --kubeconfig-dir ~/kubeconfigs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	normalizeCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show which files would change without modifying them")
+	normalizeCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of each kubeconfig change")
+
+	return normalizeCmd
+}
+
+// normalizeResult is the structured form of normalize for one kubeconfig file.
+type normalizeResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	// Sorted is set when entries were out of order, Changed when the file
+	// differs from its normalized form for any reason
+	Sorted  bool   `json:"sorted" yaml:"sorted"`
+	Changed bool   `json:"changed" yaml:"changed"`
+	Backup  string `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DryRun  bool   `json:"dryRun" yaml:"dryRun"`
+	// Error is set for a kubeconfig of --kubeconfig-dir that could not be normalized
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (o *normalizeOptions) run(out io.Writer) error {
+	if err := o.requireFormats("normalize", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	log := o.newLogger()
+
+	chains, err := o.kubeconfigChains()
+	if err != nil {
+		return err
+	}
+	results := make([]normalizeResult, 0, len(chains))
+	for _, paths := range chains {
+		label := strings.Join(paths, string(filepath.ListSeparator))
+		chainResults, err := o.normalize(paths, log)
+		if err != nil && o.isBulk() {
+			log.Errorf("%s: %v", label, err)
+			o.setExitCode(exitCodeBulkFailures)
+			results = append(results, normalizeResult{Kubeconfig: label, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		results = append(results, chainResults...)
+	}
+	return o.printResult(out, results)
+}
+
+// normalize sorts and rewrites each file of one kubeconfig or kubeconfig list.
+func (o *normalizeOptions) normalize(paths []string, log *logger.Logger) ([]normalizeResult, error) {
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return nil, err
+	}
+	defer o.unlock(locks)
+
+	// LoadMulti skips the missing files of a list as everywhere else
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	results := make([]normalizeResult, 0, len(multi.Files))
+	for i, file := range multi.Files {
+		path := multi.Paths[i]
+		before, err := os.ReadFile(path) //nolint:gosec // User-specified kubeconfig path is intentional
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+		}
+		result := normalizeResult{Kubeconfig: path, Sorted: kubeconfig.Sort(file), DryRun: o.dryRun}
+		after, err := kubeconfig.Marshal(file)
+		if err != nil {
+			return nil, err
+		}
+		if string(after) == string(before) {
+			log.Infof("%s is already normalized", path)
+			results = append(results, result)
+			continue
+		}
+		result.Changed = true
+		o.setExitCode(exitCodeChanged)
+
+		if o.showDiff {
+			printDiff(log, string(before), string(after), path, path+" (normalized)")
+		}
+		if o.dryRun {
+			log.Infof("%s would be normalized", path)
+			results = append(results, result)
+			continue
+		}
+
+		backupPath, err := o.createBackup(path, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Debugf("Created backup at: %s", backupPath)
+		result.Backup = backupPath
+		if err := kubeconfig.Save(file, path); err != nil {
+			return nil, fmt.Errorf("failed to save kubeconfig: %w", err)
+		}
+		o.recordChange(&audit.Record{
+			Summary:     "Normalize " + path,
+			Kubeconfigs: []string{path},
+			Backups:     []string{backupPath},
+		}, log)
+		log.Infof("Normalized %s", path)
+		results = append(results, result)
+	}
+	if o.dryRun && len(results) > 0 {
+		log.Infof("Dry run mode - no changes made")
+	}
+	return results, nil
+}
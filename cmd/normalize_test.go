@@ -0,0 +1,99 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestNormalizeCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	var out bytes.Buffer
+	root, global := newRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"normalize", "--dry-run", "-o", "json", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Fatal("Expected dry run to leave the kubeconfig untouched")
+	}
+	var results []normalizeResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 || !results[0].Sorted || !results[0].Changed {
+		t.Errorf("Expected the kubeconfig to need sorting, got %+v", results)
+	}
+	if global.exitCode != exitCodeChanged {
+		t.Errorf("Expected exit code %d, got %d", exitCodeChanged, global.exitCode)
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"sort", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.Contexts[0].Name != "dev" || kConfig.Clusters[0].Name != "dev-cluster" || kConfig.Users[0].Name != "dev-user" {
+		t.Errorf("Expected entries sorted by name, got %+v", kConfig.Contexts)
+	}
+	if kConfig.CurrentContext != "prod" || kConfig.GetUser("prod-user").Token != "prod-token" {
+		t.Error("Expected normalizing to keep the configuration")
+	}
+
+	// A normalized kubeconfig is left alone
+	root, global = newRootCommand()
+	root.SetArgs([]string{"normalize", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if global.exitCode != 0 {
+		t.Errorf("Expected exit code 0 for a normalized kubeconfig, got %d", global.exitCode)
+	}
+}
+
+func TestSortOnSave(t *testing.T) {
+	defer func(old bool) { kubeconfig.SortOnSave = old }(kubeconfig.SortOnSave)
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"set-namespace", "team-a", "--sort-on-save", "-q", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(t.TempDir(), "ignore")})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.Contexts[0].Name != "dev" || kConfig.GetContext("prod").Namespace != "team-a" {
+		t.Errorf("Expected the change saved with sorted entries, got %+v", kConfig.Contexts)
+	}
+}
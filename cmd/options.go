@@ -0,0 +1,922 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/gitbackup"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/remote"
+)
+
+// Output formats accepted by --output; table is another name for text
+const (
+	outputText  = "text"
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputCSV   = "csv"
+)
+
+var outputFormats = []string{outputText, outputTable, outputJSON, outputYAML, outputCSV}
+
+// backupPassphraseEnv names the environment variable holding the backup passphrase
+const backupPassphraseEnv = "KUBECTX_MANAGER_BACKUP_PASSPHRASE"
+
+// --log-file is rotated once it would grow past logFileMaxSize, keeping
+// logFileKeep rotated files
+const (
+	logFileMaxSize = 10 << 20
+	logFileKeep    = 3
+)
+
+// globalOptions holds the persistent flags shared by the root command and all subcommands.
+type globalOptions struct {
+	kubeConfig string
+	// kubeconfigDir is --kubeconfig-dir, which validate turns into the
+	// --kubeconfig glob of the files it selects
+	kubeconfigDir   string
+	backupDir       string
+	backupRetention string
+	output          string
+	logLevel        string
+	logFile         string
+	// verbose and quiet stand in for --log-level debug and error
+	verbose bool
+	quiet   bool
+	noColor bool
+	yes     bool
+	// force is --force, which writes kubeconfigs drastically smaller than
+	// the files they replace without asking
+	force bool
+	// sortOnSave is --sort-on-save, applied to kubeconfig.SortOnSave by validate
+	sortOnSave bool
+	// Backup encryption: --encrypt-backups encrypts to backupRecipients if
+	// any are given, otherwise with a passphrase
+	encryptBackups   bool
+	backupRecipients []string
+	backupIdentity   string
+	// backupRemote is the s3://bucket/prefix backups are also uploaded to
+	backupRemote string
+	// backupGit is the git repository every change is also committed to
+	backupGit string
+	// retention is backupRetention parsed by validate
+	retention kubeconfig.RetentionPolicy
+	// passphrase is the backup passphrase once read
+	passphrase string
+	// prompt asks the questions of the command, see prompter
+	prompt Prompter
+	// stdin, stdout and stderr are the streams of the command being run,
+	// which embedders and tests set with SetIn, SetOut and SetErr
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// level is the log level resolved by setupLogging
+	level logger.Level
+	// logOutput is --log-file once opened
+	logOutput *logger.RotatingFile
+	// invocation records the command line in backup manifests
+	invocation kubeconfig.Manifest
+	// remoteClient is the client for backupRemote once created
+	remoteClient *remote.S3
+	// gitRepo is the backupGit repository once opened
+	gitRepo *gitbackup.Repo
+	// gitSkipWarned is set once the warning that encrypted backups keep
+	// kubeconfigs out of backupGit has been shown
+	gitSkipWarned bool
+	// exitCode is the outcome recorded by setExitCode
+	exitCode int
+	// configLayers is set when --config is not given, so the configuration
+	// is layered from the system-wide, user and repository-local files
+	configLayers bool
+}
+
+// removal describes what a command removed from a kubeconfig, and why,
+// for the manifests of its backups.
+type removal struct {
+	contexts []string
+	// archive lists the contexts among contexts that rules move to the archive
+	archive []string
+	// matchedPatterns maps each pattern to the contexts it matched
+	matchedPatterns map[string][]string
+	authResults     map[string]kubeconfig.AuthStatus
+	// decisions tells, for each context, why it is kept or removed
+	decisions map[string]contextDecision
+}
+
+// contextDecision is why a command keeps or removes a context.
+type contextDecision struct {
+	// Action is "keep" or "remove"
+	Action string `json:"action" yaml:"action"`
+	// Reason is one of the reason constants
+	Reason string `json:"reason" yaml:"reason"`
+	// Pattern is the whitelist pattern, rule or argument that decided
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Detail is what the auth check or --older-than found
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// Reasons for keeping or removing a context
+const (
+	reasonPattern     = "pattern matched"
+	reasonRule        = "rule matched"
+	reasonArgument    = "argument matched"
+	reasonLabel       = "label matched"
+	reasonInUse       = "recently used"
+	reasonAuthValid   = "auth valid"
+	reasonAuthError   = "auth invalid"
+	reasonAuthSkipped = "auth check skipped"
+	reasonNoMatch     = "no pattern matched"
+)
+
+// describe returns the decision as a short note for text output, such as
+// "pattern matched: prod-*" or "auth invalid: token expired".
+func (d contextDecision) describe() string {
+	var details []string
+	if d.Pattern != "" {
+		details = append(details, d.Pattern)
+	}
+	if d.Detail != "" {
+		details = append(details, d.Detail)
+	}
+	if len(details) == 0 {
+		return d.Reason
+	}
+	return d.Reason + ": " + strings.Join(details, ", ")
+}
+
+// addPersistentFlags registers the shared flags on cmd so every subcommand inherits them.
+func (g *globalOptions) addPersistentFlags(cmd *cobra.Command) {
+	// Like kubectl, default to the $KUBECONFIG list before ~/.kube/config
+	defaultKubeConfig := os.Getenv("KUBECONFIG")
+	if defaultKubeConfig == "" {
+		defaultKubeConfig = filepath.Join(userHomeDir(), ".kube", "config")
+	}
+
+	cmd.PersistentFlags().StringVarP(&g.kubeConfig, "kubeconfig", "k", defaultKubeConfig,
+		fmt.Sprintf("Path to kubeconfig file, glob pattern, or %q-separated list merged like $KUBECONFIG", string(filepath.ListSeparator)))
+	cmd.PersistentFlags().StringVar(&g.kubeconfigDir, "kubeconfig-dir", "",
+		"Operate on every kubeconfig in this directory, or matching a glob in it such as ~/kubeconfigs/*.yaml, each on its own, reporting failures per file")
+	cmd.MarkFlagsMutuallyExclusive("kubeconfig", "kubeconfig-dir")
+	defaultBackupDir := os.Getenv("KUBECTX_MANAGER_BACKUP_DIR")
+	if defaultBackupDir == "" {
+		defaultBackupDir = kubeconfig.DefaultBackupDir()
+	}
+	cmd.PersistentFlags().StringVar(&g.backupDir, "backup-dir", defaultBackupDir,
+		"Directory backups are written to, in a subfolder per kubeconfig (empty writes them next to the kubeconfig)")
+	cmd.PersistentFlags().StringVar(&g.backupRetention, "backup-retention", "",
+		"Backups to keep after each new backup: a count (10), an age (30d) or both (10,30d); default keeps all")
+	cmd.PersistentFlags().BoolVar(&g.encryptBackups, "encrypt-backups", false,
+		"Encrypt new backups, to --backup-recipient keys with age or else with a passphrase ($"+backupPassphraseEnv+" or prompted)")
+	cmd.PersistentFlags().StringArrayVar(&g.backupRecipients, "backup-recipient", nil,
+		"age public key (age1...) to encrypt backups to with --encrypt-backups (can be repeated)")
+	cmd.PersistentFlags().StringVar(&g.backupIdentity, "backup-identity", "",
+		"age identity file to decrypt age-encrypted backups with (prompted for if needed)")
+	cmd.PersistentFlags().StringVar(&g.backupRemote, "backup-remote", os.Getenv(backupRemoteEnv),
+		"S3 or MinIO location (s3://bucket/prefix) to upload backups to and restore them from, using the AWS_* credentials")
+	cmd.PersistentFlags().StringVar(&g.backupGit, "backup-git", os.Getenv(backupGitEnv),
+		"Git repository to commit every kubeconfig change to, pushed if its branch has an upstream (created if missing; skipped with --encrypt-backups)")
+	sortOnSave, _ := strconv.ParseBool(os.Getenv(sortOnSaveEnv))
+	cmd.PersistentFlags().BoolVar(&g.sortOnSave, "sort-on-save", sortOnSave,
+		"Sort contexts, clusters and users by name whenever a kubeconfig is written (default: $"+sortOnSaveEnv+")")
+	cmd.PersistentFlags().StringVarP(&g.output, "output", "o", outputText,
+		fmt.Sprintf("Output format (%s)", strings.Join(outputFormats, "|")))
+	_ = cmd.RegisterFlagCompletionFunc("output", completeValues(outputFormats...))
+	cmd.PersistentFlags().StringVar(&g.logLevel, "log-level", logger.LevelInfo.String(),
+		fmt.Sprintf("Least severe messages to show (%s)", strings.Join(logger.LevelNames, "|")))
+	_ = cmd.RegisterFlagCompletionFunc("log-level", completeValues(logger.LevelNames...))
+	cmd.PersistentFlags().StringVar(&g.logFile, "log-file", "",
+		"File to also write the messages shown to, with timestamps, rotated at 10 MiB keeping 3 old files")
+	cmd.PersistentFlags().BoolVarP(&g.verbose, "verbose", "v", false, "Enable verbose (debug) output, same as --log-level debug")
+	cmd.PersistentFlags().BoolVarP(&g.quiet, "quiet", "q", false, "Suppress all output except errors, same as --log-level error")
+	cmd.PersistentFlags().BoolVar(&g.noColor, "no-color", false,
+		"Disable colors, which are otherwise used when stdout is a terminal and $NO_COLOR is unset")
+	cmd.PersistentFlags().BoolVarP(&g.yes, "yes", "y", false,
+		"Answer yes to all confirmation prompts, overwrite conflicting entries and take full backups before a restore")
+	cmd.PersistentFlags().BoolVar(&g.yes, "non-interactive", false, "Same as --yes")
+	cmd.PersistentFlags().BoolVar(&g.force, "force", false,
+		"Write a kubeconfig that is empty or drastically smaller than the file it replaces without asking, which --yes does not do")
+}
+
+// addConfigFlag registers --config on cmd for commands that read the kubectx-manager configuration file.
+func addConfigFlag(cmd *cobra.Command, configFile *string) {
+	cmd.Flags().StringVarP(configFile, "config", "c", defaultConfigPath(),
+		"Path or https:// URL of the kubectx-manager configuration file (*.yaml or *.yml for the YAML format with rules)")
+}
+
+// loadConfig loads the configuration file at path, or the configuration
+// layered from the system-wide file, path and the repository-local file
+// when --config is not given.
+func (g *globalOptions) loadConfig(path string) (*config.Config, error) {
+	if g.configLayers {
+		return g.warnConfig(config.LoadLayers(config.LayerPaths(path)...))
+	}
+	return g.loadConfigFile(path)
+}
+
+// loadConfigFile loads the configuration file at path alone.
+func (g *globalOptions) loadConfigFile(path string) (*config.Config, error) {
+	return g.warnConfig(config.Load(path))
+}
+
+// warnConfig logs the warnings of a configuration that loaded, such as a
+// remote file read from the cache, and returns what loading it returned.
+func (g *globalOptions) warnConfig(cfg *config.Config, err error) (*config.Config, error) {
+	if err == nil && len(cfg.Warnings) > 0 {
+		log := g.newLogger()
+		for _, warning := range cfg.Warnings {
+			log.Warnf("%s", warning)
+		}
+	}
+	return cfg, err
+}
+
+// addInFileDuplicatesFlag adds --in-file-duplicates to a command that reads
+// kubeconfig files into the current one.
+func addInFileDuplicatesFlag(cmd *cobra.Command, policy *string) {
+	cmd.Flags().StringVar(policy, "in-file-duplicates", "",
+		"Resolve entries an incoming kubeconfig defines more than once under the same name by: "+
+			"error, first (as kubectl does), last or rename (default: the in-file-duplicates setting, else error)")
+	_ = cmd.RegisterFlagCompletionFunc("in-file-duplicates", completeDuplicatePolicies)
+}
+
+// duplicatePolicy returns the policy named by --in-file-duplicates, or else
+// by the in-file-duplicates setting of cfg, defaulting to
+// kubeconfig.DuplicatesError.
+func duplicatePolicy(flag string, cfg *config.Config) (kubeconfig.DuplicatePolicy, error) {
+	policy := flag
+	if policy == "" {
+		policy = cfg.InFileDuplicates
+	}
+	if policy == "" {
+		return kubeconfig.DuplicatesError, nil
+	}
+	return kubeconfig.ParseDuplicatePolicy(policy)
+}
+
+// withDuplicatesHint suggests --in-file-duplicates for an incoming file
+// defining an entry twice.
+func withDuplicatesHint(err error) error {
+	var duplicate *kubeconfig.DuplicateNameError
+	if errors.As(err, &duplicate) {
+		return fmt.Errorf("%w (use --in-file-duplicates first, last or rename)", err)
+	}
+	return err
+}
+
+// defaultConfigPath returns ~/.kubectx-manager.yaml if it exists, and the
+// line-based ~/.kubectx-manager_ignore otherwise.
+func defaultConfigPath() string {
+	yamlConfig := filepath.Join(userHomeDir(), ".kubectx-manager.yaml")
+	if _, err := os.Stat(yamlConfig); err == nil {
+		return yamlConfig
+	}
+	return filepath.Join(userHomeDir(), ".kubectx-manager_ignore")
+}
+
+// authCheckFlags holds the flags that tune --auth-check.
+type authCheckFlags struct {
+	flags      *pflag.FlagSet
+	timeout    time.Duration
+	retries    int
+	cacheTTL   time.Duration
+	certWindow time.Duration
+	offline    bool
+	runExec    bool
+	probe      string
+	keepDead   bool
+	keepDenied bool
+	noCache    bool
+	removeOn   []string
+	grace      string
+	preCheck   bool
+	dialTime   time.Duration
+}
+
+// addFlags registers the flags that tune --auth-check on cmd.
+func (a *authCheckFlags) addFlags(cmd *cobra.Command) {
+	a.flags = cmd.Flags()
+	a.flags.DurationVar(&a.timeout, "auth-timeout", kubeconfig.DefaultAuthTimeout,
+		"Timeout for each cluster reachability probe of --auth-check")
+	a.flags.IntVar(&a.retries, "auth-retries", 0,
+		"Retry an unreachable cluster this many times, with exponential backoff, before treating it as dead")
+	a.flags.DurationVar(&a.cacheTTL, "auth-cache-ttl", kubeconfig.DefaultProbeCacheTTL,
+		"Reuse the reachability and auth probe results of --auth-check for this long")
+	a.flags.BoolVar(&a.noCache, "no-cache", false, "Probe every cluster in --auth-check, ignoring the results of recent runs")
+	a.flags.DurationVar(&a.certWindow, "cert-expiry-window", 0,
+		"Treat client certificates expiring within this duration (e.g. 168h) as expired in --auth-check")
+	a.flags.BoolVar(&a.offline, "offline", false,
+		"Make --auth-check rely solely on local credential and expiry checks, without probing clusters")
+	a.flags.BoolVar(&a.runExec, "auth-check-exec", false,
+		"Run exec credential plugins in --auth-check and treat failures or expired credentials as invalid")
+	a.flags.StringVar(&a.probe, "auth-probe", kubeconfig.ProbeVersion,
+		"How --auth-check probes clusters: version (unauthenticated /version), api (/api with the user's credentials) or tcp (only connect to the server)")
+	a.flags.BoolVar(&a.preCheck, "auth-precheck", false,
+		"Resolve and connect to each cluster's server before probing it in --auth-check, skipping the probe of dead hosts")
+	a.flags.DurationVar(&a.dialTime, "auth-dial-timeout", kubeconfig.DefaultDialTimeout,
+		"Timeout for the DNS lookup and connection of --auth-precheck and --auth-probe tcp")
+	a.flags.BoolVar(&a.keepDead, "keep-unreachable", false, "Keep contexts whose cluster does not respond in --auth-check")
+	a.flags.BoolVar(&a.keepDenied, "keep-unauthorized", false,
+		"Keep contexts whose credentials the cluster rejects with --auth-probe api")
+	a.flags.StringSliceVar(&a.removeOn, "remove-on", nil,
+		"Auth states that make --auth-check remove a context: unauthorized, expired, no-credentials, invalid-ca, unreachable (default all)")
+	a.flags.StringVar(&a.grace, "unreachable-grace", "",
+		"Only remove contexts whose cluster has been unreachable in every --auth-check for this long (7d, 72h)")
+	_ = cmd.RegisterFlagCompletionFunc("remove-on", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		states := make([]string, len(kubeconfig.AuthFailureStates))
+		for i, state := range kubeconfig.AuthFailureStates {
+			states[i] = string(state)
+		}
+		return states, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// options combines the flags with the settings of the configuration file;
+// flags given on the command line take precedence.
+func (a *authCheckFlags) options(cfg *config.Config) (kubeconfig.AuthCheckOptions, error) {
+	opts := kubeconfig.AuthCheckOptions{
+		Timeout:          cfg.AuthTimeout,
+		Retries:          cfg.AuthRetries,
+		CertExpiryWindow: cfg.CertExpiryWindow,
+		Offline:          a.offline,
+		RunExec:          a.runExec,
+		Probe:            a.probe,
+		PreCheck:         a.preCheck,
+		KeepUnreachable:  a.keepDead,
+		KeepUnauthorized: a.keepDenied,
+	}
+	if a.probe != kubeconfig.ProbeVersion && a.probe != kubeconfig.ProbeAPI && a.probe != kubeconfig.ProbeTCP {
+		return opts, fmt.Errorf("invalid --auth-probe %q (expected %s, %s or %s)", a.probe, kubeconfig.ProbeVersion, kubeconfig.ProbeAPI, kubeconfig.ProbeTCP)
+	}
+	if a.flags.Changed("auth-dial-timeout") {
+		if a.dialTime <= 0 {
+			return opts, fmt.Errorf("--auth-dial-timeout must be positive, got %s", a.dialTime)
+		}
+		opts.DialTimeout = a.dialTime
+	}
+	if a.flags.Changed("auth-timeout") {
+		if a.timeout <= 0 {
+			return opts, fmt.Errorf("--auth-timeout must be positive, got %s", a.timeout)
+		}
+		opts.Timeout = a.timeout
+	}
+	if a.flags.Changed("auth-retries") {
+		if a.retries < 0 {
+			return opts, fmt.Errorf("--auth-retries must not be negative, got %d", a.retries)
+		}
+		opts.Retries = a.retries
+	}
+	removeOn := cfg.AuthRemoveOn
+	if a.flags.Changed("remove-on") {
+		removeOn = strings.Join(a.removeOn, ",")
+	}
+	if removeOn != "" {
+		states, err := kubeconfig.ParseAuthStates(removeOn)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --remove-on: %w", err)
+		}
+		opts.RemoveOn = states
+	}
+	if a.flags.Changed("cert-expiry-window") {
+		if a.certWindow < 0 {
+			return opts, fmt.Errorf("--cert-expiry-window must not be negative, got %s", a.certWindow)
+		}
+		opts.CertExpiryWindow = a.certWindow
+	}
+	ttl := cfg.AuthCacheTTL
+	if ttl == 0 || a.flags.Changed("auth-cache-ttl") {
+		ttl = a.cacheTTL
+	}
+	if ttl <= 0 {
+		return opts, fmt.Errorf("--auth-cache-ttl must be positive, got %s", ttl)
+	}
+	if path := authCacheFile(); path != "" && !a.noCache && !opts.Offline {
+		opts.Cache = kubeconfig.LoadProbeCache(path, ttl)
+	}
+	grace := cfg.UnreachableGrace
+	if a.flags.Changed("unreachable-grace") {
+		grace = a.grace
+	}
+	if grace != "" {
+		var err error
+		if opts.UnreachableGrace, err = kubeconfig.ParseAge(grace); err != nil || opts.UnreachableGrace <= 0 {
+			return opts, fmt.Errorf("invalid --unreachable-grace '%s': expected an age such as 7d or 72h", grace)
+		}
+	}
+	if path := outageLogFile(); path != "" && opts.UnreachableGrace > 0 && !opts.Offline {
+		outages, err := kubeconfig.LoadOutageLog(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.Outages = outages
+	}
+	return opts, nil
+}
+
+// authCacheFile returns the path of the file auth check probe results are
+// cached in, or "" to cache nothing; tests replace it.
+var authCacheFile = kubeconfig.DefaultProbeCachePath
+
+// outageLogFile returns the path of the file --unreachable-grace records
+// outages in, or "" to record none; tests replace it.
+var outageLogFile = kubeconfig.DefaultOutageLogPath
+
+// saveAuthCache saves the probe results cached, and the outages recorded,
+// by auth checks run with opts.
+func saveAuthCache(opts kubeconfig.AuthCheckOptions, log *logger.Logger) {
+	if opts.Cache != nil {
+		if err := opts.Cache.Save(); err != nil {
+			log.Warnf("Failed to cache the auth check results: %v", err)
+		}
+	}
+	if opts.Outages != nil {
+		if err := opts.Outages.Save(); err != nil {
+			log.Warnf("Failed to record unreachable clusters: %v", err)
+		}
+	}
+}
+
+// confirmShrink asks before a kubeconfig is replaced with drastically
+// smaller content. --yes does not answer it, only --force, so that
+// unattended runs never wipe a kubeconfig.
+func (g *globalOptions) confirmShrink(path, reason string) (bool, error) {
+	if g.force {
+		return true, nil
+	}
+	answer, err := g.prompter().Ask(
+		fmt.Sprintf("%s would shrink drastically: %s.\nWrite it anyway? [y/N]: ", path, reason),
+		"pass --force to write it anyway")
+	if err != nil {
+		return false, err
+	}
+	return isYes(answer), nil
+}
+
+// validate checks the shared flag values before any command runs.
+func (g *globalOptions) validate() error {
+	retention, err := kubeconfig.ParseRetentionPolicy(g.backupRetention)
+	if err != nil {
+		return err
+	}
+	g.retention = retention
+
+	if len(g.backupRecipients) > 0 && !g.encryptBackups {
+		return errors.New("--backup-recipient requires --encrypt-backups")
+	}
+
+	kubeconfig.SortOnSave = g.sortOnSave
+	kubeconfig.ConfirmShrink = g.confirmShrink
+
+	if g.kubeconfigDir != "" {
+		pattern, err := kubeconfigDirPattern(g.kubeconfigDir)
+		if err != nil {
+			return err
+		}
+		g.kubeConfig = pattern
+	}
+
+	if g.output == outputTable {
+		g.output = outputText
+	}
+	for _, format := range outputFormats {
+		if g.output == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (expected one of: %s)", g.output, strings.Join(outputFormats, ", "))
+}
+
+// setupLogging resolves the log level, from --log-level or else from
+// --quiet or --verbose, and opens --log-file.
+func (g *globalOptions) setupLogging(cmd *cobra.Command) error {
+	level, err := logger.ParseLevel(g.logLevel)
+	if err != nil {
+		return err
+	}
+	if !cmd.Flags().Changed("log-level") {
+		switch {
+		case g.quiet:
+			level = logger.LevelError
+		case g.verbose:
+			level = logger.LevelDebug
+		}
+	}
+	g.level = level
+
+	if g.logFile != "" {
+		if g.logOutput, err = logger.OpenRotatingFile(expandHome(g.logFile), logFileMaxSize, logFileKeep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeLog closes --log-file, if it was opened.
+func (g *globalOptions) closeLog() {
+	if g.logOutput == nil {
+		return
+	}
+	if err := g.logOutput.Close(); err != nil {
+		fmt.Fprintf(g.stderr, "Warning: failed to close log file: %v\n", err)
+	}
+	g.logOutput = nil
+}
+
+// applyConfigDefaults takes --backup-retention from the configuration file
+// when it is not given on the command line. Other defaults in the file only
+// concern commands that read it anyway. A missing file is not created here,
+// and a broken one is left for those commands to report.
+func (g *globalOptions) applyConfigDefaults(cmd *cobra.Command) error {
+	g.configLayers = !cmd.Flags().Changed("config")
+	if cmd.Flags().Changed("backup-retention") {
+		return nil
+	}
+	path := defaultConfigPath()
+	if flag := cmd.Flags().Lookup("config"); flag != nil {
+		path = flag.Value.String()
+	}
+	paths := []string{path}
+	if g.configLayers {
+		paths = config.LayerPaths(path)
+	}
+	paths = slices.DeleteFunc(paths, func(path string) bool {
+		_, err := os.Stat(path)
+		return err != nil && !config.IsRemote(path)
+	})
+	if len(paths) == 0 {
+		return nil
+	}
+	cfg, err := config.LoadLayers(paths...)
+	if err != nil || cfg.BackupRetention == "" {
+		return nil
+	}
+	retention, err := kubeconfig.ParseRetentionPolicy(cfg.BackupRetention)
+	if err != nil {
+		return fmt.Errorf("invalid backup retention in %s: %w", strings.Join(paths, ", "), err)
+	}
+	g.backupRetention = cfg.BackupRetention
+	g.retention = retention
+	return nil
+}
+
+// recordInvocation remembers the command line for the manifests of the backups the command takes.
+func (g *globalOptions) recordInvocation(cmd *cobra.Command, args []string) {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		flags[flag.Name] = flag.Value.String()
+	})
+	g.invocation = kubeconfig.Manifest{Command: cmd.CommandPath(), Args: args, Flags: flags}
+}
+
+// createBackup backs up the kubeconfig at path into --backup-dir, records
+// the command line in the backup's manifest, uploads both to --backup-remote,
+// then prunes its local backups according to --backup-retention. With
+// --backup-git, changes made to the kubeconfig since its last commit are
+// committed first, so the change about to be made can be undone.
+func (g *globalOptions) createBackup(path string, log *logger.Logger) (string, error) {
+	g.commitKubeconfig(path, "Record current "+filepath.Base(path), log)
+
+	enc, err := g.backupEncryption()
+	if err != nil {
+		return "", err
+	}
+	backupPath, err := kubeconfig.CreateEncryptedBackup(path, g.backupDir, enc)
+	if err != nil {
+		return "", err
+	}
+	g.writeManifest(backupPath, path, nil, log)
+	g.pruneBackups(path, log)
+	return backupPath, nil
+}
+
+// writeManifest writes the manifest of the backup of the kubeconfig at path,
+// recording the command line and, if r is set, what the command removed,
+// then uploads both to --backup-remote. Failures are only warned about,
+// since the backup itself was written.
+func (g *globalOptions) writeManifest(backupPath, path string, r *removal, log *logger.Logger) {
+	manifest := g.invocation
+	manifest.Kubeconfig = path
+	if r != nil {
+		manifest.RemovedContexts = r.contexts
+		manifest.MatchedPatterns = r.matchedPatterns
+		manifest.AuthResults = r.authResults
+	}
+	if err := kubeconfig.WriteManifest(backupPath, &manifest); err != nil {
+		log.Warnf("Failed to write manifest of backup %s: %v", backupPath, err)
+	}
+	g.uploadBackup(backupPath, path, log)
+}
+
+// backupEncryption returns how new backups are encrypted, as selected by
+// --encrypt-backups and --backup-recipient.
+func (g *globalOptions) backupEncryption() (kubeconfig.Encryption, error) {
+	if !g.encryptBackups {
+		return kubeconfig.Encryption{}, nil
+	}
+	if len(g.backupRecipients) > 0 {
+		return kubeconfig.Encryption{Recipients: g.backupRecipients}, nil
+	}
+	passphrase, err := g.backupPassphrase(true)
+	if err != nil {
+		return kubeconfig.Encryption{}, err
+	}
+	return kubeconfig.Encryption{Passphrase: passphrase}, nil
+}
+
+// backupDecryption returns the key to read backup with, prompting for it if
+// the backup is encrypted and no key was given.
+func (g *globalOptions) backupDecryption(backup kubeconfig.Backup) (kubeconfig.Decryption, error) {
+	switch {
+	case !backup.Encrypted:
+		return kubeconfig.Decryption{}, nil
+	case strings.HasSuffix(backup.Path, kubeconfig.AgeSuffix):
+		if g.backupIdentity == "" {
+			identity, err := g.prompter().Ask("age identity file for "+backup.Name+": ", "pass --backup-identity")
+			if err != nil {
+				return kubeconfig.Decryption{}, err
+			}
+			g.backupIdentity = expandHome(identity)
+		}
+		return kubeconfig.Decryption{IdentityFile: g.backupIdentity}, nil
+	default:
+		passphrase, err := g.backupPassphrase(false)
+		if err != nil {
+			return kubeconfig.Decryption{}, err
+		}
+		return kubeconfig.Decryption{Passphrase: passphrase}, nil
+	}
+}
+
+// backupPassphrase returns the backup passphrase from $KUBECTX_MANAGER_BACKUP_PASSPHRASE,
+// or else prompts for it once, asking twice when it is used to encrypt.
+func (g *globalOptions) backupPassphrase(confirm bool) (string, error) {
+	if g.passphrase != "" {
+		return g.passphrase, nil
+	}
+	if passphrase := os.Getenv(backupPassphraseEnv); passphrase != "" {
+		g.passphrase = passphrase
+		return passphrase, nil
+	}
+
+	hint := "set $" + backupPassphraseEnv
+	passphrase, err := g.prompter().Password("Backup passphrase: ", hint)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("empty backup passphrase")
+	}
+	if confirm {
+		again, err := g.prompter().Password("Confirm backup passphrase: ", hint)
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("backup passphrases do not match")
+		}
+	}
+	g.passphrase = passphrase
+	return passphrase, nil
+}
+
+// pruneBackups removes the backups of the kubeconfig at path that
+// --backup-retention does not keep. Failures are only warned about, since
+// the operation that triggered the pruning has already succeeded.
+func (g *globalOptions) pruneBackups(path string, log *logger.Logger) {
+	removed, err := kubeconfig.PruneBackups(path, g.backupDir, g.retention)
+	for _, backup := range removed {
+		log.Debugf("Pruned backup %s", backup.Path)
+	}
+	if err != nil {
+		log.Warnf("Failed to prune backups of %s: %v", path, err)
+	}
+}
+
+// requireFormats rejects the selected output format unless it is one of formats.
+func (g *globalOptions) requireFormats(command string, formats ...string) error {
+	for _, format := range formats {
+		if g.output == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not support output format %q (supported: %s)", command, g.output, strings.Join(formats, ", "))
+}
+
+// kubeconfigPaths expands --kubeconfig into the list of files to operate on.
+// A value containing glob metacharacters (*, ? or [) selects every matching
+// regular file, skipping backups; a plain path is returned unchanged.
+// A list separated like $KUBECONFIG is expanded element by element, and
+// missing files in it are skipped as kubectl does.
+func (g *globalOptions) kubeconfigPaths() ([]string, error) {
+	if !g.isKubeconfigList() {
+		return expandKubeconfigPattern(g.kubeConfig)
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, element := range filepath.SplitList(g.kubeConfig) {
+		if element == "" {
+			continue
+		}
+		matches, err := expandKubeconfigPattern(element)
+		if err != nil {
+			if errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		for _, match := range matches {
+			if _, err := os.Stat(match); err != nil || seen[match] {
+				continue
+			}
+			seen[match] = true
+			paths = append(paths, match)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no file in %q exists", kubeconfig.ErrKubeconfigNotFound, g.kubeConfig)
+	}
+	return paths, nil
+}
+
+// isKubeconfigList reports whether --kubeconfig names several files to be merged.
+func (g *globalOptions) isKubeconfigList() bool {
+	return strings.ContainsRune(g.kubeConfig, filepath.ListSeparator)
+}
+
+// kubeconfigChains groups the selected files into the sets that are loaded
+// together with kubeconfig.LoadMulti: a list is merged into a single view,
+// while every file matched by a glob is handled on its own.
+func (g *globalOptions) kubeconfigChains() ([][]string, error) {
+	paths, err := g.kubeconfigPaths()
+	if err != nil {
+		return nil, err
+	}
+	if g.isKubeconfigList() {
+		return [][]string{paths}, nil
+	}
+	chains := make([][]string, len(paths))
+	for i, path := range paths {
+		chains[i] = []string{path}
+	}
+	return chains, nil
+}
+
+// kubeconfigChain returns the files of the single merged view selected by
+// --kubeconfig, for commands that cannot operate on independent files at once.
+func (g *globalOptions) kubeconfigChain(command string) ([]string, error) {
+	chains, err := g.kubeconfigChains()
+	if err != nil {
+		return nil, err
+	}
+	if len(chains) > 1 {
+		return nil, fmt.Errorf("%s operates on a single kubeconfig, but %q matches %d files", command, g.kubeConfig, len(chains))
+	}
+	return chains[0], nil
+}
+
+// expandKubeconfigPattern resolves one --kubeconfig path or glob pattern.
+func expandKubeconfigPattern(value string) ([]string, error) {
+	pattern := expandHome(value)
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig pattern %q: %w", value, err)
+	}
+
+	var paths []string
+	for _, match := range matches {
+		if isBackupFile(match) || strings.HasSuffix(match, ".lock") || strings.HasSuffix(match, kubeconfig.ArchiveSuffix) {
+			continue
+		}
+		info, err := os.Stat(match)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		paths = append(paths, match)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no files match %q", kubeconfig.ErrKubeconfigNotFound, value)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// kubeconfigDirPattern returns the glob selecting the kubeconfigs of
+// --kubeconfig-dir: every file of a directory, or those matching a glob.
+func kubeconfigDirPattern(dir string) (string, error) {
+	pattern := expandHome(dir)
+	if strings.ContainsAny(pattern, "*?[") {
+		return pattern, nil
+	}
+	info, err := os.Stat(pattern)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("%w: directory %s does not exist", kubeconfig.ErrKubeconfigNotFound, dir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read --kubeconfig-dir: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--kubeconfig-dir %s is not a directory; use --kubeconfig for a single file", dir)
+	}
+	return filepath.Join(pattern, "*"), nil
+}
+
+// isBulk reports whether a glob, of --kubeconfig or --kubeconfig-dir,
+// selects the kubeconfigs, in which case each file is handled on its own and
+// a file that fails does not stop the others from being processed.
+func (g *globalOptions) isBulk() bool {
+	return g.kubeconfigDir != "" || !g.isKubeconfigList() && strings.ContainsAny(expandHome(g.kubeConfig), "*?[")
+}
+
+// singleKubeconfig returns the one kubeconfig selected by --kubeconfig, for
+// commands that cannot operate on several files at once, merged or not.
+func (g *globalOptions) singleKubeconfig(command string) (string, error) {
+	paths, err := g.kubeconfigPaths()
+	if err != nil {
+		return "", err
+	}
+	if len(paths) > 1 {
+		return "", fmt.Errorf("%s operates on a single kubeconfig, but %q matches %d files", command, g.kubeConfig, len(paths))
+	}
+	return paths[0], nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path == "~" {
+		return userHomeDir()
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(userHomeDir(), path[2:])
+	}
+	return path
+}
+
+// isBackupFile reports whether path looks like a backup written by kubectx-manager.
+func isBackupFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.Contains(name, ".backup.") || strings.Contains(name, ".selective-backup.")
+}
+
+// colorOutput reports whether to use colors: on a terminal, unless
+// --no-color or $NO_COLOR says otherwise.
+func (g *globalOptions) colorOutput() bool {
+	return !g.noColor && colorEnabled()
+}
+
+// newLogger creates a logger honoring --log-level, --no-color and writing to --log-file.
+// Messages go to the command's stdout and stderr; with a structured --output
+// format, all of them go to stderr so that stdout holds only the result.
+func (g *globalOptions) newLogger() *logger.Logger {
+	log := logger.New(logger.Options{Level: g.level, Out: g.stdout, Err: g.stderr})
+	log.SetInfoToStderr(g.isStructured())
+	log.SetColor(g.colorOutput())
+	if g.logOutput != nil {
+		log.AddOutput(g.logOutput)
+	}
+	return log
+}
+
+// isInteractive reports whether the command's stdin and the writer its
+// prompts go to are both terminals, so a full-screen picker can be shown
+// instead of a numbered menu.
+func (g *globalOptions) isInteractive() bool {
+	return isTerminal(g.stdin) && isTerminal(g.interactiveOut())
+}
+
+// unlock releases the kubeconfig locks, warning on the command's stderr if
+// any could not be released.
+func (g *globalOptions) unlock(locks kubeconfig.Locks) {
+	if err := locks.Unlock(); err != nil {
+		fmt.Fprintf(g.stderr, "Warning: failed to release lock: %v\n", err)
+	}
+}
@@ -0,0 +1,324 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+func TestPersistentFlagsInherited(t *testing.T) {
+	root := NewRootCommand()
+	for _, sub := range root.Commands() {
+		for _, name := range []string{"kubeconfig", "verbose", "quiet", "output", "yes"} {
+			if sub.InheritedFlags().Lookup(name) == nil {
+				t.Errorf("Expected subcommand %q to inherit --%s", sub.Name(), name)
+			}
+		}
+	}
+}
+
+func TestPersistentFlagPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"before subcommand", []string{"-v", "-o", "json", "version"}},
+		{"after subcommand", []string{"version", "-v", "-o", "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRootCommand()
+			sub, rest, err := root.Find(tt.args)
+			if err != nil {
+				t.Fatalf("Failed to find subcommand: %v", err)
+			}
+			if err := sub.ParseFlags(rest); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			verbose, _ := sub.Flags().GetBool("verbose")
+			output, _ := sub.Flags().GetString("output")
+			if !verbose || output != outputJSON {
+				t.Errorf("Expected verbose=true output=json, got verbose=%v output=%q", verbose, output)
+			}
+		})
+	}
+}
+
+func TestLogLevelFlags(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected logger.Level
+	}{
+		{nil, logger.LevelInfo},
+		{[]string{"-v"}, logger.LevelDebug},
+		{[]string{"-q"}, logger.LevelError},
+		{[]string{"-v", "-q"}, logger.LevelError},
+		{[]string{"--log-level", "WARN"}, logger.LevelWarn},
+		{[]string{"--log-level", "info", "-q"}, logger.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		root, global := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetArgs(append([]string{"version"}, tt.args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", tt.args, err)
+		}
+		if global.level != tt.expected {
+			t.Errorf("%v: expected level %s, got %s", tt.args, tt.expected, global.level)
+		}
+	}
+
+	root := NewRootCommand()
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	root.SetArgs([]string{"version", "--log-level", "trace"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid log level") {
+		t.Errorf("Expected an invalid log level error, got %v", err)
+	}
+}
+
+func TestLogFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	logPath := filepath.Join(tmpDir, "logs", "kubectx-manager.log")
+
+	root, global := newRootCommand()
+	root.SetArgs([]string{"delete", "dev", "--yes", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(tmpDir, "ignore"), "--log-file", logPath, "--log-level", "debug"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	global.closeLog()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	log := string(data)
+	if !strings.Contains(log, " DEBUG ") || !strings.Contains(log, " INFO  ") {
+		t.Errorf("Expected debug and info messages in the log file, got:\n%s", log)
+	}
+}
+
+func TestPromptsWithoutTerminal(t *testing.T) {
+	saved := isTerminal
+	isTerminal = func(any) bool { return false }
+	t.Cleanup(func() { isTerminal = saved })
+
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	cfgPath := filepath.Join(tmpDir, "ignore")
+	run := func(args ...string) error {
+		root := NewRootCommand()
+		root.SilenceUsage = true
+		root.SilenceErrors = true
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--config", cfgPath))
+		return root.Execute()
+	}
+
+	for _, args := range [][]string{{"delete", "dev"}, {"switch"}, {"--interactive"}} {
+		if err := run(args...); !errors.Is(err, errNotTerminal) {
+			t.Errorf("Expected %v to fail without a terminal, got %v", args, err)
+		}
+	}
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil || string(data) != listTestKubeconfig {
+		t.Fatalf("Expected the kubeconfig to be left untouched (err %v)", err)
+	}
+
+	if err := run("delete", "dev", "--non-interactive"); err != nil {
+		t.Fatalf("Expected --non-interactive to confirm the removal, got %v", err)
+	}
+	if data, err := os.ReadFile(kubeconfigPath); err != nil || strings.Contains(string(data), "dev-cluster") {
+		t.Errorf("Expected dev to be removed (err %v)", err)
+	}
+}
+
+func TestInvalidOutputFormat(t *testing.T) {
+	root := NewRootCommand()
+	root.SetArgs([]string{"version", "--output", "xml"})
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid output format") {
+		t.Errorf("Expected invalid output format error, got %v", err)
+	}
+}
+
+func TestKubeconfigPathsGlob(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"config-prod", "config-dev", "config-dev.backup.20250101-120000", "config-dev.archive", "other"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("apiVersion: v1\n"), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(tmpDir, "config-dir"), 0700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	g := &globalOptions{kubeConfig: filepath.Join(tmpDir, "config-*")}
+	paths, err := g.kubeconfigPaths()
+	if err != nil {
+		t.Fatalf("kubeconfigPaths failed: %v", err)
+	}
+	expected := []string{filepath.Join(tmpDir, "config-dev"), filepath.Join(tmpDir, "config-prod")}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+
+	if _, err := g.singleKubeconfig("restore"); err == nil {
+		t.Error("Expected singleKubeconfig to reject a pattern matching several files")
+	}
+
+	g.kubeConfig = filepath.Join(tmpDir, "missing-*")
+	if _, err := g.kubeconfigPaths(); err == nil {
+		t.Error("Expected an error when the pattern matches nothing")
+	}
+
+	g.kubeConfig = filepath.Join(tmpDir, "does-not-exist")
+	paths, err = g.kubeconfigPaths()
+	if err != nil || len(paths) != 1 || paths[0] != g.kubeConfig {
+		t.Errorf("Expected plain path to be returned unchanged, got %v (err %v)", paths, err)
+	}
+}
+
+func TestKubeconfigList(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("apiVersion: v1\n"), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	list := strings.Join([]string{
+		filepath.Join(tmpDir, "a"), filepath.Join(tmpDir, "missing"), filepath.Join(tmpDir, "b"), filepath.Join(tmpDir, "a"),
+	}, string(filepath.ListSeparator))
+
+	// Without --kubeconfig the $KUBECONFIG list is used, as kubectl does
+	t.Setenv("KUBECONFIG", list)
+	root := NewRootCommand()
+	if flag := root.PersistentFlags().Lookup("kubeconfig"); flag.DefValue != list {
+		t.Errorf("Expected --kubeconfig to default to $KUBECONFIG, got %q", flag.DefValue)
+	}
+
+	g := &globalOptions{kubeConfig: list}
+	chains, err := g.kubeconfigChains()
+	if err != nil {
+		t.Fatalf("kubeconfigChains failed: %v", err)
+	}
+	expected := [][]string{{filepath.Join(tmpDir, "a"), filepath.Join(tmpDir, "b")}}
+	if !reflect.DeepEqual(chains, expected) {
+		t.Errorf("Expected one merged chain %v, got %v", expected, chains)
+	}
+	if _, err := g.kubeconfigChain("switch"); err != nil {
+		t.Errorf("Expected a list to be accepted as a single kubeconfig chain, got %v", err)
+	}
+	if _, err := g.singleKubeconfig("restore"); err == nil {
+		t.Error("Expected singleKubeconfig to reject a list of several files")
+	}
+}
+
+func TestAuthCheckFlagsPrecedence(t *testing.T) {
+	cfg := &config.Config{AuthTimeout: 20 * time.Second, AuthRetries: 3, CertExpiryWindow: 24 * time.Hour}
+
+	var a authCheckFlags
+	cmd := &cobra.Command{}
+	a.addFlags(cmd)
+	if err := cmd.ParseFlags([]string{"--auth-retries", "0", "--cert-expiry-window", "72h"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	opts, err := a.options(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.Timeout != 20*time.Second {
+		t.Errorf("Expected the configured timeout when the flag is not given, got %v", opts.Timeout)
+	}
+	if opts.Retries != 0 {
+		t.Errorf("Expected --auth-retries 0 to override the configured 3, got %d", opts.Retries)
+	}
+	if opts.CertExpiryWindow != 72*time.Hour {
+		t.Errorf("Expected --cert-expiry-window to override the configured 24h, got %v", opts.CertExpiryWindow)
+	}
+
+	if err := cmd.ParseFlags([]string{"--auth-timeout", "-1s"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if _, err := a.options(cfg); err == nil {
+		t.Error("Expected an error for a negative --auth-timeout")
+	}
+
+	var probe authCheckFlags
+	cmd = &cobra.Command{}
+	probe.addFlags(cmd)
+	if err := cmd.ParseFlags([]string{"--auth-probe", "healthz"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	if _, err := probe.options(cfg); err == nil {
+		t.Error("Expected an error for an unknown --auth-probe")
+	}
+}
+
+func TestUnlockWarnsOnCommandStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("lock files that cannot be removed are reused on Windows")
+	}
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		t.Fatalf("Failed to lock: %v", err)
+	}
+	// Replace the lock file with a directory that cannot be removed
+	lockPath := kubeconfigPath + kubeconfig.LockSuffix
+	if err := os.Remove(lockPath); err != nil {
+		t.Fatalf("Failed to remove lock file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(lockPath, "keep"), 0700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	g := &globalOptions{stdout: &stdout, stderr: &stderr}
+	g.unlock(locks)
+	if !strings.Contains(stderr.String(), "Warning: failed to release lock") {
+		t.Errorf("Expected the warning on the command's stderr, got %q", stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Expected nothing on stdout, got %q", stdout.String())
+	}
+}
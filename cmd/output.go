@@ -0,0 +1,61 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isStructured reports whether the selected output format is machine-readable.
+func (g *globalOptions) isStructured() bool {
+	return g.output == outputJSON || g.output == outputYAML
+}
+
+// printResult writes the result of a command to w if a structured output
+// format is selected; in text mode the command's log messages describe it.
+func (g *globalOptions) printResult(w io.Writer, v interface{}) error {
+	if !g.isStructured() {
+		return nil
+	}
+	return g.printStructured(w, v)
+}
+
+// printStructured writes v to w in the selected machine-readable format.
+func (g *globalOptions) printStructured(w io.Writer, v interface{}) error {
+	return encodeStructured(w, g.output, v)
+}
+
+// encodeStructured writes v to w in the machine-readable format, outputJSON
+// or outputYAML.
+func encodeStructured(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		defer func() {
+			if closeErr := enc.Close(); closeErr != nil {
+				fmt.Fprintf(w, "Warning: failed to flush YAML output: %v\n", closeErr)
+			}
+		}()
+		return enc.Encode(v)
+	default:
+		return fmt.Errorf("output format %q is not a structured format", format)
+	}
+}
@@ -0,0 +1,174 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runStructuredCommand runs the command on a copy of listTestKubeconfig,
+// with a configuration file whitelisting pr*, and returns its stdout and
+// the kubeconfig path.
+func runStructuredCommand(t *testing.T, args ...string) (string, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("pr*\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetOut(&out)
+	root.SetArgs(append(args, "--kubeconfig", kubeconfigPath, "--config", configPath))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return out.String(), kubeconfigPath
+}
+
+func TestCleanupDryRunJSON(t *testing.T) {
+	output, kubeconfigPath := runStructuredCommand(t, "--dry-run", "-o", "json")
+
+	var results []cleanupResult
+	if err := json.Unmarshal([]byte(output), &results); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected one result per kubeconfig, got %d", len(results))
+	}
+	result := results[0]
+	if !result.DryRun || result.Kubeconfig != kubeconfigPath || result.ContextsKept != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if strings.Join(result.RemovedContexts, ",") != "dev" {
+		t.Errorf("Expected dev to be removed, got %v", result.RemovedContexts)
+	}
+	if strings.Join(result.MatchedPatterns["pr*"], ",") != "prod" {
+		t.Errorf("Expected pr* to match prod, got %v", result.MatchedPatterns)
+	}
+	if d := result.Decisions["prod"]; d.Action != "keep" || d.Reason != reasonPattern || d.Pattern != "pr*" {
+		t.Errorf("Expected prod to be kept by pr*, got %+v", d)
+	}
+	if d := result.Decisions["dev"]; d.Action != "remove" || d.Reason != reasonNoMatch {
+		t.Errorf("Expected dev to be removed as no pattern matched, got %+v", d)
+	}
+	if changes := result.Changes[kubeconfigPath]; changes == nil ||
+		strings.Join(changes.Removed, ",") != "context/dev,cluster/dev-cluster,user/dev-user" {
+		t.Errorf("Expected dev with its cluster and user in the previewed changes, got %+v", result.Changes)
+	}
+	if result.ClustersRemoved != 1 || result.UsersRemoved != 1 {
+		t.Errorf("Expected the cluster and user dev would remove to be counted, got %d clusters and %d users",
+			result.ClustersRemoved, result.UsersRemoved)
+	}
+
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil || string(data) != listTestKubeconfig {
+		t.Errorf("Dry run modified the kubeconfig")
+	}
+}
+
+func TestCleanupOutputOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	var content strings.Builder
+	content.WriteString("apiVersion: v1\nkind: Config\ncontexts:\n")
+	var names []string
+	for i := 12; i > 0; i-- {
+		name := fmt.Sprintf("ctx-%02d", i)
+		names = append(names, name)
+		fmt.Fprintf(&content, "- name: %s\n  context:\n    cluster: c\n    user: u\n", name)
+	}
+	content.WriteString("clusters:\n- name: c\n  cluster:\n    server: https://c.example.com\nusers:\n- name: u\n  user:\n    token: t\n")
+	if err := os.WriteFile(kubeconfigPath, []byte(content.String()), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("# nothing kept\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	sort.Strings(names)
+
+	for run := 0; run < 5; run++ {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetArgs([]string{"--dry-run", "-o", "json", "--kubeconfig", kubeconfigPath, "--config", configPath})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var results []cleanupResult
+		if err := json.Unmarshal(out.Bytes(), &results); err != nil || len(results) != 1 {
+			t.Fatalf("Expected one JSON result, got %v: %s", err, out.String())
+		}
+		if got := strings.Join(results[0].RemovedContexts, ","); got != strings.Join(names, ",") {
+			t.Fatalf("Expected removedContexts sorted by name, got %s", got)
+		}
+	}
+}
+
+func TestCleanupTableOutput(t *testing.T) {
+	// Table mode prints the messages on stdout instead of a result
+	output, _ := runStructuredCommand(t, "--dry-run", "-o", "table")
+	if !strings.HasPrefix(output, "Contexts to keep:") || !strings.Contains(output, "Dry run mode") {
+		t.Errorf("Expected only the messages on stdout in table mode, got %q", output)
+	}
+}
+
+func TestRemoveYAML(t *testing.T) {
+	output, _ := runStructuredCommand(t, "remove", "dev", "--yes", "-o", "yaml")
+
+	var result cleanupResult
+	if err := yaml.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Output is not valid YAML: %v\n%s", err, output)
+	}
+	if result.DryRun || strings.Join(result.RemovedContexts, ",") != "dev" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result.ClustersRemoved != 1 || result.UsersRemoved != 1 || len(result.Backups) != 1 {
+		t.Errorf("Expected one cluster, user and backup, got %+v", result)
+	}
+	if d := result.Decisions["dev"]; d.Reason != reasonArgument || d.Pattern != "dev" {
+		t.Errorf("Expected dev to be removed by its argument, got %+v", d)
+	}
+}
+
+func TestRenameJSON(t *testing.T) {
+	output, _ := runStructuredCommand(t, "rename", "dev", "development", "--cluster", "development-cluster", "-o", "json")
+
+	var result renameResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	if result.Renamed["context/dev"] != "development" || result.Renamed["cluster/dev-cluster"] != "development-cluster" {
+		t.Errorf("Unexpected renames: %v", result.Renamed)
+	}
+	if result.Backup == "" {
+		t.Errorf("Expected the backup path in the result")
+	}
+}
@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/che-incubator/kubectx-manager/internal/diff"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// printDiff shows the change from oldText to newText using the shared diff renderer.
+func printDiff(log *logger.Logger, oldText, newText, oldName, newName string) {
+	rendered := diff.String(oldText, newText, diff.Options{
+		OldName:  oldName,
+		NewName:  newName,
+		Context:  diff.DefaultContext,
+		Color:    log.ColorEnabled(),
+		WordDiff: true,
+	})
+	if rendered == "" {
+		log.Infof("No changes")
+		return
+	}
+	log.Infof("%s", strings.TrimRight(rendered, "\n"))
+}
+
+// previewChanges shows every context, cluster and user that removing the
+// given contexts would remove from each file of multi, including the
+// clusters and users left unused, and returns the changes by file.
+func previewChanges(multi *kubeconfig.MultiConfig, contextsToRemove []string, log *logger.Logger) (map[string]*kubeconfig.Changes, error) {
+	changes, err := multi.PreviewRemoval(contextsToRemove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview changes: %w", err)
+	}
+	byFile := make(map[string]*kubeconfig.Changes, len(changes))
+	for i, fileChanges := range changes {
+		if fileChanges.Empty() {
+			continue
+		}
+		byFile[multi.Paths[i]] = fileChanges
+		printChanges(log, multi.Paths[i], fileChanges)
+	}
+	return byFile, nil
+}
+
+// printChanges lists the changes to the kubeconfig at path, marking
+// removed entries with -, added ones with + and modified ones with ~.
+func printChanges(log *logger.Logger, path string, changes *kubeconfig.Changes) {
+	unused := make(map[string]bool, len(changes.Unused))
+	for _, key := range changes.Unused {
+		unused[key] = true
+	}
+	log.Infof("Changes to %s:", path)
+	for _, key := range changes.Removed {
+		if unused[key] {
+			log.Infof("  - %s (already unused)", log.Paint(logger.Red, key))
+			continue
+		}
+		log.Infof("  - %s", log.Paint(logger.Red, key))
+	}
+	for _, key := range changes.Added {
+		log.Infof("  + %s", log.Paint(logger.Green, key))
+	}
+	for _, key := range changes.Modified {
+		log.Infof("  ~ %s", log.Paint(logger.Yellow, key))
+	}
+	if change := changes.CurrentContext; change != nil {
+		log.Infof("  ~ current-context: %s -> %s", orNone(change.From), orNone(change.To))
+	}
+}
+
+// orNone returns name, or "(none)" if it is empty.
+func orNone(name string) string {
+	if name == "" {
+		return "(none)"
+	}
+	return name
+}
+
+// colorEnabled reports whether stdout is a terminal and NO_COLOR is unset;
+// see also globalOptions.colorOutput.
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return stdoutIsTerminal()
+}
+
+// stdoutIsTerminal reports whether stdout is a terminal; tests replace it.
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
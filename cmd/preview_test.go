@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+func TestPreviewRemoval(t *testing.T) {
+	skipUnlessBuiltinBackend(t)
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	content := `apiVersion: v1
+kind: Config
+current-context: keep
+contexts:
+- name: keep
+  context:
+    cluster: keep-cluster
+    user: keep-user
+- name: drop
+  context:
+    cluster: drop-cluster
+    user: drop-user
+clusters:
+- name: keep-cluster
+  cluster:
+    server: https://keep.example.com
+- name: drop-cluster
+  cluster:
+    server: https://drop.example.com
+users:
+- name: keep-user
+  user:
+    token: keep-token
+- name: drop-user
+  user:
+    token: drop-token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	multi, err := kubeconfig.LoadMulti([]string{kubeconfigPath})
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	var output bytes.Buffer
+	err = previewRemoval(multi, []string{"drop"}, logger.New(logger.Options{Out: &output}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	outputStr := output.String()
+	for _, want := range []string{"-  name: drop", "-    server: https://drop.example.com", "-    token: drop-token"} {
+		if !strings.Contains(outputStr, want) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", want, outputStr)
+		}
+	}
+	if strings.Contains(outputStr, "-  name: keep") {
+		t.Errorf("Kept context should not appear as removed:\n%s", outputStr)
+	}
+
+	// The file on disk must be untouched by a preview
+	data, _ := os.ReadFile(kubeconfigPath)
+	if string(data) != content {
+		t.Error("previewRemoval must not modify the kubeconfig file")
+	}
+}
@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Prompter asks the user the questions of a command. Commands reach it
+// through globalOptions.prompter, so that the answers can come from a
+// terminal, from flags or, in tests, from a script.
+type Prompter interface {
+	// Printf writes text leading up to a question, such as a menu
+	Printf(format string, args ...any)
+	// Confirm asks a yes or no question; only y or yes, in any case, is yes
+	Confirm(question string) (bool, error)
+	// Ask asks for a line of input and returns it trimmed. hint tells how
+	// to give the answer up front, for when there is nobody to ask.
+	Ask(question, hint string) (string, error)
+	// Password asks for a secret without echoing it, as Ask does
+	Password(question, hint string) (string, error)
+}
+
+// errNotTerminal is returned instead of prompting when stdin is not a
+// terminal, so that scripts and CI jobs fail rather than wait for an answer.
+var errNotTerminal = errors.New("stdin is not a terminal")
+
+// isTerminal reports whether stream, one of the command's streams, is a
+// terminal; tests replace it.
+var isTerminal = func(stream any) bool {
+	file, ok := stream.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(int(file.Fd())) //nolint:gosec // File descriptors fit in an int
+}
+
+// newPrompter returns the Prompter for the flags of g: one asking on the
+// terminal or, when the command's stdin is not a terminal, one answering
+// from the flags alone. Tests replace it.
+var newPrompter = func(g *globalOptions) Prompter {
+	if !isTerminal(g.stdin) {
+		return &flagPrompter{yes: g.yes}
+	}
+	return newTerminalPrompter(g.stdin, g.interactiveOut(), g.yes)
+}
+
+// interactiveOut returns the writer for prompts and what they show: the
+// command's stdout or, with a structured --output format, its stderr, which
+// keeps stdout for the result.
+func (g *globalOptions) interactiveOut() io.Writer {
+	if g.isStructured() {
+		return g.stderr
+	}
+	return g.stdout
+}
+
+// prompter returns the Prompter of the command, created on first use so
+// that every question reads from the same buffered stdin.
+func (g *globalOptions) prompter() Prompter {
+	if g.prompt == nil {
+		g.prompt = newPrompter(g)
+	}
+	return g.prompt
+}
+
+// isYes reports whether answer means yes.
+func isYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// terminalPrompter asks on a terminal; with yes, confirmations are
+// answered without asking.
+type terminalPrompter struct {
+	in     io.Reader
+	reader *bufio.Reader
+	out    io.Writer
+	yes    bool
+}
+
+func newTerminalPrompter(in io.Reader, out io.Writer, yes bool) *terminalPrompter {
+	return &terminalPrompter{in: in, reader: bufio.NewReader(in), out: out, yes: yes}
+}
+
+func (p *terminalPrompter) Printf(format string, args ...any) {
+	fmt.Fprintf(p.out, format, args...)
+}
+
+func (p *terminalPrompter) Confirm(question string) (bool, error) {
+	if p.yes {
+		return true, nil
+	}
+	answer, err := p.Ask(question, "")
+	if err != nil {
+		return false, err
+	}
+	return isYes(answer), nil
+}
+
+func (p *terminalPrompter) Ask(question, _ string) (string, error) {
+	fmt.Fprint(p.out, question)
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (p *terminalPrompter) Password(question, hint string) (string, error) {
+	file, ok := p.in.(interface{ Fd() uintptr })
+	if !ok || !term.IsTerminal(int(file.Fd())) { //nolint:gosec // File descriptors fit in an int
+		return p.Ask(question, hint)
+	}
+	fd := int(file.Fd()) //nolint:gosec // File descriptors fit in an int
+	fmt.Fprint(p.out, question)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return string(password), nil
+}
+
+// flagPrompter answers without asking, as the flags decide: with yes,
+// confirmations are answered yes; any other question fails with
+// errNotTerminal and the hint.
+type flagPrompter struct {
+	yes bool
+}
+
+func (p *flagPrompter) Printf(string, ...any) {}
+
+func (p *flagPrompter) Confirm(question string) (bool, error) {
+	if p.yes {
+		return true, nil
+	}
+	return false, notAsked(question, "pass --yes to confirm it")
+}
+
+func (p *flagPrompter) Ask(question, hint string) (string, error) {
+	return "", notAsked(question, hint)
+}
+
+func (p *flagPrompter) Password(question, hint string) (string, error) {
+	return "", notAsked(question, hint)
+}
+
+// notAsked returns the error for a question there is nobody to ask,
+// quoting its last line.
+func notAsked(question, hint string) error {
+	lines := strings.Split(strings.TrimSpace(question), "\n")
+	question = strings.TrimSuffix(strings.TrimSpace(lines[len(lines)-1]), ":")
+	if hint == "" {
+		return fmt.Errorf("cannot answer %q: %w", question, errNotTerminal)
+	}
+	return fmt.Errorf("cannot answer %q: %w; %s", question, errNotTerminal, hint)
+}
@@ -0,0 +1,160 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakePrompter answers questions from a script, in order, and records
+// everything it was shown. Once the script runs out, questions fail with io.EOF.
+type fakePrompter struct {
+	answers []string
+	output  strings.Builder
+}
+
+func newFakePrompter(answers ...string) *fakePrompter {
+	return &fakePrompter{answers: answers}
+}
+
+func (p *fakePrompter) Printf(format string, args ...any) {
+	fmt.Fprintf(&p.output, format, args...)
+}
+
+func (p *fakePrompter) Confirm(question string) (bool, error) {
+	answer, err := p.Ask(question, "")
+	return isYes(answer), err
+}
+
+func (p *fakePrompter) Ask(question, _ string) (string, error) {
+	p.output.WriteString(question)
+	if len(p.answers) == 0 {
+		return "", fmt.Errorf("failed to read input: %w", io.EOF)
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return strings.TrimSpace(answer), nil
+}
+
+func (p *fakePrompter) Password(question, hint string) (string, error) {
+	return p.Ask(question, hint)
+}
+
+// usePrompter makes the commands run by the test ask p.
+func usePrompter(t *testing.T, p Prompter) {
+	t.Helper()
+	saved := newPrompter
+	newPrompter = func(*globalOptions) Prompter { return p }
+	t.Cleanup(func() { newPrompter = saved })
+}
+
+func TestTerminalPrompter(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	go func() {
+		defer w.Close()
+		_, _ = w.WriteString("Yes\n  two words  \nsecret\nlast")
+	}()
+
+	var out bytes.Buffer
+	p := newTerminalPrompter(r, &out, false)
+	if ok, err := p.Confirm("Continue? "); err != nil || !ok {
+		t.Errorf("Expected Yes to confirm, got %v, %v", ok, err)
+	}
+	if answer, err := p.Ask("Name: ", ""); err != nil || answer != "two words" {
+		t.Errorf("Expected the trimmed answer, got %q, %v", answer, err)
+	}
+	// A pipe is not a terminal, so the secret is read as a line
+	if secret, err := p.Password("Secret: ", ""); err != nil || secret != "secret" {
+		t.Errorf("Expected the secret, got %q, %v", secret, err)
+	}
+	if answer, err := p.Ask("Last: ", ""); err != nil || answer != "last" {
+		t.Errorf("Expected an unterminated last line to be read, got %q, %v", answer, err)
+	}
+	if _, err := p.Ask("More: ", ""); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF once the input ends, got %v", err)
+	}
+	if expected := "Continue? Name: Secret: Last: More: "; out.String() != expected {
+		t.Errorf("Expected prompts %q, got %q", expected, out.String())
+	}
+
+	// --yes confirms without asking
+	out.Reset()
+	p = newTerminalPrompter(r, &out, true)
+	if ok, err := p.Confirm("Continue? "); err != nil || !ok || out.Len() != 0 {
+		t.Errorf("Expected --yes to confirm silently, got %v, %v and %q", ok, err, out.String())
+	}
+}
+
+func TestFlagPrompter(t *testing.T) {
+	p := &flagPrompter{}
+	_, err := p.Confirm("This will restore config.\nAre you sure? (y/N): ")
+	if !errors.Is(err, errNotTerminal) || !strings.Contains(err.Error(), `"Are you sure? (y/N)"`) ||
+		!strings.Contains(err.Error(), "--yes") {
+		t.Errorf("Expected an error quoting the question and naming --yes, got %v", err)
+	}
+	if _, err := p.Ask("Select backup: ", "pass --latest"); !errors.Is(err, errNotTerminal) ||
+		!strings.HasSuffix(err.Error(), "; pass --latest") {
+		t.Errorf("Expected an error ending with the hint, got %v", err)
+	}
+
+	p = &flagPrompter{yes: true}
+	if ok, err := p.Confirm("Continue? "); err != nil || !ok {
+		t.Errorf("Expected --yes to confirm, got %v, %v", ok, err)
+	}
+	if _, err := p.Password("Passphrase: ", ""); !errors.Is(err, errNotTerminal) {
+		t.Errorf("Expected --yes not to answer other questions, got %v", err)
+	}
+}
+
+func TestPromptedCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(p *fakePrompter) error {
+		usePrompter(t, p)
+		root := NewRootCommand()
+		root.SetArgs([]string{"delete", "dev", "-q", "--kubeconfig", kubeconfigPath, "--config", filepath.Join(tmpDir, "ignore")})
+		return root.Execute()
+	}
+
+	p := newFakePrompter("n")
+	if err := run(p); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(p.output.String(), "remove 1 context(s)?") {
+		t.Errorf("Expected to be asked to confirm the removal, got %q", p.output.String())
+	}
+	if data, err := os.ReadFile(kubeconfigPath); err != nil || string(data) != listTestKubeconfig {
+		t.Fatalf("Expected declining to leave the kubeconfig untouched (err %v)", err)
+	}
+
+	if err := run(newFakePrompter("y")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, err := os.ReadFile(kubeconfigPath); err != nil || strings.Contains(string(data), "dev-cluster") {
+		t.Errorf("Expected confirming to remove dev (err %v)", err)
+	}
+}
@@ -0,0 +1,161 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+var (
+	protectEdit = patternEdit{command: "protect", apply: config.AddProtected,
+		changed: "Protected '%s' in %s", unchanged: "'%s' is already protected in %s"}
+	unprotectEdit = patternEdit{command: "unprotect", apply: config.RemoveProtected,
+		changed: "Unprotected '%s' in %s", unchanged: "'%s' is not a protect pattern in %s", warnUnchanged: true}
+)
+
+func newProtectCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	protectCmd := &cobra.Command{
+		Use:   "protect [CONTEXT|PATTERN...]",
+		Short: "Protect contexts from ever being removed or overwritten",
+		Long: `Add protect: lines to the configuration file for the given context names or
+patterns. Cleanup, remove, tui and restore never remove or overwrite a protected
+context, even when other patterns, rules or arguments match it, and warn when
+they skip one. Without arguments, list the protect patterns.`,
+		ValidArgsFunction: global.completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return opts.listProtected(cmd.OutOrStdout())
+			}
+			return opts.editPatterns(cmd.OutOrStdout(), protectEdit, args)
+		},
+	}
+	addConfigFlag(protectCmd, &opts.configFile)
+	return protectCmd
+}
+
+func newUnprotectCommand(global *globalOptions) *cobra.Command {
+	opts := &configOptions{globalOptions: global}
+
+	unprotectCmd := &cobra.Command{
+		Use:   "unprotect CONTEXT|PATTERN...",
+		Short: "Remove protect patterns added by protect",
+		Long: `Remove protect: lines from the configuration file. Protect rules of a YAML
+configuration file are left alone; edit the file to change them.`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeProtected,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.editPatterns(cmd.OutOrStdout(), unprotectEdit, args)
+		},
+	}
+	addConfigFlag(unprotectCmd, &opts.configFile)
+	return unprotectCmd
+}
+
+func (o *configOptions) listProtected(out io.Writer) error {
+	if err := o.requireFormats("protect", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	cfg, err := o.loadConfigFile(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	patterns := cfg.Protect
+	if patterns == nil {
+		patterns = []string{}
+	}
+	if o.isStructured() {
+		return o.printStructured(out, patterns)
+	}
+	for _, pattern := range patterns {
+		fmt.Fprintln(out, pattern)
+	}
+	return nil
+}
+
+// protectedEntries maps the contexts of kConfig that cfg protects, and the
+// clusters and users they use, as "kind/name", to the rule protecting them.
+func protectedEntries(cfg *config.Config, kConfig *kubeconfig.Config) map[string]string {
+	protected := make(map[string]string)
+	for _, name := range kConfig.GetContextNames() {
+		rule, ok := cfg.Protected(contextInfo(kConfig, name))
+		if !ok {
+			continue
+		}
+		protected["context/"+name] = rule.Name
+		if ctx := kConfig.GetContext(name); ctx != nil {
+			protected["cluster/"+ctx.Cluster] = rule.Name
+			protected["user/"+ctx.User] = rule.Name
+		}
+	}
+	return protected
+}
+
+// protectingMergeOptions makes a merge into current keep the current version
+// of protected contexts, and of their clusters and users, whatever opts
+// would have done with them, warning about each one it keeps.
+func protectingMergeOptions(opts kubeconfig.MergeOptions, cfg *config.Config, current *kubeconfig.Config, log *logger.Logger) kubeconfig.MergeOptions {
+	protected := protectedEntries(cfg, current)
+	if len(protected) == 0 {
+		return opts
+	}
+	resolve, strategy := opts.Resolve, opts.Strategy
+	opts.Resolve = func(conflict kubeconfig.Conflict) kubeconfig.DuplicateStrategy {
+		if rule, ok := protected[conflict.Kind+"/"+conflict.Name]; ok {
+			log.Warnf("Not overwriting %s '%s', protected by rule '%s'", conflict.Kind, conflict.Name, rule)
+			return kubeconfig.DuplicateKeep
+		}
+		if resolve != nil {
+			return resolve(conflict)
+		}
+		return strategy
+	}
+	return opts
+}
+
+// keepProtected puts the protected contexts of previous, with their clusters
+// and users, into restored, the backup a restore is about to write, so that
+// a restore can neither drop nor change them. It reports whether restored
+// changed.
+func keepProtected(restored, previous *kubeconfig.Config, cfg *config.Config, log *logger.Logger) (bool, error) {
+	var names []string
+	for key := range protectedEntries(cfg, previous) {
+		if name, ok := strings.CutPrefix(key, "context/"); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return false, nil
+	}
+	sort.Strings(names)
+
+	report, err := kubeconfig.Merge(restored, kubeconfig.ExtractContexts(previous, names), kubeconfig.DuplicateOverwrite)
+	if err != nil {
+		return false, err
+	}
+	kept := append(report.Added, report.Overwritten...)
+	for _, key := range kept {
+		log.Warnf("Kept protected %s from the current kubeconfig instead of the backup's", key)
+	}
+	return len(kept) > 0, nil
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// protectTestBackup holds dev alone, with a changed server.
+const protectTestBackup = `apiVersion: v1
+kind: Config
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev-old.example.com
+users:
+- name: dev-user
+  user:
+    token: dev-token
+`
+
+func TestProtect(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("# nothing kept\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(&out)
+		root.SetArgs(append(args, "--kubeconfig", kubeconfigPath, "--config", configPath,
+			"--backup-dir", filepath.Join(tmpDir, "backups")))
+		err := root.Execute()
+		return out.String(), err
+	}
+	contexts := func() []string {
+		kConfig, err := kubeconfig.Load(kubeconfigPath)
+		if err != nil {
+			t.Fatalf("Failed to load kubeconfig: %v", err)
+		}
+		names := kConfig.GetContextNames()
+		sort.Strings(names)
+		return names
+	}
+
+	if _, err := run("protect", "prod", "-q"); err != nil {
+		t.Fatalf("protect failed: %v", err)
+	}
+	if out, err := run("protect"); err != nil || out != "prod\n" {
+		t.Errorf("Expected protect to list prod, got %q, %v", out, err)
+	}
+
+	if _, err := run("remove", "prod", "--yes", "-q"); err == nil || !strings.Contains(err.Error(), "protected by rule 'protect: prod'") {
+		t.Errorf("Expected removing prod by name to fail, got %v", err)
+	}
+
+	// A restore replacing the kubeconfig with a backup lacking prod keeps it
+	backupPath := filepath.Join(tmpDir, "old.yaml")
+	if err := os.WriteFile(backupPath, []byte(protectTestBackup), 0600); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	if _, err := run("restore", "--from", backupPath, "--yes", "--no-backup", "-q"); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if names := contexts(); strings.Join(names, ",") != "dev,prod" {
+		t.Errorf("Expected the protected prod context to survive the restore, got %v", names)
+	}
+
+	// A cleanup keeps it although nothing whitelists it
+	if _, err := run("--yes", "-q"); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if names := contexts(); strings.Join(names, ",") != "prod" {
+		t.Errorf("Expected cleanup to keep only the protected prod context, got %v", names)
+	}
+
+	if _, err := run("unprotect", "prod", "-q"); err != nil {
+		t.Fatalf("unprotect failed: %v", err)
+	}
+	// Removing the last context empties the kubeconfig
+	if _, err := run("remove", "prod", "--yes", "--force", "-q"); err != nil {
+		t.Errorf("Expected prod to be removable once unprotected, got %v", err)
+	}
+}
+
+func TestProtectingMergeOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("protect: server:dev.example.com\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	backupPath := filepath.Join(tmpDir, "old.yaml")
+	if err := os.WriteFile(backupPath, []byte(protectTestBackup), 0600); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"restore", "--kubeconfig", kubeconfigPath, "--config", configPath, "--from", backupPath,
+		"--on-duplicate", "overwrite", "--yes", "--no-backup", "-q"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if server := kConfig.GetServer("dev"); server != "https://dev.example.com" {
+		t.Errorf("Expected the protected dev cluster not to be overwritten, got %s", server)
+	}
+	if user := kConfig.GetUser("dev-user"); user == nil || user.Exec == nil {
+		t.Errorf("Expected the protected dev user not to be overwritten, got %+v", user)
+	}
+}
+
+func TestKeepProtectedInMemory(t *testing.T) {
+	tmpDir := t.TempDir()
+	load := func(name, content string) *kubeconfig.Config {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		kConfig, err := kubeconfig.Load(path)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		return kConfig
+	}
+	previous := load("config", listTestKubeconfig)
+	restored := load("backup", protectTestBackup)
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("protect: prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	log := logger.New(logger.Options{Level: logger.LevelError})
+
+	// The protected context goes into the backup before anything is written
+	kept, err := keepProtected(restored, previous, cfg, log)
+	if err != nil || !kept {
+		t.Fatalf("Expected the protected context to be kept, got %v, %v", kept, err)
+	}
+	if restored.GetContext("prod") == nil {
+		t.Error("Expected prod to be added to the restored configuration")
+	}
+	if kept, err := keepProtected(restored, previous, cfg, log); err != nil || kept {
+		t.Errorf("Expected nothing to keep once prod is there, got %v, %v", kept, err)
+	}
+}
@@ -0,0 +1,181 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// pruneOptions holds the flag values for a single invocation of the prune-orphans command.
+type pruneOptions struct {
+	*globalOptions
+	dryRun   bool
+	showDiff bool
+}
+
+func newPruneOrphansCommand(global *globalOptions) *cobra.Command {
+	opts := &pruneOptions{globalOptions: global}
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune-orphans",
+		Short: "Remove clusters and users no context references",
+		Long: `Remove the clusters and users that no context references, such as those left behind by
+imports or manual edits, without touching any context. The cleanup only removes them along with
+the contexts it removes.
+
+In a kubeconfig list, an entry referenced by a context of any file of the list is kept.
+A backup of every modified file is created first.`,
+		Example: `  # Show what would be pruned
+  kubectx-manager prune-orphans --dry-run
+
+  # Prune every kubeconfig of a directory
+  kubectx-manager prune-orphans --kubeconfig-dir ~/kubeconfigs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	pruneCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show which entries would be removed without modifying the kubeconfig")
+	pruneCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change")
+
+	return pruneCmd
+}
+
+// pruneResult is the structured form of prune-orphans for one kubeconfig.
+type pruneResult struct {
+	Kubeconfig string   `json:"kubeconfig" yaml:"kubeconfig"`
+	Clusters   []string `json:"clusters" yaml:"clusters"`
+	Users      []string `json:"users" yaml:"users"`
+	Backups    []string `json:"backups,omitempty" yaml:"backups,omitempty"`
+	DryRun     bool     `json:"dryRun" yaml:"dryRun"`
+	// Error is set for a kubeconfig of --kubeconfig-dir that could not be pruned
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (o *pruneOptions) run(out io.Writer) error {
+	if err := o.requireFormats("prune-orphans", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	log := o.newLogger()
+
+	chains, err := o.kubeconfigChains()
+	if err != nil {
+		return err
+	}
+	results := make([]pruneResult, 0, len(chains))
+	for _, paths := range chains {
+		label := strings.Join(paths, string(filepath.ListSeparator))
+		if len(chains) > 1 {
+			log.Infof("==> %s", label)
+		}
+		result, err := o.prune(paths, log)
+		if err != nil && o.isBulk() {
+			log.Errorf("%s: %v", label, err)
+			o.setExitCode(exitCodeBulkFailures)
+			results = append(results, pruneResult{Kubeconfig: label, Clusters: []string{}, Users: []string{}, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		results = append(results, *result)
+	}
+	return o.printResult(out, results)
+}
+
+// prune removes the orphaned clusters and users of one kubeconfig, or of
+// the merged view of a kubeconfig list.
+func (o *pruneOptions) prune(paths []string, log *logger.Logger) (*pruneResult, error) {
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return nil, err
+	}
+	defer o.unlock(locks)
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	before := make([][]byte, len(multi.Files))
+	for i, file := range multi.Files {
+		if before[i], err = kubeconfig.Marshal(file); err != nil {
+			return nil, err
+		}
+	}
+
+	report := multi.PruneOrphans()
+	result := &pruneResult{
+		Kubeconfig: strings.Join(paths, string(filepath.ListSeparator)),
+		Clusters:   report.Clusters,
+		Users:      report.Users,
+		DryRun:     o.dryRun,
+	}
+	if report.Empty() {
+		log.Infof("No orphaned clusters or users found")
+		return result, nil
+	}
+	o.setExitCode(exitCodeChanged)
+	for _, name := range report.Clusters {
+		log.Infof("  cluster '%s' is referenced by no context", name)
+	}
+	for _, name := range report.Users {
+		log.Infof("  user '%s' is referenced by no context", name)
+	}
+
+	modified := multi.ModifiedPaths()
+	if o.showDiff {
+		for i, path := range multi.Paths {
+			after, err := kubeconfig.Marshal(multi.Files[i])
+			if err != nil {
+				return nil, err
+			}
+			if string(after) != string(before[i]) {
+				printDiff(log, string(before[i]), string(after), path, path+" (pruned)")
+			}
+		}
+	}
+	if o.dryRun {
+		log.Infof("Dry run mode - no changes made")
+		return result, nil
+	}
+
+	for _, path := range modified {
+		backupPath, err := o.createBackup(path, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backup: %w", err)
+		}
+		log.Debugf("Created backup at: %s", backupPath)
+		result.Backups = append(result.Backups, backupPath)
+	}
+	if err := multi.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Remove %d orphaned clusters and %d orphaned users", len(report.Clusters), len(report.Users)),
+		Kubeconfigs: modified,
+		Backups:     result.Backups,
+	}, log)
+
+	log.Infof("Removed %d orphaned clusters and %d orphaned users", len(report.Clusters), len(report.Users))
+	return result, nil
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+const pruneTestKubeconfig = `apiVersion: v1
+kind: Config
+current-context: a
+contexts:
+- name: a
+  context:
+    cluster: prod
+    user: admin
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+- name: imported
+  cluster:
+    server: https://imported.example.com
+users:
+- name: admin
+  user:
+    token: secret
+- name: imported-admin
+  user:
+    token: other
+`
+
+func TestPruneOrphansCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(pruneTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	var out bytes.Buffer
+	root, global := newRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"prune-orphans", "--dry-run", "-o", "json", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != pruneTestKubeconfig {
+		t.Fatal("Expected dry run to leave the kubeconfig untouched")
+	}
+	var results []pruneResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 || len(results[0].Clusters) != 1 || results[0].Clusters[0] != "imported" ||
+		len(results[0].Users) != 1 || results[0].Users[0] != "imported-admin" {
+		t.Errorf("Expected imported and imported-admin to be reported, got %+v", results)
+	}
+	if global.exitCode != exitCodeChanged {
+		t.Errorf("Expected exit code %d, got %d", exitCodeChanged, global.exitCode)
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"prune-orphans", "-q", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if len(kConfig.Contexts) != 1 || len(kConfig.Clusters) != 1 || len(kConfig.Users) != 1 || kConfig.GetCluster("prod") == nil {
+		t.Errorf("Expected only the orphans to be removed, got %+v", kConfig)
+	}
+
+	// Nothing is left to prune
+	root, global = newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"prune-orphans", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil || global.exitCode != exitCodeNothingToDo {
+		t.Errorf("Expected nothing to do, got exit code %d and %v", global.exitCode, err)
+	}
+}
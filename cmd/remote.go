@@ -0,0 +1,161 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/remote"
+)
+
+// backupRemoteEnv names the environment variable holding the default --backup-remote
+const backupRemoteEnv = "KUBECTX_MANAGER_BACKUP_REMOTE"
+
+// remoteBackups returns the client for --backup-remote, or nil if none is configured.
+func (g *globalOptions) remoteBackups() (*remote.S3, error) {
+	if g.backupRemote == "" || g.remoteClient != nil {
+		return g.remoteClient, nil
+	}
+	client, err := remote.NewS3FromEnv(g.backupRemote)
+	if err != nil {
+		return nil, err
+	}
+	g.remoteClient = client
+	return client, nil
+}
+
+// remoteKey returns the key of a backup of the kubeconfig at path on the
+// remote: a folder per kubeconfig, named like its BackupFolder.
+func remoteKey(kubeconfigPath, name string) string {
+	return kubeconfig.BackupSource(kubeconfigPath) + "/" + name
+}
+
+// uploadBackup copies the backup of the kubeconfig at path, and its manifest
+// if it has one, to --backup-remote. Failures are only warned about, since
+// the backup is safe on disk.
+func (g *globalOptions) uploadBackup(backupPath, path string, log *logger.Logger) {
+	client, err := g.remoteBackups()
+	if err != nil || client == nil {
+		if err != nil {
+			log.Warnf("Not uploading backup %s: %v", backupPath, err)
+		}
+		return
+	}
+
+	files := []string{backupPath}
+	if _, err := os.Stat(kubeconfig.ManifestPath(backupPath)); err == nil {
+		files = append(files, kubeconfig.ManifestPath(backupPath))
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file) //nolint:gosec // Backup written by this process
+		if err == nil {
+			err = client.Put(context.Background(), remoteKey(path, filepath.Base(file)), data)
+		}
+		if err != nil {
+			log.Warnf("Failed to upload backup %s to %s: %v", file, client, err)
+			return
+		}
+	}
+	log.Debugf("Uploaded backup %s to %s", backupPath, client)
+}
+
+// findBackups lists the backups of the kubeconfig at path, newest first:
+// those on disk and, with --backup-remote, those only stored on the remote.
+// An unreachable remote is only warned about.
+func (g *globalOptions) findBackups(kubeconfigPath string, log *logger.Logger) ([]kubeconfig.Backup, error) {
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, g.backupDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backups: %w", err)
+	}
+	client, err := g.remoteBackups()
+	if err == nil && client != nil {
+		var objects []remote.Object
+		if objects, err = client.List(context.Background(), kubeconfig.BackupSource(kubeconfigPath)+"/"); err == nil {
+			backups = mergeRemoteBackups(kubeconfigPath, g.backupDir, backups, objects)
+		}
+	}
+	if err != nil {
+		log.Warnf("Listing local backups only: %v", err)
+	}
+	return backups, nil
+}
+
+// mergeRemoteBackups adds the backups among objects that are not on disk to backups
+func mergeRemoteBackups(kubeconfigPath, backupDir string, backups []kubeconfig.Backup, objects []remote.Object) []kubeconfig.Backup {
+	local := make(map[string]bool, len(backups))
+	for _, backup := range backups {
+		local[backup.Name] = true
+	}
+	for _, object := range objects {
+		name := filepath.Base(object.Key)
+		if local[name] || strings.HasSuffix(name, kubeconfig.ManifestSuffix) {
+			continue
+		}
+		if backup, err := kubeconfig.RemoteBackup(kubeconfigPath, backupDir, name, object.Size); err == nil {
+			backups = append(backups, backup)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].After(backups[j])
+	})
+	return backups
+}
+
+// pullBackup downloads a backup only stored on --backup-remote, with its
+// manifest, to its Path, so it can be read like any other.
+func (g *globalOptions) pullBackup(kubeconfigPath string, backup *kubeconfig.Backup, log *logger.Logger) error {
+	if !backup.Remote {
+		return nil
+	}
+	client, err := g.remoteBackups()
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return fmt.Errorf("backup %s is stored remotely but no --backup-remote is set", backup.Name)
+	}
+
+	ctx := context.Background()
+	data, err := client.Get(ctx, remoteKey(kubeconfigPath, backup.Name))
+	if err != nil {
+		return err
+	}
+	if _, err := kubeconfig.CreateBackupFolder(kubeconfigPath, g.backupDir); err != nil {
+		return err
+	}
+	if err := os.WriteFile(backup.Path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save backup: %w", err)
+	}
+
+	manifest, err := client.Get(ctx, remoteKey(kubeconfigPath, backup.Name+kubeconfig.ManifestSuffix))
+	switch {
+	case err == nil:
+		if err := os.WriteFile(kubeconfig.ManifestPath(backup.Path), manifest, 0600); err != nil {
+			log.Warnf("Failed to save manifest of backup %s: %v", backup.Name, err)
+		}
+	case !errors.Is(err, remote.ErrNotFound):
+		log.Warnf("Failed to download manifest of backup %s: %v", backup.Name, err)
+	}
+
+	log.Infof("Downloaded backup %s from %s", backup.Name, client)
+	backup.Remote = false
+	return nil
+}
@@ -0,0 +1,162 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// newFakeBucket serves an in-memory S3 bucket named "bucket" and points the
+// AWS environment variables at it.
+func newFakeBucket(t *testing.T) map[string][]byte {
+	t.Helper()
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch {
+		case r.Method == http.MethodPut:
+			objects[key], _ = io.ReadAll(r.Body)
+		case r.URL.Query().Get("list-type") == "2":
+			var keys []string
+			for k := range objects {
+				if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			fmt.Fprint(w, "<ListBucketResult>")
+			for _, k := range keys {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key><Size>%d</Size></Contents>", k, len(objects[k]))
+			}
+			fmt.Fprint(w, "</ListBucketResult>")
+		default:
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	return objects
+}
+
+func TestBackupRemote(t *testing.T) {
+	objects := newFakeBucket(t)
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(out io.Writer, args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetOut(out)
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--backup-remote", "s3://bucket/team"))
+		return root.Execute()
+	}
+
+	// New backups are uploaded with their manifests under a folder per kubeconfig
+	if err := run(io.Discard, "backup", "create"); err != nil {
+		t.Fatalf("backup create failed: %v", err)
+	}
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected one local backup, got %v, %v", backups, err)
+	}
+	key := "team/" + kubeconfig.BackupSource(kubeconfigPath) + "/" + backups[0].Name
+	if _, ok := objects[key]; !ok {
+		t.Fatalf("Expected backup uploaded as %s, got %v", key, objects)
+	}
+	if _, ok := objects[key+kubeconfig.ManifestSuffix]; !ok {
+		t.Errorf("Expected the manifest to be uploaded")
+	}
+
+	// Once the local copy is gone, the backup is listed from the remote
+	if err := kubeconfig.RemoveBackup(backups[0].Path); err != nil {
+		t.Fatalf("Failed to remove local backup: %v", err)
+	}
+	var out bytes.Buffer
+	if err := run(&out, "backup", "list", "-o", "json"); err != nil {
+		t.Fatalf("backup list failed: %v", err)
+	}
+	var entries []backupEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", out.String(), err)
+	}
+	if len(entries) != 1 || !entries[0].Remote || entries[0].Name != backups[0].Name {
+		t.Fatalf("Expected the remote backup to be listed, got %+v", entries)
+	}
+
+	// Remote-only backups cannot be deleted locally
+	if err := run(io.Discard, "backup", "delete", "--yes", entries[0].Name); err == nil {
+		t.Error("Expected deleting a remote-only backup to fail")
+	}
+
+	// Restoring pulls the backup, with its manifest
+	if err := os.WriteFile(kubeconfigPath, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatalf("Failed to overwrite kubeconfig: %v", err)
+	}
+	if err := run(io.Discard, "restore", "--latest", "--yes", "--backup-choice", "none", "--keep-backup"); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Error("Expected the remote backup to be restored")
+	}
+	if _, err := kubeconfig.ReadManifest(backups[0].Path); err != nil {
+		t.Errorf("Expected the manifest to be downloaded: %v", err)
+	}
+}
+
+func TestBackupRemoteUnreachable(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	// Without credentials backups are still written and listed locally
+	for _, args := range [][]string{{"backup", "create"}, {"backup", "list"}} {
+		root := NewRootCommand()
+		root.SetOut(io.Discard)
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath, "--backup-remote", "s3://bucket"))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("%s failed: %v", strings.Join(args, " "), err)
+		}
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected one local backup, got %d", len(backups))
+	}
+}
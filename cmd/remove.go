@@ -0,0 +1,323 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// removeOptions holds the flag values for a single invocation of the remove command.
+type removeOptions struct {
+	*globalOptions
+	configFile string
+	dryRun     bool
+	showDiff   bool
+	archive    bool
+	selector   string
+	report     string
+}
+
+func newRemoveCommand(global *globalOptions) *cobra.Command {
+	opts := &removeOptions{globalOptions: global}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove [CONTEXT...] [-l SELECTOR]",
+		Short: "Remove the named contexts from the kubeconfig",
+		Long: `Remove the given contexts from the kubeconfig, independent of the whitelist.
+Each argument is a context name, an alias from the configuration file, or a glob pattern
+using * and ?; -l also removes the contexts whose labels match a selector such as env=scratch.
+Clusters and users no longer referenced by any context are removed as well,
+and a backup is created before the kubeconfig is modified.
+The removal is confirmed interactively unless --yes is given.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && opts.selector == "" {
+				return errors.New("requires at least one context or a --selector")
+			}
+			return nil
+		},
+		ValidArgsFunction: global.completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.report != "" {
+				if _, err := reportFormat(opts.report); err != nil {
+					return err
+				}
+			}
+			summary, err := opts.run(args)
+			if err != nil {
+				return err
+			}
+			opts.setExitCode(summary.exitCode())
+			if opts.report != "" {
+				if err := opts.writeReport(opts.report, []cleanupResult{summary.result()}); err != nil {
+					return err
+				}
+			}
+			return opts.printResult(cmd.OutOrStdout(), summary.result())
+		},
+	}
+
+	removeCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show what would be removed without making changes")
+	removeCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change in dry-run mode")
+	removeCmd.Flags().StringVarP(&opts.selector, "selector", "l", "",
+		"Also remove the contexts whose labels match this selector (e.g. env=scratch)")
+	removeCmd.Flags().BoolVar(&opts.archive, "archive", false,
+		"Move the contexts to an archive kubeconfig next to the kubeconfig (e.g. ~/.kube/config.archive), to bring back with archive restore")
+	addReportFlag(removeCmd, &opts.report)
+	addConfigFlag(removeCmd, &opts.configFile)
+
+	return removeCmd
+}
+
+func (o *removeOptions) run(args []string) (*runSummary, error) {
+	var selector *kubeconfig.Selector
+	if o.selector != "" {
+		var err error
+		if selector, err = kubeconfig.ParseSelector(o.selector); err != nil {
+			return nil, err
+		}
+	}
+	log := o.newLogger()
+
+	paths, err := o.kubeconfigChain("remove")
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return nil, err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+
+	contextsToRemove, matched, err := selectContexts(kConfig, cfg, args)
+	if err != nil {
+		return nil, err
+	}
+	var labeled []string
+	if selector != nil {
+		labeled = kubeconfig.SelectContexts(kConfig, selector)
+		contextsToRemove = mergeSelections(kConfig, contextsToRemove, labeled)
+	}
+	if contextsToRemove, err = dropProtected(kConfig, cfg, args, contextsToRemove, log); err != nil {
+		return nil, err
+	}
+	decisions := argumentDecisions(cfg, args, contextsToRemove, matched)
+	for _, name := range labeled {
+		if _, ok := decisions[name]; !ok && slices.Contains(contextsToRemove, name) {
+			decisions[name] = contextDecision{Action: "remove", Reason: reasonLabel, Pattern: selector.String()}
+		}
+	}
+	summary := newRunSummary()
+	summary.dryRun = o.dryRun
+	summary.kubeconfig = strings.Join(paths, string(filepath.ListSeparator))
+	summary.plan = removal{contexts: contextsToRemove, matchedPatterns: matched,
+		decisions: decisions}
+	summary.contextsKept = len(kConfig.Contexts) - len(contextsToRemove)
+	if len(contextsToRemove) == 0 {
+		log.Infof("No contexts to remove")
+		return summary, nil
+	}
+
+	log.Infof("Contexts to remove:")
+	for _, ctx := range contextsToRemove {
+		if len(multi.Files) > 1 {
+			log.Infof("  - %s [%s]", log.Paint(logger.Red, ctx), multi.SourceOf(ctx))
+			continue
+		}
+		log.Infof("  - %s", log.Paint(logger.Red, ctx))
+	}
+	if o.dryRun || !o.yes {
+		if summary.changes, err = previewChanges(multi, contextsToRemove, log); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.dryRun {
+		if o.showDiff {
+			if err := previewRemoval(multi, contextsToRemove, log); err != nil {
+				return nil, err
+			}
+		}
+		log.Infof("Dry run mode - no changes made")
+		summary.countPreviewed()
+		return summary, nil
+	}
+
+	confirmed, err := confirmRemoval(o.prompter(), contextsToRemove)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		log.Infof("Operation canceled by user")
+		summary.canceled = true
+		return summary, nil
+	}
+
+	if o.archive {
+		if err := archiveContexts(multi, contextsToRemove, log); err != nil {
+			return nil, err
+		}
+		summary.archived = true
+	}
+	clustersBefore, usersBefore := multi.Counts()
+	if err := multi.RemoveContexts(contextsToRemove); err != nil {
+		return nil, fmt.Errorf("failed to remove contexts: %w", err)
+	}
+	modified := multi.ModifiedPaths()
+	for _, path := range modified {
+		backupPath, err := o.createBackup(path, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backup: %w", err)
+		}
+		o.writeManifest(backupPath, path, &summary.plan, log)
+		summary.backupPaths = append(summary.backupPaths, backupPath)
+		log.Infof("Created backup at: %s", backupPath)
+	}
+	if err := multi.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     "Remove contexts " + listSubject(contextsToRemove),
+		Kubeconfigs: modified,
+		Removed:     contextsToRemove,
+		Backups:     summary.backupPaths,
+	}, log)
+
+	log.Infof("Successfully removed %d contexts", len(contextsToRemove))
+	clustersAfter, usersAfter := multi.Counts()
+	summary.clustersRemoved = clustersBefore - clustersAfter
+	summary.usersRemoved = usersBefore - usersAfter
+	log.Debugf("Garbage-collected %d clusters and %d users",
+		summary.clustersRemoved, summary.usersRemoved)
+	return summary, nil
+}
+
+// dropProtected refuses to remove the contexts among names that a protect
+// rule applies to: naming one in args is an error, while those only matched
+// by a pattern are skipped with a warning.
+func dropProtected(kConfig *kubeconfig.Config, cfg *config.Config, args, names []string, log *logger.Logger) ([]string, error) {
+	named := make(map[string]bool)
+	for _, arg := range args {
+		if !config.IsPattern(arg) {
+			named[cfg.ResolveAlias(arg)] = true
+		}
+	}
+	var kept []string
+	for _, name := range names {
+		rule, ok := cfg.Protected(contextInfo(kConfig, name))
+		if !ok {
+			kept = append(kept, name)
+			continue
+		}
+		if named[name] {
+			return nil, fmt.Errorf("context '%s' is protected by rule '%s'", name, rule.Name)
+		}
+		log.Warnf("Skipping context '%s', protected by rule '%s'", name, rule.Name)
+	}
+	return kept, nil
+}
+
+// selectContexts resolves context names, aliases and glob patterns to the
+// matching context names in kubeconfig order, and maps each pattern to the
+// contexts it matched. A plain name that does not exist is an error; a
+// pattern that matches nothing is not.
+func selectContexts(kConfig *kubeconfig.Config, cfg *config.Config, args []string) ([]string, map[string][]string, error) {
+	selected := make(map[string]bool)
+	matchedPatterns := make(map[string][]string)
+
+	for _, arg := range args {
+		if !config.IsPattern(arg) {
+			name := cfg.ResolveAlias(arg)
+			if kConfig.GetContext(name) == nil {
+				return nil, nil, fmt.Errorf("context '%s' not found", arg)
+			}
+			selected[name] = true
+			continue
+		}
+
+		for _, namedContext := range kConfig.Contexts {
+			name := namedContext.Name
+			candidates := append([]string{name}, cfg.AliasesFor(name)...)
+			for _, candidate := range candidates {
+				matched, err := config.MatchPattern(arg, candidate)
+				if err != nil {
+					return nil, nil, err
+				}
+				if matched {
+					selected[name] = true
+					matchedPatterns[arg] = append(matchedPatterns[arg], name)
+					break
+				}
+			}
+		}
+	}
+
+	var names []string
+	for _, namedContext := range kConfig.Contexts {
+		if selected[namedContext.Name] {
+			names = append(names, namedContext.Name)
+		}
+	}
+	return names, matchedPatterns, nil
+}
+
+// mergeSelections returns the contexts in either a or b, in kubeconfig order.
+func mergeSelections(kConfig *kubeconfig.Config, a, b []string) []string {
+	var names []string
+	for _, namedContext := range kConfig.Contexts {
+		if slices.Contains(a, namedContext.Name) || slices.Contains(b, namedContext.Name) {
+			names = append(names, namedContext.Name)
+		}
+	}
+	return names
+}
+
+// argumentDecisions attributes each of the contexts to the first of args,
+// a name, alias or pattern, that selected it.
+func argumentDecisions(cfg *config.Config, args, contexts []string, matchedPatterns map[string][]string) map[string]contextDecision {
+	decisions := make(map[string]contextDecision, len(contexts))
+	for _, arg := range args {
+		selected := matchedPatterns[arg]
+		if !config.IsPattern(arg) {
+			selected = []string{cfg.ResolveAlias(arg)}
+		}
+		for _, name := range selected {
+			if _, ok := decisions[name]; !ok && slices.Contains(contexts, name) {
+				decisions[name] = contextDecision{Action: "remove", Reason: reasonArgument, Pattern: arg}
+			}
+		}
+	}
+	return decisions
+}
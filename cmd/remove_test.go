@@ -0,0 +1,242 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestSelectContexts(t *testing.T) {
+	skipUnlessBuiltinBackend(t)
+	kConfig := &kubeconfig.Config{
+		Contexts: []kubeconfig.NamedContext{
+			{Name: "prod-east", Context: &kubeconfig.Context{Cluster: "c1", User: "u1"}},
+			{Name: "arn:aws:eks:us-east-1:123:cluster/payments", Context: &kubeconfig.Context{Cluster: "c2", User: "u2"}},
+			{Name: "dev", Context: &kubeconfig.Context{Cluster: "c3", User: "u3"}},
+		},
+	}
+	kConfig, err := reloadConfig(t, kConfig)
+	if err != nil {
+		t.Fatalf("Failed to reload kubeconfig: %v", err)
+	}
+
+	cfgPath := filepath.Join(t.TempDir(), "ignore")
+	if err := os.WriteFile(cfgPath, []byte("payments-prod => arn:aws:eks:us-east-1:123:cluster/payments\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expected    []string
+		expectError bool
+	}{
+		{"exact name", []string{"dev"}, []string{"dev"}, false},
+		{"alias", []string{"payments-prod"}, []string{"arn:aws:eks:us-east-1:123:cluster/payments"}, false},
+		{"pattern matches name and alias", []string{"*-prod", "prod-*"},
+			[]string{"prod-east", "arn:aws:eks:us-east-1:123:cluster/payments"}, false},
+		{"pattern matching nothing", []string{"staging-*"}, nil, false},
+		{"unknown name", []string{"missing"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, _, err := selectContexts(kConfig, cfg, tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestRemoveCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	cfgPath := filepath.Join(tmpDir, "ignore")
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"delete", "dev", "--yes", "-q", "--kubeconfig", kubeconfigPath, "--config", cfgPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected only prod to remain, got %v", names)
+	}
+	if len(kConfig.Clusters) != 1 || len(kConfig.Users) != 1 {
+		t.Errorf("Expected orphaned dev cluster and user to be removed, got %d clusters and %d users",
+			len(kConfig.Clusters), len(kConfig.Users))
+	}
+
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
+	if err != nil || len(backups) != 1 {
+		t.Errorf("Expected one backup, got %d (err %v)", len(backups), err)
+	}
+}
+
+func TestRemoveAsksBeforeEmptyingKubeconfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	run := func(args ...string) error {
+		if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+			t.Fatalf("Failed to create kubeconfig: %v", err)
+		}
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"delete", "*", "--yes", "-q", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore")}, args...))
+		return root.Execute()
+	}
+	remaining := func() []string {
+		kConfig, err := kubeconfig.Load(kubeconfigPath)
+		if err != nil {
+			t.Fatalf("Failed to load kubeconfig: %v", err)
+		}
+		return kConfig.GetContextNames()
+	}
+
+	// --yes does not answer it
+	if err := run(); err == nil || !strings.Contains(err.Error(), "pass --force") {
+		t.Errorf("Expected emptying the kubeconfig to need --force without a terminal, got %v", err)
+	}
+	if names := remaining(); len(names) != 2 {
+		t.Errorf("Expected the kubeconfig to be left alone, got %v", names)
+	}
+
+	if err := run("--force"); err != nil {
+		t.Fatalf("Expected --force to empty the kubeconfig, got %v", err)
+	}
+	if names := remaining(); len(names) != 0 {
+		t.Errorf("Expected no context left, got %v", names)
+	}
+
+	// The first answer confirms the removal, the second the shrink
+	usePrompter(t, newFakePrompter("y", "n"))
+	if err := run(); !errors.Is(err, kubeconfig.ErrDrasticShrink) {
+		t.Errorf("Expected declining to fail with ErrDrasticShrink, got %v", err)
+	}
+	usePrompter(t, newFakePrompter("y", "y"))
+	if err := run(); err != nil {
+		t.Fatalf("Expected confirming to empty the kubeconfig, got %v", err)
+	}
+	if names := remaining(); len(names) != 0 {
+		t.Errorf("Expected no context left, got %v", names)
+	}
+}
+
+// skipUnlessBuiltinBackend skips tests that depend on the built-in backend
+// keeping file order and formatting; client-go writes kubectl's layout instead.
+func skipUnlessBuiltinBackend(t *testing.T) {
+	t.Helper()
+	if kubeconfig.Backend != "builtin" {
+		t.Skipf("depends on the builtin backend's output, not %s", kubeconfig.Backend)
+	}
+}
+
+// reloadConfig round-trips a kubeconfig through Save and Load so its lookup maps are populated.
+func reloadConfig(t *testing.T, kConfig *kubeconfig.Config) (*kubeconfig.Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := kubeconfig.Save(kConfig, path); err != nil {
+		return nil, err
+	}
+	return kubeconfig.Load(path)
+}
+
+func TestDeleteDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"delete", "d*", "--dry-run", "-q", "--kubeconfig", kubeconfigPath, "--config", filepath.Join(tmpDir, "ignore")})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil || string(data) != listTestKubeconfig {
+		t.Errorf("Expected dry run to leave the kubeconfig unchanged (err %v)", err)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 0 {
+		t.Errorf("Expected no backup in dry-run mode, got %d", len(backups))
+	}
+}
+
+func TestRemoveProtected(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "kubectx-manager.yaml")
+	if err := os.WriteFile(configPath, []byte(rulesTestConfig), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	run := func(args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"remove", "--kubeconfig", kubeconfigPath, "--config", configPath,
+			"--backup-dir", filepath.Join(tmpDir, "backups"), "--yes", "-q"}, args...))
+		return root.Execute()
+	}
+
+	if err := run("prod"); err == nil || !strings.Contains(err.Error(), "protected by rule 'prod servers'") {
+		t.Errorf("Expected removing prod by name to fail, got %v", err)
+	}
+	if err := run("*"); err != nil {
+		var exitErr *ExitCodeError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("Remove failed: %v", err)
+		}
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected the pattern to skip the protected prod context, got %v", names)
+	}
+}
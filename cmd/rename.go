@@ -0,0 +1,165 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// renameOptions holds the flag values for a single invocation of the rename command.
+type renameOptions struct {
+	*globalOptions
+	configFile string
+	cluster    string
+	user       string
+	dryRun     bool
+	showDiff   bool
+}
+
+func newRenameCommand(global *globalOptions) *cobra.Command {
+	opts := &renameOptions{globalOptions: global}
+
+	renameCmd := &cobra.Command{
+		Use:   "rename OLD NEW",
+		Short: "Rename a context and optionally its cluster and user",
+		Long: `Rename a context, updating current-context if it pointed at the old name.
+With --cluster or --user the context's cluster or user entry is renamed as well, and every
+context referencing it is updated so nothing becomes orphaned.
+The context's recorded last use and its place in the switch history move to the new name.
+A backup is created before the kubeconfig is modified.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeFirstArgs(1, global.completeContexts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args[0], args[1])
+		},
+	}
+
+	renameCmd.Flags().StringVar(&opts.cluster, "cluster", "", "Also rename the context's cluster to this name")
+	renameCmd.Flags().StringVar(&opts.user, "user", "", "Also rename the context's user to this name")
+	renameCmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "d", false, "Show what would change without modifying the kubeconfig")
+	renameCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff of the kubeconfig change")
+	addConfigFlag(renameCmd, &opts.configFile)
+
+	return renameCmd
+}
+
+// renameResult is the structured form of a rename run.
+type renameResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	// Renamed maps "kind/old-name" to the new name
+	Renamed map[string]string `json:"renamed" yaml:"renamed"`
+	Backup  string            `json:"backup,omitempty" yaml:"backup,omitempty"`
+	DryRun  bool              `json:"dryRun" yaml:"dryRun"`
+}
+
+func (o *renameOptions) run(out io.Writer, oldName, newName string) error {
+	log := o.newLogger()
+
+	kubeconfigPath, err := o.singleKubeconfig("rename")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	oldName = cfg.ResolveAlias(oldName)
+	ctx := kConfig.GetContext(oldName)
+	if ctx == nil {
+		return fmt.Errorf("context '%s' not found", oldName)
+	}
+	oldCluster, oldUser := ctx.Cluster, ctx.User
+
+	before, err := kubeconfig.Marshal(kConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := kubeconfig.RenameContext(kConfig, oldName, newName); err != nil {
+		return err
+	}
+	log.Infof("Renamed context '%s' to '%s'", oldName, newName)
+	result := renameResult{
+		Kubeconfig: kubeconfigPath,
+		Renamed:    map[string]string{"context/" + oldName: newName},
+		DryRun:     o.dryRun,
+	}
+	if o.cluster != "" {
+		if err := kubeconfig.RenameCluster(kConfig, oldCluster, o.cluster); err != nil {
+			return err
+		}
+		log.Infof("Renamed cluster '%s' to '%s'", oldCluster, o.cluster)
+		result.Renamed["cluster/"+oldCluster] = o.cluster
+	}
+	if o.user != "" {
+		if err := kubeconfig.RenameUser(kConfig, oldUser, o.user); err != nil {
+			return err
+		}
+		log.Infof("Renamed user '%s' to '%s'", oldUser, o.user)
+		result.Renamed["user/"+oldUser] = o.user
+	}
+
+	if o.showDiff {
+		after, err := kubeconfig.Marshal(kConfig)
+		if err != nil {
+			return err
+		}
+		printDiff(log, string(before), string(after), kubeconfigPath, kubeconfigPath+" (renamed)")
+	}
+	if o.dryRun {
+		log.Infof("Dry run mode - no changes made")
+		o.setExitCode(exitCodeChanged)
+		return o.printResult(out, result)
+	}
+
+	backupPath, err := o.createBackup(kubeconfigPath, log)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	log.Debugf("Created backup at: %s", backupPath)
+	result.Backup = backupPath
+
+	if err := kubeconfig.Save(kConfig, kubeconfigPath); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:     fmt.Sprintf("Rename context %s to %s", oldName, newName),
+		Kubeconfigs: []string{kubeconfigPath},
+		Renamed:     map[string]string{oldName: newName},
+		Backups:     []string{backupPath},
+	}, log)
+	// Like recording usage, following the rename in the usage file is best effort
+	if err := renameUsage(oldName, newName); err != nil {
+		log.Debugf("Failed to rename context '%s' in the usage file: %v", oldName, err)
+	}
+	return o.printResult(out, result)
+}
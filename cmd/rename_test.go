@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestRenameCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"rename", "prod", "production", "--cluster", "production-cluster", "--user", "production-user",
+		"-q", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "production" {
+		t.Errorf("Expected current-context production, got %q", kConfig.CurrentContext)
+	}
+	ctx := kConfig.GetContext("production")
+	if ctx == nil || ctx.Cluster != "production-cluster" || ctx.User != "production-user" {
+		t.Fatalf("Expected renamed context with updated references, got %+v", ctx)
+	}
+	if kConfig.GetCluster("production-cluster") == nil || kConfig.GetUser("production-user") == nil {
+		t.Error("Expected cluster and user entries to be renamed")
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected one backup, got %d", len(backups))
+	}
+}
+
+func TestRenameThenSwitchBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	run := func(args ...string) {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "-q", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore")))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+	}
+
+	run("switch", "dev", "--no-backup")
+	run("rename", "prod", "production")
+	run("switch", "-", "--no-backup")
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "production" {
+		t.Errorf("Expected switch - to return to the renamed context, got %q", kConfig.CurrentContext)
+	}
+}
@@ -0,0 +1,162 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// reportMarkdown is the report format for files named *.md
+	reportMarkdown = "markdown"
+	// File permissions for report files
+	reportFileMode = 0600
+)
+
+// cleanupReport is what --report writes: the plan of a cleanup or remove
+// run for each kubeconfig, with when and how it was run.
+type cleanupReport struct {
+	Generated time.Time       `json:"generated" yaml:"generated"`
+	Command   string          `json:"command" yaml:"command"`
+	Results   []cleanupResult `json:"results" yaml:"results"`
+}
+
+// addReportFlag adds --report to cmd, a cleanup or remove command.
+func addReportFlag(cmd *cobra.Command, report *string) {
+	cmd.Flags().StringVar(report, "report", "",
+		"Write the plan (kept and removed contexts, reasons, auth results) to this file, as JSON, YAML or Markdown by its extension")
+	_ = cmd.RegisterFlagCompletionFunc("report", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", "yml", "md"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+}
+
+// reportFormat returns the format of the report file at path, chosen by its
+// extension: outputJSON, outputYAML or reportMarkdown.
+func reportFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return outputJSON, nil
+	case ".yaml", ".yml":
+		return outputYAML, nil
+	case ".md", ".markdown":
+		return reportMarkdown, nil
+	default:
+		return "", fmt.Errorf("unknown report format for %s: name it *.json, *.yaml or *.md", path)
+	}
+}
+
+// writeReport writes the results to the report file at path in the format
+// its extension names, replacing the file if it exists.
+func (g *globalOptions) writeReport(path string, results []cleanupResult) error {
+	format, err := reportFormat(path)
+	if err != nil {
+		return err
+	}
+	report := cleanupReport{Generated: time.Now().UTC().Truncate(time.Second), Command: commandLine(&g.invocation), Results: results}
+
+	var buf bytes.Buffer
+	if format == reportMarkdown {
+		writeMarkdownReport(&buf, &report)
+	} else if err := encodeStructured(&buf, format, report); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), reportFileMode); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	g.newLogger().Infof("Wrote report to %s", path)
+	return nil
+}
+
+// writeMarkdownReport renders the report as a Markdown document, with a
+// section and a decision table for each kubeconfig.
+func writeMarkdownReport(out io.Writer, report *cleanupReport) {
+	fmt.Fprintln(out, "# kubectx-manager cleanup report")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "- Generated: %s\n", report.Generated.Format(time.RFC3339))
+	fmt.Fprintf(out, "- Command: `%s`\n", report.Command)
+
+	for _, r := range report.Results {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "## %s\n\n", markdownCell(r.Kubeconfig))
+		if r.Error != "" {
+			fmt.Fprintf(out, "Failed: %s\n", markdownCell(r.Error))
+			continue
+		}
+
+		mode, removedLabel := "applied", "Contexts removed"
+		switch {
+		case r.Canceled:
+			mode = "canceled"
+		case r.DryRun:
+			mode, removedLabel = "dry run, nothing changed", "Contexts to remove"
+		}
+		fmt.Fprintf(out, "- Mode: %s\n", mode)
+		fmt.Fprintf(out, "- Contexts kept: %d\n", r.ContextsKept)
+		fmt.Fprintf(out, "- %s: %d\n", removedLabel, len(r.RemovedContexts))
+		if !r.DryRun {
+			fmt.Fprintf(out, "- Clusters garbage-collected: %d\n", r.ClustersRemoved)
+			fmt.Fprintf(out, "- Users garbage-collected: %d\n", r.UsersRemoved)
+		}
+		if r.OutputFile != "" {
+			fmt.Fprintf(out, "- Output file: %s\n", r.OutputFile)
+		}
+		if r.Archived {
+			fmt.Fprintln(out, "- Removed contexts archived")
+		}
+		for _, backup := range r.Backups {
+			fmt.Fprintf(out, "- Backup: %s\n", backup)
+		}
+		if len(r.UnmatchedPatterns) > 0 {
+			fmt.Fprintf(out, "- Unmatched whitelist patterns: %s\n", markdownCell(strings.Join(r.UnmatchedPatterns, ", ")))
+		}
+
+		if len(r.Decisions) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "| Context | Action | Reason | Pattern | Detail |")
+			fmt.Fprintln(out, "|---------|--------|--------|---------|--------|")
+			for _, name := range slices.Sorted(maps.Keys(r.Decisions)) {
+				d := r.Decisions[name]
+				fmt.Fprintf(out, "| %s | %s | %s | %s | %s |\n",
+					markdownCell(name), d.Action, d.Reason, markdownCell(d.Pattern), markdownCell(d.Detail))
+			}
+		}
+		if len(r.AuthResults) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "| Context | Auth | State | Reason |")
+			fmt.Fprintln(out, "|---------|------|-------|--------|")
+			for _, name := range slices.Sorted(maps.Keys(r.AuthResults)) {
+				status, valid := r.AuthResults[name], "invalid"
+				if status.Valid {
+					valid = "valid"
+				}
+				fmt.Fprintf(out, "| %s | %s | %s | %s |\n", markdownCell(name), valid, status.State, markdownCell(status.Reason))
+			}
+		}
+	}
+}
+
+// markdownCell escapes s for a Markdown table cell or heading.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
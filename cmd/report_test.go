@@ -0,0 +1,79 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCleanupReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	run := func(args ...string) error {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "-q", "--config", configPath, "--kubeconfig", kubeconfigPath))
+		return root.Execute()
+	}
+
+	markdownPath := filepath.Join(tmpDir, "plan.md")
+	if err := run("--dry-run", "--report", markdownPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	for _, expected := range []string{"- Mode: dry run, nothing changed", "- Contexts to remove: 1",
+		"| dev | remove | no pattern matched |", "| prod | keep | pattern matched | prod |"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected %q in the report:\n%s", expected, data)
+		}
+	}
+
+	jsonPath := filepath.Join(tmpDir, "plan.json")
+	if err := run("remove", "dev", "--dry-run", "--report", jsonPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, err = os.ReadFile(jsonPath); err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var report cleanupReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Report is not valid JSON: %v\n%s", err, data)
+	}
+	if len(report.Results) != 1 || strings.Join(report.Results[0].RemovedContexts, ",") != "dev" || !strings.HasPrefix(report.Command, "kubectx-manager remove dev") {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	// The format is checked before anything is done
+	if err := run("--report", filepath.Join(tmpDir, "plan.txt")); err == nil {
+		t.Error("Expected an error for an unknown report format")
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Error("Expected the kubeconfig to be left untouched")
+	}
+}
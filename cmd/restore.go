@@ -15,25 +15,26 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
 
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/config"
 	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
 	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/tui"
 )
 
 const (
-	// BackupTimeFormat is the timestamp format used for backup file names
-	BackupTimeFormat = "20060102-150405"
-
 	// User choice constants
 	choiceNone      = "none"
 	choiceSelective = "selective"
@@ -41,105 +42,274 @@ const (
 	choiceCancel    = "cancel"
 )
 
-var (
-	noBackup   bool
-	keepBackup bool
+// restoreOptions holds the flag values for a single invocation of the restore command.
+type restoreOptions struct {
+	*globalOptions
+	configFile       string
+	onDuplicate      string
+	inFileDuplicates string
+	from             string
+	gitRevision      string
+	backupChoice     string
+	contexts         []string
+	noBackup         bool
+	keepBackup       bool
+	showDiff         bool
+	pickContexts     bool
+	latest           bool
+	merge            bool
+	// tempDir holds the kubeconfig extracted from --git-revision
+	tempDir string
+	result  restoreResult
+}
+
+// restoreResult is the structured form of a restore run.
+type restoreResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	// Backup is the name of the selected backup, empty if there was none
+	Backup string `json:"backup,omitempty" yaml:"backup,omitempty"`
+	// Mode is replace, merge or contexts
+	Mode     string   `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Contexts []string `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	// Report is only set when restoring selected contexts
+	Report *kubeconfig.MergeReport `json:"report,omitempty" yaml:"report,omitempty"`
+	// CurrentBackup is the backup taken of the kubeconfig before restoring
+	CurrentBackup string `json:"currentBackup,omitempty" yaml:"currentBackup,omitempty"`
+	Restored      bool   `json:"restored" yaml:"restored"`
+}
+
+// Restore modes reported in restoreResult
+const (
+	restoreReplace  = "replace"
+	restoreMerge    = "merge"
+	restoreContexts = "contexts"
 )
 
-var restoreCmd = &cobra.Command{
-	Use:   "restore",
-	Short: "Restore kubeconfig from a backup",
-	Long: `Restore your kubeconfig file from a previously created backup.
+func newRestoreCommand(global *globalOptions) *cobra.Command {
+	opts := &restoreOptions{globalOptions: global}
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore kubeconfig from a backup",
+		Long: `Restore your kubeconfig file from a previously created backup.
 Lists available backups and allows you to select one to restore.
-Intelligently handles backup creation to avoid redundant backups.`,
-	RunE: runRestore,
-}
+Intelligently handles backup creation to avoid redundant backups.
+
+With --merge the backup's contexts, clusters and users are merged into the current
+kubeconfig instead of replacing it, so contexts added since the backup are kept.
+With --contexts or --pick-contexts only the chosen contexts, with the clusters and
+users they use, are merged into the current kubeconfig. Entries that exist with a
+different configuration are resolved by --on-duplicate, or else interactively
+(--yes overwrites them). The backup is kept.
+
+For scripts, --latest or --from select the backup without the numbered prompt,
+--backup-choice answers the conflict menu, and --yes skips the confirmation
+(choosing a full backup unless --backup-choice says otherwise).
+
+With --backup-git, --git-revision restores the kubeconfig as committed at
+any revision of the repository (see backup log).
+
+Examples:
+  kubectx-manager restore --contexts 'prod-*,staging-x'
+  kubectx-manager restore --pick-contexts --on-duplicate rename
+  kubectx-manager restore --latest --yes --backup-choice none
+  kubectx-manager restore --backup-git ~/kubeconfig-history --git-revision HEAD~1`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := opts.run(); err != nil {
+				return err
+			}
+			return opts.printResult(cmd.OutOrStdout(), opts.result)
+		},
+	}
 
-func init() { //nolint:gochecknoinits // Cobra CLI flag setup requires init
-	rootCmd.AddCommand(restoreCmd)
-	restoreCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose (debug) output")
-	restoreCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all output except errors")
-	restoreCmd.Flags().BoolVar(&noBackup, "no-backup", false, "Skip creating backup of current kubeconfig before restoring")
-	restoreCmd.Flags().BoolVar(&keepBackup, "keep-backup", false, "Keep backup file after successful restore (default: delete)")
-	restoreCmd.Flags().StringVarP(&kubeConfig, "kubeconfig", "k", "", "Path to kubeconfig file to restore")
+	restoreCmd.Flags().BoolVar(&opts.noBackup, "no-backup", false, "Skip creating backup of current kubeconfig before restoring")
+	restoreCmd.Flags().BoolVar(&opts.showDiff, "diff", false, "Show a diff between the current kubeconfig and the selected backup before restoring")
+	restoreCmd.Flags().BoolVar(&opts.keepBackup, "keep-backup", false, "Keep backup file after successful restore (default: delete)")
+	restoreCmd.Flags().BoolVar(&opts.merge, "merge", false,
+		"Merge the backup into the current kubeconfig instead of replacing it, asking how to resolve each conflicting entry unless --on-duplicate is given")
+	restoreCmd.Flags().StringVar(&opts.onDuplicate, "on-duplicate", "",
+		"Merge the backup into the current kubeconfig instead of replacing it, resolving entries that exist in both with "+
+			"different configuration by: overwrite, keep, rename or fail")
+	restoreCmd.Flags().StringSliceVar(&opts.contexts, "contexts", nil,
+		"Restore only these contexts (names, aliases or glob patterns, comma-separated) with their clusters and users")
+	restoreCmd.Flags().BoolVar(&opts.pickContexts, "pick-contexts", false, "Choose the contexts to restore from the selected backup interactively")
+	restoreCmd.Flags().BoolVar(&opts.latest, "latest", false, "Restore the newest backup without prompting for a selection")
+	restoreCmd.Flags().StringVar(&opts.from, "from", "", "Restore the backup file at this path without prompting for a selection")
+	restoreCmd.Flags().StringVar(&opts.gitRevision, "git-revision", "",
+		"Restore the kubeconfig as committed at this revision of --backup-git (a hash, tag or e.g. HEAD~1)")
+	restoreCmd.Flags().StringVar(&opts.backupChoice, "backup-choice", "",
+		"Back up the current kubeconfig before a conflicting restore as: full, selective (only conflicting entries) or none, instead of asking")
+	addInFileDuplicatesFlag(restoreCmd, &opts.inFileDuplicates)
+	addConfigFlag(restoreCmd, &opts.configFile)
+	_ = restoreCmd.RegisterFlagCompletionFunc("from", global.completeBackupPaths)
+	_ = restoreCmd.RegisterFlagCompletionFunc("on-duplicate", completeDuplicateStrategies)
+	_ = restoreCmd.RegisterFlagCompletionFunc("backup-choice", completeValues(choiceFull, choiceSelective, choiceNone))
+
+	return restoreCmd
 }
 
-func runRestore(_ *cobra.Command, _ []string) error {
+func (o *restoreOptions) run() error {
 	// Initialize logger
-	log := logger.New(verbose, quiet)
+	log := o.newLogger()
+	if len(o.contexts) > 0 && o.pickContexts {
+		return errors.New("--contexts and --pick-contexts cannot be used together")
+	}
+	if o.latest && o.from != "" || o.gitRevision != "" && (o.latest || o.from != "") {
+		return errors.New("only one of --latest, --from and --git-revision can be used")
+	}
+	switch o.backupChoice {
+	case "", choiceFull, choiceSelective, choiceNone:
+	default:
+		return fmt.Errorf("invalid --backup-choice %q (expected %s, %s or %s)", o.backupChoice, choiceFull, choiceSelective, choiceNone)
+	}
 
-	// Set default kubeconfig if not provided
-	if kubeConfig == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			homeDir = os.Getenv("HOME")
-			if homeDir == "" {
-				homeDir = "/tmp"
-			}
+	var strategy kubeconfig.DuplicateStrategy
+	if o.onDuplicate != "" {
+		var err error
+		if strategy, err = kubeconfig.ParseDuplicateStrategy(o.onDuplicate); err != nil {
+			return err
+		}
+	}
+	if o.inFileDuplicates != "" {
+		if _, err := kubeconfig.ParseDuplicatePolicy(o.inFileDuplicates); err != nil {
+			return err
 		}
-		kubeConfig = filepath.Join(homeDir, ".kube", "config")
+	}
+
+	kubeConfig, err := o.singleKubeconfig("restore")
+	if err != nil {
+		return err
 	}
 
 	log.Debugf("Starting kubeconfig restore...")
 	log.Debugf("Kubeconfig file: %s", kubeConfig)
+	o.result.Kubeconfig = kubeConfig
 
-	// Find available backups
-	backups, err := findBackups(kubeConfig)
-	if err != nil {
-		return fmt.Errorf("failed to find backups: %w", err)
+	defer o.removeTempDir(log)
+	selectedBackup, ok, err := o.selectBackup(kubeConfig, log)
+	if err != nil || !ok {
+		return err
 	}
+	log.Infof("Selected backup: %s", selectedBackup.Name)
+	o.result.Backup = selectedBackup.Name
 
-	if len(backups) == 0 {
-		log.Infof("No backups found for %s", kubeConfig)
-		return nil
+	dec, err := o.backupDecryption(selectedBackup)
+	if err != nil {
+		return err
 	}
 
-	// Display available backups
-	log.Infof("Available backups:")
-	for i, backup := range backups {
-		log.Infof("  %d. %s (%s)", i+1, backup.Name, backup.TimeStr)
+	// Refuse to replace a working kubeconfig with a backup that cannot be parsed
+	backupConfig, err := loadBackup(selectedBackup.Path, dec)
+	if err != nil {
+		return err
 	}
 
-	// Get user selection
-	selection, err := getUserSelection(len(backups))
+	// Protected contexts survive the restore, whatever the backup holds
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	// Reject a backup defining an entry twice before asking anything
+	duplicates, err := duplicatePolicy(o.inFileDuplicates, cfg)
 	if err != nil {
 		return err
 	}
+	if _, err := kubeconfig.ResolveDuplicates(backupConfig, duplicates); err != nil {
+		return withDuplicatesHint(fmt.Errorf("backup %s: %w", selectedBackup.Name, err))
+	}
 
-	if selection == 0 {
-		log.Infof("Restore canceled")
-		return nil
+	if len(o.contexts) > 0 || o.pickContexts {
+		o.result.Mode = restoreContexts
+		return o.restoreContexts(kubeConfig, selectedBackup, backupConfig, strategy, log)
 	}
 
-	selectedBackup := backups[selection-1]
-	log.Infof("Selected backup: %s", selectedBackup.Name)
+	if o.showDiff && (o.merge || strategy != "") {
+		previewMergeRestore(kubeConfig, selectedBackup, backupConfig, strategy, log)
+	} else if o.showDiff {
+		previewRestore(kubeConfig, selectedBackup, dec, log)
+	}
 
 	// Confirm restore
-	if !confirmRestore(selectedBackup.Name, kubeConfig) {
+	confirmed, err := confirmRestore(o.prompter(), selectedBackup.Name, kubeConfig)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		log.Infof("Restore canceled")
+		o.setExitCode(exitCodeCanceled)
 		return nil
 	}
 
-	// Smart backup handling
-	if !noBackup {
-		shouldCreateBackup, reason, conflicts := shouldCreateBackupBeforeRestore(kubeConfig, backups, selectedBackup, log)
+	// Smart backup handling, decided before the kubeconfig is locked as it may ask
+	var shouldCreateBackup bool
+	var reason string
+	var conflicts []string
+	var enc kubeconfig.Encryption
+	if !o.noBackup {
+		choice := o.backupChoice
+		if choice == "" && o.yes {
+			choice = choiceFull
+		}
+		shouldCreateBackup, reason, conflicts, err = shouldCreateBackupBeforeRestore(o.prompter(), kubeConfig, dec, selectedBackup, choice, log)
+		if errors.Is(err, errRestoreCanceled) {
+			log.Infof("Restore canceled")
+			o.setExitCode(exitCodeCanceled)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
 		if shouldCreateBackup {
-			log.Debugf("Creating backup: %s", reason)
+			if enc, err = o.backupEncryption(); err != nil {
+				return err
+			}
+		}
+	}
 
+	// Merging may ask how to resolve each conflict, so it is done before the
+	// kubeconfig is locked too; saving refuses the result if the kubeconfig
+	// changed meanwhile
+	var merged *kubeconfig.Config
+	if o.merge || strategy != "" {
+		o.result.Mode = restoreMerge
+		opts := o.mergeOptions(strategy)
+		opts.Duplicates = duplicates
+		if merged, err = mergeFromBackup(selectedBackup.Path, kubeConfig, dec, opts, cfg, log); err != nil {
+			return fmt.Errorf("failed to restore from backup: %w", err)
+		}
+	} else {
+		o.result.Mode = restoreReplace
+	}
+
+	locks, err := kubeconfig.LockAll([]string{kubeConfig})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	createdBackup := false
+	if !o.noBackup {
+		if shouldCreateBackup {
+			log.Debugf("Creating backup: %s", reason)
 			if len(conflicts) > 0 {
 				// Create selective backup
-				currentBackupPath, err := createSelectiveBackup(kubeConfig, conflicts, log)
+				currentBackupPath, err := createSelectiveBackup(kubeConfig, o.backupDir, enc, conflicts, log)
 				if err != nil {
 					return fmt.Errorf("failed to create selective backup: %w", err)
 				}
+				o.uploadBackup(currentBackupPath, kubeConfig, log)
 				log.Infof("Created selective backup of conflicting items: %s", currentBackupPath)
+				o.result.CurrentBackup = currentBackupPath
 			} else {
 				// Create full backup
-				currentBackupPath, err := kubeconfig.CreateBackup(kubeConfig)
+				currentBackupPath, err := kubeconfig.CreateEncryptedBackup(kubeConfig, o.backupDir, enc)
 				if err != nil {
 					return fmt.Errorf("failed to backup current kubeconfig: %w", err)
 				}
+				o.writeManifest(currentBackupPath, kubeConfig, nil, log)
 				log.Infof("Created full backup of current kubeconfig: %s", currentBackupPath)
+				o.result.CurrentBackup = currentBackupPath
+				createdBackup = true
 			}
 		} else {
 			log.Infof("Skipping backup: %s", reason)
@@ -148,97 +318,365 @@ func runRestore(_ *cobra.Command, _ []string) error {
 		log.Infof("Skipping backup (--no-backup flag specified)")
 	}
 
-	// Restore from backup
-	err = restoreFromBackup(selectedBackup.Path, kubeConfig)
+	// Restore from backup, committing the current kubeconfig first so --backup-git can undo the restore
+	o.commitKubeconfig(kubeConfig, "Record current "+filepath.Base(kubeConfig), log)
+	if merged != nil {
+		err = kubeconfig.Save(merged, kubeConfig)
+	} else {
+		err = replaceFromBackup(selectedBackup.Path, kubeConfig, dec, duplicates, cfg, log)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to restore from backup: %w", err)
 	}
+	o.result.Restored = true
 
 	log.Infof("Successfully restored kubeconfig from %s", selectedBackup.Name)
-
-	// Clean up backup file after successful restore (unless --keep-backup flag is used)
-	if !keepBackup {
+	o.recordChange(&audit.Record{
+		Summary:      "Restore from " + selectedBackup.Name,
+		Kubeconfigs:  []string{kubeConfig},
+		RestoredFrom: selectedBackup.Path,
+		Backups:      nonEmpty(o.result.CurrentBackup),
+	}, log)
+
+	// Clean up backup file after successful restore (unless --keep-backup flag is used);
+	// a file named by --from is never removed, and one from --git-revision is temporary
+	switch {
+	case o.gitRevision != "":
+	case !o.keepBackup && o.from == "":
 		log.Debugf("Cleaning up backup file: %s", selectedBackup.Path)
-		err = os.Remove(selectedBackup.Path)
+		err = kubeconfig.RemoveBackup(selectedBackup.Path)
 		if err != nil {
 			log.Warnf("Failed to remove backup file %s: %v", selectedBackup.Path, err)
 			log.Warnf("You may want to manually remove it")
 		} else {
 			log.Infof("Removed backup file: %s", selectedBackup.Name)
 		}
-	} else {
+	default:
 		log.Infof("Backup file preserved: %s", selectedBackup.Name)
 	}
 
+	// Pruned only after a successful restore, so the selected backup cannot be pruned first
+	if createdBackup {
+		o.pruneBackups(kubeConfig, log)
+	}
+
 	return nil
 }
 
-// Backup represents a kubeconfig backup file with metadata about when it was created.
-// It contains the file path, display name, and timestamp information for restore operations.
-type Backup struct {
-	Name    string
-	Path    string
-	Time    time.Time
-	TimeStr string
-}
+// selectBackup picks the backup to restore: the file named by --from, the
+// commit named by --git-revision, the newest with --latest, or else the one
+// the user selects from the list; backups only stored on --backup-remote are
+// downloaded first. It reports false if there is nothing to restore or the
+// user canceled.
+func (o *restoreOptions) selectBackup(kubeconfigPath string, log *logger.Logger) (kubeconfig.Backup, bool, error) {
+	if o.gitRevision != "" {
+		backup, err := o.gitBackup(kubeconfigPath)
+		return backup, err == nil, err
+	}
+	if o.from != "" {
+		backup, err := kubeconfig.BackupAt(expandHome(o.from))
+		if err != nil {
+			return kubeconfig.Backup{}, false, fmt.Errorf("failed to read backup: %w", err)
+		}
+		return backup, true, nil
+	}
 
-func findBackups(kubeconfigPath string) ([]Backup, error) {
-	dir := filepath.Dir(kubeconfigPath)
-	baseName := filepath.Base(kubeconfigPath)
+	// Find available backups
+	backups, err := o.findBackups(kubeconfigPath, log)
+	if err != nil {
+		return kubeconfig.Backup{}, false, err
+	}
+
+	if len(backups) == 0 {
+		log.Infof("No backups found for %s", kubeconfigPath)
+		return kubeconfig.Backup{}, false, nil
+	}
+	if o.latest {
+		return o.pulledBackup(kubeconfigPath, backups[0], log)
+	}
+
+	descriptions := make([]string, len(backups))
+	for i, backup := range backups {
+		descriptions[i] = fmt.Sprintf("%s (%s)", backup.Name, backup.TimeStr)
+		if backup.Remote {
+			descriptions[i] += " [remote]"
+		}
+		if manifest, err := kubeconfig.ReadManifest(backup.Path); err == nil {
+			descriptions[i] += fmt.Sprintf(" - %s, %d context(s) removed", commandLine(manifest), len(manifest.RemovedContexts))
+		}
+	}
 
-	entries, err := os.ReadDir(dir)
+	// Get user selection, letting the user inspect backups before choosing
+	inspect := func(n int) {
+		if err := o.pullBackup(kubeconfigPath, &backups[n-1], log); err != nil {
+			log.Warnf("Could not download backup %s: %v", backups[n-1].Name, err)
+			return
+		}
+		dec, err := o.backupDecryption(backups[n-1])
+		if err != nil {
+			log.Warnf("Could not decrypt backup %s: %v", backups[n-1].Name, err)
+			return
+		}
+		inspectBackup(o.interactiveOut(), kubeconfigPath, backups[n-1], dec, log)
+	}
+	var selection int
+	if o.isInteractive() {
+		selection, err = o.pickBackup(o.prompter(), descriptions, inspect)
+	} else {
+		p := o.prompter()
+		p.Printf("Available backups:\n")
+		for i, description := range descriptions {
+			p.Printf("  %d. %s\n", i+1, description)
+		}
+		selection, err = getUserSelection(p, len(backups), inspect)
+	}
 	if err != nil {
-		return nil, err
+		return kubeconfig.Backup{}, false, err
 	}
 
-	var backups []Backup
-	prefix := baseName + ".backup."
+	if selection == 0 {
+		log.Infof("Restore canceled")
+		o.setExitCode(exitCodeCanceled)
+		return kubeconfig.Backup{}, false, nil
+	}
+	return o.pulledBackup(kubeconfigPath, backups[selection-1], log)
+}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
-			continue
+// gitBackup extracts the kubeconfig at --git-revision of --backup-git to a
+// temporary file, removed by removeTempDir, and describes it as a backup.
+func (o *restoreOptions) gitBackup(kubeconfigPath string) (kubeconfig.Backup, error) {
+	repo, err := o.gitRepository()
+	if err != nil {
+		return kubeconfig.Backup{}, err
+	}
+	if repo == nil {
+		return kubeconfig.Backup{}, errors.New("--git-revision requires --backup-git")
+	}
+	commit, err := repo.Resolve(o.gitRevision)
+	if err != nil {
+		return kubeconfig.Backup{}, err
+	}
+	data, err := repo.Show(commit.Hash, kubeconfig.BackupSource(kubeconfigPath))
+	if err != nil {
+		return kubeconfig.Backup{}, err
+	}
+
+	if o.tempDir, err = os.MkdirTemp("", "kubectx-manager-"); err != nil {
+		return kubeconfig.Backup{}, err
+	}
+	name := filepath.Base(kubeconfigPath) + "@" + commit.ShortHash()
+	path := filepath.Join(o.tempDir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return kubeconfig.Backup{}, err
+	}
+	return kubeconfig.Backup{
+		Name:    name,
+		Path:    path,
+		Time:    commit.Time,
+		TimeStr: commit.Time.Format("2006-01-02 15:04:05"),
+		Size:    int64(len(data)),
+	}, nil
+}
+
+// removeTempDir removes the kubeconfig extracted by gitBackup, if any.
+func (o *restoreOptions) removeTempDir(log *logger.Logger) {
+	if o.tempDir == "" {
+		return
+	}
+	if err := os.RemoveAll(o.tempDir); err != nil {
+		log.Warnf("Failed to remove %s: %v", o.tempDir, err)
+	}
+}
+
+// pulledBackup returns backup once it is on disk, downloading it from
+// --backup-remote if it is only stored there.
+func (o *restoreOptions) pulledBackup(kubeconfigPath string, backup kubeconfig.Backup, log *logger.Logger) (kubeconfig.Backup, bool, error) {
+	if err := o.pullBackup(kubeconfigPath, &backup, log); err != nil {
+		return kubeconfig.Backup{}, false, fmt.Errorf("failed to download backup: %w", err)
+	}
+	return backup, true, nil
+}
+
+// restoreContexts merges the chosen contexts of the backup, with the clusters
+// and users they reference, into the current kubeconfig. Conflicting entries
+// are resolved by strategy or, without one, by asking; --yes overwrites them.
+func (o *restoreOptions) restoreContexts(kubeconfigPath string, backup kubeconfig.Backup, backupConfig *kubeconfig.Config,
+	strategy kubeconfig.DuplicateStrategy, log *logger.Logger) error {
+	patterns := o.contexts
+	if o.pickContexts {
+		picked, err := pickContexts(o.prompter(), backupConfig)
+		if err != nil {
+			return err
 		}
+		patterns = picked
+	}
+	if len(patterns) == 0 {
+		log.Infof("No contexts selected")
+		return nil
+	}
 
-		backupPath := filepath.Join(dir, entry.Name())
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	names, _, err := selectContexts(backupConfig, cfg, patterns)
+	if err != nil {
+		return fmt.Errorf("backup %s: %w", backup.Name, err)
+	}
+	if len(names) == 0 {
+		log.Infof("No contexts in %s match %s", backup.Name, strings.Join(patterns, ", "))
+		return nil
+	}
+	log.Infof("Contexts to restore:")
+	for _, name := range names {
+		log.Infof("  - %s", name)
+	}
+	o.result.Contexts = names
+
+	current, err := kubeconfig.Load(kubeconfigPath)
+	exists := err == nil
+	if errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		current = &kubeconfig.Config{APIVersion: "v1", Kind: "Config"}
+	} else if err != nil {
+		return fmt.Errorf("failed to load current kubeconfig: %w", err)
+	}
+	before, err := kubeconfig.Marshal(current)
+	if err != nil {
+		return err
+	}
 
-		// Extract timestamp from filename
-		timestampStr := strings.TrimPrefix(entry.Name(), prefix)
-		timestamp, err := time.Parse(BackupTimeFormat, timestampStr)
+	opts := protectingMergeOptions(o.mergeOptions(strategy), cfg, current, log)
+	report, err := kubeconfig.MergeWithOptions(current, kubeconfig.ExtractContexts(backupConfig, names), opts)
+	if err != nil {
+		return err
+	}
+
+	if o.showDiff {
+		after, err := kubeconfig.Marshal(current)
 		if err != nil {
-			continue // Skip files that don't match our backup format
+			return err
 		}
+		printDiff(log, string(before), string(after), kubeconfigPath, kubeconfigPath+" (restored)")
+	}
 
-		backup := Backup{
-			Name:    entry.Name(),
-			Path:    backupPath,
-			Time:    timestamp,
-			TimeStr: timestamp.Format("2006-01-02 15:04:05"),
+	confirmed, err := confirmRestore(o.prompter(), backup.Name, kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		log.Infof("Restore canceled")
+		o.setExitCode(exitCodeCanceled)
+		return nil
+	}
+
+	// Locked only once nothing is left to ask; saving refuses the merged
+	// kubeconfig if it changed since it was loaded
+	locks, err := kubeconfig.LockAll([]string{kubeconfigPath})
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	if exists && !o.noBackup {
+		backupPath, err := o.createBackup(kubeconfigPath, log)
+		if err != nil {
+			return fmt.Errorf("failed to backup current kubeconfig: %w", err)
 		}
-		backups = append(backups, backup)
+		log.Infof("Created backup of current kubeconfig: %s", backupPath)
+		o.result.CurrentBackup = backupPath
+	}
+	if err := kubeconfig.Save(current, kubeconfigPath); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:      fmt.Sprintf("Restore contexts %s from %s", listSubject(names), backup.Name),
+		Kubeconfigs:  []string{kubeconfigPath},
+		Restored:     names,
+		RestoredFrom: backup.Path,
+		Backups:      nonEmpty(o.result.CurrentBackup),
+	}, log)
+
+	logMergeReport(report, log)
+	log.Infof("Successfully restored %d context(s) from %s", len(names), backup.Name)
+	o.result.Report = report
+	o.result.Restored = true
+	return nil
+}
+
+// mergeOptions resolves entries of a merged backup or archive that conflict
+// with the current kubeconfig by strategy or, without one, by asking for each
+// entry, failing on the first one when stdin is not a terminal; --yes
+// overwrites them with the incoming version.
+func (g *globalOptions) mergeOptions(strategy kubeconfig.DuplicateStrategy) kubeconfig.MergeOptions {
+	if strategy != "" {
+		return kubeconfig.MergeOptions{Strategy: strategy}
 	}
+	opts := kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateOverwrite}
+	if !g.yes {
+		askConflicts(g.prompter(), &opts)
+	}
+	return opts
+}
 
-	// Sort backups by time (newest first)
-	sort.Slice(backups, func(i, j int) bool {
-		return backups[i].Time.After(backups[j].Time)
-	})
+// pickContexts lists the contexts of a backup and reads the ones to restore:
+// numbers from the list, names or glob patterns, separated by commas or spaces.
+// An empty answer selects nothing.
+func pickContexts(p Prompter, backupConfig *kubeconfig.Config) ([]string, error) {
+	names := make([]string, len(backupConfig.Contexts))
+	for i, namedContext := range backupConfig.Contexts {
+		names[i] = namedContext.Name
+	}
+	p.Printf("Contexts in backup:\n")
+	for i, name := range names {
+		p.Printf("  %d. %s\n", i+1, name)
+	}
 
-	return backups, nil
+	input, err := p.Ask("Contexts to restore (numbers, names or patterns; empty to cancel): ", "pass them with --contexts")
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var picked []string
+	separator := func(r rune) bool { return r == ',' || unicode.IsSpace(r) }
+	for _, field := range strings.FieldsFunc(input, separator) {
+		if n, err := strconv.Atoi(field); err == nil {
+			if n < 1 || n > len(names) {
+				return nil, fmt.Errorf("no context numbered %d (expected 1-%d)", n, len(names))
+			}
+			picked = append(picked, names[n-1])
+			continue
+		}
+		picked = append(picked, field)
+	}
+	return picked, nil
 }
 
-func getUserSelection(maxOptions int) (int, error) {
-	reader := bufio.NewReader(os.Stdin)
+// getUserSelection prompts for the number of the backup to restore.
+// When inspect is non-nil, answering "i<N>" calls it with N and prompts again.
+func getUserSelection(p Prompter, maxOptions int, inspect func(int)) (int, error) {
+	if inspect != nil {
+		p.Printf("Enter i<number> to inspect a backup before choosing (e.g. i1)\n")
+	}
 
 	for {
-		fmt.Printf("Select backup to restore (1-%d, or 0 to cancel): ", maxOptions)
-		input, err := reader.ReadString('\n')
+		input, err := p.Ask(fmt.Sprintf("Select backup to restore (1-%d, or 0 to cancel): ", maxOptions), "pass --from or --latest")
 		if err != nil {
 			return 0, err
 		}
 
-		input = strings.TrimSpace(input)
+		if inspect != nil && strings.HasPrefix(strings.ToLower(input), "i") {
+			n, err := strconv.Atoi(strings.TrimSpace(input[1:]))
+			if err != nil || n < 1 || n > maxOptions {
+				p.Printf("Please enter i followed by a number between 1 and %d\n", maxOptions)
+				continue
+			}
+			inspect(n)
+			continue
+		}
+
 		selection, err := strconv.Atoi(input)
 		if err != nil {
-			fmt.Println("Please enter a valid number")
+			p.Printf("Please enter a valid number\n")
 			continue
 		}
 
@@ -247,7 +685,7 @@ func getUserSelection(maxOptions int) (int, error) {
 		}
 
 		if selection < 1 || selection > maxOptions {
-			fmt.Printf("Please enter a number between 1 and %d (or 0 to cancel)\n", maxOptions)
+			p.Printf("Please enter a number between 1 and %d (or 0 to cancel)\n", maxOptions)
 			continue
 		}
 
@@ -255,162 +693,234 @@ func getUserSelection(maxOptions int) (int, error) {
 	}
 }
 
-func confirmRestore(backupName, kubeconfigPath string) bool {
-	fmt.Printf("This will restore %s from backup %s.\n", kubeconfigPath, backupName)
-	fmt.Printf("Are you sure you want to continue? (y/N): ")
+// pickBackup offers the backups in a fuzzy picker and returns the number
+// of the chosen one as getUserSelection does, 0 if the user canceled. Tab
+// inspects a backup and then returns to the picker.
+func (g *globalOptions) pickBackup(p Prompter, descriptions []string, inspect func(int)) (int, error) {
+	opts := tui.PickOptions{Prompt: "Restore backup>", Items: descriptions, Inspect: true}
+	for {
+		choice, err := tui.Pick(g.stdin, g.interactiveOut(), opts)
+		if err != nil {
+			return 0, err
+		}
+		if !choice.Inspect {
+			return choice.Index + 1, nil
+		}
+		inspect(choice.Index + 1)
+		if _, err := p.Ask("Press enter to return to the backups ", ""); err != nil {
+			return 0, err
+		}
+		opts.Query, opts.Selected = choice.Query, choice.Index
+	}
+}
 
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+// inspectBackup prints the contexts, clusters and users stored in a backup
+// to out, followed by the diff restoring it would apply to the current
+// kubeconfig.
+func inspectBackup(out io.Writer, kubeconfigPath string, backup kubeconfig.Backup, dec kubeconfig.Decryption, log *logger.Logger) {
+	backupConfig, err := kubeconfig.LoadBackup(backup.Path, dec)
 	if err != nil {
-		return false
+		log.Warnf("Could not load backup %s: %v", backup.Name, err)
+		return
+	}
+
+	fmt.Fprintln(out)
+	printBackupSummary(out, backup, backupConfig)
+	fmt.Fprintln(out)
+
+	previewRestore(kubeconfigPath, backup, dec, log)
+}
+
+// printBackupSummary writes the contexts, clusters and users stored in a
+// backup to out, preceded by what its manifest records.
+func printBackupSummary(out io.Writer, backup kubeconfig.Backup, backupConfig *kubeconfig.Config) {
+	fmt.Fprintf(out, "%s (%s)\n", backup.Name, backup.TimeStr)
+	if manifest, err := kubeconfig.ReadManifest(backup.Path); err == nil {
+		printManifest(out, manifest)
+	}
+	fmt.Fprintf(out, "Contexts (%d):\n", len(backupConfig.Contexts))
+	for _, ctx := range backupConfig.Contexts {
+		marker := " "
+		if ctx.Name == backupConfig.CurrentContext {
+			marker = "*"
+		}
+		if ctx.Context == nil {
+			fmt.Fprintf(out, " %s %s\n", marker, ctx.Name)
+			continue
+		}
+		fmt.Fprintf(out, " %s %s (cluster: %s, user: %s)\n", marker, ctx.Name, ctx.Context.Cluster, ctx.Context.User)
+	}
+	fmt.Fprintf(out, "Clusters (%d):\n", len(backupConfig.Clusters))
+	for _, cluster := range backupConfig.Clusters {
+		server := ""
+		if cluster.Cluster != nil {
+			server = cluster.Cluster.Server
+		}
+		fmt.Fprintf(out, "   %s %s\n", cluster.Name, server)
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
+	fmt.Fprintf(out, "Users (%d):\n", len(backupConfig.Users))
+	for _, user := range backupConfig.Users {
+		fmt.Fprintf(out, "   %s\n", user.Name)
+	}
+}
 
-	return response == "y" || response == "yes"
+// previewRestore prints what restoring the selected backup would change in the kubeconfig.
+func previewRestore(kubeconfigPath string, backup kubeconfig.Backup, dec kubeconfig.Decryption, log *logger.Logger) {
+	current, err := os.ReadFile(kubeconfigPath) //nolint:gosec // User-specified kubeconfig path is intentional
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("Could not read current kubeconfig for preview: %v", err)
+		return
+	}
+	restored, err := kubeconfig.ReadBackup(backup.Path, dec)
+	if err != nil {
+		log.Warnf("Could not read backup for preview: %v", err)
+		return
+	}
+	printDiff(log, string(current), string(restored), kubeconfigPath, backup.Name)
 }
 
-func shouldCreateBackupBeforeRestore(kubeconfigPath string, _ []Backup, selectedBackup Backup, log *logger.Logger) (shouldBackup bool, reason string, conflicts []string) {
+// previewMergeRestore prints what merging the selected backup would change in
+// the kubeconfig. Without a strategy, conflicting entries are shown overwritten,
+// as they are with --yes.
+func previewMergeRestore(kubeconfigPath string, backup kubeconfig.Backup, backupConfig *kubeconfig.Config,
+	strategy kubeconfig.DuplicateStrategy, log *logger.Logger) {
+	current, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		log.Warnf("Could not load current kubeconfig for preview: %v", err)
+		return
+	}
+	before, err := kubeconfig.Marshal(current)
+	if err != nil {
+		log.Warnf("Could not render current kubeconfig for preview: %v", err)
+		return
+	}
+	if strategy == "" {
+		strategy = kubeconfig.DuplicateOverwrite
+	}
+	if _, err := kubeconfig.Merge(current, backupConfig, strategy); err != nil {
+		log.Warnf("Could not merge backup for preview: %v", err)
+		return
+	}
+	after, err := kubeconfig.Marshal(current)
+	if err != nil {
+		log.Warnf("Could not render merged kubeconfig for preview: %v", err)
+		return
+	}
+	printDiff(log, string(before), string(after), kubeconfigPath, kubeconfigPath+" (merged with "+backup.Name+")")
+}
+
+func confirmRestore(p Prompter, backupName, kubeconfigPath string) (bool, error) {
+	return p.Confirm(fmt.Sprintf("This will restore %s from backup %s.\nAre you sure you want to continue? (y/N): ", kubeconfigPath, backupName))
+}
+
+// errRestoreCanceled is returned by shouldCreateBackupBeforeRestore when the
+// user cancels the restore from the conflict menu.
+var errRestoreCanceled = errors.New("restore canceled")
+
+// shouldCreateBackupBeforeRestore decides how to back up the current kubeconfig
+// before restoring selectedBackup over it. When the backup conflicts with it,
+// choice (full, selective or none) decides; if empty, the user is asked, and
+// errRestoreCanceled is returned if they cancel.
+func shouldCreateBackupBeforeRestore(p Prompter, kubeconfigPath string, dec kubeconfig.Decryption, selectedBackup kubeconfig.Backup,
+	choice string, log *logger.Logger) (shouldBackup bool, reason string, conflicts []string, err error) {
 	// Load current kubeconfig
 	currentConfig, err := kubeconfig.Load(kubeconfigPath)
 	if err != nil {
 		log.Debugf("Could not load current kubeconfig: %v", err)
-		return true, "could not load current kubeconfig for analysis", nil
+		return true, "could not load current kubeconfig for analysis", nil, nil
 	}
 
 	// Load backup kubeconfig
-	backupConfig, err := kubeconfig.Load(selectedBackup.Path)
+	backupConfig, err := kubeconfig.LoadBackup(selectedBackup.Path, dec)
 	if err != nil {
 		log.Debugf("Could not load backup kubeconfig: %v", err)
-		return true, "could not load backup kubeconfig for analysis", nil
+		return true, "could not load backup kubeconfig for analysis", nil, nil
 	}
 
 	// Analyze merge conflicts
 	conflicts = analyzeRestoreConflicts(currentConfig, backupConfig, log)
 
 	if len(conflicts) == 0 {
-		return false, "no conflicts detected - backup contexts can be safely merged", nil
+		return false, "no conflicts detected - backup contexts can be safely merged", nil, nil
 	}
 
 	log.Debugf("Found %d potential conflicts: %v", len(conflicts), conflicts)
 
 	// Ask user if they want selective backup or full backup
-	choice := askUserAboutConflicts(conflicts)
+	if choice == "" {
+		if choice, err = askUserAboutConflicts(p, conflicts); err != nil {
+			return false, "", nil, err
+		}
+	}
 	switch choice {
 	case choiceNone:
-		return false, "user chose to proceed without backup", nil
+		return false, "user chose to proceed without backup", nil, nil
 	case choiceSelective:
-		return true, "user chose selective backup of conflicting contexts", conflicts
+		return true, "user chose selective backup of conflicting contexts", conflicts, nil
 	case choiceFull:
-		return true, "user chose full backup", nil
+		return true, "user chose full backup", nil, nil
 	default:
-		return false, "restore canceled by user", nil
+		return false, "", nil, errRestoreCanceled
 	}
 }
 
 func analyzeRestoreConflicts(current, backup *kubeconfig.Config, log *logger.Logger) []string {
 	var conflicts []string
 
-	// Check context conflicts
-	for _, backupContext := range backup.Contexts {
-		if currentContext := current.GetContext(backupContext.Name); currentContext != nil {
-			// Context exists in both - check if they're different
-			if !contextsEqual(currentContext, backupContext.Context) {
-				conflicts = append(conflicts, fmt.Sprintf("context '%s' (different configuration)", backupContext.Name))
-				log.Debugf("Context conflict: %s", backupContext.Name)
-			}
-		}
-	}
-
-	// Check cluster conflicts
-	currentClusters := make(map[string]*kubeconfig.Cluster)
-	for _, cluster := range current.Clusters {
-		currentClusters[cluster.Name] = cluster.Cluster
-	}
-
-	for _, backupCluster := range backup.Clusters {
-		if currentCluster, exists := currentClusters[backupCluster.Name]; exists {
-			if !clustersEqual(currentCluster, backupCluster.Cluster) {
-				conflicts = append(conflicts, fmt.Sprintf("cluster '%s' (different server/auth)", backupCluster.Name))
-				log.Debugf("Cluster conflict: %s", backupCluster.Name)
-			}
-		}
-	}
-
-	// Check user conflicts
-	currentUsers := make(map[string]*kubeconfig.User)
-	for _, user := range current.Users {
-		currentUsers[user.Name] = user.User
-	}
-
-	for _, backupUser := range backup.Users {
-		if currentUser, exists := currentUsers[backupUser.Name]; exists {
-			if !usersEqual(currentUser, backupUser.User) {
-				conflicts = append(conflicts, fmt.Sprintf("user '%s' (different credentials)", backupUser.Name))
-				log.Debugf("User conflict: %s", backupUser.Name)
-			}
+	for _, conflict := range kubeconfig.FindConflicts(current, backup) {
+		var detail string
+		switch conflict.Kind {
+		case "cluster":
+			detail = "different server/auth"
+		case "user":
+			detail = "different credentials"
+		default:
+			detail = "different configuration"
 		}
+		conflicts = append(conflicts, fmt.Sprintf("%s '%s' (%s)", conflict.Kind, conflict.Name, detail))
+		log.Debugf("%s%s conflict: %s", strings.ToUpper(conflict.Kind[:1]), conflict.Kind[1:], conflict.Name)
 	}
 
 	return conflicts
 }
 
-func contextsEqual(a, b *kubeconfig.Context) bool {
-	return a.Cluster == b.Cluster && a.User == b.User && a.Namespace == b.Namespace
-}
-
-func clustersEqual(a, b *kubeconfig.Cluster) bool {
-	return a.Server == b.Server &&
-		a.CertificateAuthorityData == b.CertificateAuthorityData &&
-		a.CertificateAuthority == b.CertificateAuthority &&
-		a.InsecureSkipTLSVerify == b.InsecureSkipTLSVerify
-}
-
-func usersEqual(a, b *kubeconfig.User) bool {
-	return a.ClientCertificateData == b.ClientCertificateData &&
-		a.ClientKeyData == b.ClientKeyData &&
-		a.ClientCertificate == b.ClientCertificate &&
-		a.ClientKey == b.ClientKey &&
-		a.Token == b.Token &&
-		a.Username == b.Username &&
-		a.Password == b.Password
-}
-
-func askUserAboutConflicts(conflicts []string) string {
-	fmt.Printf("⚠️  Restoring this backup would overwrite %d existing items:\n", len(conflicts))
+// askUserAboutConflicts asks how to back up the current kubeconfig before a
+// conflicting restore. It fails if no answer can be read, as when stdin is
+// not a terminal.
+func askUserAboutConflicts(p Prompter, conflicts []string) (string, error) {
+	p.Printf("⚠️  Restoring this backup would overwrite %d existing items:\n", len(conflicts))
 	for _, conflict := range conflicts {
-		fmt.Printf("  - %s\n", conflict)
+		p.Printf("  - %s\n", conflict)
 	}
-	fmt.Println()
-	fmt.Println("Backup options:")
-	fmt.Println("  1. No backup - proceed anyway (n)")
-	fmt.Println("  2. Selective backup - backup only conflicting items (s)")
-	fmt.Println("  3. Full backup - backup entire kubeconfig (f)")
-	fmt.Println("  4. Cancel restore (c)")
-	fmt.Printf("Choose (n/s/f/c): ")
-
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	p.Printf("\n")
+	p.Printf("Backup options:\n")
+	p.Printf("  1. No backup - proceed anyway (n)\n")
+	p.Printf("  2. Selective backup - backup only conflicting items (s)\n")
+	p.Printf("  3. Full backup - backup entire kubeconfig (f)\n")
+	p.Printf("  4. Cancel restore (c)\n")
+
+	response, err := p.Ask("Choose (n/s/f/c): ", "pass --backup-choice")
 	if err != nil {
-		return choiceCancel
+		return "", err
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
+	response = strings.ToLower(response)
 
 	switch response {
 	case "n", "no":
-		return choiceNone
+		return choiceNone, nil
 	case "s", "selective":
-		return choiceSelective
+		return choiceSelective, nil
 	case "f", "full":
-		return choiceFull
+		return choiceFull, nil
 	case "c", choiceCancel:
-		return choiceCancel
+		return choiceCancel, nil
 	default:
-		fmt.Printf("Invalid choice '%s', defaulting to cancel\n", response)
-		return choiceCancel
+		p.Printf("Invalid choice '%s', defaulting to cancel\n", response)
+		return choiceCancel, nil
 	}
 }
 
-func createSelectiveBackup(kubeconfigPath string, conflicts []string, log *logger.Logger) (string, error) {
+func createSelectiveBackup(kubeconfigPath, backupDir string, enc kubeconfig.Encryption, conflicts []string, log *logger.Logger) (string, error) {
 	// Load current kubeconfig
 	currentConfig, err := kubeconfig.Load(kubeconfigPath)
 	if err != nil {
@@ -472,11 +982,19 @@ func createSelectiveBackup(kubeconfigPath string, conflicts []string, log *logge
 	}
 
 	// Create backup filename
-	timestamp := time.Now().Format(BackupTimeFormat)
-	backupPath := kubeconfigPath + ".selective-backup." + timestamp
-
-	// Save selective backup
-	err = kubeconfig.Save(selectiveConfig, backupPath)
+	folder, err := kubeconfig.CreateBackupFolder(kubeconfigPath, backupDir)
+	if err != nil {
+		return "", err
+	}
+	// Save selective backup, encrypted like full backups
+	var backupPath string
+	data, err := kubeconfig.Marshal(selectiveConfig)
+	if err == nil {
+		data, err = enc.Encrypt(data)
+	}
+	if err == nil {
+		backupPath, err = kubeconfig.WriteNewBackup(folder, filepath.Base(kubeconfigPath)+".selective-backup.", enc.Suffix(), data)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to save selective backup: %w", err)
 	}
@@ -503,18 +1021,109 @@ func extractNameFromConflict(conflict, itemType string) string {
 	return conflict[start : start+end]
 }
 
-func restoreFromBackup(backupPath, kubeconfigPath string) error {
-	// Read backup file
-	data, err := os.ReadFile(backupPath) //nolint:gosec // User-selected backup file path is intentional
+// replaceFromBackup replaces the kubeconfig with the backup, keeping the
+// contexts of the current kubeconfig that cfg protects. A backup that
+// defines an entry more than once is written as resolved by duplicates,
+// and one missing or changing a protected context with it put back, in a
+// single save; any other backup is copied as-is.
+func replaceFromBackup(backupPath, kubeconfigPath string, dec kubeconfig.Decryption, duplicates kubeconfig.DuplicatePolicy,
+	cfg *config.Config, log *logger.Logger) error {
+	previous, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil && !errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		return fmt.Errorf("failed to load current kubeconfig: %w", err)
+	}
+	backupConfig, err := loadBackup(backupPath, dec)
 	if err != nil {
-		return fmt.Errorf("failed to read backup file: %w", err)
+		return err
+	}
+	resolved, err := kubeconfig.ResolveDuplicates(backupConfig, duplicates)
+	if err != nil {
+		return err
+	}
+	for _, duplicate := range resolved {
+		log.Infof("Resolved %s/%s, defined more than once in the backup", duplicate.Kind, duplicate.Name)
 	}
+	kept := false
+	if previous != nil {
+		if kept, err = keepProtected(backupConfig, previous, cfg, log); err != nil {
+			return err
+		}
+	}
+	if len(resolved) > 0 || kept {
+		return kubeconfig.Save(backupConfig, kubeconfigPath)
+	}
+	return kubeconfig.RestoreEncryptedBackup(backupPath, kubeconfigPath, dec)
+}
 
-	// Write to kubeconfig
-	err = os.WriteFile(kubeconfigPath, data, 0600) //nolint:mnd // Use 0600 for security (kubeconfig contains credentials)
+// mergeFromBackup merges the backup into the current kubeconfig, resolving
+// duplicate entries as opts says, except those cfg protects, and returns the
+// result to save in place of the kubeconfig. Saving it fails if the
+// kubeconfig changes in between. It returns nil when there is no current
+// kubeconfig to merge into, and the backup can replace it as-is.
+func mergeFromBackup(backupPath, kubeconfigPath string, dec kubeconfig.Decryption, opts kubeconfig.MergeOptions,
+	cfg *config.Config, log *logger.Logger) (*kubeconfig.Config, error) {
+	backupConfig, err := loadBackup(backupPath, dec)
 	if err != nil {
-		return fmt.Errorf("failed to write kubeconfig: %w", err)
+		return nil, err
 	}
 
-	return nil
+	currentConfig, err := kubeconfig.Load(kubeconfigPath)
+	if errors.Is(err, kubeconfig.ErrKubeconfigNotFound) {
+		if len(kubeconfig.FindDuplicates(backupConfig)) == 0 {
+			log.Debugf("No current kubeconfig at %s, restoring backup as-is", kubeconfigPath)
+			return nil, nil
+		}
+		currentConfig = &kubeconfig.Config{APIVersion: "v1", Kind: "Config"}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load current kubeconfig: %w", err)
+	}
+
+	report, err := kubeconfig.MergeWithOptions(currentConfig, backupConfig, protectingMergeOptions(opts, cfg, currentConfig, log))
+	if err != nil {
+		return nil, err
+	}
+	logMergeReport(report, log)
+	return currentConfig, nil
+}
+
+// loadBackup loads a backup file, decrypting it with dec if needed, and
+// reports parse failures as kubeconfig.ErrBackupCorrupt.
+func loadBackup(backupPath string, dec kubeconfig.Decryption) (*kubeconfig.Config, error) {
+	data, err := kubeconfig.ReadBackup(backupPath, dec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load backup: %w", err)
+	}
+	backupConfig, err := kubeconfig.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", kubeconfig.ErrBackupCorrupt, filepath.Base(backupPath), err)
+	}
+	return backupConfig, nil
+}
+
+// logMergeReport describes how each merged entry was handled.
+func logMergeReport(report *kubeconfig.MergeReport, log *logger.Logger) {
+	for _, key := range report.Duplicates {
+		log.Infof("Resolved %s, defined more than once in the incoming file", key)
+	}
+	for _, key := range report.Added {
+		log.Debugf("Added %s", key)
+	}
+	for _, key := range report.Identical {
+		log.Debugf("Skipped identical %s", key)
+	}
+	for _, key := range report.Overwritten {
+		log.Infof("Overwrote %s", key)
+	}
+	for _, key := range report.Kept {
+		log.Infof("Kept existing %s", key)
+	}
+	renamed := make([]string, 0, len(report.Renamed))
+	for key := range report.Renamed {
+		renamed = append(renamed, key)
+	}
+	sort.Strings(renamed)
+	for _, key := range renamed {
+		log.Infof("Renamed incoming %s to '%s'", key, report.Renamed[key])
+	}
+	log.Infof("Merged %d new entries", len(report.Added))
 }
@@ -78,7 +78,7 @@ func TestRestoreCleanupLogic(t *testing.T) {
 			// Create a test logger to capture output
 			captureLogger := &CapturingLogger{}
 
-			selectedBackup := Backup{
+			selectedBackup := kubeconfig.Backup{
 				Name: filepath.Base(backupPath),
 				Path: backupPath,
 			}
@@ -193,7 +193,7 @@ func TestBackupCleanupWithPermissionError(t *testing.T) {
 
 	// Test cleanup with permission error
 	captureLogger := &CapturingLogger{}
-	selectedBackup := Backup{
+	selectedBackup := kubeconfig.Backup{
 		Name: filepath.Base(backupPath),
 		Path: backupPath,
 	}
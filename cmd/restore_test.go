@@ -14,12 +14,17 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
 )
 
 func TestFindBackups(t *testing.T) {
@@ -65,8 +70,8 @@ func TestFindBackups(t *testing.T) {
 		t.Fatalf("Failed to create other file: %v", err)
 	}
 
-	// Test findBackups function
-	backups, err := findBackups(kubeconfigPath)
+	// Test FindBackups function
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -108,7 +113,7 @@ func TestFindBackupsEmptyDirectory(t *testing.T) {
 	kubeconfigPath := filepath.Join(tmpDir, "config")
 
 	// Don't create the original file
-	backups, err := findBackups(kubeconfigPath)
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
 	if err != nil {
 		t.Errorf("Unexpected error for empty directory: %v", err)
 	}
@@ -137,32 +142,8 @@ func TestGetUserSelection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Mock stdin
-			oldStdin := os.Stdin
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			// Mock stdout to capture prompts
-			oldStdout := os.Stdout
-			rOut, wOut, _ := os.Pipe()
-			os.Stdout = wOut
-
-			// Write input in goroutine
-			go func() {
-				defer w.Close()
-				w.WriteString(tt.input)
-			}()
-
-			result, err := getUserSelection(tt.maxOptions)
-
-			// Close and restore
-			wOut.Close()
-			os.Stdin = oldStdin
-			os.Stdout = oldStdout
-
-			// Read output (prompts)
-			var output bytes.Buffer
-			output.ReadFrom(rOut)
+			p := newFakePrompter(strings.Split(strings.TrimSuffix(tt.input, "\n"), "\n")...)
+			result, err := getUserSelection(p, tt.maxOptions, nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -181,7 +162,7 @@ func TestGetUserSelection(t *testing.T) {
 			}
 
 			// Verify prompt display
-			outputStr := output.String()
+			outputStr := p.output.String()
 			expectedPrompt := fmt.Sprintf("Select backup to restore (1-%d, or 0 to cancel):", tt.maxOptions)
 			if !strings.Contains(outputStr, expectedPrompt) {
 				t.Errorf("Expected prompt %q in output %q", expectedPrompt, outputStr)
@@ -190,6 +171,44 @@ func TestGetUserSelection(t *testing.T) {
 	}
 }
 
+func TestGetUserSelectionInspect(t *testing.T) {
+	p := newFakePrompter("i2", "i9", "1")
+	var inspected []int
+	result, err := getUserSelection(p, 3, func(n int) { inspected = append(inspected, n) })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 1 {
+		t.Errorf("Expected selection 1, got %d", result)
+	}
+	if len(inspected) != 1 || inspected[0] != 2 {
+		t.Errorf("Expected backup 2 to be inspected once, got %v", inspected)
+	}
+}
+
+func TestInspectBackupWritesToOut(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	current := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://moved.example.com")
+	if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	writeOldBackups(t, kubeconfigPath, 1)
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected one backup, got %d (%v)", len(backups), err)
+	}
+
+	var out, logOut bytes.Buffer
+	inspectBackup(&out, kubeconfigPath, backups[0], kubeconfig.Decryption{}, logger.New(logger.Options{Out: &logOut, Err: &logOut}))
+	if !strings.Contains(out.String(), backups[0].Name) || !strings.Contains(out.String(), "prod") {
+		t.Errorf("Expected the backup summary on out, got %q", out.String())
+	}
+	if !strings.Contains(logOut.String(), "moved.example.com") {
+		t.Errorf("Expected the restore diff to be logged, got %q", logOut.String())
+	}
+}
+
 func TestConfirmRestore(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -208,38 +227,18 @@ func TestConfirmRestore(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Mock stdin
-			oldStdin := os.Stdin
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			// Mock stdout to capture prompt
-			oldStdout := os.Stdout
-			rOut, wOut, _ := os.Pipe()
-			os.Stdout = wOut
-
-			// Write input
-			go func() {
-				defer w.Close()
-				w.WriteString(tt.input)
-			}()
-
-			result := confirmRestore("test.backup.123", "/path/to/config")
-
-			wOut.Close()
-			os.Stdin = oldStdin
-			os.Stdout = oldStdout
-
-			// Read the prompt output
-			var output bytes.Buffer
-			output.ReadFrom(rOut)
+			p := newFakePrompter(tt.input)
+			result, err := confirmRestore(p, "test.backup.123", "/path/to/config")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v for input %q", tt.expected, result, tt.input)
 			}
 
 			// Verify prompt content
-			outputStr := output.String()
+			outputStr := p.output.String()
 			if !strings.Contains(outputStr, "test.backup.123") {
 				t.Errorf("Prompt should contain backup name, got: %s", outputStr)
 			}
@@ -270,7 +269,7 @@ func TestRestoreFromBackup(t *testing.T) {
 	}
 
 	// Restore from backup
-	err = restoreFromBackup(backupPath, targetPath)
+	err = kubeconfig.RestoreBackup(backupPath, targetPath)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -291,7 +290,7 @@ func TestRestoreFromBackupNonExistentBackup(t *testing.T) {
 	backupPath := filepath.Join(tmpDir, "nonexistent.backup")
 	targetPath := filepath.Join(tmpDir, "target.file")
 
-	err := restoreFromBackup(backupPath, targetPath)
+	err := kubeconfig.RestoreBackup(backupPath, targetPath)
 	if err == nil {
 		t.Errorf("Expected error for non-existent backup file, got none")
 	}
@@ -346,21 +345,11 @@ func TestRestoreCommandDryRun(t *testing.T) {
 	}
 
 	// Test with no backups
-	originalArgs := os.Args
-	defer func() { os.Args = originalArgs }()
-
 	var output bytes.Buffer
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	os.Args = []string{"kubectx-manager", "restore", "--kubeconfig", kubeconfigPath}
-
-	err = Execute()
-
-	w.Close()
-	os.Stdout = oldStdout
-	output.ReadFrom(r)
+	root := NewRootCommand()
+	root.SetOut(&output)
+	root.SetArgs([]string{"restore", "--kubeconfig", kubeconfigPath})
+	err = root.Execute()
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -397,7 +386,7 @@ func TestRestoreWithBackups(t *testing.T) {
 	}
 
 	// Test finding backups
-	backups, err := findBackups(kubeconfigPath)
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir())
 	if err != nil {
 		t.Errorf("Unexpected error finding backups: %v", err)
 	}
@@ -411,3 +400,433 @@ func TestRestoreWithBackups(t *testing.T) {
 		t.Errorf("Expected newest backup first, got %s", backups[0].Name)
 	}
 }
+
+func TestMergeFromBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	backupPath := kubeconfigPath + ".backup.20231201-120000"
+
+	current := `apiVersion: v1
+kind: Config
+current-context: local
+contexts:
+- name: local
+  context:
+    cluster: local
+    user: local
+clusters:
+- name: local
+  cluster:
+    server: https://current.example.com
+users:
+- name: local
+  user:
+    token: current-token
+`
+	backup := `apiVersion: v1
+kind: Config
+contexts:
+- name: local
+  context:
+    cluster: local
+    user: local
+- name: removed
+  context:
+    cluster: local
+    user: local
+clusters:
+- name: local
+  cluster:
+    server: https://backup.example.com
+users:
+- name: local
+  user:
+    token: current-token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(backupPath, []byte(backup), 0600); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	merged, err := mergeFromBackup(backupPath, kubeconfigPath, kubeconfig.Decryption{}, kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateKeep}, &config.Config{}, logger.New(logger.Options{Level: logger.LevelError}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != current {
+		t.Error("Expected the merge to leave saving the result to the caller")
+	}
+	if merged.GetContext("removed") == nil {
+		t.Error("Expected context only present in the backup to be restored")
+	}
+	if server := merged.GetCluster("local").Server; server != "https://current.example.com" {
+		t.Errorf("Expected keep strategy to preserve current cluster, got %s", server)
+	}
+	if merged.CurrentContext != "local" {
+		t.Errorf("Expected current-context to be preserved, got %q", merged.CurrentContext)
+	}
+
+	// The merge is prepared before the kubeconfig is locked, so saving it
+	// must not overwrite a change made in the meantime
+	changed := strings.Replace(current, "current-token", "changed-token", 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(changed), 0600); err != nil {
+		t.Fatalf("Failed to change kubeconfig: %v", err)
+	}
+	if err := kubeconfig.Save(merged, kubeconfigPath); !errors.Is(err, kubeconfig.ErrModifiedSinceLoad) {
+		t.Errorf("Expected saving over a changed kubeconfig to fail, got %v", err)
+	}
+
+	// fail strategy aborts without touching the file
+	before, _ := os.ReadFile(kubeconfigPath)
+	_, err = mergeFromBackup(backupPath, kubeconfigPath, kubeconfig.Decryption{}, kubeconfig.MergeOptions{Strategy: kubeconfig.DuplicateFail}, &config.Config{}, logger.New(logger.Options{Level: logger.LevelError}))
+	if err == nil {
+		t.Error("Expected fail strategy to report the conflicting cluster")
+	}
+	after, _ := os.ReadFile(kubeconfigPath)
+	if string(before) != string(after) {
+		t.Error("Failed merge must not modify the kubeconfig")
+	}
+}
+
+func TestRestoreContexts(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	backupPath := kubeconfigPath + ".backup.20231201-120000"
+
+	current := `apiVersion: v1
+kind: Config
+current-context: prod
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://current.example.com
+users:
+- name: prod-user
+  user:
+    token: prod-token
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(backupPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	backupConfig, err := kubeconfig.Load(backupPath)
+	if err != nil {
+		t.Fatalf("Failed to load backup: %v", err)
+	}
+
+	opts := &restoreOptions{
+		globalOptions: &globalOptions{backupDir: filepath.Join(tmpDir, "backups"), yes: true},
+		configFile:    filepath.Join(tmpDir, "ignore"),
+		contexts:      []string{"d*"},
+	}
+	backup := kubeconfig.Backup{Name: filepath.Base(backupPath), Path: backupPath}
+	log := logger.New(logger.Options{Level: logger.LevelError})
+	if err := opts.restoreContexts(kubeconfigPath, backup, backupConfig, "", log); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	restored, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load restored kubeconfig: %v", err)
+	}
+	if restored.GetContext("dev") == nil || restored.GetCluster("dev-cluster") == nil || restored.GetUser("dev-user") == nil {
+		t.Error("Expected dev to be restored with its cluster and user")
+	}
+	if server := restored.GetCluster("prod-cluster").Server; server != "https://current.example.com" {
+		t.Errorf("Expected entries that were not selected to be left alone, got %s", server)
+	}
+	if restored.CurrentContext != "prod" {
+		t.Errorf("Expected current-context to be preserved, got %q", restored.CurrentContext)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, opts.backupDir); len(backups) != 2 {
+		t.Errorf("Expected the current kubeconfig to be backed up and the restored backup kept, got %d backups", len(backups))
+	}
+
+	// With --yes, conflicting entries of the selected contexts are overwritten
+	opts.contexts = []string{"prod"}
+	if err := opts.restoreContexts(kubeconfigPath, backup, backupConfig, "", log); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restored, _ = kubeconfig.Load(kubeconfigPath)
+	if server := restored.GetCluster("prod-cluster").Server; server != "https://prod.example.com" {
+		t.Errorf("Expected the backup's prod-cluster to be restored, got %s", server)
+	}
+
+	// An explicit strategy is honored
+	opts.contexts = []string{"missing"}
+	if err := opts.restoreContexts(kubeconfigPath, backup, backupConfig, kubeconfig.DuplicateFail, log); err == nil {
+		t.Error("Expected a context missing from the backup to be reported")
+	}
+}
+
+func TestPickContexts(t *testing.T) {
+	backupConfig, err := kubeconfig.Parse([]byte(listTestKubeconfig))
+	if err != nil {
+		t.Fatalf("Failed to parse kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{input: "1, dev-*\n", want: []string{"prod", "dev-*"}},
+		{input: "2\n", want: []string{"dev"}},
+		{input: "\n", want: nil},
+		{input: "3\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := pickContexts(newFakePrompter(tt.input), backupConfig)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%q: expected error %v, got %v", tt.input, tt.wantErr, err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%q: expected %v, got %v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestRestoreNonInteractive(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	current := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://moved.example.com")
+	writeCurrent := func() {
+		t.Helper()
+		if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+			t.Fatalf("Failed to create kubeconfig: %v", err)
+		}
+	}
+	run := func(args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"restore", "-q", "--kubeconfig", kubeconfigPath}, args...))
+		return root.Execute()
+	}
+	restored := func() string {
+		t.Helper()
+		data, err := os.ReadFile(kubeconfigPath)
+		if err != nil {
+			t.Fatalf("Failed to read kubeconfig: %v", err)
+		}
+		return string(data)
+	}
+
+	writeOldBackups(t, kubeconfigPath, 2)
+	writeCurrent()
+
+	// --latest restores the newest backup; --backup-choice none skips backing up the conflicting kubeconfig
+	if err := run("--latest", "--yes", "--backup-choice", "none"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if restored() != listTestKubeconfig {
+		t.Error("Expected the newest backup to be restored")
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected the restored backup to be removed and no new one created, got %d backups", len(backups))
+	}
+
+	// --yes alone backs up the current kubeconfig in full
+	writeCurrent()
+	if err := run("--latest", "--yes", "--keep-backup"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 2 {
+		t.Errorf("Expected a full backup of the current kubeconfig, got %d backups", len(backups))
+	}
+
+	// --from restores any file and leaves it in place
+	saved := filepath.Join(tmpDir, "saved.yaml")
+	if err := os.WriteFile(saved, []byte(current), 0600); err != nil {
+		t.Fatalf("Failed to write saved kubeconfig: %v", err)
+	}
+	if err := run("--from", saved, "--yes", "--backup-choice", "none"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if restored() != current {
+		t.Error("Expected the --from file to be restored")
+	}
+	if _, err := os.Stat(saved); err != nil {
+		t.Errorf("Expected the --from file to be kept: %v", err)
+	}
+
+	if err := run("--latest", "--from", saved); err == nil {
+		t.Error("Expected --latest and --from together to be rejected")
+	}
+	if err := run("--latest", "--backup-choice", "some"); err == nil {
+		t.Error("Expected an invalid --backup-choice to be rejected")
+	}
+}
+
+func TestRestoreMerge(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	writeOldBackups(t, kubeconfigPath, 1)
+
+	// A context added since the backup, and prod pointing elsewhere
+	current := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://moved.example.com") + `- name: new-user
+  user:
+    token: new-token
+`
+	current = strings.Replace(current, "clusters:\n", `- name: new
+  context:
+    cluster: prod-cluster
+    user: new-user
+clusters:
+`, 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"restore", "-q", "--kubeconfig", kubeconfigPath, "--latest", "--merge", "--yes", "--backup-choice", "none"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	merged, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load merged kubeconfig: %v", err)
+	}
+	if merged.GetContext("new") == nil || merged.GetUser("new-user") == nil {
+		t.Error("Expected the context added since the backup to be kept")
+	}
+	if server := merged.GetCluster("prod-cluster").Server; server != "https://prod.example.com" {
+		t.Errorf("Expected --yes to overwrite the conflicting cluster with the backup's, got %s", server)
+	}
+}
+
+func TestRestoreInFileDuplicates(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	// A saved kubeconfig defining prod-cluster a second time, pointing elsewhere
+	saved := filepath.Join(tmpDir, "saved.yaml")
+	withDuplicate := strings.Replace(listTestKubeconfig, "clusters:\n", `clusters:
+- name: prod-cluster
+  cluster:
+    server: https://moved.example.com
+`, 1)
+	if err := os.WriteFile(saved, []byte(withDuplicate), 0600); err != nil {
+		t.Fatalf("Failed to write saved kubeconfig: %v", err)
+	}
+	run := func(args ...string) error {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"restore", "-q", "--kubeconfig", kubeconfigPath, "--from", saved, "--yes", "--backup-choice", "none"}, args...))
+		return root.Execute()
+	}
+
+	err := run()
+	if !errors.Is(err, kubeconfig.ErrConflict) || !strings.Contains(err.Error(), "--in-file-duplicates") {
+		t.Fatalf("Expected the duplicated cluster to be rejected, got %v", err)
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Error("Expected the kubeconfig to be unchanged after a rejected restore")
+	}
+
+	if err := run("--in-file-duplicates", "first"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restored, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load restored kubeconfig: %v", err)
+	}
+	if len(kubeconfig.FindDuplicates(restored)) != 0 {
+		t.Errorf("Expected the restored kubeconfig to define each name once, got %v", kubeconfig.FindDuplicates(restored))
+	}
+	if server := restored.GetCluster("prod-cluster").Server; server != "https://moved.example.com" {
+		t.Errorf("Expected the first definition to be restored, got %s", server)
+	}
+}
+
+func TestRestoreValidatesFlagsBeforeLocking(t *testing.T) {
+	oldTimeout := kubeconfig.LockTimeout
+	kubeconfig.LockTimeout = 50 * time.Millisecond
+	defer func() { kubeconfig.LockTimeout = oldTimeout }()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	lock, err := kubeconfig.Lock(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to lock kubeconfig: %v", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	for _, args := range [][]string{
+		{"--contexts", "prod", "--pick-contexts"},
+		{"--latest", "--from", "saved.yaml"},
+		{"--latest", "--backup-choice", "some"},
+		{"--latest", "--on-duplicate", "some"},
+		{"--latest", "--in-file-duplicates", "some"},
+	} {
+		root := NewRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"restore", "-q", "--kubeconfig", kubeconfigPath}, args...))
+		err := root.Execute()
+		if err == nil || errors.Is(err, kubeconfig.ErrLocked) {
+			t.Errorf("Expected %v to be rejected without waiting for the lock, got %v", args, err)
+		}
+	}
+}
+
+func TestRestoreCanceledFromConflictMenu(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	current := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://current.example.com")
+	saved := filepath.Join(tmpDir, "saved.yaml")
+	if err := os.WriteFile(saved, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to write saved kubeconfig: %v", err)
+	}
+	run := func(answers ...string) (*globalOptions, error) {
+		if err := os.WriteFile(kubeconfigPath, []byte(current), 0600); err != nil {
+			t.Fatalf("Failed to create kubeconfig: %v", err)
+		}
+		usePrompter(t, newFakePrompter(answers...))
+		root, global := newRootCommand()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs([]string{"restore", "-q", "--kubeconfig", kubeconfigPath, "--from", saved})
+		return global, root.Execute()
+	}
+	unchanged := func() {
+		t.Helper()
+		if data, _ := os.ReadFile(kubeconfigPath); string(data) != current {
+			t.Error("Expected the kubeconfig to be unchanged")
+		}
+		if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 0 {
+			t.Errorf("Expected no backup to be written, got %d", len(backups))
+		}
+	}
+
+	// Confirm, then choose "c" in the conflict menu
+	global, err := run("y", "c")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if global.exitCode != exitCodeCanceled {
+		t.Errorf("Expected exit code %d, got %d", exitCodeCanceled, global.exitCode)
+	}
+	unchanged()
+
+	// Failing to read the choice fails the restore instead of proceeding
+	if _, err := run("y"); err == nil {
+		t.Error("Expected a failed prompt to fail the restore")
+	}
+	unchanged()
+}
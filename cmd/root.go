@@ -13,15 +13,23 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/che-incubator/kubectx-manager/internal/audit"
 	"github.com/che-incubator/kubectx-manager/internal/config"
 	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
 	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
 )
 
 // Version information, set by build flags
@@ -29,33 +37,163 @@ var (
 	Version   = "dev"
 	GitCommit = "unknown"
 	BuildDate = "unknown"
+	// ReleasePublicKey is the minisign public key the checksums of releases
+	// are signed with; self-update refuses to run in builds without it
+	ReleasePublicKey = ""
 )
 
-var (
+// rootOptions holds the flag values for a single invocation of the cleanup command.
+type rootOptions struct {
+	*globalOptions
+	configFile string
+	outputFile string
+	// report is --report, the file the plan is written to
+	report      string
 	dryRun      bool
+	auth        authCheckFlags
 	authCheck   bool
-	verbose     bool
-	quiet       bool
-	configFile  string
-	kubeConfig  string
 	interactive bool
-)
+	showDiff    bool
+	archive     bool
+	// olderThan is --older-than, defaulting to the configuration file
+	olderThan string
+	// keep and remove are the ad-hoc patterns of --keep and --remove
+	keep, remove []string
+}
+
+// NewRootCommand builds the kubectx-manager command tree.
+// Every call returns independent commands with their own option values,
+// so the tree can be embedded or executed concurrently.
+func NewRootCommand() *cobra.Command {
+	rootCmd, _ := newRootCommand()
+	return rootCmd
+}
 
-var rootCmd = &cobra.Command{
-	Use:   "kubectx-manager",
-	Short: "Advanced Kubernetes context management tool",
-	Long: `kubectx-manager is a CLI tool that intelligently manages Kubernetes contexts in your kubeconfig file.
+// newRootCommand returns the root command with the options its subcommands share.
+func newRootCommand() (*cobra.Command, *globalOptions) {
+	global := &globalOptions{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	opts := &rootOptions{globalOptions: global}
+
+	rootCmd := &cobra.Command{
+		Use:   "kubectx-manager",
+		Short: "Advanced Kubernetes context management tool",
+		Long: `kubectx-manager is a CLI tool that intelligently manages Kubernetes contexts in your kubeconfig file.
 It features advanced pattern matching, authentication validation, cluster reachability checks, and comprehensive safety features including merge-aware backups.`,
-	RunE: runCleanup,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Flags and arguments have parsed: later errors are not usage
+			// errors and must not bury the error, structured or not, under
+			// the usage text
+			cmd.SilenceUsage = true
+			global.stdin, global.stdout, global.stderr = cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr()
+			global.recordInvocation(cmd, args)
+			if err := global.validate(); err != nil {
+				return err
+			}
+			if err := global.setupLogging(cmd); err != nil {
+				return err
+			}
+			return global.applyConfigDefaults(cmd)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	global.addPersistentFlags(rootCmd)
+
+	opts.addCleanupFlags(rootCmd)
+	rootCmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Prompt for confirmation before removing contexts")
+	addReportFlag(rootCmd, &opts.report)
+
+	// Add subcommands
+	rootCmd.AddCommand(newRestoreCommand(global))
+	rootCmd.AddCommand(newBackupCommand(global))
+	rootCmd.AddCommand(newArchiveCommand(global))
+	rootCmd.AddCommand(newListCommand(global))
+	rootCmd.AddCommand(newShowCommand(global))
+	rootCmd.AddCommand(newExportCommand(global))
+	rootCmd.AddCommand(newRemoveCommand(global))
+	rootCmd.AddCommand(newCurrentCommand(global))
+	rootCmd.AddCommand(newShellPromptCommand(global))
+	rootCmd.AddCommand(newSwitchCommand(global))
+	rootCmd.AddCommand(newRenameCommand(global))
+	rootCmd.AddCommand(newCopyCommand(global))
+	rootCmd.AddCommand(newSetNamespaceCommand(global))
+	rootCmd.AddCommand(newLabelCommand(global))
+	rootCmd.AddCommand(newAliasCommand(global))
+	rootCmd.AddCommand(newMergeCommand(global))
+	rootCmd.AddCommand(newImportCommand(global))
+	rootCmd.AddCommand(newDedupeCommand(global))
+	rootCmd.AddCommand(newPruneOrphansCommand(global))
+	rootCmd.AddCommand(newStatsCommand(global))
+	rootCmd.AddCommand(newNormalizeCommand(global))
+	rootCmd.AddCommand(newTUICommand(global))
+	rootCmd.AddCommand(newTrackCommand(global))
+	rootCmd.AddCommand(newHistoryCommand(global))
+	rootCmd.AddCommand(newWatchCommand(global))
+	rootCmd.AddCommand(newInstallScheduleCommand(global))
+	rootCmd.AddCommand(newUninstallScheduleCommand(global))
+	rootCmd.AddCommand(newConfigCommand(global))
+	rootCmd.AddCommand(newProtectCommand(global))
+	rootCmd.AddCommand(newUnprotectCommand(global))
+	rootCmd.AddCommand(newVersionCommand(global))
+	rootCmd.AddCommand(newSelfUpdateCommand(global))
+	rootCmd.AddCommand(newGenDocsCommand())
+	applyAliases(rootCmd)
+
+	return rootCmd, global
+}
+
+// addCleanupFlags adds the flags of the cleanup policy to cmd, the root
+// command or watch.
+func (o *rootOptions) addCleanupFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.dryRun, "dry-run", "d", false, "Show what would be removed without making changes")
+	cmd.Flags().BoolVarP(&o.authCheck, "auth-check", "a", false, "Remove contexts with expired or unreachable authentication")
+	o.auth.addFlags(cmd)
+	cmd.Flags().BoolVar(&o.showDiff, "diff", false, "Show a diff of the kubeconfig change in dry-run mode")
+	cmd.Flags().BoolVar(&o.archive, "archive", false,
+		"Move removed contexts to an archive kubeconfig next to the kubeconfig (e.g. ~/.kube/config.archive) instead of only backing them up")
+	cmd.Flags().StringVar(&o.olderThan, "older-than", "",
+		"Only remove contexts not used within this age (e.g. 30d or 72h), by their usage recorded by switch and track or else the modification time of their file")
+	cmd.Flags().StringArrayVar(&o.keep, "keep", nil,
+		"Also keep contexts matching this pattern, for this run only (can be repeated)")
+	cmd.Flags().StringArrayVar(&o.remove, "remove", nil,
+		"Remove contexts matching this pattern, even if the configuration file keeps them, for this run only (can be repeated)")
+	_ = cmd.RegisterFlagCompletionFunc("keep", o.completeContexts)
+	_ = cmd.RegisterFlagCompletionFunc("remove", o.completeContexts)
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "",
+		"Write the cleaned kubeconfig to this file instead of modifying the source (no backup is created)")
+	addConfigFlag(cmd, &o.configFile)
 }
 
 // Execute runs the root command and handles all CLI operations.
 // It sets up the CLI interface and executes the appropriate subcommands.
+// Errors are returned unprinted; pass them to ReportError. A successful run
+// that changed something, found auth failures or was canceled returns an
+// ExitCodeError carrying the exit code for that outcome, and one where some
+// of the kubeconfigs failed returns errBulkFailures.
 func Execute() error {
-	return rootCmd.Execute()
+	rootCmd, global := newRootCommand()
+	rootCmd.SilenceErrors = true
+	defer global.closeLog()
+	output := func() string {
+		output, _ := rootCmd.PersistentFlags().GetString("output")
+		return output
+	}
+	if err := rootCmd.Execute(); err != nil {
+		return &commandError{err: err, output: output()}
+	}
+	if global.exitCode == exitCodeBulkFailures {
+		return &commandError{err: errBulkFailures, output: output()}
+	}
+	if global.exitCode != exitCodeNothingToDo {
+		return &ExitCodeError{Code: global.exitCode}
+	}
+	return nil
 }
 
-func init() { //nolint:gochecknoinits // Cobra CLI flag setup requires init
+// userHomeDir returns the user's home directory, falling back to $HOME and then /tmp.
+func userHomeDir() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		homeDir = os.Getenv("HOME")
@@ -63,127 +201,481 @@ func init() { //nolint:gochecknoinits // Cobra CLI flag setup requires init
 			homeDir = "/tmp"
 		}
 	}
-	defaultConfig := filepath.Join(homeDir, ".kubectx-manager_ignore")
-	defaultKubeConfig := filepath.Join(homeDir, ".kube", "config")
-
-	rootCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Show what would be removed without making changes")
-	rootCmd.Flags().BoolVarP(&authCheck, "auth-check", "a", false, "Remove contexts with expired or unreachable authentication")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose (debug) output")
-	rootCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all output except errors")
-	rootCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for confirmation before removing contexts")
-	rootCmd.Flags().StringVarP(&configFile, "config", "c", defaultConfig, "Path to kubectx-manager configuration file")
-	rootCmd.Flags().StringVarP(&kubeConfig, "kubeconfig", "k", defaultKubeConfig, "Path to kubeconfig file")
-
-	// Add subcommands
-	rootCmd.AddCommand(restoreCmd)
-	rootCmd.AddCommand(versionCmd)
+	return homeDir
 }
 
-func runCleanup(_ *cobra.Command, _ []string) error {
-	// Initialize logger
-	log := logger.New(verbose, quiet)
+func (o *rootOptions) run(out io.Writer) error {
+	if o.report != "" {
+		if _, err := reportFormat(o.report); err != nil {
+			return err
+		}
+	}
+	results, err := o.cleanupAll(o.newLogger())
+	if err != nil {
+		return err
+	}
+	if o.report != "" {
+		if err := o.writeReport(o.report, results); err != nil {
+			return err
+		}
+	}
+	return o.printResult(out, results)
+}
 
+// cleanupAll applies the cleanup policy to every kubeconfig and returns the
+// result for each.
+func (o *rootOptions) cleanupAll(log *logger.Logger) ([]cleanupResult, error) {
 	log.Debugf("Starting kubectx-manager...")
-	log.Debugf("Config file: %s", configFile)
-	log.Debugf("Kubeconfig file: %s", kubeConfig)
+	log.Debugf("Config file: %s", o.configFile)
+	log.Debugf("Kubeconfig file: %s", o.kubeConfig)
 
 	// Load configuration
-	cfg, err := config.Load(configFile)
+	cfg, err := o.loadConfig(o.configFile)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.AddCommandLinePatterns(o.keep, o.remove); err != nil {
+		return nil, err
 	}
-	log.Debugf("Loaded configuration with %d whitelist patterns", len(cfg.Whitelist))
+	log.Debugf("Loaded configuration with %d whitelist patterns and %d rules", len(cfg.Whitelist), len(cfg.Rules))
 
-	// Load kubeconfig
-	kConfig, err := kubeconfig.Load(kubeConfig)
+	chains, err := o.kubeconfigChains()
 	if err != nil {
-		return fmt.Errorf("failed to load kubeconfig: %w", err)
+		return nil, err
+	}
+	if o.outputFile != "" && len(chains) > 1 {
+		return nil, fmt.Errorf("--output-file requires a single kubeconfig, but %q matches %d files", o.kubeConfig, len(chains))
+	}
+	if o.outputFile != "" && o.archive {
+		return nil, errors.New("--archive cannot be used with --output-file, which leaves the kubeconfig unchanged")
+	}
+	olderThan := o.olderThan
+	if olderThan == "" {
+		olderThan = cfg.OlderThan
+	}
+	var usageStore *usage.Store
+	var maxAge time.Duration
+	if olderThan != "" {
+		if maxAge, err = kubeconfig.ParseAge(olderThan); err != nil || maxAge <= 0 {
+			return nil, fmt.Errorf("invalid --older-than '%s': expected an age such as 30d or 72h", olderThan)
+		}
+		if usageStore, err = usage.Load(usageFile()); err != nil {
+			return nil, err
+		}
 	}
-	log.Debugf("Loaded kubeconfig with %d contexts", len(kConfig.Contexts))
 
-	// Create backup before modifications
-	if !dryRun {
-		backupPath, err := kubeconfig.CreateBackup(kubeConfig)
+	results := make([]cleanupResult, 0, len(chains))
+	for _, paths := range chains {
+		label := strings.Join(paths, string(filepath.ListSeparator))
+		if len(chains) > 1 {
+			log.Infof("==> %s", label)
+		}
+		var activity *contextActivity
+		if usageStore != nil {
+			activity = &contextActivity{cutoff: time.Now().Add(-maxAge), lastUsed: usageStore.LastUsed}
+		}
+		summary, err := o.cleanup(paths, cfg, activity, log)
+		if err != nil && o.isBulk() {
+			log.Errorf("%s: %v", label, err)
+			o.setExitCode(exitCodeBulkFailures)
+			results = append(results, cleanupResult{Kubeconfig: label, RemovedContexts: []string{}, Error: err.Error()})
+			continue
+		}
 		if err != nil {
-			return fmt.Errorf("failed to create backup: %w", err)
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+		o.setExitCode(summary.exitCode())
+		results = append(results, summary.result())
+	}
+	if o.isBulk() {
+		printBulkReport(results, o.authCheck, log)
+	}
+	return results, nil
+}
+
+// cleanup removes unwanted contexts from one kubeconfig, or from the merged
+// view of a kubeconfig list, writing changes back to the file each came from.
+func (o *rootOptions) cleanup(paths []string, cfg *config.Config, activity *contextActivity, log *logger.Logger) (*runSummary, error) {
+	summary := newRunSummary()
+	summary.dryRun = o.dryRun
+	summary.kubeconfig = strings.Join(paths, string(filepath.ListSeparator))
+	summary.outputFile = o.outputFile
+
+	// Hold the locks across the whole load-modify-save cycle
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return nil, err
+	}
+	defer o.unlock(locks)
+
+	// Load kubeconfig
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+	log.Debugf("Loaded kubeconfig with %d contexts from %d files", len(kConfig.Contexts), len(multi.Files))
+
+	// Create backups before modifications; writing elsewhere leaves the sources untouched
+	if !o.dryRun && o.outputFile == "" {
+		for _, path := range multi.Paths {
+			backupPath, err := o.createBackup(path, log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create backup: %w", err)
+			}
+			log.Infof("Created backup at: %s", backupPath)
+			summary.backupPaths = append(summary.backupPaths, backupPath)
 		}
-		log.Infof("Created backup at: %s", backupPath)
 	}
 
 	// Find contexts to remove
-	contextsToRemove := findContextsToRemove(kConfig, cfg, log)
+	contextNames := kConfig.GetContextNames()
+	var authOpts *kubeconfig.AuthCheckOptions
+	if o.authCheck {
+		opts, err := o.auth.options(cfg)
+		if err != nil {
+			return nil, err
+		}
+		authOpts = &opts
+	}
+	if activity != nil {
+		activity.load(multi)
+	}
+	plan := findContextsToRemove(kConfig, cfg, authOpts, activity, log)
+	if authOpts != nil {
+		saveAuthCache(*authOpts, log)
+	}
+	contextsToRemove := plan.contexts
+	summary.plan = plan
+	contexts := make([]config.ContextInfo, len(contextNames))
+	for i, name := range contextNames {
+		contexts[i] = contextInfo(kConfig, name)
+	}
+	summary.unmatchedPatterns = cfg.UnmatchedContextPatterns(contexts)
+	summary.contextsKept = len(contextNames) - len(contextsToRemove)
+	summary.contextsRemoved = len(contextsToRemove)
+	explain := o.dryRun || o.level <= logger.LevelDebug
+	if explain {
+		printKept(plan, log)
+	}
 
 	if len(contextsToRemove) == 0 {
 		log.Infof("No contexts to remove")
-		return nil
+		if o.outputFile != "" && !o.dryRun {
+			if err := o.writeOutputFile(kConfig, log); err != nil {
+				return nil, err
+			}
+		}
+		summary.print(log)
+		return summary, nil
 	}
 
 	// Display what will be removed
 	log.Infof("Contexts to remove:")
+	archivedByRule := make(map[string]bool, len(plan.archive))
+	for _, ctx := range plan.archive {
+		archivedByRule[ctx] = true
+	}
 	for _, ctx := range contextsToRemove {
-		log.Infof("  - %s", ctx)
+		line := log.Paint(logger.Red, ctx)
+		if explain {
+			line += " (" + plan.decisions[ctx].describe() + ")"
+		}
+		if archivedByRule[ctx] && !o.archive {
+			line += " (to archive)"
+		}
+		if activity != nil {
+			line += " (last active " + activity.describe(ctx) + ")"
+		}
+		if aliases := cfg.AliasesFor(ctx); len(aliases) > 0 {
+			line += " (" + strings.Join(aliases, ", ") + ")"
+		}
+		if len(multi.Files) > 1 {
+			line += " [" + multi.SourceOf(ctx) + "]"
+		}
+		log.Infof("  - %s", line)
+	}
+	if o.dryRun || (o.interactive && !o.yes) {
+		if summary.changes, err = previewChanges(multi, contextsToRemove, log); err != nil {
+			return nil, err
+		}
 	}
 
-	if dryRun {
+	if o.dryRun {
+		if o.showDiff {
+			if err := previewRemoval(multi, contextsToRemove, log); err != nil {
+				return nil, err
+			}
+		}
 		log.Infof("Dry run mode - no changes made")
-		return nil
+		summary.countPreviewed()
+		summary.print(log)
+		return summary, nil
 	}
 
 	// Confirm with user if interactive mode is enabled
-	if interactive {
-		if !confirmRemoval(contextsToRemove) {
+	if o.interactive {
+		confirmed, err := confirmRemoval(o.prompter(), contextsToRemove)
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
 			log.Infof("Operation canceled by user")
-			return nil
+			summary.canceled = true
+			return summary, nil
+		}
+	}
+
+	toArchive := plan.archive
+	if o.archive {
+		toArchive = contextsToRemove
+	}
+	if len(toArchive) > 0 && o.outputFile != "" {
+		log.Warnf("Not archiving %s: --output-file leaves the kubeconfig unchanged", listSubject(toArchive))
+	} else if len(toArchive) > 0 {
+		if err := archiveContexts(multi, toArchive, log); err != nil {
+			return nil, err
 		}
+		summary.archived = true
 	}
 
 	// Remove contexts and cleanup orphaned entries
-	err = kubeconfig.RemoveContexts(kConfig, contextsToRemove)
+	clustersBefore, usersBefore := multi.Counts()
+	err = multi.RemoveContexts(contextsToRemove)
 	if err != nil {
-		return fmt.Errorf("failed to remove contexts: %w", err)
+		return nil, fmt.Errorf("failed to remove contexts: %w", err)
 	}
 
 	// Save modified kubeconfig
-	err = kubeconfig.Save(kConfig, kubeConfig)
-	if err != nil {
-		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	if o.outputFile != "" {
+		if err := o.writeOutputFile(multi.Merged, log); err != nil {
+			return nil, err
+		}
+	} else if err := multi.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	for i, backupPath := range summary.backupPaths {
+		o.writeManifest(backupPath, multi.Paths[i], &plan, log)
+	}
+	if o.outputFile == "" {
+		o.recordChange(&audit.Record{
+			Summary:     "Clean up contexts " + listSubject(contextsToRemove),
+			Kubeconfigs: multi.Paths,
+			Removed:     contextsToRemove,
+			Backups:     summary.backupPaths,
+		}, log)
 	}
 
 	log.Infof("Successfully removed %d contexts", len(contextsToRemove))
+	clustersAfter, usersAfter := multi.Counts()
+	summary.clustersRemoved = clustersBefore - clustersAfter
+	summary.usersRemoved = usersBefore - usersAfter
+	summary.print(log)
+	return summary, nil
+}
+
+// writeOutputFile saves the cleaned kubeconfig to --output-file.
+func (o *rootOptions) writeOutputFile(kConfig *kubeconfig.Config, log *logger.Logger) error {
+	if err := kubeconfig.Save(kConfig, o.outputFile); err != nil {
+		return fmt.Errorf("failed to write %s: %w", o.outputFile, err)
+	}
+	log.Infof("Wrote cleaned kubeconfig to: %s", o.outputFile)
 	return nil
 }
 
-func findContextsToRemove(kConfig *kubeconfig.Config, cfg *config.Config, log *logger.Logger) []string {
-	var toRemove []string
+// contextActivity tells which contexts --older-than considers in use.
+type contextActivity struct {
+	// cutoff is the time before which a context counts as unused
+	cutoff time.Time
+	// lastUsed holds the recorded usage of contexts by name
+	lastUsed map[string]time.Time
+	// modified holds, for each context, when the file defining it was last
+	// modified, which stands in for contexts without recorded usage
+	modified map[string]time.Time
+}
+
+// load notes when the file defining each context of multi was modified.
+func (a *contextActivity) load(multi *kubeconfig.MultiConfig) {
+	a.modified = make(map[string]time.Time)
+	mtimes := make(map[string]time.Time)
+	for _, name := range multi.Merged.GetContextNames() {
+		path := multi.SourceOf(name)
+		if _, ok := mtimes[path]; !ok {
+			if info, err := os.Stat(path); err == nil {
+				mtimes[path] = info.ModTime()
+			}
+		}
+		a.modified[name] = mtimes[path]
+	}
+}
+
+// lastActive returns when the context was last used or, if its usage was
+// never recorded, when its file was last modified.
+func (a *contextActivity) lastActive(contextName string) time.Time {
+	if used, ok := a.lastUsed[contextName]; ok {
+		return used
+	}
+	return a.modified[contextName]
+}
+
+// inUse reports whether the context was active after the cutoff.
+func (a *contextActivity) inUse(contextName string) bool {
+	return a.lastActive(contextName).After(a.cutoff)
+}
+
+// describe tells when the context was last active and how that is known.
+func (a *contextActivity) describe(contextName string) string {
+	if used, ok := a.lastUsed[contextName]; ok {
+		return "used " + used.Local().Format("2006-01-02")
+	}
+	if modified := a.modified[contextName]; !modified.IsZero() {
+		return "file modified " + modified.Local().Format("2006-01-02")
+	}
+	return "unknown"
+}
+
+// findContextsToRemove lists the contexts no whitelist pattern or rule
+// keeps, with the patterns and rules that kept the others. Rules may also
+// remove or archive the contexts they match outright. With activity set,
+// contexts no rule applies to that are still in use are kept, and with
+// authOpts set, so are those whose authentication is valid and those
+// skip-auth-check patterns exempt from the check.
+func findContextsToRemove(kConfig *kubeconfig.Config, cfg *config.Config, authOpts *kubeconfig.AuthCheckOptions, activity *contextActivity, log *logger.Logger) removal {
+	plan := removal{matchedPatterns: make(map[string][]string), decisions: make(map[string]contextDecision)}
+	if authOpts != nil {
+		plan.authResults = make(map[string]kubeconfig.AuthStatus)
+	}
+	keep := func(name string, decision contextDecision) {
+		decision.Action = "keep"
+		plan.decisions[name] = decision
+	}
+	remove := func(name string, decision contextDecision) {
+		decision.Action = "remove"
+		plan.decisions[name] = decision
+		plan.contexts = append(plan.contexts, name)
+	}
 
-	for _, contextName := range kConfig.GetContextNames() {
-		// Check if context matches whitelist patterns
-		if cfg.MatchesWhitelist(contextName) {
-			log.Debugf("Context '%s' matches whitelist, keeping", contextName)
+	// Decide in name order, so the plan and the output built from it are
+	// the same on every run
+	names := kConfig.GetContextNames()
+	sort.Strings(names)
+	for _, contextName := range names {
+		// Check if context matches whitelist patterns or rules
+		if rule, ok := cfg.MatchContext(contextInfo(kConfig, contextName)); ok {
+			reason := reasonPattern
+			if slices.ContainsFunc(cfg.Rules, func(r config.Rule) bool { return r.Name == rule.Name }) {
+				reason = reasonRule
+			}
+			decision := contextDecision{Reason: reason, Pattern: rule.Name}
+			switch rule.Action {
+			case config.ActionKeep, config.ActionProtect:
+				log.Debugf("Context '%s' matches '%s', keeping", contextName, rule.Name)
+				plan.matchedPatterns[rule.Name] = append(plan.matchedPatterns[rule.Name], contextName)
+				keep(contextName, decision)
+			case config.ActionArchive:
+				log.Debugf("Context '%s' matches rule '%s', marking for archiving", contextName, rule.Name)
+				remove(contextName, decision)
+				plan.archive = append(plan.archive, contextName)
+			case config.ActionRemove:
+				log.Debugf("Context '%s' matches rule '%s', marking for removal", contextName, rule.Name)
+				remove(contextName, decision)
+			}
+			continue
+		}
+
+		if activity != nil && activity.inUse(contextName) {
+			log.Debugf("Context '%s' was active recently (%s), keeping", contextName, activity.describe(contextName))
+			keep(contextName, contextDecision{Reason: reasonInUse, Detail: "last active " + activity.describe(contextName)})
 			continue
 		}
 
 		// If auth-check is enabled, check authentication status
-		if authCheck {
-			if kubeconfig.IsAuthValid(kConfig, contextName) {
+		if authOpts != nil {
+			if pattern, ok := cfg.SkipsAuthCheck(contextInfo(kConfig, contextName)); ok {
+				log.Debugf("Context '%s' matches skip-auth-check pattern '%s', keeping", contextName, pattern)
+				keep(contextName, contextDecision{Reason: reasonAuthSkipped, Pattern: "skip-auth-check: " + pattern})
+				continue
+			}
+			status := kubeconfig.CheckAuth(kConfig, contextName, *authOpts)
+			plan.authResults[contextName] = status
+			if status.Valid && status.Reason != "" {
+				log.Debugf("Context '%s': %s", contextName, status.Reason)
+				keep(contextName, contextDecision{Reason: reasonAuthValid, Detail: status.Reason})
+				continue
+			}
+			if status.Valid {
 				log.Debugf("Context '%s' has valid auth, keeping", contextName)
+				keep(contextName, contextDecision{Reason: reasonAuthValid})
 				continue
 			}
-			log.Debugf("Context '%s' has invalid auth, marking for removal", contextName)
+			log.Debugf("Context '%s' has invalid auth (%s), marking for removal", contextName, status.Reason)
+			remove(contextName, contextDecision{Reason: reasonAuthError, Detail: status.Reason})
+			continue
 		}
 
-		toRemove = append(toRemove, contextName)
+		remove(contextName, contextDecision{Reason: reasonNoMatch})
 	}
 
-	return toRemove
+	return plan
 }
 
-func confirmRemoval(contexts []string) bool {
-	fmt.Printf("Are you sure you want to remove %d context(s)? (y/N): ", len(contexts))
-	var response string
-	_, err := fmt.Scanln(&response)
-	if err != nil {
-		return false
+// printKept lists the contexts plan keeps, with why.
+func printKept(plan removal, log *logger.Logger) {
+	var kept []string
+	for name, decision := range plan.decisions {
+		if decision.Action == "keep" {
+			kept = append(kept, name)
+		}
+	}
+	if len(kept) == 0 {
+		return
 	}
-	return response == "y" || response == "Y" || response == "yes" || response == "Yes"
+	sort.Strings(kept)
+	log.Infof("Contexts to keep:")
+	for _, name := range kept {
+		log.Infof("  - %s (%s)", log.Paint(logger.Green, name), plan.decisions[name].describe())
+	}
+}
+
+// contextInfo describes the named context of kConfig for matching configuration rules.
+func contextInfo(kConfig *kubeconfig.Config, name string) config.ContextInfo {
+	info := config.ContextInfo{Name: name, Server: kConfig.GetServer(name)}
+	if ctx := kConfig.GetContext(name); ctx != nil {
+		info.Cluster, info.User = ctx.Cluster, ctx.User
+		info.Namespace = ctx.Namespace
+		info.AuthType = kubeconfig.AuthType(kConfig.GetUser(ctx.User))
+	}
+	return info
+}
+
+// previewRemoval prints the diff of every kubeconfig file that removing the given contexts would change.
+// The configs are modified in memory only; callers must not save them afterwards.
+func previewRemoval(multi *kubeconfig.MultiConfig, contextsToRemove []string, log *logger.Logger) error {
+	before := make([][]byte, len(multi.Files))
+	for i, file := range multi.Files {
+		data, err := kubeconfig.Marshal(file)
+		if err != nil {
+			return err
+		}
+		before[i] = data
+	}
+	if err := multi.RemoveContexts(contextsToRemove); err != nil {
+		return fmt.Errorf("failed to remove contexts: %w", err)
+	}
+	for i, file := range multi.Files {
+		after, err := kubeconfig.Marshal(file)
+		if err != nil {
+			return err
+		}
+		if len(multi.Files) > 1 && string(after) == string(before[i]) {
+			continue
+		}
+		path := multi.Paths[i]
+		printDiff(log, string(before[i]), string(after), path, path+" (cleaned)")
+	}
+	return nil
+}
+
+func confirmRemoval(p Prompter, contexts []string) (bool, error) {
+	return p.Confirm(fmt.Sprintf("Are you sure you want to remove %d context(s)? (y/N): ", len(contexts)))
 }
@@ -14,31 +14,59 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/spf13/cobra"
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
 )
 
+// TestMain keeps the backups, configuration and usage written by command
+// tests out of the user's home and data directories
+func TestMain(m *testing.M) {
+	dataHome, err := os.MkdirTemp("", "kubectx-manager-test")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create data directory: %v\n", err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", dataHome)
+	os.Setenv("XDG_DATA_HOME", dataHome)
+	os.Setenv("XDG_STATE_HOME", dataHome)
+	os.Unsetenv("KUBECTX_MANAGER_BACKUP_DIR")
+	// Test servers come and go; probe them afresh in every test
+	authCacheFile = func() string { return "" }
+	outageLogFile = func() string { return "" }
+
+	code := m.Run()
+	os.RemoveAll(dataHome)
+	os.Exit(code)
+}
+
 func TestRootCommand(t *testing.T) {
 	// Test that the root command can be created without errors
-	cmd := &cobra.Command{
-		Use:   "kubectx-manager",
-		Short: "Clean up Kubernetes contexts from your kubeconfig",
-		RunE:  runCleanup,
-	}
+	cmd := NewRootCommand()
 
 	if cmd.Use != "kubectx-manager" {
 		t.Errorf("Expected command name 'kubectx-manager', got %s", cmd.Use)
 	}
-	if cmd.Short != "Clean up Kubernetes contexts from your kubeconfig" {
+	if cmd.Short != "Advanced Kubernetes context management tool" {
 		t.Errorf("Expected command short description, got %s", cmd.Short)
 	}
 	if cmd.RunE == nil {
 		t.Error("Expected RunE function to be set")
 	}
+
+	// Each call must produce an independent command tree
+	if other := NewRootCommand(); other == cmd || other.Flags() == cmd.Flags() {
+		t.Error("Expected NewRootCommand to return a fresh command on every call")
+	}
 }
 
 func TestFindContextsToRemove(t *testing.T) {
@@ -115,46 +143,29 @@ users:
 		t.Fatalf("Failed to create test kubeconfig: %v", err)
 	}
 
-	// Test the function (we'll need to import the necessary packages)
-	// Test command execution
-	originalArgs := os.Args
-	defer func() { os.Args = originalArgs }()
-
 	// Capture output
 	var output bytes.Buffer
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	// Set up command args for dry-run
-	os.Args = []string{"kubectx-manager", "--dry-run", "--config", configPath, "--kubeconfig", kubeconfigPath}
-
-	// Reset flags for testing
-	dryRun = false
-	authCheck = false
-	verbose = false
-	quiet = false
-	interactive = false
-	configFile = ""
-	kubeConfig = ""
-
-	// Execute root command
-	err = Execute()
-
-	w.Close()
-	os.Stdout = oldStdout
-	output.ReadFrom(r)
-
-	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+	root, global := newRootCommand()
+	root.SetOut(&output)
+	root.SetArgs([]string{"--dry-run", "--config", configPath, "--kubeconfig", kubeconfigPath})
+	err = root.Execute()
+
+	// A dry run that finds contexts to remove exits with exitCodeChanged
+	if err != nil || global.exitCode != exitCodeChanged {
+		t.Errorf("Expected exit code %d, got %d and %v", exitCodeChanged, global.exitCode, err)
 	}
 
-	outputStr := output.String()
+	// The contexts kept are listed too, with the pattern keeping them
+	kept, outputStr, _ := strings.Cut(output.String(), "Contexts to remove:")
+	if !strings.Contains(kept, "production-cluster (pattern matched: production-*)") {
+		t.Errorf("Expected production-* to be reported as keeping production-cluster, got: %s", kept)
+	}
+	outputStr, _, _ = strings.Cut(outputStr, "Changes to")
 
 	// Check that it identifies the correct contexts to remove
 	// Should remove: development-cluster, test-cluster
 	// Should keep: production-cluster, production-backup, staging-cluster
-	if !strings.Contains(outputStr, "development-cluster") {
+	if !strings.Contains(outputStr, "development-cluster (no pattern matched)") {
 		t.Errorf("Expected to remove development-cluster, but it's not in output: %s", outputStr)
 	}
 	if !strings.Contains(outputStr, "test-cluster") {
@@ -168,6 +179,161 @@ users:
 	}
 }
 
+func TestFindContextsToRemoveByServer(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("server:prod.example.com\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	plan := findContextsToRemove(kConfig, cfg, nil, nil, logger.New(logger.Options{Level: logger.LevelError}))
+	if len(plan.contexts) != 1 || plan.contexts[0] != "dev" {
+		t.Errorf("Expected only dev to be removed, got %v", plan.contexts)
+	}
+	if kept := plan.matchedPatterns["server:prod.example.com"]; len(kept) != 1 || kept[0] != "prod" {
+		t.Errorf("Expected the server pattern to keep prod, got %v", plan.matchedPatterns)
+	}
+}
+
+func TestFindContextsToRemoveSkipsAuthCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("skip-auth-check: prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	content := strings.ReplaceAll(listTestKubeconfig, "https://prod.example.com", "https://127.0.0.1:1")
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	authOpts := &kubeconfig.AuthCheckOptions{Timeout: time.Second}
+	plan := findContextsToRemove(kConfig, cfg, authOpts, nil, logger.New(logger.Options{Level: logger.LevelError}))
+	if decision := plan.decisions["prod"]; decision.Action != "keep" || decision.Reason != reasonAuthSkipped || decision.Pattern != "skip-auth-check: prod" {
+		t.Errorf("Expected the unreachable prod context to be kept without an auth check, got %+v", decision)
+	}
+	if _, checked := plan.authResults["prod"]; checked {
+		t.Error("Expected prod not to be probed")
+	}
+	if len(plan.contexts) != 1 || plan.contexts[0] != "dev" {
+		t.Errorf("Expected only dev to be removed, got %v", plan.contexts)
+	}
+}
+
+// rulesTestConfig protects prod by its server and archives dev.
+const rulesTestConfig = `rules:
+  - name: prod servers
+    match:
+      server: prod.example.com
+    action: protect
+  - name: dev
+    match:
+      name: dev
+    action: archive
+defaults:
+  backupRetention: "1"
+`
+
+func TestCleanupRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "kubectx-manager.yaml")
+	if err := os.WriteFile(configPath, []byte(rulesTestConfig), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	backupDir := filepath.Join(tmpDir, "backups")
+
+	for i := 0; i < 2; i++ {
+		root := NewRootCommand()
+		root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath, "--backup-dir", backupDir, "--yes", "-q"})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Cleanup failed: %v", err)
+		}
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected only the protected prod context to be kept, got %v", names)
+	}
+	archive, err := kubeconfig.LoadArchive(kubeconfigPath)
+	if err != nil || archive.GetContext("dev") == nil {
+		t.Errorf("Expected the archive rule to archive dev (%v)", err)
+	}
+	// The retention default of the configuration file prunes the first backup
+	backups, err := kubeconfig.FindBackups(kubeconfigPath, backupDir)
+	if err != nil || len(backups) != 1 {
+		t.Errorf("Expected backupRetention to keep 1 backup, got %d (%v)", len(backups), err)
+	}
+}
+
+func TestCleanupOlderThan(t *testing.T) {
+	_ = os.Remove(usageFile())
+	t.Cleanup(func() { _ = os.Remove(usageFile()) })
+	if err := recordUsage("dev", time.Now().Add(-60*24*time.Hour)); err != nil {
+		t.Fatalf("Failed to record usage: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("set older-than = 30d\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath, "--yes", "-q"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+
+	// dev was last used two months ago; prod was never recorded, but its
+	// file was just written
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected only the unused dev context to be removed, got %v", names)
+	}
+
+	root = NewRootCommand()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath, "--older-than", "soon"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --older-than") {
+		t.Errorf("Expected an invalid --older-than to fail, got %v", err)
+	}
+}
+
 func TestConfirmRemoval(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -187,20 +353,10 @@ func TestConfirmRemoval(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Mock stdin
-			oldStdin := os.Stdin
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-
-			// Write input
-			go func() {
-				defer w.Close()
-				w.WriteString(tt.input)
-			}()
-
-			result := confirmRemoval([]string{"test-context"})
-
-			os.Stdin = oldStdin
+			result, err := confirmRemoval(newFakePrompter(tt.input), []string{"test-context"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v for input %q", tt.expected, result, tt.input)
@@ -210,22 +366,7 @@ func TestConfirmRemoval(t *testing.T) {
 }
 
 func TestFlagsInitialization(t *testing.T) {
-	// Create a new command to test flag initialization
-	testCmd := &cobra.Command{
-		Use: "test",
-	}
-
-	homeDir, _ := os.UserHomeDir()
-	defaultConfig := filepath.Join(homeDir, ".kubectx-manager_ignore")
-	defaultKubeConfig := filepath.Join(homeDir, ".kube", "config")
-
-	testCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Show what would be removed without making changes")
-	testCmd.Flags().BoolVarP(&authCheck, "auth-check", "a", false, "Remove contexts with expired or unreachable authentication")
-	testCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose (debug) output")
-	testCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all output except errors")
-	testCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for confirmation before removing contexts")
-	testCmd.Flags().StringVarP(&configFile, "config", "c", defaultConfig, "Path to kubectx-manager configuration file")
-	testCmd.Flags().StringVarP(&kubeConfig, "kubeconfig", "k", defaultKubeConfig, "Path to kubeconfig file")
+	testCmd := NewRootCommand()
 
 	// Test flag defaults
 	flag := testCmd.Flags().Lookup("dry-run")
@@ -255,7 +396,15 @@ func TestFlagsInitialization(t *testing.T) {
 
 func TestNoInteractiveDefault(t *testing.T) {
 	// Test that interactive is false by default (no prompts by default)
-	if interactive != false {
+	cmd := NewRootCommand()
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	interactive, err := cmd.Flags().GetBool("interactive")
+	if err != nil {
+		t.Fatalf("Failed to read interactive flag: %v", err)
+	}
+	if interactive {
 		t.Errorf("Expected interactive to default to false, got %v", interactive)
 	}
 }
@@ -284,26 +433,11 @@ users: []
 	}
 
 	// Test with empty kubeconfig
-	originalArgs := os.Args
-	defer func() { os.Args = originalArgs }()
-
 	var output bytes.Buffer
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	os.Args = []string{"kubectx-manager", "--dry-run", "--config", configPath, "--kubeconfig", kubeconfigPath}
-
-	// Reset flags
-	dryRun = false
-	configFile = ""
-	kubeConfig = ""
-
-	err = Execute()
-
-	w.Close()
-	os.Stdout = oldStdout
-	output.ReadFrom(r)
+	root := NewRootCommand()
+	root.SetOut(&output)
+	root.SetArgs([]string{"--dry-run", "--config", configPath, "--kubeconfig", kubeconfigPath})
+	err = root.Execute()
 
 	if err != nil {
 		t.Errorf("Unexpected error with empty kubeconfig: %v", err)
@@ -314,3 +448,314 @@ users: []
 		t.Errorf("Expected 'No contexts to remove' message, got: %s", outputStr)
 	}
 }
+
+func TestOutputFileLeavesSourceUntouched(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	outputPath := filepath.Join(tmpDir, "cleaned.yaml")
+	configPath := filepath.Join(tmpDir, "ignore")
+
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"-q", "--config", configPath, "--kubeconfig", kubeconfigPath, "--output-file", outputPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	source, err := os.ReadFile(kubeconfigPath)
+	if err != nil || string(source) != listTestKubeconfig {
+		t.Errorf("Expected source kubeconfig to be unchanged (err %v)", err)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 0 {
+		t.Errorf("Expected no backup when writing to --output-file, got %d", len(backups))
+	}
+
+	cleaned, err := kubeconfig.Load(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to load output file: %v", err)
+	}
+	if names := cleaned.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected only prod in output file, got %v", names)
+	}
+}
+
+func TestCleanupKubeconfigList(t *testing.T) {
+	tmpDir := t.TempDir()
+	contextsPath := filepath.Join(tmpDir, "contexts")
+	clustersPath := filepath.Join(tmpDir, "clusters")
+	configPath := filepath.Join(tmpDir, "ignore")
+
+	// Contexts and the clusters/users they reference live in different files
+	contexts := `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+`
+	clusters := `apiVersion: v1
+kind: Config
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com
+users:
+- name: dev-user
+  user:
+    token: dev
+- name: prod-user
+  user:
+    token: prod
+`
+	if err := os.WriteFile(contextsPath, []byte(contexts), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(clustersPath, []byte(clusters), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	list := contextsPath + string(filepath.ListSeparator) + clustersPath
+	root := NewRootCommand()
+	root.SetArgs([]string{"-q", "--config", configPath, "--kubeconfig", list})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	contextsConfig, err := kubeconfig.Load(contextsPath)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", contextsPath, err)
+	}
+	if names := contextsConfig.GetContextNames(); len(names) != 1 || names[0] != "prod" {
+		t.Errorf("Expected only prod to remain, got %v", names)
+	}
+	if contextsConfig.CurrentContext != "prod" {
+		t.Errorf("Expected current-context to move to prod, got %q", contextsConfig.CurrentContext)
+	}
+
+	clustersConfig, err := kubeconfig.Load(clustersPath)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", clustersPath, err)
+	}
+	if clustersConfig.GetCluster("dev-cluster") != nil || clustersConfig.GetUser("dev-user") != nil {
+		t.Error("Expected dev's cluster and user to be removed from the file that defines them")
+	}
+	if clustersConfig.GetCluster("prod-cluster") == nil || clustersConfig.GetUser("prod-user") == nil {
+		t.Error("Expected prod's cluster and user, referenced from another file, to be kept")
+	}
+}
+
+func TestCleanupKubeconfigDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, "kubeconfigs")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	for name, content := range map[string]string{
+		"team-a.yaml":                        listTestKubeconfig,
+		"team-b.yaml":                        listTestKubeconfig,
+		"broken.yaml":                        "contexts: [\n",
+		"team-a.yaml.backup.20250101-000000": listTestKubeconfig,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	// The broken file fails without stopping the others
+	var out bytes.Buffer
+	root, global := newRootCommand()
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"--dry-run", "--config", configPath, "--kubeconfig-dir", dir})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if global.exitCode != exitCodeBulkFailures {
+		t.Errorf("Expected exit code %d, got %d", exitCodeBulkFailures, global.exitCode)
+	}
+	report := out.String()[strings.Index(out.String(), "Report for"):]
+	if !strings.HasPrefix(report, "Report for 3 kubeconfigs (1 failed):") {
+		t.Errorf("Expected a report for the 3 kubeconfigs, got:\n%s", report)
+	}
+	for _, name := range []string{"team-a.yaml", "team-b.yaml"} {
+		if !regexp.MustCompile(regexp.QuoteMeta(filepath.Join(dir, name)) + ` +1 +1 +ok`).MatchString(report) {
+			t.Errorf("Expected %s to keep and remove one context, got:\n%s", name, report)
+		}
+	}
+	if !strings.Contains(report, filepath.Join(dir, "broken.yaml")) || !strings.Contains(report, "failed: ") {
+		t.Errorf("Expected broken.yaml to be reported as failed, got:\n%s", report)
+	}
+
+	// --kubeconfig-dir selects files instead of --kubeconfig
+	root = NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"--kubeconfig-dir", dir, "--kubeconfig", filepath.Join(dir, "team-a.yaml")})
+	if err := root.Execute(); err == nil {
+		t.Error("Expected --kubeconfig and --kubeconfig-dir to be mutually exclusive")
+	}
+	root = NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"--kubeconfig-dir", filepath.Join(dir, "team-a.yaml")})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "not a directory") {
+		t.Errorf("Expected a file to be rejected, got %v", err)
+	}
+}
+
+func TestCleanupKubeconfigGlob(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.yaml":      listTestKubeconfig,
+		"broken.yaml": "contexts: [\n",
+		"c.yaml":      listTestKubeconfig,
+	} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	// The file that cannot be loaded, sorted between the others, stops neither of them
+	var out bytes.Buffer
+	root, global := newRootCommand()
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"--yes", "--config", configPath, "--kubeconfig", filepath.Join(tmpDir, "*.yaml")})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if global.exitCode != exitCodeBulkFailures {
+		t.Errorf("Expected exit code %d, got %d", exitCodeBulkFailures, global.exitCode)
+	}
+	index := strings.Index(out.String(), "Report for")
+	if index < 0 {
+		t.Fatalf("Expected a report for every kubeconfig, got:\n%s", out.String())
+	}
+	report := out.String()[index:]
+	if !strings.HasPrefix(report, "Report for 3 kubeconfigs (1 failed):") {
+		t.Errorf("Expected a report for the 3 kubeconfigs, got:\n%s", report)
+	}
+	if !strings.Contains(report, filepath.Join(tmpDir, "broken.yaml")) || !strings.Contains(report, "failed: ") {
+		t.Errorf("Expected broken.yaml to be reported as failed, got:\n%s", report)
+	}
+	for _, name := range []string{"a.yaml", "c.yaml"} {
+		kConfig, err := kubeconfig.Load(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		if len(kConfig.Contexts) != 1 || kConfig.GetContext("prod") == nil {
+			t.Errorf("Expected %s to be cleaned up to prod, got %v", name, kConfig.GetContextNames())
+		}
+	}
+}
+
+func BenchmarkFindContextsToRemove(b *testing.B) {
+	tmpDir := b.TempDir()
+	configPath := filepath.Join(tmpDir, ".kubectx-manager_ignore")
+	var patterns strings.Builder
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&patterns, "team-%d-*\nshort-%d => context-%d\n", i, i, i)
+	}
+	if err := os.WriteFile(configPath, []byte(patterns.String()), 0644); err != nil {
+		b.Fatalf("Failed to create test config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		b.Fatalf("Failed to load config: %v", err)
+	}
+
+	kConfig := &kubeconfig.Config{APIVersion: "v1", Kind: "Config"}
+	for i := 0; i < 10000; i++ {
+		kConfig.Contexts = append(kConfig.Contexts, kubeconfig.NamedContext{
+			Name:    fmt.Sprintf("context-%d", i),
+			Context: &kubeconfig.Context{Cluster: "cluster", User: "user"},
+		})
+	}
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := kubeconfig.Save(kConfig, kubeconfigPath); err != nil {
+		b.Fatalf("Failed to save kubeconfig: %v", err)
+	}
+	if kConfig, err = kubeconfig.Load(kubeconfigPath); err != nil {
+		b.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	log := logger.New(logger.Options{Level: logger.LevelError})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		findContextsToRemove(kConfig, cfg, nil, nil, log)
+	}
+}
+
+func TestCleanupKeepRemoveFlags(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	// --keep spares dev, which the file does not keep
+	root := NewRootCommand()
+	root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath, "--keep", "d?v", "--yes", "-q"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 2 {
+		t.Errorf("Expected --keep to keep both contexts, got %v", names)
+	}
+
+	// --remove overrides the file, and --keep overrides --remove
+	root = NewRootCommand()
+	root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath,
+		"--keep", "dev", "--remove", "*", "--yes", "-q"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if kConfig, err = kubeconfig.Load(kubeconfigPath); err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if names := kConfig.GetContextNames(); len(names) != 1 || names[0] != "dev" {
+		t.Errorf("Expected --remove to remove prod only, got %v", names)
+	}
+
+	root = NewRootCommand()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetArgs([]string{"--kubeconfig", kubeconfigPath, "--config", configPath, "--remove", "regex:("})
+	if err := root.Execute(); !errors.Is(err, config.ErrInvalidPattern) {
+		t.Errorf("Expected an invalid --remove pattern to fail, got %v", err)
+	}
+}
@@ -0,0 +1,614 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/schedule"
+)
+
+const (
+	// scheduleUnit names the systemd service and timer running the cleanup
+	scheduleUnit = "kubectx-manager-cleanup"
+	// scheduleLabel is the label of the launchd agent running the cleanup
+	scheduleLabel = "io.github.che-incubator.kubectx-manager.cleanup"
+	// maxCalendarIntervals bounds the StartCalendarInterval entries a cron
+	// expression may expand to for launchd
+	maxCalendarIntervals = 1000
+)
+
+// scheduleGOOS selects the scheduler; tests replace it to generate the
+// files of another platform.
+var scheduleGOOS = runtime.GOOS
+
+// runScheduler runs a systemctl or launchctl command; tests replace it to
+// record the commands instead.
+var runScheduler = func(name string, args ...string) error {
+	output, err := exec.Command(name, args...).CombinedOutput() //nolint:gosec // Arguments are built by this package
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%s %s failed: %w: %s", name, strings.Join(args, " "), err, msg)
+		}
+		return fmt.Errorf("%s %s failed: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// scheduleFile is a file a scheduler reads the schedule from.
+type scheduleFile struct {
+	Path    string
+	Content string
+}
+
+// scheduler describes how the cleanup is scheduled on this platform.
+type scheduler struct {
+	// kind names the scheduler for messages, e.g. "systemd timer"
+	kind  string
+	files []scheduleFile
+	// load enables the schedule once its files are written, unload disables
+	// it before they are removed and afterRemove runs once they are
+	load, unload, afterRemove [][]string
+}
+
+// scheduleResult is the structured output of install-schedule.
+type scheduleResult struct {
+	Scheduler string   `json:"scheduler" yaml:"scheduler"`
+	Schedule  string   `json:"schedule" yaml:"schedule"`
+	Command   []string `json:"command" yaml:"command"`
+	Files     []string `json:"files" yaml:"files"`
+	// Installed is false with --print
+	Installed bool `json:"installed" yaml:"installed"`
+}
+
+// unscheduleResult is the structured output of uninstall-schedule.
+type unscheduleResult struct {
+	Scheduler string   `json:"scheduler" yaml:"scheduler"`
+	Removed   []string `json:"removed" yaml:"removed"`
+}
+
+// installScheduleOptions holds the flag values for a single invocation of the install-schedule command.
+type installScheduleOptions struct {
+	rootOptions
+	interval string
+	print    bool
+}
+
+func newInstallScheduleCommand(global *globalOptions) *cobra.Command {
+	opts := &installScheduleOptions{rootOptions: rootOptions{globalOptions: global}}
+
+	installCmd := &cobra.Command{
+		Use:   "install-schedule",
+		Short: "Install a systemd timer or launchd agent running the cleanup periodically",
+		Long: `Install a systemd user timer (Linux) or launchd agent (macOS) running the cleanup every
+--interval, with the flags given to install-schedule, so it runs without a watch process.
+
+--interval takes either an age such as 24h or 7d, or a cron expression such as "0 3 * * *"
+(minute, hour, day of month, month, day of week) or @daily, as watch does.
+
+The scheduled command is this executable with every flag given here and the kubeconfig, backup
+directory and backup destinations currently in effect, so changing $KUBECONFIG later does not
+change what is cleaned up; relative paths are made absolute. Running install-schedule again
+replaces the schedule. Use --print to see the files and commands without installing them, and
+uninstall-schedule to remove the schedule.
+
+On Linux the units are written to ~/.config/systemd/user and the output of each run goes to the
+journal (journalctl --user -u kubectx-manager-cleanup). On macOS the agent is written to
+~/Library/LaunchAgents and the output goes to ~/Library/Logs/kubectx-manager.log.`,
+		Example: `  # Clean up once a day
+  kubectx-manager install-schedule
+
+  # Remove contexts unused for 30 days every Monday at 9:00
+  kubectx-manager install-schedule --interval "0 9 * * mon" --older-than 30d
+
+  # Show the unit files instead of installing them
+  kubectx-manager install-schedule --interval 12h --print`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd, cmd.OutOrStdout())
+		},
+	}
+
+	opts.addCleanupFlags(installCmd)
+	installCmd.Flags().StringVar(&opts.interval, "interval", defaultWatchInterval,
+		`How often to clean up: an age such as 24h or 7d, or a cron expression such as "0 3 * * *"`)
+	installCmd.Flags().BoolVar(&opts.print, "print", false, "Show the files and commands of the schedule without installing it")
+
+	return installCmd
+}
+
+func newUninstallScheduleCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall-schedule",
+		Short: "Remove the schedule installed by install-schedule",
+		Long: `Disable and remove the systemd user timer (Linux) or launchd agent (macOS) installed by
+install-schedule. Nothing happens if no schedule is installed.`,
+		Example: `  kubectx-manager uninstall-schedule`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return global.uninstallSchedule(cmd.OutOrStdout())
+		},
+	}
+}
+
+func (o *installScheduleOptions) run(cmd *cobra.Command, out io.Writer) error {
+	if err := o.requireFormats("install-schedule", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	if o.olderThan != "" {
+		if maxAge, err := kubeconfig.ParseAge(o.olderThan); err != nil || maxAge <= 0 {
+			return fmt.Errorf("invalid --older-than '%s': expected an age such as 30d or 72h", o.olderThan)
+		}
+	}
+	sched, err := parseSchedule(o.interval)
+	if err != nil {
+		return err
+	}
+	command, err := o.scheduledCommand(cmd)
+	if err != nil {
+		return err
+	}
+	s, err := newScheduler(sched, command)
+	if err != nil {
+		return err
+	}
+	result := scheduleResult{Scheduler: s.kind, Schedule: describeSchedule(sched), Command: command, Installed: !o.print}
+	for _, file := range s.files {
+		result.Files = append(result.Files, file.Path)
+	}
+
+	if o.print {
+		if o.isStructured() {
+			return o.printStructured(out, result)
+		}
+		for _, file := range s.files {
+			fmt.Fprintf(out, "# %s\n%s\n", file.Path, file.Content)
+		}
+		fmt.Fprintln(out, "# Then run:")
+		for _, args := range s.load {
+			fmt.Fprintln(out, shellJoin(args))
+		}
+		return nil
+	}
+
+	log := o.newLogger()
+	// Reinstalling replaces the schedule, which the scheduler may still have loaded
+	if s.installed() {
+		for _, args := range s.unload {
+			if err := runScheduler(args[0], args[1:]...); err != nil {
+				log.Debugf("Ignoring: %v", err)
+			}
+		}
+	}
+	for _, file := range s.files {
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil { //nolint:gosec // Scheduler directories are read by the user's service manager
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(file.Path), err)
+		}
+		if err := os.WriteFile(file.Path, []byte(file.Content), 0644); err != nil { //nolint:gosec // Unit files hold no secrets
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		log.Debugf("Wrote %s", file.Path)
+	}
+	for _, args := range s.load {
+		if err := runScheduler(args[0], args[1:]...); err != nil {
+			return err
+		}
+	}
+
+	if o.isStructured() {
+		return o.printStructured(out, result)
+	}
+	log.Infof("Installed the %s running the cleanup %s: %s", s.kind, result.Schedule, shellJoin(command))
+	return nil
+}
+
+func (g *globalOptions) uninstallSchedule(out io.Writer) error {
+	if err := g.requireFormats("uninstall-schedule", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	s, err := newScheduler(nil, nil)
+	if err != nil {
+		return err
+	}
+	log := g.newLogger()
+	result := unscheduleResult{Scheduler: s.kind, Removed: []string{}}
+
+	if s.installed() {
+		for _, args := range s.unload {
+			if err := runScheduler(args[0], args[1:]...); err != nil {
+				log.Warnf("%v", err)
+			}
+		}
+		for _, file := range s.files {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", file.Path, err)
+			} else if err == nil {
+				result.Removed = append(result.Removed, file.Path)
+			}
+		}
+		for _, args := range s.afterRemove {
+			if err := runScheduler(args[0], args[1:]...); err != nil {
+				log.Warnf("%v", err)
+			}
+		}
+	}
+
+	if g.isStructured() {
+		return g.printStructured(out, result)
+	}
+	if len(result.Removed) == 0 {
+		log.Infof("No schedule installed")
+		return nil
+	}
+	log.Infof("Removed the %s: %s", s.kind, strings.Join(result.Removed, ", "))
+	return nil
+}
+
+// installed reports whether any file of the schedule exists.
+func (s *scheduler) installed() bool {
+	for _, file := range s.files {
+		if _, err := os.Stat(file.Path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleFlagPaths are the flags taking paths, made absolute for the
+// scheduler, which does not run in the current directory.
+var scheduleFlagPaths = map[string]bool{
+	"kubeconfig": true, "kubeconfig-dir": true, "config": true, "backup-dir": true, "backup-identity": true, "log-file": true, "output-file": true,
+}
+
+// scheduledCommand returns the command line the scheduler runs: this
+// executable with the flags set on the command line, except those of
+// install-schedule itself and --output, which is for its own result, and the kubeconfig and backup destinations
+// that come from the environment, which the scheduler does not share.
+func (o *installScheduleOptions) scheduledCommand(cmd *cobra.Command) ([]string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the kubectx-manager executable: %w", err)
+	}
+	command := []string{executable}
+	addFlag := func(name, value string) error {
+		if scheduleFlagPaths[name] {
+			abs, err := absolutePaths(value, name == "kubeconfig")
+			if err != nil {
+				return err
+			}
+			value = abs
+		}
+		command = append(command, "--"+name+"="+value)
+		return nil
+	}
+
+	var flagErr error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flagErr != nil || flag.Name == "interval" || flag.Name == "print" || flag.Name == "output" {
+			return
+		}
+		switch {
+		case flag.Changed:
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				for _, value := range slice.GetSlice() {
+					if flagErr = addFlag(flag.Name, value); flagErr != nil {
+						return
+					}
+				}
+				return
+			}
+			if flag.Value.Type() == "bool" && flag.Value.String() == "true" {
+				command = append(command, "--"+flag.Name)
+				return
+			}
+			flagErr = addFlag(flag.Name, flag.Value.String())
+		case flag.Name == "kubeconfig" && o.kubeconfigDir != "":
+			// Selected by --kubeconfig-dir instead
+		case flag.Name == "kubeconfig" || flag.Name == "backup-dir" || flag.Name == "backup-remote" || flag.Name == "backup-git":
+			// Defaults from the environment
+			if flag.Value.String() != "" {
+				flagErr = addFlag(flag.Name, flag.Value.String())
+			}
+		case flag.Name == "sort-on-save" && flag.Value.String() == "true":
+			command = append(command, "--"+flag.Name)
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+	return command, nil
+}
+
+// absolutePaths makes path absolute, or each path of a list if list is set.
+// Paths starting with ~ and URLs are kept as they are.
+func absolutePaths(path string, list bool) (string, error) {
+	paths := []string{path}
+	if list {
+		paths = filepath.SplitList(path)
+	}
+	for i, p := range paths {
+		if p == "" || filepath.IsAbs(p) || strings.HasPrefix(p, "~") || strings.Contains(p, "://") {
+			continue
+		}
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return "", fmt.Errorf("failed to make %s absolute: %w", p, err)
+		}
+		paths[i] = abs
+	}
+	return strings.Join(paths, string(filepath.ListSeparator)), nil
+}
+
+// newScheduler describes the schedule running command on sched for this
+// platform. Without sched it only has the paths of the files and the
+// commands removing them, for uninstall-schedule.
+func newScheduler(sched schedule.Schedule, command []string) (*scheduler, error) {
+	switch scheduleGOOS {
+	case "linux":
+		return systemdScheduler(sched, command)
+	case "darwin":
+		return launchdScheduler(sched, command)
+	default:
+		return nil, fmt.Errorf("scheduling is supported with systemd on Linux and launchd on macOS, not on %s; run kubectx-manager watch instead", scheduleGOOS)
+	}
+}
+
+// systemdScheduler describes a systemd user service running command and the
+// timer starting it.
+func systemdScheduler(sched schedule.Schedule, command []string) (*scheduler, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(userHomeDir(), ".config")
+	}
+	dir := filepath.Join(configHome, "systemd", "user")
+	timer := scheduleUnit + ".timer"
+	s := &scheduler{
+		kind: "systemd timer",
+		files: []scheduleFile{
+			{Path: filepath.Join(dir, scheduleUnit+".service")},
+			{Path: filepath.Join(dir, timer)},
+		},
+		load: [][]string{
+			{"systemctl", "--user", "daemon-reload"},
+			{"systemctl", "--user", "enable", "--now", timer},
+		},
+		unload:      [][]string{{"systemctl", "--user", "disable", "--now", timer}},
+		afterRemove: [][]string{{"systemctl", "--user", "daemon-reload"}},
+	}
+	if sched == nil {
+		return s, nil
+	}
+
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = systemdQuote(arg)
+	}
+	s.files[0].Content = fmt.Sprintf(`[Unit]
+Description=Clean up stale kubeconfig contexts
+
+[Service]
+Type=oneshot
+ExecStart=%s
+`, strings.Join(quoted, " "))
+
+	var trigger strings.Builder
+	switch sched := sched.(type) {
+	case schedule.Every:
+		// Like watch, clean up soon after starting, then every interval
+		fmt.Fprintf(&trigger, "OnBootSec=5min\nOnUnitActiveSec=%ds\n", int64(time.Duration(sched)/time.Second))
+	case *schedule.Cron:
+		for _, calendar := range onCalendar(sched.Fields()) {
+			fmt.Fprintf(&trigger, "OnCalendar=%s\n", calendar)
+		}
+		trigger.WriteString("Persistent=true\n")
+	default:
+		return nil, fmt.Errorf("unsupported schedule %v", sched)
+	}
+	s.files[1].Content = fmt.Sprintf(`[Unit]
+Description=Clean up stale kubeconfig contexts %s
+
+[Timer]
+%s
+[Install]
+WantedBy=timers.target
+`, describeSchedule(sched), trigger.String())
+	return s, nil
+}
+
+// systemdQuote quotes arg for an ExecStart line, escaping the % specifiers
+// and $ variables systemd would otherwise expand.
+func systemdQuote(arg string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	arg = strings.ReplaceAll(arg, "$", "$$")
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\;") {
+		return arg
+	}
+	arg = strings.ReplaceAll(arg, `\`, `\\`)
+	arg = strings.ReplaceAll(arg, `"`, `\"`)
+	arg = strings.ReplaceAll(arg, "\n", `\n`)
+	return `"` + arg + `"`
+}
+
+// weekdayNames are the names systemd calendar events give days of the week.
+var weekdayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// onCalendar returns the systemd calendar events matching the times f
+// does: two when a day may match either the days of the month or of the week.
+func onCalendar(f schedule.Fields) []string {
+	event := func(days, weekdays []int) string {
+		var prefix string
+		if weekdays != nil {
+			names := make([]string, len(weekdays))
+			for i, day := range weekdays {
+				names[i] = weekdayNames[day]
+			}
+			prefix = strings.Join(names, ",") + " "
+		}
+		return fmt.Sprintf("%s*-%s-%s %s:%s:00", prefix, calendarValues(f.Months), calendarValues(days),
+			calendarValues(f.Hours), calendarValues(f.Minutes))
+	}
+	if f.EitherDay {
+		return []string{event(f.Days, nil), event(nil, f.Weekdays)}
+	}
+	return []string{event(f.Days, f.Weekdays)}
+}
+
+// calendarValues formats the values of a calendar event component, * for all.
+func calendarValues(values []int) string {
+	if values == nil {
+		return "*"
+	}
+	list := make([]string, len(values))
+	for i, v := range values {
+		list[i] = fmt.Sprintf("%02d", v)
+	}
+	return strings.Join(list, ",")
+}
+
+// launchdScheduler describes a launchd agent running command.
+func launchdScheduler(sched schedule.Schedule, command []string) (*scheduler, error) {
+	home := userHomeDir()
+	path := filepath.Join(home, "Library", "LaunchAgents", scheduleLabel+".plist")
+	domain := "gui/" + strconv.Itoa(os.Getuid())
+	s := &scheduler{
+		kind:   "launchd agent",
+		files:  []scheduleFile{{Path: path}},
+		load:   [][]string{{"launchctl", "bootstrap", domain, path}},
+		unload: [][]string{{"launchctl", "bootout", domain + "/" + scheduleLabel}},
+	}
+	if sched == nil {
+		return s, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", plistEscape(scheduleLabel))
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range command {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", plistEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+
+	switch sched := sched.(type) {
+	case schedule.Every:
+		// Like watch, clean up when loaded, then every interval
+		fmt.Fprintf(&b, "\t<key>StartInterval</key>\n\t<integer>%d</integer>\n", int64(time.Duration(sched)/time.Second))
+		b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	case *schedule.Cron:
+		intervals := calendarIntervals(sched.Fields())
+		if len(intervals) > maxCalendarIntervals {
+			return nil, fmt.Errorf("cron expression %q expands to %d launchd calendar intervals, more than %d; use a simpler expression or an age",
+				sched.String(), len(intervals), maxCalendarIntervals)
+		}
+		b.WriteString("\t<key>StartCalendarInterval</key>\n\t<array>\n")
+		for _, interval := range intervals {
+			b.WriteString("\t\t<dict>\n")
+			for _, entry := range interval {
+				fmt.Fprintf(&b, "\t\t\t<key>%s</key>\n\t\t\t<integer>%d</integer>\n", entry.key, entry.value)
+			}
+			b.WriteString("\t\t</dict>\n")
+		}
+		b.WriteString("\t</array>\n")
+	default:
+		return nil, fmt.Errorf("unsupported schedule %v", sched)
+	}
+
+	logFile := plistEscape(filepath.Join(home, "Library", "Logs", "kubectx-manager.log"))
+	fmt.Fprintf(&b, "\t<key>StandardOutPath</key>\n\t<string>%s</string>\n", logFile)
+	fmt.Fprintf(&b, "\t<key>StandardErrorPath</key>\n\t<string>%s</string>\n", logFile)
+	b.WriteString("</dict>\n</plist>\n")
+	s.files[0].Content = b.String()
+	return s, nil
+}
+
+// calendarEntry is one key of a launchd calendar interval.
+type calendarEntry struct {
+	key   string
+	value int
+}
+
+// calendarIntervals returns the launchd calendar intervals matching the
+// times f does, one for every combination of the values of its fields.
+func calendarIntervals(f schedule.Fields) [][]calendarEntry {
+	product := func(days, weekdays []int) [][]calendarEntry {
+		intervals := [][]calendarEntry{nil}
+		for _, field := range []struct {
+			key    string
+			values []int
+		}{{"Month", f.Months}, {"Day", days}, {"Weekday", weekdays}, {"Hour", f.Hours}, {"Minute", f.Minutes}} {
+			if field.values == nil {
+				continue
+			}
+			var next [][]calendarEntry
+			for _, interval := range intervals {
+				for _, v := range field.values {
+					next = append(next, append(interval[:len(interval):len(interval)], calendarEntry{field.key, v}))
+				}
+			}
+			intervals = next
+		}
+		return intervals
+	}
+	if f.EitherDay {
+		return append(product(f.Days, nil), product(nil, f.Weekdays)...)
+	}
+	return product(f.Days, f.Weekdays)
+}
+
+// plistEscape escapes text for a property list.
+func plistEscape(text string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
+
+// describeSchedule describes sched for messages, e.g. "every 24h0m0s" or
+// "at 0 3 * * *".
+func describeSchedule(sched schedule.Schedule) string {
+	if cron, ok := sched.(*schedule.Cron); ok {
+		return "at " + cron.String()
+	}
+	return fmt.Sprint(sched)
+}
+
+// shellJoin joins args into a command line a POSIX shell would split back
+// into them.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=.,/:@+%") == "" {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/schedule"
+)
+
+// useScheduler makes install-schedule target goos and returns the
+// systemctl or launchctl commands it runs.
+func useScheduler(t *testing.T, goos string) *[]string {
+	t.Helper()
+	var commands []string
+	oldGOOS, oldRun := scheduleGOOS, runScheduler
+	scheduleGOOS = goos
+	runScheduler = func(name string, args ...string) error {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	t.Cleanup(func() { scheduleGOOS, runScheduler = oldGOOS, oldRun })
+	return &commands
+}
+
+func TestInstallScheduleSystemd(t *testing.T) {
+	commands := useScheduler(t, "linux")
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+
+	root := NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"install-schedule", "--interval", "0 3 * * mon-fri", "--older-than", "30d",
+		"--keep", "team a*", "--keep", "ci-*", "--kubeconfig", kubeconfigPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	unitDir := filepath.Join(configHome, "systemd", "user")
+	service, err := os.ReadFile(filepath.Join(unitDir, "kubectx-manager-cleanup.service"))
+	if err != nil {
+		t.Fatalf("Failed to read the service: %v", err)
+	}
+	for _, arg := range []string{"--older-than=30d", `"--keep=team a*"`, "--keep=ci-*", "--kubeconfig=" + kubeconfigPath} {
+		if !strings.Contains(string(service), " "+arg) {
+			t.Errorf("Expected %s in the service, got:\n%s", arg, service)
+		}
+	}
+	if strings.Contains(string(service), "--interval") {
+		t.Errorf("Expected the service not to pass --interval, got:\n%s", service)
+	}
+	timer, err := os.ReadFile(filepath.Join(unitDir, "kubectx-manager-cleanup.timer"))
+	if err != nil {
+		t.Fatalf("Failed to read the timer: %v", err)
+	}
+	if !strings.Contains(string(timer), "OnCalendar=Mon,Tue,Wed,Thu,Fri *-*-* 03:00:00\n") {
+		t.Errorf("Expected weekdays at 3:00 in the timer, got:\n%s", timer)
+	}
+	expected := "systemctl --user daemon-reload,systemctl --user enable --now kubectx-manager-cleanup.timer"
+	if strings.Join(*commands, ",") != expected {
+		t.Errorf("Expected %s, got %v", expected, *commands)
+	}
+
+	*commands = nil
+	root = NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"uninstall-schedule"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entries, _ := os.ReadDir(unitDir); len(entries) != 0 {
+		t.Errorf("Expected the units to be removed, found %v", entries)
+	}
+	expected = "systemctl --user disable --now kubectx-manager-cleanup.timer,systemctl --user daemon-reload"
+	if strings.Join(*commands, ",") != expected {
+		t.Errorf("Expected %s, got %v", expected, *commands)
+	}
+}
+
+func TestInstallScheduleLaunchdPrint(t *testing.T) {
+	commands := useScheduler(t, "darwin")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"install-schedule", "--interval", "12h", "--dry-run", "--print", "--kubeconfig", "/tmp/a&b"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"<string>--dry-run</string>",
+		"<string>--kubeconfig=/tmp/a&amp;b</string>",
+		"<key>StartInterval</key>\n\t<integer>43200</integer>",
+		"launchctl bootstrap gui/",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in the output, got:\n%s", expected, out.String())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(home, "Library")); !os.IsNotExist(err) {
+		t.Errorf("Expected --print not to write the agent")
+	}
+	if len(*commands) != 0 {
+		t.Errorf("Expected --print not to run launchctl, got %v", *commands)
+	}
+}
+
+func TestUninstallScheduleNothingInstalled(t *testing.T) {
+	commands := useScheduler(t, "linux")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"uninstall-schedule"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No schedule installed") || len(*commands) != 0 {
+		t.Errorf("Expected nothing to uninstall, got %v:\n%s", *commands, out.String())
+	}
+}
+
+func TestInstallScheduleUnsupported(t *testing.T) {
+	useScheduler(t, "windows")
+	root := NewRootCommand()
+	root.SetArgs([]string{"install-schedule"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "watch") {
+		t.Errorf("Expected an error suggesting watch, got %v", err)
+	}
+}
+
+func TestCalendarSchedules(t *testing.T) {
+	cron, err := schedule.ParseCron("30 9 1,15 * fri")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	f := cron.Fields()
+	if calendars := strings.Join(onCalendar(f), "|"); calendars != "*-*-01,15 09:30:00|Fri *-*-* 09:30:00" {
+		t.Errorf("Unexpected calendar events: %s", calendars)
+	}
+	intervals := calendarIntervals(f)
+	if len(intervals) != 3 {
+		t.Fatalf("Expected 3 calendar intervals, got %v", intervals)
+	}
+	if last := intervals[2]; len(last) != 3 || last[0] != (calendarEntry{"Weekday", 5}) {
+		t.Errorf("Expected Fridays at 9:30 last, got %v", last)
+	}
+}
@@ -0,0 +1,150 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/release"
+)
+
+// selfUpdateOptions holds the flag values for a single invocation of the self-update command.
+type selfUpdateOptions struct {
+	*globalOptions
+	releases   *release.Client
+	publicKey  string
+	channel    string
+	executable string
+	force      bool
+}
+
+func newSelfUpdateCommand(global *globalOptions) *cobra.Command {
+	opts := &selfUpdateOptions{globalOptions: global, releases: release.NewClient(), publicKey: ReleasePublicKey}
+
+	selfUpdateCmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update kubectx-manager to the latest release",
+		Long: `Download the latest kubectx-manager release for this platform from GitHub,
+verify the minisign signature of the published SHA-256 checksums, verify the download
+against them and replace the running executable.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return opts.run(ctx)
+		},
+	}
+
+	selfUpdateCmd.Flags().StringVar(&opts.channel, "channel", release.ChannelStable,
+		fmt.Sprintf("Release channel to follow (%s|%s)", release.ChannelStable, release.ChannelPrerelease))
+	selfUpdateCmd.Flags().BoolVar(&opts.force, "force", false, "Reinstall even if the current version is up to date")
+
+	return selfUpdateCmd
+}
+
+func (o *selfUpdateOptions) run(ctx context.Context) error {
+	log := o.newLogger()
+
+	latest, err := o.releases.SelectRelease(ctx, o.channel)
+	if err != nil {
+		return fmt.Errorf("failed to find latest release: %w", err)
+	}
+	log.Debugf("Latest %s release: %s", o.channel, latest.TagName)
+
+	if !o.force && !release.IsNewer(Version, latest.TagName) {
+		log.Infof("kubectx-manager %s is up to date (latest %s release: %s)", Version, o.channel, latest.TagName)
+		return nil
+	}
+	if o.publicKey == "" {
+		return fmt.Errorf("this build has no key to verify releases with; refusing to install an unverified binary, download %s from GitHub instead", latest.TagName)
+	}
+
+	archiveName := release.ArchiveName(latest.TagName, runtime.GOOS, runtime.GOARCH)
+	archiveAsset := latest.FindAsset(archiveName)
+	if archiveAsset == nil {
+		return fmt.Errorf("release %s has no build for %s/%s (expected %s)", latest.TagName, runtime.GOOS, runtime.GOARCH, archiveName)
+	}
+	checksumsAsset := latest.FindAsset(release.ChecksumsName(latest.TagName))
+	if checksumsAsset == nil {
+		return fmt.Errorf("release %s has no checksums file; refusing to install an unverified binary", latest.TagName)
+	}
+	signatureAsset := latest.FindAsset(release.SignatureName(latest.TagName))
+	if signatureAsset == nil {
+		return fmt.Errorf("release %s has no checksums signature; refusing to install an unverified binary", latest.TagName)
+	}
+
+	log.Infof("Downloading %s...", archiveName)
+	archive, err := o.releases.Download(ctx, archiveAsset.DownloadURL)
+	if err != nil {
+		return err
+	}
+	checksums, err := o.releases.Download(ctx, checksumsAsset.DownloadURL)
+	if err != nil {
+		return err
+	}
+	signature, err := o.releases.Download(ctx, signatureAsset.DownloadURL)
+	if err != nil {
+		return err
+	}
+	if err := release.VerifySignature(checksums, signature, o.publicKey); err != nil {
+		return fmt.Errorf("failed to verify the checksums of release %s: %w", latest.TagName, err)
+	}
+	log.Debugf("Signature verified for %s", checksumsAsset.Name)
+	if err := release.VerifyChecksum(archive, archiveName, checksums); err != nil {
+		return err
+	}
+	log.Debugf("Checksum verified for %s", archiveName)
+
+	binaryName := release.ProjectName
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+	binary, err := release.ExtractBinary(archive, binaryName)
+	if err != nil {
+		return err
+	}
+
+	executable, err := o.executablePath()
+	if err != nil {
+		return err
+	}
+	if err := release.ReplaceExecutable(executable, binary); err != nil {
+		return err
+	}
+
+	log.Infof("Updated kubectx-manager %s -> %s (%s)", Version, latest.TagName, executable)
+	return nil
+}
+
+// executablePath resolves the path of the running binary, following symlinks.
+func (o *selfUpdateOptions) executablePath() (string, error) {
+	if o.executable != "" {
+		return o.executable, nil
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate running executable: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(executable)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/release"
+)
+
+func TestSelfUpdate(t *testing.T) {
+	binaryName := release.ProjectName
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: binaryName, Mode: 0755, Size: 10, Typeflag: tar.TypeReg})
+	tw.Write([]byte("new binary"))
+	tw.Close()
+	gz.Close()
+
+	archiveName := release.ArchiveName("v2.0.0", runtime.GOOS, runtime.GOARCH)
+	sum := sha256.Sum256(archive.Bytes())
+	checksums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archiveName)
+	publicKey, signature := signChecksums(t, checksums)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/latest":
+			fmt.Fprintf(w, `{"tag_name":"v2.0.0","assets":[
+				{"name":%q,"browser_download_url":"%s/archive"},
+				{"name":%q,"browser_download_url":"%s/checksums"},
+				{"name":%q,"browser_download_url":"%s/signature"}]}`,
+				archiveName, server.URL, release.ChecksumsName("v2.0.0"), server.URL, release.SignatureName("v2.0.0"), server.URL)
+		case "/archive":
+			w.Write(archive.Bytes())
+		case "/checksums":
+			fmt.Fprint(w, checksums)
+		case "/signature":
+			w.Write(signature)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	executable := filepath.Join(t.TempDir(), binaryName)
+	if err := os.WriteFile(executable, []byte("old binary"), 0755); err != nil {
+		t.Fatalf("Failed to create executable: %v", err)
+	}
+
+	oldVersion := Version
+	Version = "v1.0.0"
+	defer func() { Version = oldVersion }()
+
+	opts := &selfUpdateOptions{
+		globalOptions: &globalOptions{level: logger.LevelError},
+		releases:      &release.Client{HTTPClient: server.Client(), BaseURL: server.URL},
+		channel:       release.ChannelStable,
+		executable:    executable,
+	}
+
+	// Neither a build without a key nor another key installs anything
+	for _, key := range []string{"", otherPublicKey(t)} {
+		opts.publicKey = key
+		if err := opts.run(context.Background()); err == nil {
+			t.Errorf("Expected an error verifying the release with key %q", key)
+		}
+		if data, _ := os.ReadFile(executable); string(data) != "old binary" {
+			t.Errorf("Expected executable to be kept, got %q", data)
+		}
+	}
+	opts.publicKey = publicKey
+	if err := opts.run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(executable)
+	if string(data) != "new binary" {
+		t.Errorf("Expected executable to be replaced, got %q", data)
+	}
+
+	// Already up to date: nothing is downloaded or replaced
+	Version = "v2.0.0"
+	os.WriteFile(executable, []byte("current"), 0755)
+	if err := opts.run(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, _ = os.ReadFile(executable)
+	if string(data) != "current" {
+		t.Errorf("Up-to-date binary should not be replaced, got %q", data)
+	}
+}
+
+// signChecksums signs checksums as minisign does with a new key, returning
+// the public key and the signature file.
+func signChecksums(t *testing.T, checksums string) (string, []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyID := []byte("KEYID123")
+	sig := ed25519.Sign(priv, []byte(checksums))
+	comment := "file:checksums.txt"
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), comment...))
+	publicKey := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+	signature := fmt.Sprintf("untrusted comment: test\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), sig...)), comment,
+		base64.StdEncoding.EncodeToString(globalSig))
+	return publicKey, []byte(signature)
+}
+
+// otherPublicKey returns a public key that signed nothing.
+func otherPublicKey(t *testing.T) string {
+	t.Helper()
+	publicKey, _ := signChecksums(t, "")
+	return publicKey
+}
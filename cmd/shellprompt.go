@@ -0,0 +1,209 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// defaultPromptFormat is the template prompt renders without --format
+const defaultPromptFormat = "⎈ {{.Context}}/{{.Namespace}}"
+
+// promptShells lists the values of --shell: the shells whose prompts need
+// color sequences marked as taking no space
+var promptShells = []string{"none", "bash", "zsh"}
+
+// promptColors are the colors the --format template can paint text with
+var promptColors = map[string]logger.Color{
+	"red":     logger.Red,
+	"green":   logger.Green,
+	"yellow":  logger.Yellow,
+	"blue":    "\x1b[34m",
+	"magenta": "\x1b[35m",
+	"cyan":    "\x1b[36m",
+	"bold":    "\x1b[1m",
+}
+
+// promptReset ends a color started by a promptColors sequence
+const promptReset = "\x1b[0m"
+
+// promptCacheDir returns the directory prompt caches the current context in; tests replace it.
+var promptCacheDir = func() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "kubectx-manager", "prompt"), nil
+}
+
+// shellPromptOptions holds the flag values for a single invocation of the prompt command.
+type shellPromptOptions struct {
+	*globalOptions
+	format string
+	shell  string
+}
+
+func newShellPromptCommand(global *globalOptions) *cobra.Command {
+	opts := &shellPromptOptions{globalOptions: global}
+
+	promptCmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print the current context for a shell prompt",
+		Long: `Print the current context in a compact form for PS1, PROMPT or a starship segment, by default
+"⎈ context/namespace". Nothing is printed when no context is current.
+
+--format is a Go template over .Context, .Cluster, .Server, .User, .Namespace and .Kubeconfig,
+with red, green, yellow, blue, magenta, cyan and bold to paint text, e.g. {{cyan .Context}}.
+Colors are left out with --no-color or $NO_COLOR. --shell bash or zsh marks the color sequences
+so the shell measures the prompt correctly.
+
+The result is cached until a kubeconfig file changes, so most prompts skip reading them.`,
+		Example: `  # bash
+  PS1='$(kubectx-manager prompt --shell bash --format "{{cyan .Context}}:{{.Namespace}}") \$ '
+
+  # zsh
+  setopt PROMPT_SUBST
+  PROMPT='$(kubectx-manager prompt --shell zsh) %# '`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.OutOrStdout())
+		},
+	}
+
+	promptCmd.Flags().StringVar(&opts.format, "format", defaultPromptFormat, "Go template of the prompt")
+	promptCmd.Flags().StringVar(&opts.shell, "shell", "none",
+		fmt.Sprintf("Shell to mark color sequences for (%s)", strings.Join(promptShells, "|")))
+	_ = promptCmd.RegisterFlagCompletionFunc("shell", completeValues(promptShells...))
+
+	return promptCmd
+}
+
+func (o *shellPromptOptions) run(out io.Writer) error {
+	if err := o.requireFormats("prompt", outputText); err != nil {
+		return err
+	}
+	// Sequences the shell should not count are put between markStart and markEnd
+	var markStart, markEnd string
+	switch o.shell {
+	case "none":
+	case "bash":
+		markStart, markEnd = `\[`, `\]`
+	case "zsh":
+		markStart, markEnd = "%{", "%}"
+	default:
+		return fmt.Errorf("invalid shell %q (expected one of: %s)", o.shell, strings.Join(promptShells, ", "))
+	}
+
+	color := !o.noColor
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		color = false
+	}
+	funcs := template.FuncMap{}
+	for name, c := range promptColors {
+		funcs[name] = func(text string) string {
+			if !color || text == "" {
+				return text
+			}
+			return markStart + string(c) + markEnd + text + markStart + promptReset + markEnd
+		}
+	}
+	tmpl, err := template.New("prompt").Funcs(funcs).Parse(o.format)
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+
+	paths, err := o.kubeconfigChain("prompt")
+	if err != nil {
+		return err
+	}
+	result, err := o.cachedCurrent(paths)
+	if err != nil || result == nil {
+		return err
+	}
+	return tmpl.Execute(out, result)
+}
+
+// promptCache is what prompt caches: the current context of a kubeconfig
+// chain as of the size and modification time of its files.
+type promptCache struct {
+	Files []promptCacheFile `json:"files"`
+	// Result is nil when no context was current
+	Result *currentResult `json:"result"`
+}
+
+// promptCacheFile identifies one version of a kubeconfig file; Size is -1 for a missing file.
+type promptCacheFile struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modTime"`
+}
+
+// cachedCurrent returns the current context of the kubeconfig chain paths,
+// or nil when none is current, from the cache if no file changed since it
+// was written. Cache failures only cost speed and are not reported.
+func (o *shellPromptOptions) cachedCurrent(paths []string) (*currentResult, error) {
+	files := make([]promptCacheFile, len(paths))
+	for i, path := range paths {
+		files[i] = promptCacheFile{Path: path, Size: -1}
+		if info, err := os.Stat(path); err == nil {
+			files[i].Size = info.Size()
+			files[i].ModTime = info.ModTime().UnixNano()
+		}
+	}
+
+	cachePath := ""
+	if dir, err := promptCacheDir(); err == nil {
+		sum := sha256.Sum256([]byte(strings.Join(paths, "\x00")))
+		cachePath = filepath.Join(dir, hex.EncodeToString(sum[:8])+".json")
+		var cached promptCache
+		data, err := os.ReadFile(cachePath) //nolint:gosec // Path is derived from the user's cache directory
+		if err == nil && json.Unmarshal(data, &cached) == nil && slices.Equal(cached.Files, files) {
+			return cached.Result, nil
+		}
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	result, err := newCurrentResult(multi)
+	if errors.Is(err, errNoCurrentContext) {
+		result, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		data, _ := json.Marshal(promptCache{Files: files, Result: result})
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o700); err == nil {
+			_ = kubeconfig.WriteFile(cachePath, data)
+		}
+	}
+	return result, nil
+}
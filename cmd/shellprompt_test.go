@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShellPromptCommand(t *testing.T) {
+	cacheDir := t.TempDir()
+	oldCacheDir := promptCacheDir
+	promptCacheDir = func() (string, error) { return cacheDir, nil }
+	defer func() { promptCacheDir = oldCacheDir }()
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	prompt := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(append([]string{"prompt"}, args...), "--kubeconfig", kubeconfigPath))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return out.String()
+	}
+
+	if out := prompt(); out != "⎈ prod/apps" {
+		t.Errorf("Expected the default prompt, got %q", out)
+	}
+	if out := prompt("--shell", "bash", "--format", "{{cyan .Context}} {{.Server}}"); out != `\[`+"\x1b[36m"+`\]prod\[`+"\x1b[0m"+`\] https://prod.example.com` {
+		t.Errorf("Expected a bash-marked colored prompt, got %q", out)
+	}
+	if out := prompt("--no-color", "--format", "{{red .Context}}"); out != "prod" {
+		t.Errorf("Expected no colors with --no-color, got %q", out)
+	}
+
+	// The cached result is used while the kubeconfig is unchanged
+	cached, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil || len(cached) != 1 {
+		t.Fatalf("Expected one cache file, got %v (%v)", cached, err)
+	}
+	data, _ := os.ReadFile(cached[0])
+	if err := os.WriteFile(cached[0], bytes.Replace(data, []byte(`"apps"`), []byte(`"cached"`), 1), 0600); err != nil {
+		t.Fatalf("Failed to edit cache: %v", err)
+	}
+	if out := prompt(); out != "⎈ prod/cached" {
+		t.Errorf("Expected the cached prompt, got %q", out)
+	}
+
+	// A changed kubeconfig is read again; without a current context nothing is printed
+	if err := os.WriteFile(kubeconfigPath, []byte(strings.Replace(listTestKubeconfig, "current-context: prod\n", "", 1)), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	if out := prompt(); out != "" {
+		t.Errorf("Expected no prompt without a current context, got %q", out)
+	}
+}
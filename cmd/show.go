@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// showOptions holds the flag values for a single invocation of the show command.
+type showOptions struct {
+	*globalOptions
+	configFile string
+	reveal     bool
+}
+
+func newShowCommand(global *globalOptions) *cobra.Command {
+	opts := &showOptions{globalOptions: global}
+
+	showCmd := &cobra.Command{
+		Use:   "show [CONTEXT]",
+		Short: "Show the configuration of a context with its secrets redacted",
+		Long: `Show a context, or the current context, with its cluster and user as a kubeconfig.
+Tokens, passwords, client keys, secret auth provider settings and exec environment variables,
+and passwords in server and proxy URLs are replaced with REDACTED, so the output can be shared
+for diagnostics. Certificates and credential file paths are shown as they are.
+Use --reveal to show the secrets too.`,
+		Example: `  kubectx-manager show prod
+  kubectx-manager show prod -o json
+  kubectx-manager show --reveal`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFirstArgs(1, global.completeContexts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	showCmd.Flags().BoolVar(&opts.reveal, "reveal", false, "Show tokens, passwords and keys instead of redacting them")
+	addConfigFlag(showCmd, &opts.configFile)
+
+	return showCmd
+}
+
+// showResult is the structured form of a show run.
+type showResult struct {
+	Context    string `json:"context" yaml:"context"`
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	Redacted   bool   `json:"redacted" yaml:"redacted"`
+	// Config is the kubeconfig holding only the context, its cluster and its user
+	Config map[string]interface{} `json:"config" yaml:"config"`
+}
+
+func (o *showOptions) run(out io.Writer, args []string) error {
+	if err := o.requireFormats("show", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+
+	paths, err := o.kubeconfigChain("show")
+	if err != nil {
+		return err
+	}
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+
+	name := kConfig.CurrentContext
+	if len(args) == 1 {
+		name = cfg.ResolveAlias(args[0])
+	} else if name == "" {
+		return errors.New("no current context is set; name the context to show")
+	}
+	extracted, err := exportConfig(kConfig, []string{name}, !o.reveal)
+	if err != nil {
+		return err
+	}
+	data, err := kubeconfig.Marshal(extracted)
+	if err != nil {
+		return err
+	}
+
+	if !o.isStructured() {
+		_, err := out.Write(data)
+		return err
+	}
+	result := showResult{Context: name, Kubeconfig: multi.SourceOf(name), Redacted: !o.reveal}
+	if err := yaml.Unmarshal(data, &result.Config); err != nil {
+		return err
+	}
+	return o.printStructured(out, result)
+}
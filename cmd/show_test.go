@@ -0,0 +1,63 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShowCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	show := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(append([]string{"show"}, args...), "--kubeconfig", kubeconfigPath))
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	// The current context, redacted
+	out, err := show()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "token: REDACTED") || strings.Contains(out, "prod-token") {
+		t.Errorf("Expected the token to be redacted, got:\n%s", out)
+	}
+	if !strings.Contains(out, "server: https://prod.example.com") || strings.Contains(out, "dev-cluster") {
+		t.Errorf("Expected only prod and its cluster and user, got:\n%s", out)
+	}
+
+	if out, err = show("prod", "--reveal", "-o", "json"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"token": "prod-token"`) || !strings.Contains(out, `"redacted": false`) {
+		t.Errorf("Expected --reveal to show the token, got:\n%s", out)
+	}
+
+	if _, err = show("missing"); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != listTestKubeconfig {
+		t.Error("Expected show to leave the kubeconfig untouched")
+	}
+}
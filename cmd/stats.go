@@ -0,0 +1,208 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// authTypeOIDC is the auth type of stats for OpenID Connect, whether through
+// the oidc auth provider or the kubelogin exec plugin
+const authTypeOIDC = "oidc"
+
+func newStatsCommand(global *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize what the kubeconfig is made of",
+		Long: `Show the number of contexts, clusters and users of the kubeconfig, how many clusters
+and users no context references, how the contexts authenticate (token, client-certificate,
+basic, exec, oidc, ...), which cloud their clusters run in as inferred from the server URLs,
+the size of the kubeconfig and its number of backups. Nothing is changed; run it before and
+after a large cleanup to compare, with -o json for a record.`,
+		Example: `  kubectx-manager stats
+  kubectx-manager stats -o json > before.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStats(global, cmd.OutOrStdout())
+		},
+	}
+}
+
+// statsResult is the structured form of stats for one kubeconfig.
+type statsResult struct {
+	Kubeconfig string `json:"kubeconfig" yaml:"kubeconfig"`
+	// Size is the size of the kubeconfig files in bytes
+	Size             int64 `json:"size" yaml:"size"`
+	Contexts         int   `json:"contexts" yaml:"contexts"`
+	Clusters         int   `json:"clusters" yaml:"clusters"`
+	Users            int   `json:"users" yaml:"users"`
+	OrphanedClusters int   `json:"orphanedClusters" yaml:"orphanedClusters"`
+	OrphanedUsers    int   `json:"orphanedUsers" yaml:"orphanedUsers"`
+	// AuthTypes and Clouds count the contexts by auth type and by cloud
+	AuthTypes map[string]int `json:"authTypes" yaml:"authTypes"`
+	Clouds    map[string]int `json:"clouds" yaml:"clouds"`
+	// Backups counts the local backups; remote ones are not listed
+	Backups int `json:"backups" yaml:"backups"`
+	// Error is set for a kubeconfig of --kubeconfig-dir that could not be read
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runStats(g *globalOptions, out io.Writer) error {
+	if err := g.requireFormats("stats", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+	log := g.newLogger()
+
+	chains, err := g.kubeconfigChains()
+	if err != nil {
+		return err
+	}
+	results := make([]statsResult, 0, len(chains))
+	for _, paths := range chains {
+		label := strings.Join(paths, string(filepath.ListSeparator))
+		result, err := g.stats(paths)
+		if err != nil && g.isBulk() {
+			log.Errorf("%s: %v", label, err)
+			g.setExitCode(exitCodeBulkFailures)
+			results = append(results, statsResult{Kubeconfig: label, AuthTypes: map[string]int{}, Clouds: map[string]int{}, Error: err.Error()})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		results = append(results, *result)
+	}
+
+	if g.isStructured() {
+		return g.printStructured(out, results)
+	}
+	for i := range results {
+		if results[i].Error != "" {
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := writeStats(out, &results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stats computes the figures of one kubeconfig, or of the merged view of a
+// kubeconfig list.
+func (g *globalOptions) stats(paths []string) (*statsResult, error) {
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+
+	result := &statsResult{
+		Kubeconfig: strings.Join(paths, string(filepath.ListSeparator)),
+		Contexts:   len(kConfig.Contexts),
+		AuthTypes:  make(map[string]int),
+		Clouds:     make(map[string]int),
+	}
+	result.Clusters, result.Users = multi.Counts()
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			result.Size += info.Size()
+		}
+		backups, err := kubeconfig.FindBackups(path, g.backupDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find backups: %w", err)
+		}
+		result.Backups += len(backups)
+	}
+	for _, ctx := range kConfig.Contexts {
+		var user *kubeconfig.User
+		server := ""
+		if ctx.Context != nil {
+			user = kConfig.GetUser(ctx.Context.User)
+			if cluster := kConfig.GetCluster(ctx.Context.Cluster); cluster != nil {
+				server = cluster.Server
+			}
+		}
+		result.AuthTypes[statsAuthType(user)]++
+		result.Clouds[kubeconfig.Cloud(server)]++
+	}
+
+	// The kubeconfig is not saved, so pruning it only counts the orphans
+	orphans := multi.PruneOrphans()
+	result.OrphanedClusters, result.OrphanedUsers = len(orphans.Clusters), len(orphans.Users)
+	return result, nil
+}
+
+// statsAuthType returns the auth type of user without the provider or
+// command AuthType adds, except that OpenID Connect is told apart.
+func statsAuthType(user *kubeconfig.User) string {
+	authType, detail, _ := strings.Cut(kubeconfig.AuthType(user), ":")
+	switch {
+	case authType == kubeconfig.AuthTypeAuthProvider && detail == authTypeOIDC:
+		return authTypeOIDC
+	case authType == kubeconfig.AuthTypeExec && (slices.Contains(user.Exec.Args, "oidc-login") ||
+		filepath.Base(detail) == "kubectl-oidc_login"):
+		return authTypeOIDC
+	}
+	return authType
+}
+
+func writeStats(out io.Writer, r *statsResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Kubeconfig:\t%s (%s)\n", r.Kubeconfig, formatSize(r.Size))
+	fmt.Fprintf(w, "Contexts:\t%d\n", r.Contexts)
+	fmt.Fprintf(w, "Clusters:\t%d (%d orphaned)\n", r.Clusters, r.OrphanedClusters)
+	fmt.Fprintf(w, "Users:\t%d (%d orphaned)\n", r.Users, r.OrphanedUsers)
+	fmt.Fprintf(w, "Backups:\t%d\n", r.Backups)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := writeBreakdown(out, "AUTH TYPE", r.AuthTypes); err != nil {
+		return err
+	}
+	return writeBreakdown(out, "CLOUD", r.Clouds)
+}
+
+// writeBreakdown writes counts as a table, the largest first.
+func writeBreakdown(out io.Writer, heading string, counts map[string]int) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCONTEXTS\n", heading)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%d\n", name, counts[name])
+	}
+	return w.Flush()
+}
@@ -0,0 +1,74 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatsCommand(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	// An orphaned cluster, on an EKS server
+	content := strings.Replace(listTestKubeconfig, "\nclusters:\n",
+		"\nclusters:\n- name: orphan-cluster\n  cluster:\n    server: https://abc.eks.amazonaws.com\n", 1)
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	stats := func(args ...string) string {
+		var out bytes.Buffer
+		root := NewRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append([]string{"stats", "--kubeconfig", kubeconfigPath}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("stats failed: %v", err)
+		}
+		return out.String()
+	}
+
+	var results []statsResult
+	if err := json.Unmarshal([]byte(stats("-o", "json")), &results); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected one result, got %d", len(results))
+	}
+	r := results[0]
+	if r.Contexts != 2 || r.Clusters != 3 || r.Users != 2 || r.OrphanedClusters != 1 || r.OrphanedUsers != 0 {
+		t.Errorf("Unexpected counts %+v", r)
+	}
+	if r.AuthTypes["token"] != 1 || r.AuthTypes["exec"] != 1 {
+		t.Errorf("Unexpected auth types %v", r.AuthTypes)
+	}
+	if r.Clouds["other"] != 2 {
+		t.Errorf("Expected the clouds of the contexts only, got %v", r.Clouds)
+	}
+	if r.Size != int64(len(content)) {
+		t.Errorf("Expected size %d, got %d", len(content), r.Size)
+	}
+
+	out := stats()
+	for _, expected := range []string{"Clusters:    3 (1 orphaned)", "AUTH TYPE", "CLOUD"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, out)
+		}
+	}
+	if data, _ := os.ReadFile(kubeconfigPath); string(data) != content {
+		t.Error("Expected stats to leave the kubeconfig untouched")
+	}
+}
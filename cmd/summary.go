@@ -0,0 +1,228 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+// runSummary collects the figures reported at the end of a cleanup run.
+type runSummary struct {
+	start             time.Time
+	kubeconfig        string
+	outputFile        string
+	plan              removal
+	backupPaths       []string
+	unmatchedPatterns []string
+	// changes maps each kubeconfig file to the changes previewed for it
+	changes         map[string]*kubeconfig.Changes
+	contextsKept    int
+	contextsRemoved int
+	clustersRemoved int
+	usersRemoved    int
+	dryRun          bool
+	archived        bool
+	canceled        bool
+}
+
+// cleanupResult is the structured form of a cleanup or remove run.
+type cleanupResult struct {
+	// MatchedPatterns maps each pattern to the contexts it kept (cleanup) or removed (remove)
+	MatchedPatterns map[string][]string `json:"matchedPatterns,omitempty" yaml:"matchedPatterns,omitempty"`
+	// Decisions tells, for each context, why it was kept or removed
+	Decisions map[string]contextDecision `json:"decisions,omitempty" yaml:"decisions,omitempty"`
+	// AuthResults is only set with --auth-check
+	AuthResults       map[string]kubeconfig.AuthStatus `json:"authResults,omitempty" yaml:"authResults,omitempty"`
+	Kubeconfig        string                           `json:"kubeconfig" yaml:"kubeconfig"`
+	OutputFile        string                           `json:"outputFile,omitempty" yaml:"outputFile,omitempty"`
+	RemovedContexts   []string                         `json:"removedContexts" yaml:"removedContexts"`
+	UnmatchedPatterns []string                         `json:"unmatchedPatterns,omitempty" yaml:"unmatchedPatterns,omitempty"`
+	// Changes is only set in dry-run and interactive mode, for the files that would change
+	Changes         map[string]*kubeconfig.Changes `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Backups         []string                       `json:"backups,omitempty" yaml:"backups,omitempty"`
+	ContextsKept    int                            `json:"contextsKept" yaml:"contextsKept"`
+	ClustersRemoved int                            `json:"clustersRemoved" yaml:"clustersRemoved"`
+	UsersRemoved    int                            `json:"usersRemoved" yaml:"usersRemoved"`
+	DryRun          bool                           `json:"dryRun" yaml:"dryRun"`
+	Archived        bool                           `json:"archived,omitempty" yaml:"archived,omitempty"`
+	Canceled        bool                           `json:"canceled,omitempty" yaml:"canceled,omitempty"`
+	// Error is set for a kubeconfig of --kubeconfig-dir that could not be cleaned up
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func newRunSummary() *runSummary {
+	return &runSummary{start: time.Now()}
+}
+
+// result returns the structured form of the summary. In dry-run mode the
+// removed contexts, clusters and users are those that would be removed. They are sorted by
+// name, so the same run always gives the same output.
+func (s *runSummary) result() cleanupResult {
+	removed := slices.Sorted(slices.Values(s.plan.contexts))
+	if removed == nil {
+		removed = []string{}
+	}
+	return cleanupResult{
+		Kubeconfig:        s.kubeconfig,
+		OutputFile:        s.outputFile,
+		RemovedContexts:   removed,
+		MatchedPatterns:   s.plan.matchedPatterns,
+		Decisions:         s.plan.decisions,
+		AuthResults:       s.plan.authResults,
+		UnmatchedPatterns: s.unmatchedPatterns,
+		Changes:           s.changes,
+		Backups:           s.backupPaths,
+		ContextsKept:      s.contextsKept,
+		ClustersRemoved:   s.clustersRemoved,
+		UsersRemoved:      s.usersRemoved,
+		DryRun:            s.dryRun,
+		Archived:          s.archived,
+		Canceled:          s.canceled,
+	}
+}
+
+// countPreviewed sets the clusters and users removed to those the previewed
+// changes would remove, as a dry run does not garbage-collect anything.
+func (s *runSummary) countPreviewed() {
+	s.clustersRemoved, s.usersRemoved = 0, 0
+	for _, changes := range s.changes {
+		for _, key := range changes.Removed {
+			switch {
+			case strings.HasPrefix(key, "cluster/"):
+				s.clustersRemoved++
+			case strings.HasPrefix(key, "user/"):
+				s.usersRemoved++
+			}
+		}
+	}
+}
+
+// exitCode returns the exit code for the outcome of the run.
+func (s *runSummary) exitCode() int {
+	if s.canceled {
+		return exitCodeCanceled
+	}
+	for _, status := range s.plan.authResults {
+		if !status.Valid {
+			return exitCodeAuthFailures
+		}
+	}
+	if len(s.plan.contexts) > 0 {
+		return exitCodeChanged
+	}
+	return exitCodeNothingToDo
+}
+
+// render formats the summary as a two-column table.
+func (s *runSummary) render() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	removedLabel, collectedLabel := "Contexts removed", "garbage-collected"
+	if s.dryRun {
+		removedLabel, collectedLabel = "Contexts to remove", "garbage-collected (would remove)"
+	}
+
+	backup := "none"
+	if len(s.backupPaths) > 0 {
+		backup = strings.Join(s.backupPaths, ", ")
+	}
+
+	unmatched := "none"
+	if len(s.unmatchedPatterns) > 0 {
+		unmatched = strings.Join(s.unmatchedPatterns, ", ")
+	}
+
+	fmt.Fprintf(w, "Contexts kept\t%d\n", s.contextsKept)
+	fmt.Fprintf(w, "%s\t%d\n", removedLabel, s.contextsRemoved)
+	fmt.Fprintf(w, "Clusters %s\t%d\n", collectedLabel, s.clustersRemoved)
+	fmt.Fprintf(w, "Users %s\t%d\n", collectedLabel, s.usersRemoved)
+	fmt.Fprintf(w, "Unmatched whitelist patterns\t%s\n", unmatched)
+	fmt.Fprintf(w, "Backup\t%s\n", backup)
+	fmt.Fprintf(w, "Duration\t%s\n", time.Since(s.start).Round(time.Millisecond))
+
+	if err := w.Flush(); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// print writes the summary through the logger so --quiet suppresses it.
+func (s *runSummary) print(log *logger.Logger) {
+	log.Infof("")
+	log.Infof("Summary:")
+	for _, line := range strings.Split(strings.TrimRight(s.render(), "\n"), "\n") {
+		log.Infof("  %s", line)
+	}
+}
+
+// printBulkReport writes the outcome for each kubeconfig selected by a glob
+// through the logger, after the output of the individual runs.
+func printBulkReport(results []cleanupResult, authCheck bool, log *logger.Logger) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	removedLabel := "REMOVED"
+	if len(results) > 0 && results[0].DryRun {
+		removedLabel = "TO REMOVE"
+	}
+	header := "KUBECONFIG\tKEPT\t" + removedLabel
+	if authCheck {
+		header += "\tAUTH FAILURES"
+	}
+	fmt.Fprintln(w, header+"\tSTATUS")
+
+	failed := 0
+	for _, r := range results {
+		kept, removed, status := strconv.Itoa(r.ContextsKept), strconv.Itoa(len(r.RemovedContexts)), "ok"
+		switch {
+		case r.Error != "":
+			kept, removed, status = "-", "-", "failed: "+r.Error
+			failed++
+		case r.Canceled:
+			status = "canceled"
+		}
+		line := r.Kubeconfig + "\t" + kept + "\t" + removed
+		if authCheck {
+			invalid := 0
+			for _, auth := range r.AuthResults {
+				if !auth.Valid {
+					invalid++
+				}
+			}
+			if r.Error != "" {
+				line += "\t-"
+			} else {
+				line += "\t" + strconv.Itoa(invalid)
+			}
+		}
+		fmt.Fprintln(w, line+"\t"+status)
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	log.Infof("")
+	log.Infof("Report for %d kubeconfigs (%d failed):", len(results), failed)
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		log.Infof("  %s", line)
+	}
+}
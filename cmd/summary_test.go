@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+func TestRunSummaryRender(t *testing.T) {
+	summary := newRunSummary()
+	summary.contextsKept = 3
+	summary.contextsRemoved = 2
+	summary.clustersRemoved = 1
+	summary.usersRemoved = 2
+	summary.backupPaths = []string{"/tmp/config.backup.20250101-120000"}
+	summary.unmatchedPatterns = []string{"legacy-*", "old-?"}
+
+	output := summary.render()
+
+	expected := []string{
+		"Contexts kept",
+		"Contexts removed",
+		"Clusters garbage-collected",
+		"Users garbage-collected",
+		"legacy-*, old-?",
+		"/tmp/config.backup.20250101-120000",
+		"Duration",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunSummaryRenderDryRun(t *testing.T) {
+	summary := newRunSummary()
+	summary.dryRun = true
+	summary.contextsRemoved = 4
+	summary.changes = map[string]*kubeconfig.Changes{
+		"/tmp/config": {Removed: []string{"context/dev", "cluster/dev-cluster", "user/dev-user", "user/old-user"}},
+	}
+	summary.countPreviewed()
+
+	output := summary.render()
+
+	if !strings.Contains(output, "Contexts to remove") {
+		t.Errorf("Expected dry-run label, got:\n%s", output)
+	}
+	for _, want := range []string{"Clusters garbage-collected (would remove)  1", "Users garbage-collected (would remove)     2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected dry-run summary to contain %q, got:\n%s", want, output)
+		}
+	}
+	if !strings.Contains(output, "Backup") || !strings.Contains(output, "none") {
+		t.Errorf("Expected empty backup to render as none, got:\n%s", output)
+	}
+}
+
+func TestRunSummaryExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  runSummary
+		exitCode int
+	}{
+		{"nothing to do", runSummary{}, exitCodeNothingToDo},
+		{"removed", runSummary{plan: removal{contexts: []string{"dev"}}}, exitCodeChanged},
+		{"auth failure", runSummary{plan: removal{
+			contexts:    []string{"dev"},
+			authResults: map[string]kubeconfig.AuthStatus{"dev": {Reason: "token expired"}, "prod": {Valid: true}},
+		}}, exitCodeAuthFailures},
+		{"canceled", runSummary{plan: removal{contexts: []string{"dev"}}, canceled: true}, exitCodeCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := tt.summary.exitCode(); code != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/tui"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+// switchOptions holds the flag values for a single invocation of the switch command.
+type switchOptions struct {
+	*globalOptions
+	configFile string
+	noBackup   bool
+}
+
+func newSwitchCommand(global *globalOptions) *cobra.Command {
+	opts := &switchOptions{globalOptions: global}
+
+	switchCmd := &cobra.Command{
+		Use:   "switch [CONTEXT | -]",
+		Short: "Change the current context",
+		Long: `Set current-context in the kubeconfig to the given context or alias.
+"-" switches back to the context that was current before the last switch, like cd -.
+Without an argument, the available contexts are listed for interactive selection.
+A backup is created before the kubeconfig is modified.`,
+		Example: `  kubectx-manager switch prod
+  kubectx-manager switch -`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFirstArgs(1, global.completeContexts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	switchCmd.Flags().BoolVar(&opts.noBackup, "no-backup", false, "Skip creating a backup of the kubeconfig before switching")
+	addConfigFlag(switchCmd, &opts.configFile)
+
+	return switchCmd
+}
+
+// switchResult is the structured form of a switch run.
+type switchResult struct {
+	Previous string   `json:"previous" yaml:"previous"`
+	Current  string   `json:"current" yaml:"current"`
+	Backups  []string `json:"backups,omitempty" yaml:"backups,omitempty"`
+	Canceled bool     `json:"canceled,omitempty" yaml:"canceled,omitempty"`
+}
+
+func (o *switchOptions) run(out io.Writer, args []string) error {
+	log := o.newLogger()
+
+	paths, err := o.kubeconfigChain("switch")
+	if err != nil {
+		return err
+	}
+
+	locks, err := kubeconfig.LockAll(paths)
+	if err != nil {
+		return err
+	}
+	defer o.unlock(locks)
+
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	kConfig := multi.Merged
+	result := switchResult{Previous: kConfig.CurrentContext, Current: kConfig.CurrentContext}
+
+	var target string
+	if len(args) == 1 && args[0] == "-" {
+		store, err := usage.Load(usageFile())
+		if err != nil {
+			return err
+		}
+		if target = store.Previous(); target == "" {
+			return errors.New("no previous context to switch back to")
+		}
+		if kConfig.GetContext(target) == nil {
+			return fmt.Errorf("previous context '%s' not found", target)
+		}
+	} else if len(args) == 1 {
+		target = cfg.ResolveAlias(args[0])
+		if kConfig.GetContext(target) == nil {
+			return fmt.Errorf("context '%s' not found", args[0])
+		}
+	} else {
+		names := kConfig.GetContextNames()
+		if len(names) == 0 {
+			log.Infof("No contexts in %s", strings.Join(multi.Paths, ", "))
+			return o.printResult(out, result)
+		}
+		sort.Strings(names)
+		if target, err = o.selectContext(o.prompter(), names, kConfig.CurrentContext); err != nil {
+			return err
+		}
+		if target == "" {
+			log.Infof("Switch canceled")
+			o.setExitCode(exitCodeCanceled)
+			result.Canceled = true
+			return o.printResult(out, result)
+		}
+	}
+
+	if kConfig.CurrentContext == target {
+		log.Infof("Already using context '%s'", target)
+		return o.printResult(out, result)
+	}
+
+	multi.SetCurrentContext(target)
+	modified := multi.ModifiedPaths()
+	if !o.noBackup {
+		for _, path := range modified {
+			backupPath, err := o.createBackup(path, log)
+			if err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+			log.Debugf("Created backup at: %s", backupPath)
+			result.Backups = append(result.Backups, backupPath)
+		}
+	}
+
+	if err := multi.Save(); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	o.recordChange(&audit.Record{
+		Summary:        "Switch to context " + target,
+		Kubeconfigs:    modified,
+		CurrentContext: target,
+		Backups:        result.Backups,
+	}, log)
+	// Switching to a context uses it; like track, recording is best effort
+	if err := recordSwitch(result.Previous, target, time.Now()); err != nil {
+		log.Debugf("Failed to record usage of context '%s': %v", target, err)
+	}
+
+	log.Infof("Switched to context '%s'", target)
+	result.Current = target
+	return o.printResult(out, result)
+}
+
+// selectContext prompts for one of names and returns it, or "" if the user cancels.
+// On a terminal the names are offered in a fuzzy picker, otherwise in a numbered menu.
+func (g *globalOptions) selectContext(p Prompter, names []string, current string) (string, error) {
+	if g.isInteractive() {
+		return g.pickContext(names, current)
+	}
+	for i, name := range names {
+		marker := " "
+		if name == current {
+			marker = "*"
+		}
+		p.Printf(" %s %d. %s\n", marker, i+1, name)
+	}
+
+	for {
+		input, err := p.Ask(fmt.Sprintf("Select context (1-%d, or 0 to cancel): ", len(names)), "name the context to switch to")
+		if err != nil {
+			return "", err
+		}
+
+		selection, err := strconv.Atoi(input)
+		if err != nil || selection < 0 || selection > len(names) {
+			p.Printf("Please enter a number between 1 and %d (or 0 to cancel)\n", len(names))
+			continue
+		}
+		if selection == 0 {
+			return "", nil
+		}
+		return names[selection-1], nil
+	}
+}
+
+// pickContext offers names in a fuzzy picker, starting on the current context.
+func (g *globalOptions) pickContext(names []string, current string) (string, error) {
+	opts := tui.PickOptions{Prompt: "Switch to context>", Items: make([]string, len(names))}
+	for i, name := range names {
+		opts.Items[i] = "  " + name
+		if name == current {
+			opts.Items[i] = "* " + name
+			opts.Selected = i
+		}
+	}
+	choice, err := tui.Pick(g.stdin, g.interactiveOut(), opts)
+	if err != nil || choice.Index < 0 {
+		return "", err
+	}
+	return names[choice.Index], nil
+}
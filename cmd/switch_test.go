@@ -0,0 +1,154 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+func TestSwitchCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("development => dev\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"switch", "development", "-q", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "dev" {
+		t.Errorf("Expected current-context dev, got %q", kConfig.CurrentContext)
+	}
+	if backups, _ := kubeconfig.FindBackups(kubeconfigPath, kubeconfig.DefaultBackupDir()); len(backups) != 1 {
+		t.Errorf("Expected one backup, got %d", len(backups))
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"switch", "missing", "-q", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	if err := root.Execute(); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+}
+
+func TestSelectContext(t *testing.T) {
+	p := newFakePrompter("7", "2")
+	selected, err := (&globalOptions{stdin: strings.NewReader("")}).selectContext(p, []string{"dev", "prod"}, "dev")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if selected != "prod" {
+		t.Errorf("Expected prod, got %q", selected)
+	}
+	if !strings.Contains(p.output.String(), " * 1. dev") {
+		t.Errorf("Expected the current context to be marked, got %q", p.output.String())
+	}
+}
+
+func TestSwitchPickerUsesCommandStreams(t *testing.T) {
+	saved := isTerminal
+	isTerminal = func(any) bool { return true }
+	t.Cleanup(func() { isTerminal = saved })
+
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	root := NewRootCommand()
+	root.SetIn(strings.NewReader("dev\r"))
+	root.SetOut(&stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"switch", "-q", "--no-backup", "--kubeconfig", kubeconfigPath,
+		"--config", filepath.Join(tmpDir, "ignore")})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "Switch to context>") {
+		t.Errorf("Expected the picker to be drawn to the command's stdout, got %q", stdout.String())
+	}
+	kConfig, err := kubeconfig.Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "dev" {
+		t.Errorf("Expected current-context dev, got %s", kConfig.CurrentContext)
+	}
+}
+
+func TestSwitchBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	switchTo := func(target string) (string, error) {
+		root := NewRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs([]string{"switch", target, "-q", "--no-backup", "--kubeconfig", kubeconfigPath,
+			"--config", filepath.Join(tmpDir, "ignore")})
+		if err := root.Execute(); err != nil {
+			return "", err
+		}
+		kConfig, err := kubeconfig.Load(kubeconfigPath)
+		if err != nil {
+			t.Fatalf("Failed to load kubeconfig: %v", err)
+		}
+		return kConfig.CurrentContext, nil
+	}
+
+	for _, step := range []struct{ target, expected string }{{"dev", "dev"}, {"-", "prod"}, {"-", "dev"}} {
+		current, err := switchTo(step.target)
+		if err != nil {
+			t.Fatalf("switch %s: unexpected error: %v", step.target, err)
+		}
+		if current != step.expected {
+			t.Errorf("switch %s: expected current-context %s, got %s", step.target, step.expected, current)
+		}
+	}
+
+	store, err := usage.Load(usageFile())
+	if err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+	store.RecordSwitch("gone")
+	if err := store.Save(); err != nil {
+		t.Fatalf("Failed to save usage: %v", err)
+	}
+	if _, err := switchTo("-"); err == nil || !strings.Contains(err.Error(), "gone") {
+		t.Errorf("Expected an error for a previous context that no longer exists, got %v", err)
+	}
+}
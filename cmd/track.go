@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+// shellFunctions holds the wrapper printed by track --shell-function for each supported shell.
+var shellFunctions = map[string]string{
+	"bash": `kubectl() { kubectx-manager track -- kubectl "$@"; }`,
+	"zsh":  `kubectl() { kubectx-manager track -- kubectl "$@"; }`,
+	"fish": `function kubectl; kubectx-manager track -- kubectl $argv; end`,
+}
+
+// ExitCodeError reports that a wrapped command exited with a non-zero status,
+// or the outcome of a successful run. main exits with Code instead of
+// printing an error.
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// trackOptions holds the flag values for a single invocation of the track command.
+type trackOptions struct {
+	*globalOptions
+	shellFunction string
+}
+
+func newTrackCommand(global *globalOptions) *cobra.Command {
+	opts := &trackOptions{globalOptions: global}
+
+	trackCmd := &cobra.Command{
+		Use:   "track -- kubectl [ARG...]",
+		Short: "Run kubectl and record which context it used",
+		Long: `Run a kubectl command and record the context it used, so context ages reflect real usage.
+The context is taken from --context when given, otherwise from the current context of the
+kubeconfig kubectl would read (--kubeconfig, then $KUBECONFIG, then --kubeconfig of kubectx-manager).
+
+Use --shell-function to print a kubectl wrapper for your shell profile, for example:
+  eval "$(kubectx-manager track --shell-function bash)"`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.shellFunction != "" {
+				return opts.printShellFunction(cmd.OutOrStdout())
+			}
+			if len(args) == 0 {
+				return errors.New("track requires a command to run, e.g. kubectx-manager track -- kubectl get pods")
+			}
+			return opts.run(args)
+		},
+	}
+
+	trackCmd.Flags().StringVar(&opts.shellFunction, "shell-function", "",
+		"Print a kubectl wrapper function for the given shell (bash|zsh|fish) instead of running a command")
+
+	return trackCmd
+}
+
+func (o *trackOptions) printShellFunction(out io.Writer) error {
+	function, ok := shellFunctions[o.shellFunction]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (expected one of: bash, zsh, fish)", o.shellFunction)
+	}
+	_, err := fmt.Fprintln(out, function)
+	return err
+}
+
+func (o *trackOptions) run(args []string) error {
+	log := o.newLogger()
+
+	// Recording is best effort: kubectl must run even if usage cannot be tracked
+	if contextName := o.invokedContext(args[1:], log); contextName != "" {
+		if err := recordUsage(contextName, time.Now()); err != nil {
+			log.Debugf("Failed to record usage of context '%s': %v", contextName, err)
+		}
+	}
+
+	command := exec.Command(args[0], args[1:]...) //nolint:gosec // Running the user's command is the purpose of track
+	command.Stdin = o.stdin
+	command.Stdout = o.stdout
+	command.Stderr = o.stderr
+
+	err := command.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitCodeError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
+
+// invokedContext determines the context a kubectl invocation will use, or "" if unknown.
+func (o *trackOptions) invokedContext(kubectlArgs []string, log *logger.Logger) string {
+	if contextName := flagValue(kubectlArgs, "context"); contextName != "" {
+		return contextName
+	}
+
+	var paths []string
+	if kubeconfigPath := flagValue(kubectlArgs, "kubeconfig"); kubeconfigPath != "" {
+		paths = []string{expandHome(kubeconfigPath)}
+	} else if env := os.Getenv("KUBECONFIG"); env != "" {
+		paths = filepath.SplitList(env)
+	} else {
+		var err error
+		if paths, err = o.kubeconfigPaths(); err != nil {
+			log.Debugf("Could not determine current context: %v", err)
+			return ""
+		}
+	}
+
+	multi, err := kubeconfig.LoadMulti(paths)
+	if err != nil {
+		log.Debugf("Could not determine current context: %v", err)
+		return ""
+	}
+	return multi.Merged.CurrentContext
+}
+
+// flagValue returns the value of --name given as "--name value" or "--name=value".
+func flagValue(args []string, name string) string {
+	flag := "--" + name
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+		if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// usageFile returns the path of the file context usage is recorded in.
+func usageFile() string {
+	return filepath.Join(userHomeDir(), ".kubectx-manager_usage.json")
+}
+
+func recordUsage(contextName string, at time.Time) error {
+	return usage.Update(usageFile(), func(store *usage.Store) {
+		store.Record(contextName, at)
+	})
+}
+
+// renameUsage records that the context oldName was renamed to newName.
+func renameUsage(oldName, newName string) error {
+	return usage.Update(usageFile(), func(store *usage.Store) {
+		store.Rename(oldName, newName)
+	})
+}
+
+// recordSwitch records a switch from the context from to the context to,
+// which uses to and lets switch - return to from.
+func recordSwitch(from, to string, at time.Time) error {
+	return usage.Update(usageFile(), func(store *usage.Store) {
+		store.Record(to, at)
+		if from != to {
+			store.RecordSwitch(from)
+		}
+	})
+}
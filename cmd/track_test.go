@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/usage"
+)
+
+func TestFlagValue(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"get", "pods", "--context", "prod"}, "prod"},
+		{[]string{"--context=dev", "get", "pods"}, "dev"},
+		{[]string{"get", "pods"}, ""},
+		{[]string{"exec", "pod", "--", "--context", "ignored"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := flagValue(tt.args, "context"); got != tt.expected {
+			t.Errorf("flagValue(%v) = %q, expected %q", tt.args, got, tt.expected)
+		}
+	}
+}
+
+func TestTrackRecordsContext(t *testing.T) {
+	if _, err := exec.LookPath("true"); err != nil {
+		t.Skip("true is not available")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	kubeconfigPath := filepath.Join(home, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	for _, args := range [][]string{{"true", "get", "pods"}, {"true", "--context", "dev"}} {
+		root := NewRootCommand()
+		root.SetArgs(append([]string{"track", "--"}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	store, err := usage.Load(usageFile())
+	if err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+	for _, name := range []string{"prod", "dev"} {
+		if _, ok := store.Get(name); !ok {
+			t.Errorf("Expected usage of context %q to be recorded", name)
+		}
+	}
+}
+
+func TestTrackExitCode(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false is not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"track", "--", "false", "--context", "prod"})
+	err := root.Execute()
+
+	var exitErr *ExitCodeError
+	if !errors.As(err, &exitErr) || exitErr.Code != 1 {
+		t.Errorf("Expected exit code 1, got %v", err)
+	}
+}
+
+func TestTrackShellFunction(t *testing.T) {
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"track", "--shell-function", "bash"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "kubectx-manager track -- kubectl") {
+		t.Errorf("Expected a kubectl wrapper, got %q", out.String())
+	}
+
+	root = NewRootCommand()
+	root.SetArgs([]string{"track", "--shell-function", "tcsh"})
+	if err := root.Execute(); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+}
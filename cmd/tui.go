@@ -0,0 +1,272 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/tui"
+)
+
+// tuiOptions holds the flag values for a single invocation of the tui command.
+type tuiOptions struct {
+	*globalOptions
+	configFile string
+	auth       authCheckFlags
+	authCheck  bool
+}
+
+func newTUICommand(global *globalOptions) *cobra.Command {
+	opts := &tuiOptions{globalOptions: global}
+
+	tuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse and manage contexts in a full-screen terminal UI",
+		Long: `Show the contexts of the kubeconfig in a navigable list with their server, namespace,
+whitelist status and auth status. Type / to filter the list, and act on the selected context:
+enter switches to it, r renames it, d deletes it, e exports it with its cluster and user to a
+file, and a checks its authentication. With --auth-check every context is checked on start.
+Like the other commands, a backup is created before the kubeconfig is modified.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return opts.run()
+		},
+	}
+
+	tuiCmd.Flags().BoolVarP(&opts.authCheck, "auth-check", "a", false, "Check the authentication of every context on start")
+	opts.auth.addFlags(tuiCmd)
+	addConfigFlag(tuiCmd, &opts.configFile)
+
+	return tuiCmd
+}
+
+func (o *tuiOptions) run() error {
+	path, err := o.singleKubeconfig("tui")
+	if err != nil {
+		return err
+	}
+	if !o.isInteractive() {
+		return fmt.Errorf("the tui command needs a terminal: %w", errNotTerminal)
+	}
+	cfg, err := o.loadConfig(o.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	authOpts, err := o.auth.options(cfg)
+	if err != nil {
+		return err
+	}
+
+	browser := &contextBrowser{
+		globalOptions: o.globalOptions,
+		path:          path,
+		cfg:           cfg,
+		authOpts:      authOpts,
+		auth:          make(map[string]string),
+		// Messages would draw over the screen; actions report through the status line
+		log: logger.New(logger.Options{Level: logger.LevelError}),
+	}
+	if o.authCheck {
+		kConfig, err := kubeconfig.Load(path)
+		if err != nil {
+			return fmt.Errorf("failed to load kubeconfig: %w", err)
+		}
+		for _, name := range kConfig.GetContextNames() {
+			browser.checkAuth(kConfig, name)
+		}
+	}
+	rows, err := browser.Rows()
+	if err != nil {
+		return err
+	}
+	return tui.Run(o.stdin, o.interactiveOut(), tui.New("kubectx-manager: "+path, rows, browser))
+}
+
+// contextBrowser carries out the actions of the tui command on the kubeconfig.
+// Each action loads the kubeconfig afresh under its lock, so changes made by
+// other tools while the browser is open are not lost.
+type contextBrowser struct {
+	*globalOptions
+	cfg      *config.Config
+	log      *logger.Logger
+	authOpts kubeconfig.AuthCheckOptions
+	// auth holds the result of each context's last auth check
+	auth map[string]string
+	path string
+}
+
+// Rows lists the contexts of the kubeconfig sorted by name.
+func (b *contextBrowser) Rows() ([]tui.Row, error) {
+	kConfig, err := kubeconfig.Load(b.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	entries := buildInventory(kConfig)
+	rows := make([]tui.Row, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, tui.Row{
+			Name:        e.Name,
+			Cluster:     e.Cluster,
+			Server:      e.Server,
+			User:        e.User,
+			Namespace:   e.Namespace,
+			Auth:        b.auth[e.Name],
+			Current:     e.Current,
+			Whitelisted: b.cfg.MatchesContext(e.info()),
+		})
+	}
+	return rows, nil
+}
+
+// Switch makes the context current.
+func (b *contextBrowser) Switch(name string) (string, error) {
+	var previous string
+	err := b.update(&audit.Record{Summary: "Switch to context " + name, CurrentContext: name}, nil, func(kConfig *kubeconfig.Config) error {
+		if kConfig.GetContext(name) == nil {
+			return fmt.Errorf("context '%s' not found", name)
+		}
+		previous = kConfig.CurrentContext
+		kConfig.CurrentContext = name
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := recordSwitch(previous, name, time.Now()); err != nil {
+		b.log.Debugf("Failed to record usage of context '%s': %v", name, err)
+	}
+	return fmt.Sprintf("Switched to context '%s'", name), nil
+}
+
+// Rename renames the context.
+func (b *contextBrowser) Rename(oldName, newName string) (string, error) {
+	r := &audit.Record{Summary: fmt.Sprintf("Rename context %s to %s", oldName, newName), Renamed: map[string]string{oldName: newName}}
+	err := b.update(r, nil, func(kConfig *kubeconfig.Config) error {
+		return kubeconfig.RenameContext(kConfig, oldName, newName)
+	})
+	if err != nil {
+		return "", err
+	}
+	if result, ok := b.auth[oldName]; ok {
+		b.auth[newName] = result
+		delete(b.auth, oldName)
+	}
+	if err := renameUsage(oldName, newName); err != nil {
+		b.log.Debugf("Failed to rename context '%s' in the usage file: %v", oldName, err)
+	}
+	return fmt.Sprintf("Renamed context '%s' to '%s'", oldName, newName), nil
+}
+
+// Delete removes the context with the clusters and users only it used.
+func (b *contextBrowser) Delete(name string) (string, error) {
+	plan := &removal{contexts: []string{name}}
+	err := b.update(&audit.Record{Summary: "Remove contexts " + name, Removed: plan.contexts}, plan, func(kConfig *kubeconfig.Config) error {
+		if kConfig.GetContext(name) == nil {
+			return fmt.Errorf("context '%s' not found", name)
+		}
+		if rule, ok := b.cfg.Protected(contextInfo(kConfig, name)); ok {
+			return fmt.Errorf("context '%s' is protected by rule '%s'", name, rule.Name)
+		}
+		return kubeconfig.RemoveContexts(kConfig, plan.contexts)
+	})
+	if err != nil {
+		return "", err
+	}
+	delete(b.auth, name)
+	return fmt.Sprintf("Deleted context '%s'", name), nil
+}
+
+// Export writes the context with its cluster and user to a new kubeconfig.
+func (b *contextBrowser) Export(name, path string) (string, error) {
+	kConfig, err := kubeconfig.Load(b.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if kConfig.GetContext(name) == nil {
+		return "", fmt.Errorf("context '%s' not found", name)
+	}
+	path = expandHome(path)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("%s already exists", path)
+	}
+	exported, err := exportConfig(kConfig, []string{name}, false)
+	if err != nil {
+		return "", err
+	}
+	if err := kubeconfig.Save(exported, path); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return fmt.Sprintf("Exported context '%s' to %s", name, path), nil
+}
+
+// CheckAuth checks the context's authentication.
+func (b *contextBrowser) CheckAuth(name string) (string, error) {
+	kConfig, err := kubeconfig.Load(b.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return fmt.Sprintf("Auth of '%s': %s", name, b.checkAuth(kConfig, name)), nil
+}
+
+// checkAuth checks the context's authentication and records the result.
+func (b *contextBrowser) checkAuth(kConfig *kubeconfig.Config, name string) string {
+	status := kubeconfig.CheckAuth(kConfig, name, b.authOpts)
+	saveAuthCache(b.authOpts, b.log)
+	result := "valid"
+	if !status.Valid {
+		result = "invalid: " + status.Reason
+	}
+	b.auth[name] = result
+	return result
+}
+
+// update applies change to the kubeconfig under its lock, backing it up
+// first, and records it as r. If plan is set it is recorded in the backup's
+// manifest.
+func (b *contextBrowser) update(r *audit.Record, plan *removal, change func(*kubeconfig.Config) error) error {
+	locks, err := kubeconfig.LockAll([]string{b.path})
+	if err != nil {
+		return err
+	}
+	defer b.unlock(locks)
+
+	kConfig, err := kubeconfig.Load(b.path)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if err := change(kConfig); err != nil {
+		return err
+	}
+
+	backupPath, err := b.createBackup(b.path, b.log)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	if plan != nil {
+		b.writeManifest(backupPath, b.path, plan, b.log)
+	}
+	if err := kubeconfig.Save(kConfig, b.path); err != nil {
+		return fmt.Errorf("failed to save kubeconfig: %w", err)
+	}
+	r.Kubeconfigs, r.Backups = []string{b.path}, []string{backupPath}
+	b.recordChange(r, b.log)
+	return nil
+}
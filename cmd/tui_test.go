@@ -0,0 +1,115 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/config"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+)
+
+func newTestBrowser(t *testing.T) *contextBrowser {
+	t.Helper()
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	return &contextBrowser{
+		globalOptions: &globalOptions{backupDir: filepath.Join(tmpDir, "backups")},
+		path:          kubeconfigPath,
+		cfg:           cfg,
+		auth:          make(map[string]string),
+		log:           logger.New(logger.Options{Level: logger.LevelError}),
+	}
+}
+
+func TestContextBrowserRows(t *testing.T) {
+	b := newTestBrowser(t)
+
+	rows, err := b.Rows()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "dev" || rows[1].Name != "prod" {
+		t.Fatalf("Expected dev and prod sorted by name, got %+v", rows)
+	}
+	if rows[0].Whitelisted || !rows[1].Whitelisted || !rows[1].Current || rows[1].Namespace != "apps" {
+		t.Errorf("Unexpected rows %+v", rows)
+	}
+}
+
+func TestContextBrowserActions(t *testing.T) {
+	b := newTestBrowser(t)
+
+	if _, err := b.Switch("dev"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if _, err := b.Rename("dev", "development"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if _, err := b.Delete("prod"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	kConfig, err := kubeconfig.Load(b.path)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if kConfig.CurrentContext != "development" || len(kConfig.Contexts) != 1 || kConfig.GetCluster("prod-cluster") != nil {
+		t.Errorf("Unexpected kubeconfig after switch, rename and delete: %+v", kConfig)
+	}
+
+	backups, err := kubeconfig.FindBackups(b.path, b.backupDir)
+	if err != nil || len(backups) == 0 {
+		t.Errorf("Expected the actions to back up the kubeconfig, got %d backups (%v)", len(backups), err)
+	}
+
+	if _, err := b.Delete("prod"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected deleting a missing context to fail, got %v", err)
+	}
+}
+
+func TestContextBrowserExport(t *testing.T) {
+	b := newTestBrowser(t)
+	exportPath := filepath.Join(t.TempDir(), "prod.kubeconfig")
+
+	if _, err := b.Export("prod", exportPath); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	exported, err := kubeconfig.Load(exportPath)
+	if err != nil {
+		t.Fatalf("Failed to load export: %v", err)
+	}
+	if exported.CurrentContext != "prod" || len(exported.Contexts) != 1 ||
+		exported.GetCluster("prod-cluster") == nil || exported.GetUser("prod-user") == nil {
+		t.Errorf("Unexpected export %+v", exported)
+	}
+
+	if _, err := b.Export("prod", exportPath); err == nil {
+		t.Errorf("Expected exporting over an existing file to fail")
+	}
+}
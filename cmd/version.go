@@ -13,24 +13,105 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"runtime"
 
 	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/release"
 )
 
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Show version information",
-	Long:  "Display version, build commit, and build date information for kubectx-manager",
-	RunE:  runVersion,
+// versionInfo is the structured form of the version command output.
+type versionInfo struct {
+	Update    *updateInfo `json:"update,omitempty" yaml:"update,omitempty"`
+	Version   string      `json:"version" yaml:"version"`
+	GitCommit string      `json:"gitCommit" yaml:"gitCommit"`
+	BuildDate string      `json:"buildDate" yaml:"buildDate"`
+	GoVersion string      `json:"goVersion" yaml:"goVersion"`
+	Platform  string      `json:"platform" yaml:"platform"`
+	Backend   string      `json:"kubeconfigBackend" yaml:"kubeconfigBackend"`
+}
+
+// updateInfo reports the result of a --check query against GitHub releases.
+type updateInfo struct {
+	LatestVersion   string `json:"latestVersion" yaml:"latestVersion"`
+	URL             string `json:"url,omitempty" yaml:"url,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable" yaml:"updateAvailable"`
+}
+
+// versionOptions holds the flag values for a single invocation of the version command.
+type versionOptions struct {
+	*globalOptions
+	releases *release.Client
+	check    bool
 }
 
-func runVersion(_ *cobra.Command, _ []string) error {
-	fmt.Printf("kubectx-manager version %s\n", Version)
-	fmt.Printf("Git commit: %s\n", GitCommit)
-	fmt.Printf("Build date: %s\n", BuildDate)
-	fmt.Printf("Go version: %s\n", runtime.Version())
-	fmt.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+func newVersionCommand(global *globalOptions) *cobra.Command {
+	opts := &versionOptions{globalOptions: global, releases: release.NewClient()}
+
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version information",
+		Long:  "Display version, build commit, and build date information for kubectx-manager",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return opts.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	versionCmd.Flags().BoolVar(&opts.check, "check", false, "Check GitHub releases for a newer version")
+
+	return versionCmd
+}
+
+func (o *versionOptions) run(ctx context.Context, out io.Writer) error {
+	if err := o.requireFormats("version", outputText, outputJSON, outputYAML); err != nil {
+		return err
+	}
+
+	info := versionInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Backend:   kubeconfig.Backend,
+	}
+
+	if o.check {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		latest, err := o.releases.Latest(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+		info.Update = &updateInfo{
+			LatestVersion:   latest.TagName,
+			URL:             latest.HTMLURL,
+			UpdateAvailable: release.IsNewer(Version, latest.TagName),
+		}
+	}
+
+	if o.isStructured() {
+		return o.printStructured(out, info)
+	}
+
+	fmt.Fprintf(out, "kubectx-manager version %s\n", info.Version)
+	fmt.Fprintf(out, "Git commit: %s\n", info.GitCommit)
+	fmt.Fprintf(out, "Build date: %s\n", info.BuildDate)
+	fmt.Fprintf(out, "Go version: %s\n", info.GoVersion)
+	fmt.Fprintf(out, "OS/Arch: %s\n", info.Platform)
+	fmt.Fprintf(out, "Kubeconfig backend: %s\n", info.Backend)
+
+	if info.Update != nil {
+		if info.Update.UpdateAvailable {
+			fmt.Fprintf(out, "A newer release is available: %s (%s)\n", info.Update.LatestVersion, info.Update.URL)
+		} else {
+			fmt.Fprintf(out, "You are running the latest release (%s)\n", info.Update.LatestVersion)
+		}
+	}
 	return nil
 }
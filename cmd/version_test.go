@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/internal/release"
+)
+
+func TestVersionJSONOutput(t *testing.T) {
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"version", "-o", "json"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var info versionInfo
+	if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if info.Version != Version || info.GitCommit != GitCommit || info.BuildDate != BuildDate {
+		t.Errorf("Unexpected version info: %+v", info)
+	}
+	if info.Update != nil {
+		t.Error("Update info should be omitted without --check")
+	}
+}
+
+func TestVersionCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"tag_name":"v9.9.9","html_url":"https://example.com/releases/v9.9.9"}`))
+	}))
+	defer server.Close()
+
+	oldVersion := Version
+	Version = "v1.0.0"
+	defer func() { Version = oldVersion }()
+
+	var out bytes.Buffer
+	opts := &versionOptions{
+		globalOptions: &globalOptions{output: outputText},
+		releases:      &release.Client{HTTPClient: server.Client(), BaseURL: server.URL},
+		check:         true,
+	}
+	if err := opts.run(context.Background(), &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "A newer release is available: v9.9.9") {
+		t.Errorf("Expected update notice, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,148 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+	"github.com/che-incubator/kubectx-manager/internal/logger"
+	"github.com/che-incubator/kubectx-manager/internal/schedule"
+)
+
+// defaultWatchInterval is how often watch cleans up without --interval
+const defaultWatchInterval = "24h"
+
+// watchOptions holds the flag values for a single invocation of the watch command.
+type watchOptions struct {
+	rootOptions
+	interval string
+}
+
+func newWatchCommand(global *globalOptions) *cobra.Command {
+	opts := &watchOptions{rootOptions: rootOptions{globalOptions: global}}
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Run the cleanup periodically until stopped",
+		Long: `Run the cleanup, with the same flags and configuration file as kubectx-manager itself,
+every --interval until interrupted, so stale contexts never accumulate.
+
+--interval takes either an age such as 24h or 7d, in which case the first cleanup runs at once,
+or a cron expression such as "0 3 * * *" (minute, hour, day of month, month, day of week) or
+@daily, in which case each cleanup runs at the next time it matches.
+
+Every run is recorded in the audit log (see history), including with --dry-run, which reports
+the contexts the policy would remove without removing them. A failed run is logged and the next
+one still happens.`,
+		Example: `  # Clean up once a day
+  kubectx-manager watch
+
+  # Report what the policy would remove every Monday at 9:00, without removing anything
+  kubectx-manager watch --interval "0 9 * * mon" --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return opts.run(ctx, cmd.OutOrStdout())
+		},
+	}
+
+	opts.addCleanupFlags(watchCmd)
+	watchCmd.Flags().StringVar(&opts.interval, "interval", defaultWatchInterval,
+		`How often to clean up: an age such as 24h or 7d, or a cron expression such as "0 3 * * *"`)
+
+	return watchCmd
+}
+
+// parseSchedule parses --interval: a cron expression if it has several
+// fields or starts with @, otherwise an age.
+func parseSchedule(interval string) (schedule.Schedule, error) {
+	interval = strings.TrimSpace(interval)
+	if strings.HasPrefix(interval, "@") || len(strings.Fields(interval)) > 1 {
+		return schedule.ParseCron(interval)
+	}
+	every, err := kubeconfig.ParseAge(interval)
+	if err != nil || every <= 0 {
+		return nil, fmt.Errorf("invalid --interval '%s': expected an age such as 24h or 7d, or a cron expression", interval)
+	}
+	return schedule.Every(every), nil
+}
+
+func (o *watchOptions) run(ctx context.Context, out io.Writer) error {
+	sched, err := parseSchedule(o.interval)
+	if err != nil {
+		return err
+	}
+	log := o.newLogger()
+
+	next := time.Now()
+	if _, isCron := sched.(*schedule.Cron); isCron {
+		next = sched.Next(next)
+	}
+	for {
+		if next.IsZero() {
+			return fmt.Errorf("--interval '%s' never matches", o.interval)
+		}
+		if wait := time.Until(next); wait > 0 {
+			log.Infof("Next cleanup at %s", next.Format("2006-01-02 15:04:05"))
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				log.Infof("Stopped watching")
+				// Stopping is how watch ends, not a failure
+				o.exitCode = exitCodeNothingToDo
+				return nil
+			case <-timer.C:
+			}
+		}
+		if err := o.runOnce(out, log); err != nil {
+			log.Errorf("Cleanup failed: %v", err)
+		}
+		next = sched.Next(time.Now())
+	}
+}
+
+// runOnce runs the cleanup, recording a dry run that finds contexts to
+// remove in the audit log; the changes of a real run are recorded as they
+// are made.
+func (o *watchOptions) runOnce(out io.Writer, log *logger.Logger) error {
+	results, err := o.cleanupAll(log)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if !result.DryRun || len(result.RemovedContexts) == 0 {
+			continue
+		}
+		o.appendAudit(&audit.Record{
+			Summary:     "Clean up contexts " + listSubject(result.RemovedContexts),
+			Kubeconfigs: filepath.SplitList(result.Kubeconfig),
+			Removed:     result.RemovedContexts,
+			DryRun:      true,
+		}, log)
+	}
+	return o.printResult(out, results)
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/audit"
+	"github.com/che-incubator/kubectx-manager/internal/schedule"
+)
+
+func TestParseSchedule(t *testing.T) {
+	sched, err := parseSchedule("7d")
+	if err != nil || sched != schedule.Every(7*24*time.Hour) {
+		t.Errorf("Expected every 7 days, got %v, %v", sched, err)
+	}
+	for _, interval := range []string{"0 3 * * *", "@daily"} {
+		if sched, err := parseSchedule(interval); err != nil {
+			t.Errorf("Failed to parse %q: %v", interval, err)
+		} else if _, ok := sched.(*schedule.Cron); !ok {
+			t.Errorf("Expected %q to be a cron expression, got %T", interval, sched)
+		}
+	}
+	for _, interval := range []string{"0", "soon", "0 3 * *"} {
+		if _, err := parseSchedule(interval); err == nil {
+			t.Errorf("Expected an error for %q", interval)
+		}
+	}
+}
+
+func TestWatchDryRun(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(listTestKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	configPath := filepath.Join(tmpDir, "ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0600); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+
+	// An interval runs the first cleanup at once; watch then stops while
+	// waiting for the next one, as the context is already done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	root := NewRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"watch", "--interval", "1h", "--dry-run", "--kubeconfig", kubeconfigPath, "--config", configPath})
+	if err := root.ExecuteContext(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Contexts to remove:") || !strings.Contains(out.String(), "Stopped watching") {
+		t.Errorf("Expected one dry run before stopping, got:\n%s", out.String())
+	}
+	if data, err := os.ReadFile(kubeconfigPath); err != nil || string(data) != listTestKubeconfig {
+		t.Errorf("Dry run modified the kubeconfig")
+	}
+	records, err := audit.Read(auditFile())
+	if err != nil {
+		t.Fatalf("Failed to read the audit log: %v", err)
+	}
+	if len(records) != 1 || !records[0].DryRun || strings.Join(records[0].Removed, ",") != "dev" ||
+		records[0].Command != "kubectx-manager watch" {
+		t.Errorf("Expected the dry run in the audit log, got %+v", records)
+	}
+}
@@ -17,6 +17,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -24,6 +25,28 @@ import (
 	"testing"
 )
 
+// Process exit codes, see cmd/errors.go
+const (
+	exitCodeChanged      = 2
+	exitCodeAuthFailures = 3
+	exitCodeError        = 10
+)
+
+// runExitCode runs cmd and returns its exit code, failing the test if the
+// command could not be started at all.
+func runExitCode(t *testing.T, cmd *exec.Cmd) int {
+	t.Helper()
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("Failed to run %s: %v", cmd.Path, err)
+	}
+	return 0
+}
+
 func TestIntegrationDryRun(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test in short mode")
@@ -133,9 +156,9 @@ users:
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err = cmd.Run()
-	if err != nil {
-		t.Fatalf("Command failed: %v\nOutput: %s", err, output.String())
+	// A dry run that finds contexts to remove exits with exitCodeChanged
+	if code := runExitCode(t, cmd); code != exitCodeChanged {
+		t.Fatalf("Expected exit code %d, got %d\nOutput: %s", exitCodeChanged, code, output.String())
 	}
 
 	outputStr := output.String()
@@ -249,34 +272,37 @@ users:
 		t.Fatalf("Failed to build binary: %v", err)
 	}
 
-	// Run actual cleanup (non-interactive by default)
+	// Run actual cleanup (non-interactive by default), keeping backups in the test
+	dataHome := filepath.Join(tmpDir, "data")
 	cmd = exec.CommandContext(context.Background(), binaryPath, "--config", configPath, "--kubeconfig", kubeconfigPath)
+	cmd.Env = append(os.Environ(), "HOME="+tmpDir, "XDG_DATA_HOME="+dataHome, "XDG_STATE_HOME="+filepath.Join(tmpDir, "state"))
 
 	var output bytes.Buffer
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err = cmd.Run()
-	if err != nil {
-		t.Fatalf("Command failed: %v\nOutput: %s", err, output.String())
+	if code := runExitCode(t, cmd); code != exitCodeChanged {
+		t.Fatalf("Expected exit code %d, got %d\nOutput: %s", exitCodeChanged, code, output.String())
 	}
 
-	// Verify backup creation
-	backupFiles := []string{}
-	entries, err := os.ReadDir(tmpDir)
+	// Verify backup creation in a subfolder of $XDG_DATA_HOME/kubectx-manager/backups
+	matches, err := filepath.Glob(filepath.Join(dataHome, "kubectx-manager", "backups", "*", "kubeconfig.backup.*"))
 	if err != nil {
-		t.Fatalf("Failed to read temp dir: %v", err)
+		t.Fatalf("Failed to list backups: %v", err)
 	}
-
-	for _, entry := range entries {
-		if strings.Contains(entry.Name(), "kubeconfig.backup.") {
-			backupFiles = append(backupFiles, entry.Name())
+	backupFiles := []string{}
+	for _, match := range matches {
+		// Skip the JSON manifest written next to each backup
+		if !strings.HasSuffix(match, ".json") {
+			backupFiles = append(backupFiles, match)
 		}
 	}
-
 	if len(backupFiles) != 1 {
 		t.Errorf("Expected 1 backup file, found %d: %v", len(backupFiles), backupFiles)
 	}
+	if legacy, _ := filepath.Glob(kubeconfigPath + ".backup.*"); len(legacy) != 0 {
+		t.Errorf("Expected no backup next to the kubeconfig, found %v", legacy)
+	}
 
 	// Read modified kubeconfig
 	modifiedKubeconfig, err := os.ReadFile(kubeconfigPath)
@@ -376,33 +402,66 @@ users:
 		t.Fatalf("Failed to build binary: %v", err)
 	}
 
-	// Test restore command (just list backups)
-	cmd = exec.CommandContext(context.Background(), binaryPath, "restore", "--kubeconfig", kubeconfigPath)
+	env := append(os.Environ(), "HOME="+tmpDir, "XDG_DATA_HOME="+filepath.Join(tmpDir, "data"))
 
-	// Provide input to cancel the restore
-	cmd.Stdin = strings.NewReader("0\n")
+	// List the backups
+	cmd = exec.CommandContext(context.Background(), binaryPath, "backup", "list", "--kubeconfig", kubeconfigPath)
+	cmd.Env = env
 
 	var output bytes.Buffer
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err = cmd.Run()
-	if err != nil {
-		t.Fatalf("Restore command failed: %v\nOutput: %s", err, output.String())
+	if code := runExitCode(t, cmd); code != 0 {
+		t.Fatalf("Backup list failed with exit code %d\nOutput: %s", code, output.String())
 	}
 
 	outputStr := output.String()
 
 	// Verify backup discovery and listing
-	if !strings.Contains(outputStr, "Available backups:") {
-		t.Errorf("Expected backup listing, got: %s", outputStr)
-	}
 	if !strings.Contains(outputStr, "kubeconfig.backup.20231201-120000") {
 		t.Errorf("Expected backup file to be listed, got: %s", outputStr)
 	}
 	if !strings.Contains(outputStr, "2023-12-01 12:00:00") {
 		t.Errorf("Expected formatted timestamp, got: %s", outputStr)
 	}
+
+	// Restoring with piped stdin cannot prompt for the backup to restore
+	cmd = exec.CommandContext(context.Background(), binaryPath, "restore", "--kubeconfig", kubeconfigPath)
+	cmd.Env = env
+	cmd.Stdin = strings.NewReader("0\n")
+	output.Reset()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if code := runExitCode(t, cmd); code != exitCodeError {
+		t.Fatalf("Expected exit code %d without a terminal, got %d\nOutput: %s", exitCodeError, code, output.String())
+	}
+	if !strings.Contains(output.String(), "stdin is not a terminal; pass --from or --latest") {
+		t.Errorf("Expected the non-interactive error, got: %s", output.String())
+	}
+
+	// Restore the newest backup without prompting
+	cmd = exec.CommandContext(context.Background(), binaryPath, "restore", "--latest", "--yes", "--kubeconfig", kubeconfigPath)
+	cmd.Env = env
+	output.Reset()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if code := runExitCode(t, cmd); code != exitCodeChanged {
+		t.Fatalf("Expected exit code %d, got %d\nOutput: %s", exitCodeChanged, code, output.String())
+	}
+	if !strings.Contains(output.String(), "Selected backup: kubeconfig.backup.20231201-120000") {
+		t.Errorf("Expected the newest backup to be selected, got: %s", output.String())
+	}
+
+	restored, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to read restored kubeconfig: %v", err)
+	}
+	if !strings.Contains(string(restored), "current-context: backup-context") {
+		t.Errorf("Expected the backup to be restored, got:\n%s", restored)
+	}
 }
 
 func TestIntegrationAuthCheck(t *testing.T) {
@@ -471,16 +530,17 @@ users:
 	}
 
 	// Run with auth-check flag
-	cmd = exec.CommandContext(context.Background(), binaryPath, "--auth-check", "--dry-run", "--verbose",
+	// Check offline, the example.com clusters are not reachable
+	cmd = exec.CommandContext(context.Background(), binaryPath, "--auth-check", "--offline", "--dry-run", "--verbose",
 		"--config", configPath, "--kubeconfig", kubeconfigPath)
 
 	var output bytes.Buffer
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err = cmd.Run()
-	if err != nil {
-		t.Fatalf("Auth check command failed: %v\nOutput: %s", err, output.String())
+	// empty-user-context fails the check
+	if code := runExitCode(t, cmd); code != exitCodeAuthFailures {
+		t.Fatalf("Expected exit code %d, got %d\nOutput: %s", exitCodeAuthFailures, code, output.String())
 	}
 
 	outputStr := output.String()
@@ -547,9 +607,8 @@ users:
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err = cmd.Run()
-	if err != nil {
-		t.Fatalf("Quiet mode command failed: %v\nOutput: %s", err, output.String())
+	if code := runExitCode(t, cmd); code != exitCodeChanged {
+		t.Fatalf("Expected exit code %d, got %d\nOutput: %s", exitCodeChanged, code, output.String())
 	}
 
 	outputStr := output.String()
@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// Package audit keeps a log of the changes made to kubeconfigs, one JSON
+// record per line, appended to and never rewritten.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// The audit log names contexts and backups; keep it private to the owner
+	auditFileMode = 0600
+	auditDirMode  = 0700
+)
+
+// Record describes one change made to one or more kubeconfigs.
+type Record struct {
+	Time time.Time `json:"time" yaml:"time"`
+	// Command is the full command path, e.g. "kubectx-manager remove"
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Flags holds the flags set on the command line, by name
+	Flags map[string]string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	// Summary describes the change, e.g. "Rename context dev to development"
+	Summary     string   `json:"summary" yaml:"summary"`
+	Kubeconfigs []string `json:"kubeconfigs" yaml:"kubeconfigs"`
+	// Removed, Restored and Renamed (old name to new name) list the contexts changed
+	Removed  []string          `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Restored []string          `json:"restored,omitempty" yaml:"restored,omitempty"`
+	Renamed  map[string]string `json:"renamed,omitempty" yaml:"renamed,omitempty"`
+	// CurrentContext is set when the change made another context current
+	CurrentContext string `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+	// RestoredFrom is the backup contexts were restored from
+	RestoredFrom string `json:"restoredFrom,omitempty" yaml:"restoredFrom,omitempty"`
+	// Backups lists the backups taken before the change
+	Backups []string `json:"backups,omitempty" yaml:"backups,omitempty"`
+	// DryRun is set for changes a dry run reported without making them
+	DryRun bool `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+}
+
+// DefaultPath returns the path of the audit log: kubectx-manager/audit.log
+// under $XDG_STATE_HOME, or ~/.local/state when it is unset. It returns ""
+// if the home directory cannot be determined.
+func DefaultPath() string {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "kubectx-manager", "audit.log")
+}
+
+// Append adds r to the audit log at path, creating it and its directory if needed.
+func Append(path string, r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), auditDirMode); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, auditFileMode) //nolint:gosec // Audit log path is derived from the user's home directory
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	// A single write keeps concurrent records from interleaving
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Read returns the records of the audit log at path, oldest first. A
+// missing log has no records.
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path) //nolint:gosec // Audit log path is derived from the user's home directory
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("invalid audit record on line %d of %s: %w", line, path, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "audit.log")
+
+	records, err := Read(path)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("Expected no records in a missing log, got %v, %v", records, err)
+	}
+
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := &Record{Time: at, Command: "kubectx-manager remove", Args: []string{"dev"}, Summary: "Remove contexts dev",
+		Kubeconfigs: []string{"/home/me/.kube/config"}, Removed: []string{"dev"}, Backups: []string{"/backups/config.backup"}}
+	second := &Record{Time: at.Add(time.Minute), Command: "kubectx-manager rename", Summary: "Rename context a to b",
+		Kubeconfigs: []string{"/home/me/.kube/config"}, Renamed: map[string]string{"a": "b"}}
+	for _, r := range []*Record{first, second} {
+		if err := Append(path, r); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat the audit log: %v", err)
+	}
+	if info.Mode().Perm() != auditFileMode {
+		t.Errorf("Expected mode %o, got %o", auditFileMode, info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+		t.Errorf("Expected one line per record, got %q", data)
+	}
+
+	records, err = Read(path)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if !records[0].Time.Equal(at) || records[0].Removed[0] != "dev" || records[0].Backups[0] != "/backups/config.backup" {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[1].Renamed["a"] != "b" || records[1].Summary != "Rename context a to b" {
+		t.Errorf("Unexpected second record: %+v", records[1])
+	}
+
+	if err := os.WriteFile(path, append(data, "not json\n"...), auditFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected an error naming the invalid line, got %v", err)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	if path := DefaultPath(); path != filepath.Join("/state", "kubectx-manager", "audit.log") {
+		t.Errorf("Expected the log under $XDG_STATE_HOME, got %s", path)
+	}
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	if path := DefaultPath(); path != filepath.Join("/home/me", ".local", "state", "kubectx-manager", "audit.log") {
+		t.Errorf("Expected the log under ~/.local/state, got %s", path)
+	}
+}
@@ -16,38 +16,224 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// ErrInvalidPattern is returned when a whitelist or command-line pattern cannot be compiled
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// ErrInvalidAlias is returned when an alias cannot be written to the configuration file
+var ErrInvalidAlias = errors.New("invalid alias")
+
 const (
 	// File permissions for configuration files
 	configFileMode = 0644 // readable by all, writable by owner
 	configDirMode  = 0755 // readable/executable by all, writable by owner
+
+	// aliasSeparator separates an alias from the context name it stands for
+	aliasSeparator = "=>"
+	// settingPrefix starts a line that sets an option ("set name = value")
+	settingPrefix = "set "
+	// serverPrefix starts a pattern matched against the server URL of a
+	// context's cluster instead of the context name
+	serverPrefix = "server:"
+	// protectPrefix starts a line with a pattern of contexts no command may remove
+	protectPrefix = "protect:"
+	// skipAuthPrefix starts a line with a pattern of contexts --auth-check neither probes nor removes
+	skipAuthPrefix = "skip-auth-check:"
+	// includePrefix starts a line naming another configuration file to include
+	includePrefix = "include "
+)
+
+// regexPrefixes start a pattern that is a regular expression instead of a glob
+var regexPrefixes = []string{"regex:", "~"}
+
+// MatchMode is how much of a name a glob pattern has to match.
+type MatchMode string
+
+const (
+	// MatchFull matches the whole name, the default
+	MatchFull MatchMode = "full"
+	// MatchPrefix matches the start of the name
+	MatchPrefix MatchMode = "prefix"
+	// MatchSubstring matches any part of the name
+	MatchSubstring MatchMode = "substring"
+)
+
+// Names that name patterns can match besides the context name and its aliases
+const (
+	MatchCluster = "cluster"
+	MatchUser    = "user"
 )
 
 // Config represents the configuration for kubectx-manager.
-// It contains whitelist patterns used to match contexts that should be ignored during cleanup.
+// It contains whitelist patterns used to match contexts that should be ignored during cleanup,
+// rules deciding what cleanup does with the contexts they match, and friendly aliases for
+// long context names.
 type Config struct {
-	Whitelist []string `yaml:"whitelist"`
-	patterns  []*regexp.Regexp
+	Whitelist []string          `yaml:"whitelist"`
+	Aliases   map[string]string `yaml:"aliases"`
+	// Protect holds the patterns of contexts that no command may remove or
+	// overwrite, whatever other patterns, rules or arguments say
+	Protect []string `yaml:"protect"`
+	// SkipAuthCheck holds the patterns of contexts that --auth-check never
+	// probes, and keeps, such as those only reachable over a VPN
+	SkipAuthCheck []string `yaml:"skipAuthCheck"`
+	// Rules are only read from YAML configuration files
+	Rules []Rule `yaml:"rules"`
+	// AuthTimeout bounds each cluster reachability probe; zero selects the default
+	AuthTimeout time.Duration `yaml:"authTimeout"`
+	// AuthRetries is how many more times an unreachable cluster is probed
+	AuthRetries int `yaml:"authRetries"`
+	// AuthCacheTTL is how long probe results are reused; zero selects the default
+	AuthCacheTTL time.Duration `yaml:"authCacheTTL"`
+	// CertExpiryWindow treats client certificates expiring within it as expired
+	CertExpiryWindow time.Duration `yaml:"certExpiryWindow"`
+	// AuthRemoveOn is the default for --remove-on, validated when applied
+	AuthRemoveOn string `yaml:"authRemoveOn"`
+	// UnreachableGrace is the default for --unreachable-grace, validated when applied
+	UnreachableGrace string `yaml:"unreachableGrace"`
+	// BackupRetention is the default for --backup-retention, validated when applied
+	BackupRetention string `yaml:"backupRetention"`
+	// OlderThan is the default for --older-than, validated when applied
+	OlderThan string `yaml:"olderThan"`
+	// InFileDuplicates is the default for --in-file-duplicates, validated when applied
+	InFileDuplicates string `yaml:"inFileDuplicates"`
+	// TrackCurrentContext makes list record the current context as used
+	// whenever it runs, in addition to switch and track
+	TrackCurrentContext bool `yaml:"trackCurrentContext"`
+	// IgnoreCase makes patterns and rule criteria match regardless of case
+	IgnoreCase bool `yaml:"ignoreCase"`
+	// MatchMode is how much of a name a glob has to match; empty means MatchFull
+	MatchMode MatchMode `yaml:"matchMode"`
+	// MatchAgainst lists the names, MatchCluster and MatchUser, that name
+	// patterns match besides the context name and its aliases
+	MatchAgainst []string `yaml:"matchAgainst"`
+	// Include lists the configuration files this one includes, as written
+	Include []string `yaml:"include"`
+	// Warnings tells what went wrong loading the configuration without
+	// failing it, such as a remote file read from the cache; callers
+	// report them
+	Warnings  []string `yaml:"-"`
+	patterns  []compiledPattern
+	protected []compiledPattern
+	skipAuth  []compiledPattern
+	rules     []compiledRule
+	// settings records the settings applied, in order, for merging layers
+	settings []setting
+	// aliasIndex maps each context name to its sorted aliases
+	aliasIndex map[string][]string
+}
+
+// compiledPattern is a whitelist pattern ready for matching
+type compiledPattern struct {
+	regex *regexp.Regexp
+	// server is set for server: patterns
+	server bool
+	// against is the match-against setting of the file the pattern is from
+	against []string
+}
+
+// setting is a setting as written in a configuration file
+type setting struct {
+	name, value string
 }
 
-// Load reads the configuration file and compiles patterns
+// Load reads the configuration file and compiles patterns, then merges in
+// the files it includes. Files named *.yaml or *.yml use the YAML format,
+// which adds rules; any other file is read line by line.
 func Load(configPath string) (*Config, error) {
-	cfg := &Config{}
+	return load(configPath, nil)
+}
+
+// load is Load for a file included by the files in including, which are
+// checked for include cycles. Only a missing file that nothing includes is
+// created from the template.
+func load(configPath string, including []string) (*Config, error) {
+	if IsRemote(configPath) {
+		return loadRemote(configPath, including)
+	}
 
 	// Check if config file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) && len(including) == 0 {
 		// Create default config file
 		if err := createDefaultConfig(configPath); err != nil {
 			return nil, fmt.Errorf("failed to create default config: %w", err)
 		}
 	}
 
+	cfg, err := loadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.withIncludes(configPath, including)
+}
+
+// loadFile reads a single configuration file and compiles its patterns,
+// leaving the files it includes alone.
+func loadFile(configPath string) (*Config, error) {
+	var cfg *Config
+	var err error
+	if isYAMLConfig(configPath) {
+		cfg, err = loadYAML(configPath)
+	} else {
+		cfg, err = loadLines(configPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Compile patterns
+	for _, pattern := range cfg.Whitelist {
+		compiled, err := cfg.compileContextPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		cfg.patterns = append(cfg.patterns, compiled)
+	}
+	for _, pattern := range cfg.Protect {
+		compiled, err := cfg.compileContextPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		cfg.protected = append(cfg.protected, compiled)
+	}
+	for _, pattern := range cfg.SkipAuthCheck {
+		compiled, err := cfg.compileContextPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		cfg.skipAuth = append(cfg.skipAuth, compiled)
+	}
+
+	return cfg, nil
+}
+
+// compileContextPattern compiles a whitelist, protect or skip-auth-check
+// pattern, which may have a server: prefix, with the matching settings of c.
+func (c *Config) compileContextPattern(pattern string) (compiledPattern, error) {
+	expr, server := strings.CutPrefix(pattern, serverPrefix)
+	regex, err := c.compile(strings.TrimSpace(expr))
+	if err != nil {
+		return compiledPattern{}, fmt.Errorf("%w '%s': %w", ErrInvalidPattern, pattern, err)
+	}
+	return compiledPattern{regex: regex, server: server, against: c.MatchAgainst}, nil
+}
+
+// loadLines reads a line-based configuration file: whitelist patterns,
+// alias lines and settings.
+func loadLines(configPath string) (*Config, error) {
+	cfg := &Config{}
+
 	// Read config file
 	file, err := os.Open(configPath) //nolint:gosec // User-specified config file path is intentional
 	if err != nil {
@@ -69,37 +255,341 @@ func Load(configPath string) (*Config, error) {
 			continue
 		}
 
+		if setting, ok := strings.CutPrefix(line, settingPrefix); ok {
+			name, value, _ := strings.Cut(setting, "=")
+			if err := cfg.applySetting(strings.TrimSpace(name), strings.TrimSpace(value)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if pattern, ok := strings.CutPrefix(line, protectPrefix); ok {
+			cfg.Protect = append(cfg.Protect, strings.TrimSpace(pattern))
+			continue
+		}
+
+		if pattern, ok := strings.CutPrefix(line, skipAuthPrefix); ok {
+			cfg.SkipAuthCheck = append(cfg.SkipAuthCheck, strings.TrimSpace(pattern))
+			continue
+		}
+
+		if include, ok := strings.CutPrefix(line, includePrefix); ok {
+			cfg.Include = append(cfg.Include, strings.TrimSpace(include))
+			continue
+		}
+
+		if _, ok := cutRegexPrefix(strings.TrimPrefix(line, serverPrefix)); ok {
+			// A regular expression may itself contain the alias separator
+			cfg.Whitelist = append(cfg.Whitelist, line)
+			continue
+		}
+
+		if alias, target, ok := strings.Cut(line, aliasSeparator); ok {
+			if err := cfg.addAlias(strings.TrimSpace(alias), strings.TrimSpace(target)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		cfg.Whitelist = append(cfg.Whitelist, line)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	return cfg, nil
+}
 
-	// Compile patterns
-	for _, pattern := range cfg.Whitelist {
-		regex, err := compilePattern(pattern)
+// addAlias records alias as another name for the context target
+func (c *Config) addAlias(alias, target string) error {
+	if alias == "" || target == "" {
+		return fmt.Errorf("invalid alias '%s %s %s': both sides must be non-empty", alias, aliasSeparator, target)
+	}
+	if existing, ok := c.Aliases[alias]; ok && existing != target {
+		return fmt.Errorf("alias '%s' is defined for both '%s' and '%s'", alias, existing, target)
+	}
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[alias] = target
+	c.aliasIndex = nil
+	return nil
+}
+
+// applySetting sets the option name from a "set name = value" line
+func (c *Config) applySetting(name, value string) error {
+	switch name {
+	case "auth-timeout":
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid auth-timeout '%s': expected a positive duration such as 10s", value)
+		}
+		c.AuthTimeout = timeout
+	case "auth-retries":
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return fmt.Errorf("invalid auth-retries '%s': expected a non-negative number", value)
+		}
+		c.AuthRetries = retries
+	case "auth-cache-ttl":
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid auth-cache-ttl '%s': expected a positive duration such as 1h", value)
+		}
+		c.AuthCacheTTL = ttl
+	case "cert-expiry-window":
+		window, err := time.ParseDuration(value)
+		if err != nil || window < 0 {
+			return fmt.Errorf("invalid cert-expiry-window '%s': expected a non-negative duration such as 168h", value)
+		}
+		c.CertExpiryWindow = window
+	case "auth-remove-on":
+		if value == "" {
+			return errors.New("invalid auth-remove-on '': expected auth states such as unauthorized,expired")
+		}
+		c.AuthRemoveOn = value
+	case "unreachable-grace":
+		if value == "" {
+			return errors.New("invalid unreachable-grace '': expected an age such as 7d")
+		}
+		c.UnreachableGrace = value
+	case "backup-retention":
+		if value == "" {
+			return errors.New("invalid backup-retention '': expected a count, an age or both such as 10,30d")
+		}
+		c.BackupRetention = value
+	case "older-than":
+		if value == "" {
+			return errors.New("invalid older-than '': expected an age such as 30d")
+		}
+		c.OlderThan = value
+	case "in-file-duplicates":
+		if value == "" {
+			return errors.New("invalid in-file-duplicates '': expected error, first, last or rename")
+		}
+		c.InFileDuplicates = value
+	case "track-current-context":
+		track, err := strconv.ParseBool(value)
 		if err != nil {
-			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+			return fmt.Errorf("invalid track-current-context '%s': expected true or false", value)
 		}
-		cfg.patterns = append(cfg.patterns, regex)
+		c.TrackCurrentContext = track
+	case "ignore-case":
+		ignore, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid ignore-case '%s': expected true or false", value)
+		}
+		c.IgnoreCase = ignore
+	case "match-mode":
+		switch mode := MatchMode(value); mode {
+		case MatchFull, MatchPrefix, MatchSubstring:
+			c.MatchMode = mode
+		default:
+			return fmt.Errorf("invalid match-mode '%s': expected %s, %s or %s", value, MatchFull, MatchPrefix, MatchSubstring)
+		}
+	case "match-against":
+		c.MatchAgainst = nil
+		for _, name := range strings.Split(value, ",") {
+			switch name = strings.TrimSpace(name); name {
+			case MatchCluster, MatchUser:
+				c.MatchAgainst = append(c.MatchAgainst, name)
+			default:
+				return fmt.Errorf("invalid match-against '%s': expected %s, %s or both, separated by a comma", value, MatchCluster, MatchUser)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown setting '%s'", name)
 	}
+	c.settings = append(c.settings, setting{name, value})
+	return nil
+}
 
-	return cfg, nil
+// ResolveAlias returns the context name an alias stands for.
+// Names that are not aliases are returned unchanged.
+func (c *Config) ResolveAlias(name string) string {
+	if target, ok := c.Aliases[name]; ok {
+		return target
+	}
+	return name
 }
 
-// MatchesWhitelist checks if a context name matches any whitelist pattern
+// AliasesFor returns the sorted aliases defined for a context name
+func (c *Config) AliasesFor(contextName string) []string {
+	if len(c.Aliases) == 0 {
+		return nil
+	}
+	if c.aliasIndex == nil {
+		c.aliasIndex = make(map[string][]string)
+		for alias, target := range c.Aliases {
+			c.aliasIndex[target] = append(c.aliasIndex[target], alias)
+		}
+		for _, aliases := range c.aliasIndex {
+			sort.Strings(aliases)
+		}
+	}
+	return c.aliasIndex[contextName]
+}
+
+// MatchesWhitelist checks if a context name, or any of its aliases, matches a whitelist pattern
+// or a rule that keeps it. Only criteria on the name are considered; use MatchesContext to
+// match the others too.
 func (c *Config) MatchesWhitelist(contextName string) bool {
-	for _, pattern := range c.patterns {
-		if pattern.MatchString(contextName) {
+	return c.MatchesContext(ContextInfo{Name: contextName})
+}
+
+// MatchingPattern returns the first whitelist pattern, or the name of the
+// first rule, that keeps a context name or any of its aliases, and whether
+// there is one
+func (c *Config) MatchingPattern(contextName string) (string, bool) {
+	rule, ok := c.MatchContext(ContextInfo{Name: contextName})
+	if !ok || (rule.Action != ActionKeep && rule.Action != ActionProtect) {
+		return "", false
+	}
+	return rule.Name, true
+}
+
+// patternMatches reports whether pattern matches the names of the context,
+// as nameMatches does, or, for a server: pattern, the server URL
+func (c *Config) patternMatches(pattern compiledPattern, ctx ContextInfo) bool {
+	if pattern.server {
+		return serverMatches(pattern.regex, ctx.Server)
+	}
+	return c.nameMatches(pattern.regex, pattern.against, ctx)
+}
+
+// nameMatches reports whether regex matches the context name or one of its
+// aliases or, as against asks, its cluster or user name
+func (c *Config) nameMatches(regex *regexp.Regexp, against []string, ctx ContextInfo) bool {
+	if regex.MatchString(ctx.Name) {
+		return true
+	}
+	for _, alias := range c.AliasesFor(ctx.Name) {
+		if regex.MatchString(alias) {
+			return true
+		}
+	}
+	for _, name := range against {
+		switch {
+		case name == MatchCluster && ctx.Cluster != "" && regex.MatchString(ctx.Cluster):
+			return true
+		case name == MatchUser && ctx.User != "" && regex.MatchString(ctx.User):
 			return true
 		}
 	}
 	return false
 }
 
-// compilePattern converts a glob-like pattern to a regex
+// serverMatches reports whether pattern matches the server URL as a whole,
+// its host with the port or its host name alone, so that both
+// server:https://api.example.com:6443 and server:*.example.com work
+func serverMatches(pattern *regexp.Regexp, server string) bool {
+	if server == "" {
+		return false
+	}
+	if pattern.MatchString(server) {
+		return true
+	}
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return pattern.MatchString(u.Host) || pattern.MatchString(u.Hostname())
+}
+
+// UnmatchedPatterns returns the whitelist patterns that match none of the given context names.
+// server: patterns and rules are matched on the name only; use UnmatchedContextPatterns to
+// match them fully.
+func (c *Config) UnmatchedPatterns(contextNames []string) []string {
+	contexts := make([]ContextInfo, len(contextNames))
+	for i, name := range contextNames {
+		contexts[i] = ContextInfo{Name: name}
+	}
+	return c.UnmatchedContextPatterns(contexts)
+}
+
+// UnmatchedContextPatterns returns the names of the rules, then the
+// whitelist patterns, that match none of the given contexts. Protect patterns
+// are not reported: protecting a context that does not exist is harmless.
+func (c *Config) UnmatchedContextPatterns(contexts []ContextInfo) []string {
+	var unmatched []string
+	for _, rule := range c.rules {
+		matched := false
+		for _, ctx := range contexts {
+			if c.ruleMatches(rule, ctx) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, rule.Name)
+		}
+	}
+	for i, pattern := range c.patterns {
+		matched := false
+		for _, ctx := range contexts {
+			if c.patternMatches(pattern, ctx) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, c.Whitelist[i])
+		}
+	}
+	return unmatched
+}
+
+// IsPattern reports whether s contains glob metacharacters or is a regular expression
+func IsPattern(s string) bool {
+	_, isRegex := cutRegexPrefix(s)
+	return isRegex || strings.ContainsAny(s, "*?")
+}
+
+// MatchPattern reports whether a context name matches a glob-like pattern
+// or regular expression using the same rules as whitelist entries.
+func MatchPattern(pattern, contextName string) (bool, error) {
+	regex, err := compilePattern(pattern)
+	if err != nil {
+		return false, fmt.Errorf("%w '%s': %w", ErrInvalidPattern, pattern, err)
+	}
+	return regex.MatchString(contextName), nil
+}
+
+// cutRegexPrefix returns pattern without its regex: or ~ prefix, and whether it had one
+func cutRegexPrefix(pattern string) (string, bool) {
+	for _, prefix := range regexPrefixes {
+		if expr, ok := strings.CutPrefix(pattern, prefix); ok {
+			return expr, true
+		}
+	}
+	return pattern, false
+}
+
+// compilePattern converts a glob-like pattern to a regex. A pattern with a
+// regex: or ~ prefix is compiled as given, unanchored, like grep -E.
 func compilePattern(pattern string) (*regexp.Regexp, error) {
+	return compilePatternWith(pattern, MatchFull, false)
+}
+
+// compile compiles a pattern of the configuration file with its matching settings.
+func (c *Config) compile(pattern string) (*regexp.Regexp, error) {
+	return compilePatternWith(pattern, c.MatchMode, c.IgnoreCase)
+}
+
+// compilePatternWith is compilePattern anchoring globs as mode says, and
+// ignoring case if asked. Regular expressions are never anchored.
+func compilePatternWith(pattern string, mode MatchMode, ignoreCase bool) (*regexp.Regexp, error) {
+	flags := ""
+	if ignoreCase {
+		flags = "(?i)"
+	}
+	if expr, ok := cutRegexPrefix(pattern); ok {
+		if strings.TrimSpace(expr) == "" {
+			return nil, errors.New("empty regular expression")
+		}
+		return regexp.Compile(flags + expr)
+	}
+
 	// Escape special regex characters except * and ?
 	escaped := regexp.QuoteMeta(pattern)
 
@@ -107,10 +597,16 @@ func compilePattern(pattern string) (*regexp.Regexp, error) {
 	escaped = strings.ReplaceAll(escaped, `\*`, ".*")
 	escaped = strings.ReplaceAll(escaped, `\?`, ".")
 
-	// Anchor the pattern to match the entire string
-	escaped = "^" + escaped + "$"
+	// Anchor the pattern to match the entire string, or its start
+	switch mode {
+	case MatchSubstring:
+	case MatchPrefix:
+		escaped = "^" + escaped
+	default:
+		escaped = "^" + escaped + "$"
+	}
 
-	return regexp.Compile(escaped)
+	return regexp.Compile(flags + escaped)
 }
 
 // createDefaultConfig creates a default configuration file
@@ -121,14 +617,58 @@ func createDefaultConfig(configPath string) error {
 		return err
 	}
 
+	if isYAMLConfig(configPath) {
+		return os.WriteFile(configPath, []byte(defaultYAMLContent), configFileMode)
+	}
+
 	defaultContent := `# kubectx-manager ignore file (contexts to keep)
 # List context patterns to keep (whitelist)
-# Supports glob patterns: * (any characters) and ? (single character)
+# Supports glob patterns: * (any characters) and ? (single character),
+# and regular expressions after a regex: or ~ prefix (unanchored; add ^ and $)
 # Examples:
 # production-*
 # staging-cluster
 # *-important
 # my-dev-context
+# regex:^(dev|tmp)-\d+-.*
+#
+# A server: prefix matches the server URL, host or host name of the
+# context's cluster instead of the context name:
+# server:*.corp.example.com
+#
+# A protect: prefix protects the contexts a pattern matches: no command
+# removes or overwrites them, whatever other patterns or arguments say:
+# protect: production-*
+#
+# A skip-auth-check: prefix exempts the contexts a pattern matches from
+# --auth-check: they are neither probed nor removed by it, which suits
+# clusters only reachable over a VPN:
+# skip-auth-check: vpn-only-*
+#
+# Aliases give long context names a short, friendly name that patterns and
+# commands accept in place of the real context name:
+# payments-prod => arn:aws:eks:us-east-1:123456789012:cluster/payments
+#
+# Settings tune --auth-check and backups; command-line flags take precedence:
+# set auth-timeout = 10s
+# set auth-retries = 2
+# set auth-cache-ttl = 1h
+# set cert-expiry-window = 168h
+# set auth-remove-on = unauthorized,expired,no-credentials
+# set unreachable-grace = 7d
+# set backup-retention = 10,30d
+# set older-than = 30d
+# set in-file-duplicates = first
+# set track-current-context = true
+#
+# Matching settings apply to the patterns and rules of this file:
+# set ignore-case = true           (match regardless of case)
+# set match-mode = prefix          (full, the default, prefix or substring)
+# set match-against = cluster,user (also match cluster and user names)
+#
+# An include line merges in another configuration file, local (relative to
+# this one) or https://; this file's settings and aliases take precedence:
+# include ~/team/kubectx-policy.yaml
 
 # Add your patterns below (one per line):
 `
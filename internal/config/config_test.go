@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -213,7 +214,7 @@ func TestMatchesWhitelist(t *testing.T) {
 				if err != nil {
 					t.Fatalf("Failed to compile pattern %q: %v", pattern, err)
 				}
-				cfg.patterns = append(cfg.patterns, regex)
+				cfg.patterns = append(cfg.patterns, compiledPattern{regex: regex})
 			}
 
 			result := cfg.MatchesWhitelist(tt.contextName)
@@ -225,6 +226,257 @@ func TestMatchesWhitelist(t *testing.T) {
 	}
 }
 
+func TestUnmatchedPatterns(t *testing.T) {
+	patterns := []string{"production-*", "staging-cluster", "legacy-?"}
+	cfg := &Config{Whitelist: patterns}
+	for _, pattern := range patterns {
+		regex, err := compilePattern(pattern)
+		if err != nil {
+			t.Fatalf("Failed to compile pattern %q: %v", pattern, err)
+		}
+		cfg.patterns = append(cfg.patterns, compiledPattern{regex: regex})
+	}
+
+	unmatched := cfg.UnmatchedPatterns([]string{"production-east", "dev-cluster"})
+	if len(unmatched) != 2 || unmatched[0] != "staging-cluster" || unmatched[1] != "legacy-?" {
+		t.Errorf("Expected [staging-cluster legacy-?], got %v", unmatched)
+	}
+
+	if unmatched := cfg.UnmatchedPatterns([]string{"production-a", "staging-cluster", "legacy-1"}); len(unmatched) != 0 {
+		t.Errorf("Expected no unmatched patterns, got %v", unmatched)
+	}
+}
+
+func TestMatchingPattern(t *testing.T) {
+	patterns := []string{"production-*", "*-cluster"}
+	cfg := &Config{Whitelist: patterns}
+	for _, pattern := range patterns {
+		regex, err := compilePattern(pattern)
+		if err != nil {
+			t.Fatalf("Failed to compile pattern %q: %v", pattern, err)
+		}
+		cfg.patterns = append(cfg.patterns, compiledPattern{regex: regex})
+	}
+
+	if pattern, ok := cfg.MatchingPattern("production-cluster"); !ok || pattern != "production-*" {
+		t.Errorf("Expected the first matching pattern production-*, got %q (%v)", pattern, ok)
+	}
+	if pattern, ok := cfg.MatchingPattern("dev"); ok {
+		t.Errorf("Expected no matching pattern, got %q", pattern)
+	}
+}
+
+func TestServerPatterns(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	content := "server:*.corp.example.com\nserver: https://10.0.0.1:6443\nserver:regex:^staging\\.\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		server   string
+		expected string
+	}{
+		{"dev", "https://api.eu.corp.example.com:6443", "server:*.corp.example.com"},
+		{"ip", "https://10.0.0.1:6443", "server: https://10.0.0.1:6443"},
+		{"stage", "https://staging.example.org", "server:regex:^staging\\."},
+		{"other", "https://api.example.com", ""},
+		// Server patterns never match the context name
+		{"api.corp.example.com", "", ""},
+	}
+	for _, tt := range tests {
+		rule, _ := cfg.MatchContext(ContextInfo{Name: tt.name, Server: tt.server})
+		if rule.Name != tt.expected {
+			t.Errorf("%s (%s): expected pattern %q, got %q", tt.name, tt.server, tt.expected, rule.Name)
+		}
+	}
+	if cfg.MatchesWhitelist("dev") {
+		t.Errorf("Expected server patterns not to match without a server")
+	}
+
+	unmatched := cfg.UnmatchedContextPatterns([]ContextInfo{{Name: "dev", Server: "https://a.corp.example.com"}})
+	if len(unmatched) != 2 || unmatched[0] != "server: https://10.0.0.1:6443" {
+		t.Errorf("Expected the other two server patterns to be unmatched, got %v", unmatched)
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kubectx-manager_ignore")
+	content := `payments-prod => arn:aws:eks:us-east-1:123:cluster/payments
+payments => arn:aws:eks:us-east-1:123:cluster/payments
+*-prod
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	target := "arn:aws:eks:us-east-1:123:cluster/payments"
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "*-prod" {
+		t.Errorf("Expected alias lines to be excluded from whitelist, got %v", cfg.Whitelist)
+	}
+	if got := cfg.ResolveAlias("payments-prod"); got != target {
+		t.Errorf("Expected alias to resolve to %q, got %q", target, got)
+	}
+	if got := cfg.ResolveAlias("other"); got != "other" {
+		t.Errorf("Expected non-alias to be returned unchanged, got %q", got)
+	}
+	if aliases := cfg.AliasesFor(target); len(aliases) != 2 || aliases[0] != "payments" || aliases[1] != "payments-prod" {
+		t.Errorf("Expected [payments payments-prod], got %v", aliases)
+	}
+	if !cfg.MatchesWhitelist(target) {
+		t.Error("Expected pattern to match context through its alias")
+	}
+	if unmatched := cfg.UnmatchedPatterns([]string{target}); len(unmatched) != 0 {
+		t.Errorf("Expected no unmatched patterns, got %v", unmatched)
+	}
+}
+
+func TestLoadInvalidAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty alias", " => some-context\n"},
+		{"empty target", "short =>\n"},
+		{"conflicting alias", "short => context-a\nshort => context-b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test config file: %v", err)
+			}
+			if _, err := Load(configPath); err == nil {
+				t.Error("Expected error, but got none")
+			}
+		})
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	content := "set auth-timeout = 15s\nset auth-retries=3\nset auth-cache-ttl = 1h\nset cert-expiry-window = 168h\nset auth-remove-on = unauthorized,expired\nset unreachable-grace = 7d\nset track-current-context = true\nprod-*\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.AuthTimeout != 15*time.Second || cfg.AuthRetries != 3 {
+		t.Errorf("Expected auth-timeout 15s and auth-retries 3, got %v and %d", cfg.AuthTimeout, cfg.AuthRetries)
+	}
+	if cfg.AuthCacheTTL != time.Hour {
+		t.Errorf("Expected auth-cache-ttl 1h, got %v", cfg.AuthCacheTTL)
+	}
+	if cfg.CertExpiryWindow != 168*time.Hour {
+		t.Errorf("Expected cert-expiry-window 168h, got %v", cfg.CertExpiryWindow)
+	}
+	if cfg.AuthRemoveOn != "unauthorized,expired" {
+		t.Errorf("Expected auth-remove-on unauthorized,expired, got %q", cfg.AuthRemoveOn)
+	}
+	if cfg.UnreachableGrace != "7d" {
+		t.Errorf("Expected unreachable-grace 7d, got %q", cfg.UnreachableGrace)
+	}
+	if !cfg.TrackCurrentContext {
+		t.Errorf("Expected track-current-context to be enabled")
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "prod-*" {
+		t.Errorf("Expected setting lines to be excluded from whitelist, got %v", cfg.Whitelist)
+	}
+
+	for _, invalid := range []string{"set auth-timeout = soon\n", "set auth-retries = -1\n", "set auth-cache-ttl = 0s\n", "set cert-expiry-window = -1h\n", "set auth-remove-on =\n", "set track-current-context = sometimes\n", "set color = always\n"} {
+		if err := os.WriteFile(configPath, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to create test config file: %v", err)
+		}
+		if _, err := Load(configPath); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestMatchingSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		ctx      ContextInfo
+		expected bool
+	}{
+		{"case-sensitive by default", "prod-*\n", ContextInfo{Name: "PROD-eu"}, false},
+		{"ignore-case", "set ignore-case = true\nprod-*\n", ContextInfo{Name: "PROD-eu"}, true},
+		{"ignore-case regex", "set ignore-case = true\nregex:^prod\n", ContextInfo{Name: "Prod-eu"}, true},
+		{"full by default", "prod\n", ContextInfo{Name: "prod-eu"}, false},
+		{"prefix", "set match-mode = prefix\nprod\n", ContextInfo{Name: "prod-eu"}, true},
+		{"prefix stays anchored", "set match-mode = prefix\nprod\n", ContextInfo{Name: "old-prod"}, false},
+		{"substring", "set match-mode = substring\nprod\n", ContextInfo{Name: "old-prod-eu"}, true},
+		{"cluster not matched by default", "shared-*\n", ContextInfo{Name: "ci", Cluster: "shared-eks"}, false},
+		{"match-against cluster", "set match-against = cluster\nshared-*\n", ContextInfo{Name: "ci", Cluster: "shared-eks"}, true},
+		{"match-against user", "set match-against = cluster, user\nadmin\n", ContextInfo{Name: "ci", User: "admin"}, true},
+		{"match-against leaves server patterns alone", "set match-against = cluster\nserver:shared-*\n", ContextInfo{Name: "ci", Cluster: "shared-eks"}, false},
+	}
+
+	for _, tt := range tests {
+		configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+		if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+			t.Fatalf("Failed to create test config file: %v", err)
+		}
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got := cfg.MatchesContext(tt.ctx); got != tt.expected {
+			t.Errorf("%s: expected %v for %+v, got %v", tt.name, tt.expected, tt.ctx, got)
+		}
+	}
+
+	for _, invalid := range []string{"set ignore-case = maybe\n", "set match-mode = fuzzy\n", "set match-against = namespace\n"} {
+		configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+		if err := os.WriteFile(configPath, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to create test config file: %v", err)
+		}
+		if _, err := Load(configPath); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestLoadRegexPatterns(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	content := "prod-*\nregex:^(dev|tmp)-\\d+-.*\n~^a=>b$\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(cfg.Whitelist) != 3 || len(cfg.Aliases) != 0 {
+		t.Fatalf("Expected three patterns and no aliases, got %v and %v", cfg.Whitelist, cfg.Aliases)
+	}
+	for name, expected := range map[string]bool{"prod-eu": true, "dev-7-x": true, "tmp-x": false, "a=>b": true} {
+		if got := cfg.MatchesWhitelist(name); got != expected {
+			t.Errorf("%s: expected match=%v, got %v", name, expected, got)
+		}
+	}
+	if !IsPattern("regex:^dev") || !IsPattern("~dev") || IsPattern("dev") {
+		t.Errorf("Expected regular expressions to be patterns")
+	}
+}
+
 func TestCompilePattern(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -281,6 +533,34 @@ func TestCompilePattern(t *testing.T) {
 			testString:  "testXcluster",
 			shouldMatch: false,
 		},
+		{
+			name:        "regex prefix",
+			pattern:     `regex:^(dev|tmp)-\d+-.*`,
+			testString:  "tmp-42-feature",
+			shouldMatch: true,
+		},
+		{
+			name:        "regex prefix no match",
+			pattern:     `regex:^(dev|tmp)-\d+-.*`,
+			testString:  "dev-x-feature",
+			shouldMatch: false,
+		},
+		{
+			name:        "tilde prefix is unanchored",
+			pattern:     "~staging",
+			testString:  "eu-staging-1",
+			shouldMatch: true,
+		},
+		{
+			name:        "invalid regex",
+			pattern:     "regex:(unclosed",
+			expectError: true,
+		},
+		{
+			name:        "empty regex",
+			pattern:     "~",
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
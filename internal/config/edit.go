@@ -0,0 +1,365 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// patternList is a list of patterns the configuration file can hold: a
+// YAML key and, in the line-based format, the prefix of its lines.
+type patternList struct {
+	yamlKey    string
+	linePrefix string
+	entries    func(cfg *Config) []string
+}
+
+var (
+	whitelistPatterns = patternList{yamlKey: "whitelist", entries: func(cfg *Config) []string { return cfg.Whitelist }}
+	protectPatterns   = patternList{yamlKey: "protect", linePrefix: protectPrefix + " ", entries: func(cfg *Config) []string { return cfg.Protect }}
+)
+
+// AddPattern appends a whitelist pattern to the configuration file at
+// configPath, creating the file if it does not exist, and reports whether it
+// was added: a pattern already in the whitelist is left where it is. The
+// rest of the file, comments included, is kept.
+func AddPattern(configPath, pattern string) (bool, error) {
+	return addToList(configPath, whitelistPatterns, pattern)
+}
+
+// RemovePattern removes a whitelist pattern from the configuration file at
+// configPath and reports whether it was there. The rest of the file,
+// comments included, is kept.
+func RemovePattern(configPath, pattern string) (bool, error) {
+	return removeFromList(configPath, whitelistPatterns, pattern)
+}
+
+// AddProtected adds a protect pattern to the configuration file at
+// configPath, as AddPattern does for whitelist patterns.
+func AddProtected(configPath, pattern string) (bool, error) {
+	return addToList(configPath, protectPatterns, pattern)
+}
+
+// RemoveProtected removes a protect pattern from the configuration file at
+// configPath, as RemovePattern does for whitelist patterns.
+func RemoveProtected(configPath, pattern string) (bool, error) {
+	return removeFromList(configPath, protectPatterns, pattern)
+}
+
+func addToList(configPath string, list patternList, pattern string) (bool, error) {
+	if IsRemote(configPath) {
+		return false, ErrRemoteConfig
+	}
+	pattern = strings.TrimSpace(pattern)
+	if err := validatePattern(configPath, list, pattern); err != nil {
+		return false, err
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(list.entries(cfg), pattern) {
+		return false, nil
+	}
+
+	if isYAMLConfig(configPath) {
+		return true, editYAMLList(configPath, list.yamlKey, func(seq *yaml.Node) {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: pattern})
+		})
+	}
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, list.linePrefix+pattern+"\n"...)
+	return true, writeConfigFile(configPath, data)
+}
+
+func removeFromList(configPath string, list patternList, pattern string) (bool, error) {
+	if IsRemote(configPath) {
+		return false, ErrRemoteConfig
+	}
+	pattern = strings.TrimSpace(pattern)
+	cfg, err := Load(configPath)
+	if err != nil {
+		return false, err
+	}
+	if !slices.Contains(list.entries(cfg), pattern) {
+		return false, nil
+	}
+
+	if isYAMLConfig(configPath) {
+		return true, editYAMLList(configPath, list.yamlKey, func(seq *yaml.Node) {
+			seq.Content = slices.DeleteFunc(seq.Content, func(item *yaml.Node) bool {
+				return strings.TrimSpace(item.Value) == pattern
+			})
+		})
+	}
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+	// The pattern is in the list, so every line reading as it belongs to the list
+	prefix := strings.TrimSpace(list.linePrefix)
+	lines := strings.SplitAfter(string(data), "\n")
+	lines = slices.DeleteFunc(lines, func(line string) bool {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), prefix)
+		return ok && strings.TrimSpace(value) == pattern
+	})
+	return true, writeConfigFile(configPath, []byte(strings.Join(lines, "")))
+}
+
+// validatePattern checks that pattern compiles and, in the line-based
+// format, would be read back into list rather than as a comment, setting,
+// alias, protect, skip-auth-check or include line.
+func validatePattern(configPath string, list patternList, pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("%w: empty pattern", ErrInvalidPattern)
+	}
+	if _, err := (&Config{}).compileContextPattern(pattern); err != nil {
+		return err
+	}
+	if isYAMLConfig(configPath) || list.linePrefix != "" {
+		return nil
+	}
+	_, regex := cutRegexPrefix(strings.TrimSpace(strings.TrimPrefix(pattern, serverPrefix)))
+	if strings.HasPrefix(pattern, "#") || strings.HasPrefix(pattern, settingPrefix) || strings.HasPrefix(pattern, protectPrefix) ||
+		strings.HasPrefix(pattern, skipAuthPrefix) || strings.HasPrefix(pattern, includePrefix) ||
+		(!regex && strings.Contains(pattern, aliasSeparator)) {
+		return fmt.Errorf("%w '%s': the configuration file would not read it as a pattern", ErrInvalidPattern, pattern)
+	}
+	return nil
+}
+
+// SetAlias makes alias stand for the context target in the configuration
+// file at configPath, creating the file if it does not exist, and reports
+// whether the file changed: an alias already standing for target is left
+// alone, and one standing for another context is changed in place. The
+// rest of the file, comments included, is kept.
+func SetAlias(configPath, alias, target string) (bool, error) {
+	if IsRemote(configPath) {
+		return false, ErrRemoteConfig
+	}
+	alias, target = strings.TrimSpace(alias), strings.TrimSpace(target)
+	if err := validateAlias(configPath, alias, target); err != nil {
+		return false, err
+	}
+	// Load creates a missing file; the aliases of included files are not ours to change
+	if _, err := Load(configPath); err != nil {
+		return false, err
+	}
+	cfg, err := loadFile(configPath)
+	if err != nil {
+		return false, err
+	}
+	existing, ok := cfg.Aliases[alias]
+	if ok && existing == target {
+		return false, nil
+	}
+
+	if isYAMLConfig(configPath) {
+		return true, editYAMLMapping(configPath, "aliases", func(mapping *yaml.Node) {
+			for i := 0; i+1 < len(mapping.Content); i += 2 {
+				if strings.TrimSpace(mapping.Content[i].Value) == alias {
+					mapping.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Value: target}
+					return
+				}
+			}
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: alias}, &yaml.Node{Kind: yaml.ScalarNode, Value: target})
+		})
+	}
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+	line := alias + " " + aliasSeparator + " " + target + "\n"
+	if ok {
+		lines := strings.SplitAfter(string(data), "\n")
+		for i := range lines {
+			if name, isAlias := lineAlias(lines[i]); isAlias && name == alias {
+				lines[i] = line
+			}
+		}
+		return true, writeConfigFile(configPath, []byte(strings.Join(lines, "")))
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	return true, writeConfigFile(configPath, append(data, line...))
+}
+
+// RemoveAlias removes an alias from the configuration file at configPath and
+// reports whether it was there. Aliases defined by included files are left
+// alone. The rest of the file, comments included, is kept.
+func RemoveAlias(configPath, alias string) (bool, error) {
+	if IsRemote(configPath) {
+		return false, ErrRemoteConfig
+	}
+	alias = strings.TrimSpace(alias)
+	if _, err := Load(configPath); err != nil {
+		return false, err
+	}
+	cfg, err := loadFile(configPath)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := cfg.Aliases[alias]; !ok {
+		return false, nil
+	}
+
+	if isYAMLConfig(configPath) {
+		return true, editYAMLMapping(configPath, "aliases", func(mapping *yaml.Node) {
+			for i := 0; i+1 < len(mapping.Content); i += 2 {
+				if strings.TrimSpace(mapping.Content[i].Value) == alias {
+					mapping.Content = slices.Delete(mapping.Content, i, i+2)
+					return
+				}
+			}
+		})
+	}
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	lines = slices.DeleteFunc(lines, func(line string) bool {
+		name, ok := lineAlias(line)
+		return ok && name == alias
+	})
+	return true, writeConfigFile(configPath, []byte(strings.Join(lines, "")))
+}
+
+// validateAlias checks that alias and target are non-empty and, in the
+// line-based format, that the alias line would be read back as that alias.
+func validateAlias(configPath, alias, target string) error {
+	if alias == "" || target == "" {
+		return fmt.Errorf("%w '%s %s %s': both sides must be non-empty", ErrInvalidAlias, alias, aliasSeparator, target)
+	}
+	if alias == target {
+		return fmt.Errorf("%w '%s': an alias cannot stand for itself", ErrInvalidAlias, alias)
+	}
+	if isYAMLConfig(configPath) {
+		return nil
+	}
+	if name, ok := lineAlias(alias + " " + aliasSeparator + " " + target); !ok || name != alias {
+		return fmt.Errorf("%w '%s': the configuration file would not read it as an alias", ErrInvalidAlias, alias)
+	}
+	return nil
+}
+
+// lineAlias returns the alias a line of a line-based configuration file
+// defines, reading the line the way loadLines does.
+func lineAlias(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, settingPrefix) ||
+		strings.HasPrefix(line, protectPrefix) || strings.HasPrefix(line, skipAuthPrefix) || strings.HasPrefix(line, includePrefix) {
+		return "", false
+	}
+	if _, regex := cutRegexPrefix(strings.TrimPrefix(line, serverPrefix)); regex {
+		return "", false
+	}
+	alias, _, ok := strings.Cut(line, aliasSeparator)
+	return strings.TrimSpace(alias), ok
+}
+
+// editYAMLList applies edit to the sequence under key in the YAML
+// configuration file at configPath, adding the sequence if the file has
+// none, and writes the file back. Comments are kept, though blank lines
+// between sections may not be.
+func editYAMLList(configPath, key string, edit func(seq *yaml.Node)) error {
+	return editYAMLNode(configPath, key, yaml.SequenceNode, "a list", edit)
+}
+
+// editYAMLMapping is editYAMLList for the mapping under key.
+func editYAMLMapping(configPath, key string, edit func(mapping *yaml.Node)) error {
+	return editYAMLNode(configPath, key, yaml.MappingNode, "a mapping", edit)
+}
+
+// editYAMLNode applies edit to the node of the given kind under key in the
+// YAML configuration file at configPath, adding it if the file has none, and
+// writes the file back. kindName describes the kind in errors.
+func editYAMLNode(configPath, key string, kind yaml.Kind, kindName string, edit func(node *yaml.Node)) error {
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return errors.New("failed to edit config file: the top level is not a mapping")
+	}
+
+	var node *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			node = root.Content[i+1]
+			break
+		}
+	}
+	if node == nil {
+		node = &yaml.Node{Kind: kind}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		// "key:" with nothing after it
+		*node = yaml.Node{Kind: kind, HeadComment: node.HeadComment, LineComment: node.LineComment}
+	}
+	if node.Kind != kind {
+		return fmt.Errorf("failed to edit config file: %s is not %s", key, kindName)
+	}
+
+	edit(node)
+	// An empty list or mapping in the template, such as "whitelist: []",
+	// would otherwise stay on one line
+	node.Style &^= yaml.FlowStyle
+	if len(node.Content) == 0 {
+		node.Style |= yaml.FlowStyle
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return writeConfigFile(configPath, out.Bytes())
+}
+
+// writeConfigFile replaces the contents of the configuration file, keeping
+// its permissions.
+func writeConfigFile(configPath string, data []byte) error {
+	if err := os.WriteFile(configPath, data, configFileMode); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddRemovePattern(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	content := "# Production\nprod-*\nset auth-retries = 2\np => prod-eu\nstaging"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if added, err := AddPattern(configPath, "dev-*"); err != nil || !added {
+		t.Fatalf("Expected dev-* to be added, got %v, %v", added, err)
+	}
+	if added, err := AddPattern(configPath, " prod-* "); err != nil || added {
+		t.Errorf("Expected the existing prod-* to be left alone, got %v, %v", added, err)
+	}
+	if removed, err := RemovePattern(configPath, "prod-*"); err != nil || !removed {
+		t.Fatalf("Expected prod-* to be removed, got %v, %v", removed, err)
+	}
+	if removed, err := RemovePattern(configPath, "prod-*"); err != nil || removed {
+		t.Errorf("Expected nothing to remove the second time, got %v, %v", removed, err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	expected := "# Production\nset auth-retries = 2\np => prod-eu\nstaging\ndev-*\n"
+	if string(data) != expected {
+		t.Errorf("Expected comments, settings and order to be kept:\n%q\ngot:\n%q", expected, string(data))
+	}
+}
+
+func TestAddInvalidPattern(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	for _, pattern := range []string{"", "regex:(", "# note", "set color = always", "a => b"} {
+		if _, err := AddPattern(configPath, pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("%q: expected ErrInvalidPattern, got %v", pattern, err)
+		}
+	}
+	// A regular expression may contain the alias separator
+	if added, err := AddPattern(configPath, "regex:a=>b"); err != nil || !added {
+		t.Errorf("Expected the regular expression to be added, got %v, %v", added, err)
+	}
+}
+
+func TestAddRemovePatternYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	// The file is created from the template, whose whitelist is empty
+	for _, pattern := range []string{"prod-*", "server:*.corp.example.com"} {
+		if added, err := AddPattern(configPath, pattern); err != nil || !added {
+			t.Fatalf("Expected %s to be added, got %v, %v", pattern, added, err)
+		}
+	}
+	if removed, err := RemovePattern(configPath, "prod-*"); err != nil || !removed {
+		t.Fatalf("Expected prod-* to be removed, got %v, %v", removed, err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load edited config: %v", err)
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "server:*.corp.example.com" {
+		t.Errorf("Unexpected whitelist %v", cfg.Whitelist)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if !strings.Contains(string(data), "# Friendly names for long context names") {
+		t.Errorf("Expected the template's comments to be kept:\n%s", data)
+	}
+}
+
+func TestAddRemoveProtected(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	if err := os.WriteFile(configPath, []byte("prod\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if added, err := AddProtected(configPath, "prod"); err != nil || !added {
+		t.Fatalf("Expected prod to be protected, got %v, %v", added, err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(cfg.Protect) != 1 || len(cfg.Whitelist) != 1 {
+		t.Errorf("Expected prod both whitelisted and protected, got %v and %v", cfg.Whitelist, cfg.Protect)
+	}
+
+	if removed, err := RemoveProtected(configPath, "prod"); err != nil || !removed {
+		t.Fatalf("Expected prod to be unprotected, got %v, %v", removed, err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if string(data) != "prod\n" {
+		t.Errorf("Expected only the protect line to be removed, got %q", data)
+	}
+}
+
+func TestSetRemoveAlias(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	content := "# Aliases\np => prod-eu\nregex:a=>b\nstaging"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if changed, err := SetAlias(configPath, "p", "prod-eu"); err != nil || changed {
+		t.Errorf("Expected the existing alias to be left alone, got %v, %v", changed, err)
+	}
+	if changed, err := SetAlias(configPath, "p", "prod-us"); err != nil || !changed {
+		t.Fatalf("Expected p to be changed, got %v, %v", changed, err)
+	}
+	if changed, err := SetAlias(configPath, "d", "dev"); err != nil || !changed {
+		t.Fatalf("Expected d to be added, got %v, %v", changed, err)
+	}
+	for _, alias := range []string{"", "# note", "set x", "a=>b", "dev"} {
+		if _, err := SetAlias(configPath, alias, "dev"); !errors.Is(err, ErrInvalidAlias) {
+			t.Errorf("%q: expected ErrInvalidAlias, got %v", alias, err)
+		}
+	}
+	if removed, err := RemoveAlias(configPath, "d"); err != nil || !removed {
+		t.Fatalf("Expected d to be removed, got %v, %v", removed, err)
+	}
+	if removed, err := RemoveAlias(configPath, "d"); err != nil || removed {
+		t.Errorf("Expected nothing to remove the second time, got %v, %v", removed, err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	expected := "# Aliases\np => prod-us\nregex:a=>b\nstaging\n"
+	if string(data) != expected {
+		t.Errorf("Expected comments, patterns and order to be kept:\n%q\ngot:\n%q", expected, string(data))
+	}
+}
+
+func TestSetRemoveAliasYAML(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	// The file is created from the template, whose aliases are empty
+	if changed, err := SetAlias(configPath, "p", "prod-eu"); err != nil || !changed {
+		t.Fatalf("Expected p to be added, got %v, %v", changed, err)
+	}
+	if changed, err := SetAlias(configPath, "d", "dev"); err != nil || !changed {
+		t.Fatalf("Expected d to be added, got %v, %v", changed, err)
+	}
+	if changed, err := SetAlias(configPath, "p", "prod-us"); err != nil || !changed {
+		t.Fatalf("Expected p to be changed, got %v, %v", changed, err)
+	}
+	if removed, err := RemoveAlias(configPath, "d"); err != nil || !removed {
+		t.Fatalf("Expected d to be removed, got %v, %v", removed, err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load edited config: %v", err)
+	}
+	if len(cfg.Aliases) != 1 || cfg.Aliases["p"] != "prod-us" {
+		t.Errorf("Unexpected aliases %v", cfg.Aliases)
+	}
+}
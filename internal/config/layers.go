@@ -0,0 +1,229 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// systemConfigDir holds the system-wide configuration file; tests replace it.
+var systemConfigDir = "/etc/kubectx-manager"
+
+// Names of the repository-local configuration file, the YAML one first
+var localConfigNames = []string{".kubectx-manager.yaml", ".kubectx-manager_ignore"}
+
+// LayerPaths returns the configuration files LoadLayers merges, from the
+// lowest precedence to the highest: the system-wide file
+// /etc/kubectx-manager/config(.yaml), if there is one, userPath, and the
+// repository-local file, if there is one. The repository-local file is the
+// closest .kubectx-manager.yaml or .kubectx-manager_ignore in the working
+// directory or, inside a git repository, one of its parents up to the root
+// of the repository.
+func LayerPaths(userPath string) []string {
+	var paths []string
+	for _, name := range []string{"config.yaml", "config"} {
+		path := filepath.Join(systemConfigDir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			paths = append(paths, path)
+			break
+		}
+	}
+	paths = append(paths, userPath)
+	if local := localConfigPath(); local != "" && !sameFile(local, userPath) {
+		paths = append(paths, local)
+	}
+	return paths
+}
+
+// localConfigPath returns the repository-local configuration file, or "".
+func localConfigPath() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	var found string
+	for current := dir; ; current = filepath.Dir(current) {
+		for _, name := range localConfigNames {
+			if _, err := os.Stat(filepath.Join(current, name)); err == nil && found == "" {
+				found = filepath.Join(current, name)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return found
+		}
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+	// Outside a repository, only the working directory counts
+	if found != "" && filepath.Dir(found) == dir {
+		return found
+	}
+	return ""
+}
+
+// sameFile reports whether a and b are the same local file.
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// LoadLayers loads each of the configuration files at paths, with the files
+// they include, and merges them, from the lowest precedence to the highest,
+// as merge does.
+func LoadLayers(paths ...string) (*Config, error) {
+	layers := make([]*Config, 0, len(paths))
+	for _, path := range paths {
+		cfg, err := Load(path)
+		if err != nil {
+			if len(paths) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		layers = append(layers, cfg)
+	}
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	return merge(layers...)
+}
+
+// withIncludes merges the files c includes under it, configPath being the
+// file c was read from and including the files that include it.
+func (c *Config) withIncludes(configPath string, including []string) (*Config, error) {
+	if len(c.Include) == 0 {
+		return c, nil
+	}
+	including = append(slices.Clip(including), includeKey(configPath))
+
+	layers := make([]*Config, 0, len(c.Include)+1)
+	for _, include := range c.Include {
+		path, err := resolveInclude(configPath, include)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(including, includeKey(path)) {
+			return nil, fmt.Errorf("include cycle: %s includes %s, which includes it", configPath, include)
+		}
+		included, err := load(path, including)
+		if err != nil {
+			return nil, fmt.Errorf("failed to include %s from %s: %w", include, configPath, err)
+		}
+		layers = append(layers, included)
+	}
+	merged, err := merge(append(layers, c)...)
+	if err != nil {
+		return nil, err
+	}
+	merged.Include = c.Include
+	return merged, nil
+}
+
+// resolveInclude returns the path or URL of the file include names, which
+// may start with ~/ or be relative to the including file or URL base.
+func resolveInclude(base, include string) (string, error) {
+	if include == "" {
+		return "", fmt.Errorf("empty include in %s", base)
+	}
+	if IsRemote(include) {
+		return include, nil
+	}
+	if IsRemote(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(include)
+		if err != nil {
+			return "", fmt.Errorf("invalid include '%s' in %s: %w", include, base, err)
+		}
+		return baseURL.ResolveReference(ref).String(), nil
+	}
+	if rest, ok := strings.CutPrefix(include, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve include '%s': %w", include, err)
+		}
+		return filepath.Join(home, rest), nil
+	}
+	if filepath.IsAbs(include) {
+		return include, nil
+	}
+	return filepath.Join(filepath.Dir(base), include), nil
+}
+
+// includeKey identifies a file for detecting include cycles.
+func includeKey(path string) string {
+	if IsRemote(path) {
+		return path
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// merge layers configurations, from the lowest precedence to the highest.
+// The patterns and rules of higher layers come first, so the first rule
+// matching a context is from the highest layer that has one; a pattern in
+// several layers is kept once. Settings and aliases of higher layers
+// override those of lower ones. Each pattern and rule keeps the matching
+// settings of its file. The warnings of every layer are kept.
+func merge(layers ...*Config) (*Config, error) {
+	merged := &Config{}
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		for j, pattern := range layer.Whitelist {
+			if !slices.Contains(merged.Whitelist, pattern) {
+				merged.Whitelist = append(merged.Whitelist, pattern)
+				merged.patterns = append(merged.patterns, layer.patterns[j])
+			}
+		}
+		for j, pattern := range layer.Protect {
+			if !slices.Contains(merged.Protect, pattern) {
+				merged.Protect = append(merged.Protect, pattern)
+				merged.protected = append(merged.protected, layer.protected[j])
+			}
+		}
+		for j, pattern := range layer.SkipAuthCheck {
+			if !slices.Contains(merged.SkipAuthCheck, pattern) {
+				merged.SkipAuthCheck = append(merged.SkipAuthCheck, pattern)
+				merged.skipAuth = append(merged.skipAuth, layer.skipAuth[j])
+			}
+		}
+		merged.Rules = append(merged.Rules, layer.Rules...)
+		merged.rules = append(merged.rules, layer.rules...)
+	}
+	for _, layer := range layers {
+		merged.Warnings = append(merged.Warnings, layer.Warnings...)
+		for alias, target := range layer.Aliases {
+			if merged.Aliases == nil {
+				merged.Aliases = make(map[string]string)
+			}
+			merged.Aliases[alias] = target
+		}
+		for _, s := range layer.settings {
+			if err := merged.applySetting(s.name, s.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return merged, nil
+}
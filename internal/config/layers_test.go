@@ -0,0 +1,162 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+}
+
+func TestInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeTestConfig(t, filepath.Join(dir, "team", "policy.yaml"), `
+whitelist:
+  - prod-*
+  - corp-*
+aliases:
+  p: prod-eu
+  s: staging-old
+rules:
+  - name: tmp
+    match:
+      name: "tmp-*"
+    action: remove
+defaults:
+  authRetries: 3
+  ignoreCase: true
+`)
+	configPath := filepath.Join(dir, ".kubectx-manager_ignore")
+	writeTestConfig(t, configPath, "include team/policy.yaml\nstaging-*\nprod-*\ns => staging-new\nset auth-retries = 1\n")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if expected := []string{"staging-*", "prod-*", "corp-*"}; !slices.Equal(cfg.Whitelist, expected) {
+		t.Errorf("Expected whitelist %v, got %v", expected, cfg.Whitelist)
+	}
+	if cfg.AuthRetries != 1 {
+		t.Errorf("Expected the including file's auth-retries 1, got %d", cfg.AuthRetries)
+	}
+	if cfg.ResolveAlias("s") != "staging-new" || cfg.ResolveAlias("p") != "prod-eu" {
+		t.Errorf("Expected the including file's aliases to win, got %v", cfg.Aliases)
+	}
+	if rule, ok := cfg.MatchContext(ContextInfo{Name: "tmp-1"}); !ok || rule.Action != ActionRemove {
+		t.Errorf("Expected the included rule to remove tmp-1, got %+v, %v", rule, ok)
+	}
+	// Matching settings only apply to the patterns of their own file
+	if !cfg.MatchesWhitelist("CORP-eu") {
+		t.Error("Expected the included corp-* to ignore case")
+	}
+	if cfg.MatchesWhitelist("STAGING-1") {
+		t.Error("Expected the including file's staging-* to match case")
+	}
+}
+
+func TestIncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	writeTestConfig(t, a, "include b\n")
+	writeTestConfig(t, filepath.Join(dir, "b"), "include "+a+"\n")
+	if _, err := Load(a); err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Expected an include cycle error, got %v", err)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	c := filepath.Join(dir, "c")
+	writeTestConfig(t, c, "include missing\n")
+	if _, err := Load(c); err == nil {
+		t.Error("Expected an error including a missing file")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("Expected a missing included file not to be created")
+	}
+}
+
+func TestLayers(t *testing.T) {
+	root := t.TempDir()
+	saved := systemConfigDir
+	systemConfigDir = filepath.Join(root, "etc")
+	t.Cleanup(func() { systemConfigDir = saved })
+	writeTestConfig(t, filepath.Join(systemConfigDir, "config"), "corp-*\nset auth-retries = 5\nset auth-timeout = 20s\n")
+
+	userPath := filepath.Join(root, "home", ".kubectx-manager_ignore")
+	writeTestConfig(t, userPath, "dev-*\nset auth-retries = 2\n")
+
+	repo := filepath.Join(root, "repo")
+	writeTestConfig(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeTestConfig(t, filepath.Join(repo, ".kubectx-manager.yaml"), `
+whitelist:
+  - project-*
+rules:
+  - name: no dev here
+    match:
+      name: "dev-*"
+    action: remove
+`)
+	workDir := filepath.Join(repo, "src", "app")
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		t.Fatalf("Failed to create working directory: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	paths := LayerPaths(userPath)
+	if len(paths) != 3 || paths[1] != userPath || filepath.Base(paths[2]) != ".kubectx-manager.yaml" {
+		t.Fatalf("Expected system, user and repository layers, got %v", paths)
+	}
+	cfg, err := LoadLayers(paths...)
+	if err != nil {
+		t.Fatalf("Failed to load layers: %v", err)
+	}
+	if expected := []string{"project-*", "dev-*", "corp-*"}; !slices.Equal(cfg.Whitelist, expected) {
+		t.Errorf("Expected whitelist %v, got %v", expected, cfg.Whitelist)
+	}
+	if cfg.AuthRetries != 2 || cfg.AuthTimeout.String() != "20s" {
+		t.Errorf("Expected auth-retries 2 from the user and auth-timeout 20s from the system, got %d and %v", cfg.AuthRetries, cfg.AuthTimeout)
+	}
+	// The repository's rule comes before the user's whitelist
+	if cfg.MatchesWhitelist("dev-1") {
+		t.Error("Expected the repository rule to remove dev-1")
+	}
+
+	// Outside a repository, only the working directory is searched
+	if err := os.Remove(filepath.Join(repo, ".git", "HEAD")); err != nil {
+		t.Fatalf("Failed to remove .git: %v", err)
+	}
+	if err := os.Remove(filepath.Join(repo, ".git")); err != nil {
+		t.Fatalf("Failed to remove .git: %v", err)
+	}
+	if paths := LayerPaths(userPath); len(paths) != 2 {
+		t.Errorf("Expected no repository layer outside a repository, got %v", paths)
+	}
+}
@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// remoteTimeout bounds the request for a remote configuration file
+	remoteTimeout = 10 * time.Second
+	// maxRemoteSize is the upper bound on the size of a remote configuration file
+	maxRemoteSize = 1 << 20
+	// cacheFileMode keeps cached policies private like the kubeconfig they govern
+	cacheFileMode = 0600
+	cacheDirMode  = 0700
+)
+
+// ErrRemoteConfig is returned when a command would change a remote configuration file.
+var ErrRemoteConfig = errors.New("remote configuration files are read-only")
+
+// remoteClient fetches remote configuration files; tests replace it.
+var remoteClient = &http.Client{Timeout: remoteTimeout}
+
+// cacheMeta is what the cache remembers about a fetched file to revalidate it.
+type cacheMeta struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// IsRemote reports whether configPath is the URL of a remote configuration file.
+func IsRemote(configPath string) bool {
+	return strings.HasPrefix(configPath, "https://") || strings.HasPrefix(configPath, "http://")
+}
+
+// loadRemote fetches the configuration file at rawURL into the cache and
+// loads the cached copy. The cached copy is revalidated with its ETag or
+// modification time on every load; when the server cannot be reached, or
+// fails, the cached copy is used with a warning in Config.Warnings, so
+// cleanups keep working offline.
+func loadRemote(rawURL string, including []string) (*Config, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration URL %s: %w", rawURL, err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid configuration URL %s: only https is supported", rawURL)
+	}
+
+	cachePath, err := remoteCachePath(u)
+	if err != nil {
+		return nil, err
+	}
+	fetchErr := fetchRemote(rawURL, cachePath)
+	if fetchErr != nil {
+		if _, statErr := os.Stat(cachePath); statErr != nil {
+			return nil, fmt.Errorf("failed to fetch configuration from %s: %w", rawURL, fetchErr)
+		}
+	}
+	cfg, err := loadFile(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	if fetchErr != nil {
+		cfg.Warnings = append(cfg.Warnings,
+			fmt.Sprintf("Failed to fetch configuration from %s, using the cached copy: %v", rawURL, fetchErr))
+	}
+	// Relative includes are resolved against the URL, not the cache
+	return cfg.withIncludes(rawURL, including)
+}
+
+// remoteCachePath returns where the copy of the file at u is cached: a file
+// named after a hash of the URL, keeping its extension so the format is
+// still recognized.
+func remoteCachePath(u *url.URL) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the cache directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(u.String()))
+	name := hex.EncodeToString(sum[:8]) + path.Ext(u.Path)
+	return filepath.Join(cacheDir, "kubectx-manager", "config", name), nil
+}
+
+// fetchRemote downloads the file at rawURL to cachePath unless the cached
+// copy is still current.
+func fetchRemote(rawURL, cachePath string) error {
+	metaPath := cachePath + ".meta.json"
+	var meta cacheMeta
+	if _, err := os.Stat(cachePath); err == nil {
+		if data, err := os.ReadFile(metaPath); err == nil { //nolint:gosec // Path is derived from the user's cache directory
+			_ = json.Unmarshal(data, &meta)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && meta.URL != "":
+		return nil
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxRemoteSize {
+		return fmt.Errorf("configuration file is larger than %d bytes", maxRemoteSize)
+	}
+
+	// A broken file must not replace a cached copy that works
+	if err := os.MkdirAll(filepath.Dir(cachePath), cacheDirMode); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	ext := filepath.Ext(cachePath)
+	newPath := strings.TrimSuffix(cachePath, ext) + ".new" + ext
+	if err := os.WriteFile(newPath, data, cacheFileMode); err != nil {
+		return fmt.Errorf("failed to cache configuration: %w", err)
+	}
+	if _, err := loadFile(newPath); err != nil {
+		_ = os.Remove(newPath)
+		return err
+	}
+	if err := os.Rename(newPath, cachePath); err != nil {
+		return fmt.Errorf("failed to cache configuration: %w", err)
+	}
+	meta = cacheMeta{
+		URL:          rawURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Fetched:      time.Now().UTC(),
+	}
+	metaData, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, metaData, cacheFileMode)
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// serveConfig serves body with an ETag over HTTPS, counting the requests and
+// the revalidations answered with 304 Not Modified.
+func serveConfig(t *testing.T, body *string) (server *httptest.Server, requests, revalidated *atomic.Int32) {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	requests, revalidated = &atomic.Int32{}, &atomic.Int32{}
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		etag := strconv.Quote(*body)
+		if r.Header.Get("If-None-Match") == etag {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(*body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := remoteClient
+	remoteClient = server.Client()
+	t.Cleanup(func() { remoteClient = client })
+	return server, requests, revalidated
+}
+
+func TestLoadRemote(t *testing.T) {
+	body := "whitelist:\n  - prod-*\n"
+	server, requests, revalidated := serveConfig(t, &body)
+	url := server.URL + "/kubectx-policy.yaml"
+
+	cfg, err := Load(url)
+	if err != nil {
+		t.Fatalf("Failed to load remote config: %v", err)
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "prod-*" {
+		t.Errorf("Expected whitelist [prod-*], got %v", cfg.Whitelist)
+	}
+
+	// The cached copy is revalidated rather than downloaded again
+	if _, err := Load(url); err != nil {
+		t.Fatalf("Failed to reload remote config: %v", err)
+	}
+	if requests.Load() != 2 || revalidated.Load() != 1 {
+		t.Errorf("Expected 2 requests with 1 revalidation, got %d and %d", requests.Load(), revalidated.Load())
+	}
+
+	// A changed file is downloaded again
+	body = "whitelist:\n  - staging-*\n"
+	if cfg, err = Load(url); err != nil {
+		t.Fatalf("Failed to reload changed remote config: %v", err)
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "staging-*" {
+		t.Errorf("Expected whitelist [staging-*], got %v", cfg.Whitelist)
+	}
+
+	// A broken file keeps the cached copy
+	body = "whitelist: [\n"
+	if cfg, err = Load(url); err != nil {
+		t.Fatalf("Expected the cached copy to be used for a broken file, got %v", err)
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "staging-*" {
+		t.Errorf("Expected the cached whitelist [staging-*], got %v", cfg.Whitelist)
+	}
+
+	// Offline, the cached copy is used
+	server.Close()
+	if cfg, err = Load(url); err != nil {
+		t.Fatalf("Expected the cached copy to be used offline, got %v", err)
+	}
+	if len(cfg.Whitelist) != 1 || cfg.Whitelist[0] != "staging-*" {
+		t.Errorf("Expected the cached whitelist [staging-*], got %v", cfg.Whitelist)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], "using the cached copy") {
+		t.Errorf("Expected a warning about the cached copy, got %v", cfg.Warnings)
+	}
+}
+
+func TestLoadRemoteErrors(t *testing.T) {
+	body := "prod-*\n"
+	server, _, _ := serveConfig(t, &body)
+	url := server.URL + "/kubectx-policy"
+
+	if _, err := Load("http://intranet.example.com/kubectx-policy.yaml"); err == nil {
+		t.Error("Expected an error for a plain http URL")
+	}
+	if _, err := AddPattern(url, "dev-*"); !errors.Is(err, ErrRemoteConfig) {
+		t.Errorf("Expected ErrRemoteConfig editing a remote config, got %v", err)
+	}
+
+	server.Close()
+	if _, err := Load(url); err == nil {
+		t.Error("Expected an error offline without a cached copy")
+	}
+}
@@ -0,0 +1,388 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what cleanup does with the contexts a rule matches.
+type Action string
+
+const (
+	// ActionKeep keeps the context, like a whitelist pattern
+	ActionKeep Action = "keep"
+	// ActionRemove removes the context, even if --auth-check finds it valid
+	ActionRemove Action = "remove"
+	// ActionProtect keeps the context and refuses to remove it with any command
+	ActionProtect Action = "protect"
+	// ActionArchive removes the context into the archive kubeconfig, as --archive does
+	ActionArchive Action = "archive"
+)
+
+// Rule decides what happens to the contexts matching all of its criteria.
+type Rule struct {
+	Name   string    `yaml:"name"`
+	Match  RuleMatch `yaml:"match"`
+	Action Action    `yaml:"action"`
+}
+
+// RuleMatch holds the criteria of a rule. Each is a glob or, with a regex:
+// or ~ prefix, a regular expression; empty criteria match anything.
+type RuleMatch struct {
+	// Name matches the context name or one of its aliases, and the cluster
+	// and user names listed by the matchAgainst default
+	Name string `yaml:"name,omitempty"`
+	// Server matches the server URL, host or host name of the context's cluster
+	Server string `yaml:"server,omitempty"`
+	// Namespace matches the context's namespace, empty if it sets none
+	Namespace string `yaml:"namespace,omitempty"`
+	// AuthType matches the kind of credentials of the context's user, as
+	// shown by list: token, client-certificate, basic, exec:<command>, ...
+	AuthType string `yaml:"authType,omitempty"`
+}
+
+// ContextInfo describes a context for matching rules against it.
+type ContextInfo struct {
+	Name string
+	// Cluster and User are the names of the context's cluster and user
+	Cluster   string
+	User      string
+	Server    string
+	Namespace string
+	AuthType  string
+}
+
+// compiledRule is a rule ready for matching; nil criteria match anything.
+type compiledRule struct {
+	Rule
+	name, server, namespace, authType *regexp.Regexp
+	// against is the match-against setting of the file the rule is from
+	against []string
+}
+
+// yamlConfig is the layout of a YAML configuration file.
+type yamlConfig struct {
+	Include   []string `yaml:"include"`
+	Whitelist []string `yaml:"whitelist"`
+	Protect   []string `yaml:"protect"`
+	// SkipAuthCheck holds skip-auth-check patterns
+	SkipAuthCheck []string          `yaml:"skipAuthCheck"`
+	Aliases       map[string]string `yaml:"aliases"`
+	Rules         []Rule            `yaml:"rules"`
+	Defaults      yamlDefaults      `yaml:"defaults"`
+}
+
+// yamlDefaults holds the flag defaults of a YAML configuration file, with the
+// same meaning as the settings of the line-based format.
+type yamlDefaults struct {
+	AuthTimeout      string `yaml:"authTimeout"`
+	AuthRetries      string `yaml:"authRetries"`
+	AuthCacheTTL     string `yaml:"authCacheTTL"`
+	CertExpiryWindow string `yaml:"certExpiryWindow"`
+	AuthRemoveOn     string `yaml:"authRemoveOn"`
+	UnreachableGrace string `yaml:"unreachableGrace"`
+	BackupRetention  string `yaml:"backupRetention"`
+	OlderThan        string `yaml:"olderThan"`
+	InFileDuplicates string `yaml:"inFileDuplicates"`
+	// The settings below are not flag defaults, but settings all the same
+	TrackCurrentContext string `yaml:"trackCurrentContext"`
+	IgnoreCase          string `yaml:"ignoreCase"`
+	MatchMode           string `yaml:"matchMode"`
+	MatchAgainst        string `yaml:"matchAgainst"`
+}
+
+// isYAMLConfig reports whether the configuration file at path uses the YAML format.
+func isYAMLConfig(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// loadYAML reads a YAML configuration file. Unknown keys are rejected so a
+// misspelled rule cannot silently match nothing.
+func loadYAML(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath) //nolint:gosec // User-specified config file path is intentional
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var file yamlConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	cfg := &Config{Whitelist: file.Whitelist, Protect: file.Protect, SkipAuthCheck: file.SkipAuthCheck, Include: file.Include}
+	for alias, target := range file.Aliases {
+		if err := cfg.addAlias(alias, target); err != nil {
+			return nil, err
+		}
+	}
+	settings := []struct{ name, value string }{
+		{"auth-timeout", file.Defaults.AuthTimeout},
+		{"auth-retries", file.Defaults.AuthRetries},
+		{"auth-cache-ttl", file.Defaults.AuthCacheTTL},
+		{"cert-expiry-window", file.Defaults.CertExpiryWindow},
+		{"auth-remove-on", file.Defaults.AuthRemoveOn},
+		{"unreachable-grace", file.Defaults.UnreachableGrace},
+		{"backup-retention", file.Defaults.BackupRetention},
+		{"older-than", file.Defaults.OlderThan},
+		{"in-file-duplicates", file.Defaults.InFileDuplicates},
+		{"track-current-context", file.Defaults.TrackCurrentContext},
+		{"ignore-case", file.Defaults.IgnoreCase},
+		{"match-mode", file.Defaults.MatchMode},
+		{"match-against", file.Defaults.MatchAgainst},
+	}
+	for _, setting := range settings {
+		if setting.value == "" {
+			continue
+		}
+		if err := cfg.applySetting(setting.name, setting.value); err != nil {
+			return nil, err
+		}
+	}
+	for i, rule := range file.Rules {
+		if err := cfg.addRule(rule, i+1); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// addRule validates and compiles the rule at position n (counting from 1),
+// naming it after its position if it has no name.
+func (c *Config) addRule(rule Rule, n int) error {
+	if rule.Name == "" {
+		rule.Name = fmt.Sprintf("rule %d", n)
+	}
+	for _, existing := range c.Rules {
+		if existing.Name == rule.Name {
+			return fmt.Errorf("rule '%s' is defined twice", rule.Name)
+		}
+	}
+	switch rule.Action {
+	case ActionKeep, ActionRemove, ActionProtect, ActionArchive:
+	case "":
+		return fmt.Errorf("rule '%s' has no action (expected keep, remove, protect or archive)", rule.Name)
+	default:
+		return fmt.Errorf("rule '%s' has unknown action '%s' (expected keep, remove, protect or archive)", rule.Name, rule.Action)
+	}
+	if rule.Match == (RuleMatch{}) {
+		return fmt.Errorf("rule '%s' has no match criteria", rule.Name)
+	}
+
+	compiled := compiledRule{Rule: rule, against: c.MatchAgainst}
+	criteria := []struct {
+		pattern string
+		regex   **regexp.Regexp
+	}{
+		{rule.Match.Name, &compiled.name},
+		{rule.Match.Server, &compiled.server},
+		{rule.Match.Namespace, &compiled.namespace},
+		{rule.Match.AuthType, &compiled.authType},
+	}
+	for _, criterion := range criteria {
+		if criterion.pattern == "" {
+			continue
+		}
+		regex, err := c.compile(criterion.pattern)
+		if err != nil {
+			return fmt.Errorf("%w '%s' in rule '%s': %w", ErrInvalidPattern, criterion.pattern, rule.Name, err)
+		}
+		*criterion.regex = regex
+	}
+	c.Rules = append(c.Rules, rule)
+	c.rules = append(c.rules, compiled)
+	return nil
+}
+
+// AddCommandLinePatterns adds the --keep and --remove patterns of a single
+// run as rules checked before those of the file and its whitelist: keep
+// patterns first, so a context both match is kept. Protect patterns and
+// rules still win. Like other command-line patterns, they ignore the
+// matching settings of the file, and a server: prefix matches the server.
+func (c *Config) AddCommandLinePatterns(keep, remove []string) error {
+	var rules []compiledRule
+	for _, flag := range []struct {
+		name     string
+		action   Action
+		patterns []string
+	}{
+		{"--keep", ActionKeep, keep},
+		{"--remove", ActionRemove, remove},
+	} {
+		for _, pattern := range flag.patterns {
+			compiled, err := (&Config{}).compileContextPattern(strings.TrimSpace(pattern))
+			if err != nil {
+				return fmt.Errorf("%s: %w", flag.name, err)
+			}
+			rule := compiledRule{Rule: Rule{Name: flag.name + " " + pattern, Action: flag.action}}
+			if server, ok := strings.CutPrefix(strings.TrimSpace(pattern), serverPrefix); ok {
+				rule.Match.Server, rule.server = strings.TrimSpace(server), compiled.regex
+			} else {
+				rule.Match.Name, rule.name = pattern, compiled.regex
+			}
+			rules = append(rules, rule)
+		}
+	}
+	exported := make([]Rule, len(rules))
+	for i, rule := range rules {
+		exported[i] = rule.Rule
+	}
+	c.Rules = append(exported, c.Rules...)
+	c.rules = append(rules, c.rules...)
+	return nil
+}
+
+// ruleMatches reports whether the context meets every criterion of rule
+func (c *Config) ruleMatches(rule compiledRule, ctx ContextInfo) bool {
+	if rule.name != nil && !c.nameMatches(rule.name, rule.against, ctx) {
+		return false
+	}
+	if rule.server != nil && !serverMatches(rule.server, ctx.Server) {
+		return false
+	}
+	if rule.namespace != nil && !rule.namespace.MatchString(ctx.Namespace) {
+		return false
+	}
+	return rule.authType == nil || rule.authType.MatchString(ctx.AuthType)
+}
+
+// MatchContext returns the rule that applies to a context: the rule
+// protecting it, if any, or else the first rule that matches it or else, as
+// a keep rule named after it, the first whitelist pattern that does.
+func (c *Config) MatchContext(ctx ContextInfo) (Rule, bool) {
+	if rule, ok := c.Protected(ctx); ok {
+		return rule, true
+	}
+	for _, rule := range c.rules {
+		if c.ruleMatches(rule, ctx) {
+			return rule.Rule, true
+		}
+	}
+	for i, pattern := range c.patterns {
+		if c.patternMatches(pattern, ctx) {
+			return Rule{Name: c.Whitelist[i], Action: ActionKeep}, true
+		}
+	}
+	return Rule{}, false
+}
+
+// MatchesContext reports whether a context is kept by the rule that applies
+// to it, which keeps or protects it.
+func (c *Config) MatchesContext(ctx ContextInfo) bool {
+	rule, ok := c.MatchContext(ctx)
+	return ok && (rule.Action == ActionKeep || rule.Action == ActionProtect)
+}
+
+// Protected returns the rule protecting a context from removal, if one
+// applies to it: any protect rule matching it, whatever rules come before,
+// or else a protect: pattern, as a protect rule named after it.
+func (c *Config) Protected(ctx ContextInfo) (Rule, bool) {
+	for _, rule := range c.rules {
+		if rule.Action == ActionProtect && c.ruleMatches(rule, ctx) {
+			return rule.Rule, true
+		}
+	}
+	for i, pattern := range c.protected {
+		if c.patternMatches(pattern, ctx) {
+			return Rule{Name: protectPrefix + " " + c.Protect[i], Action: ActionProtect}, true
+		}
+	}
+	return Rule{}, false
+}
+
+// SkipsAuthCheck returns the skip-auth-check pattern exempting a context
+// from auth checks, if one matches it.
+func (c *Config) SkipsAuthCheck(ctx ContextInfo) (string, bool) {
+	for i, pattern := range c.skipAuth {
+		if c.patternMatches(pattern, ctx) {
+			return c.SkipAuthCheck[i], true
+		}
+	}
+	return "", false
+}
+
+// defaultYAMLContent is written to a YAML configuration file that does not exist yet.
+const defaultYAMLContent = `# kubectx-manager configuration
+#
+# Other configuration files to merge in, local (relative to this one) or
+# https://; this file's rules come first and its settings and aliases win
+include: []
+#  - ~/team/kubectx-policy.yaml
+#
+# Rules are checked in order; the first one matching a context decides what
+# cleanup does with it. Criteria are globs, or regular expressions after a
+# regex: or ~ prefix, and a rule matches when all of its criteria do:
+#   name       the context name or one of its aliases
+#   server     the server URL, host or host name of the context's cluster
+#   namespace  the context's namespace
+#   authType   token, client-certificate, basic, exec:<command>, ...
+# Actions:
+#   keep       keep the context
+#   remove     remove the context, even if --auth-check finds it valid
+#   protect    keep the context and refuse to remove or overwrite it with
+#              any command, even if a rule before this one matches it
+#   archive    remove the context into the archive kubeconfig
+# Contexts matching no rule or whitelist pattern are removed, unless
+# --auth-check finds their credentials valid or --older-than finds them in use.
+rules: []
+#  - name: corp clusters
+#    match:
+#      server: "*.corp.example.com"
+#    action: protect
+#  - name: CI leftovers
+#    match:
+#      name: "regex:^(dev|tmp)-\\d+-.*"
+#    action: archive
+
+# Patterns of contexts to keep, as in the line-based ignore file
+whitelist: []
+
+# Patterns of contexts no command may remove or overwrite, as protect: lines
+protect: []
+
+# Patterns of contexts --auth-check neither probes nor removes, such as
+# those only reachable over a VPN
+skipAuthCheck: []
+#  - vpn-only-*
+
+# Friendly names for long context names
+aliases: {}
+#  payments-prod: arn:aws:eks:us-east-1:123456789012:cluster/payments
+
+# Defaults for command-line flags; a flag given on the command line wins
+defaults: {}
+#  authTimeout: 15s
+#  authRetries: 2
+#  authCacheTTL: 1h
+#  certExpiryWindow: 168h
+#  authRemoveOn: unauthorized,expired,no-credentials
+#  unreachableGrace: 7d
+#  backupRetention: 10,30d
+#  olderThan: 30d
+#  inFileDuplicates: first
+#  trackCurrentContext: true
+#  ignoreCase: true
+#  matchMode: prefix
+#  matchAgainst: cluster,user
+`
@@ -0,0 +1,267 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testYAMLConfig = `rules:
+  - name: corp
+    match:
+      server: "*.corp.example.com"
+    action: protect
+  - name: ci leftovers
+    match:
+      name: "regex:^(dev|tmp)-\\d+-.*"
+    action: archive
+  - match:
+      namespace: scratch
+      authType: "exec:*"
+    action: remove
+whitelist:
+  - prod-*
+aliases:
+  ci: dev-1-build
+defaults:
+  authTimeout: 15s
+  authRetries: 2
+  backupRetention: 10,30d
+  olderThan: 30d
+  inFileDuplicates: rename
+`
+
+func writeYAMLConfig(t *testing.T, content string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadYAML(t *testing.T) {
+	cfg, err := Load(writeYAMLConfig(t, testYAMLConfig))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(cfg.Rules) != 3 || cfg.Rules[2].Name != "rule 3" {
+		t.Errorf("Expected three rules, the last named by position, got %+v", cfg.Rules)
+	}
+	if cfg.AuthTimeout != 15*time.Second || cfg.AuthRetries != 2 || cfg.BackupRetention != "10,30d" || cfg.OlderThan != "30d" ||
+		cfg.InFileDuplicates != "rename" {
+		t.Errorf("Unexpected defaults %+v", cfg)
+	}
+	if cfg.ResolveAlias("ci") != "dev-1-build" {
+		t.Errorf("Expected the alias to resolve")
+	}
+
+	tests := []struct {
+		ctx    ContextInfo
+		rule   string
+		action Action
+	}{
+		{ContextInfo{Name: "prod-eu", Server: "https://api.corp.example.com:6443"}, "corp", ActionProtect},
+		{ContextInfo{Name: "dev-1-build"}, "ci leftovers", ActionArchive},
+		{ContextInfo{Name: "x", Namespace: "scratch", AuthType: "exec:aws"}, "rule 3", ActionRemove},
+		{ContextInfo{Name: "x", Namespace: "scratch", AuthType: "token"}, "", ""},
+		{ContextInfo{Name: "prod-us"}, "prod-*", ActionKeep},
+	}
+	for _, tt := range tests {
+		rule, ok := cfg.MatchContext(tt.ctx)
+		if ok != (tt.rule != "") || rule.Name != tt.rule || rule.Action != tt.action {
+			t.Errorf("%+v: expected rule %q (%s), got %q (%s)", tt.ctx, tt.rule, tt.action, rule.Name, rule.Action)
+		}
+	}
+
+	if !cfg.MatchesContext(ContextInfo{Name: "a", Server: "https://b.corp.example.com"}) || cfg.MatchesWhitelist("dev-1-build") {
+		t.Errorf("Expected protected contexts to be kept and archived ones not")
+	}
+	if _, ok := cfg.Protected(ContextInfo{Name: "a", Server: "https://b.corp.example.com"}); !ok {
+		t.Errorf("Expected the corp context to be protected")
+	}
+	unmatched := cfg.UnmatchedContextPatterns([]ContextInfo{{Name: "prod-a"}})
+	if strings.Join(unmatched, ",") != "corp,ci leftovers,rule 3" {
+		t.Errorf("Unexpected unmatched rules %v", unmatched)
+	}
+}
+
+func TestLoadYAMLErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"rules:\n  - name: a\n    action: keep\n", "no match criteria"},
+		{"rules:\n  - name: a\n    match: {name: x}\n", "no action"},
+		{"rules:\n  - name: a\n    match: {name: x}\n    action: delete\n", "unknown action"},
+		{"rules:\n  - name: a\n    match: {name: x}\n    action: keep\n  - name: a\n    match: {name: y}\n    action: keep\n", "defined twice"},
+		{"rules:\n  - name: a\n    match: {name: 'regex:('}\n    action: keep\n", "invalid pattern"},
+		{"rules:\n  - name: a\n    match: {cluster: x}\n    action: keep\n", "field cluster not found"},
+		{"defaults:\n  authTimeout: soon\n", "invalid auth-timeout"},
+	}
+	for _, tt := range tests {
+		if _, err := Load(writeYAMLConfig(t, tt.content)); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%q: expected an error containing %q, got %v", tt.content, tt.err, err)
+		}
+	}
+}
+
+func TestCreateDefaultYAMLConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load the default config: %v", err)
+	}
+	if len(cfg.Rules) != 0 || len(cfg.Whitelist) != 0 {
+		t.Errorf("Expected an empty default config, got %+v", cfg)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil || !strings.Contains(string(data), "rules:") {
+		t.Errorf("Expected a commented YAML template, got %q (%v)", data, err)
+	}
+}
+
+func TestProtectOverridesRules(t *testing.T) {
+	cfg, err := Load(writeYAMLConfig(t, `rules:
+  - name: everything
+    match:
+      name: "*"
+    action: remove
+  - name: prod servers
+    match:
+      server: "*.prod.example.com"
+    action: protect
+protect:
+  - payments
+`))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		ctx      ContextInfo
+		expected string
+	}{
+		{ContextInfo{Name: "eu", Server: "https://eu.prod.example.com"}, "prod servers"},
+		{ContextInfo{Name: "payments"}, "protect: payments"},
+		{ContextInfo{Name: "dev"}, ""},
+	}
+	for _, tt := range tests {
+		rule, ok := cfg.Protected(tt.ctx)
+		if rule.Name != tt.expected || ok != (tt.expected != "") {
+			t.Errorf("%s: expected protection by %q, got %q (%v)", tt.ctx.Name, tt.expected, rule.Name, ok)
+		}
+		if matched, _ := cfg.MatchContext(tt.ctx); ok && matched.Action != ActionProtect {
+			t.Errorf("%s: expected protection to win over the earlier remove rule, got %+v", tt.ctx.Name, matched)
+		}
+	}
+}
+
+func TestSkipsAuthCheck(t *testing.T) {
+	yamlPath := writeYAMLConfig(t, "skipAuthCheck:\n  - vpn-only-*\n  - server:*.corp.internal\n")
+	linePath := filepath.Join(t.TempDir(), ".kubectx-manager_ignore")
+	if err := os.WriteFile(linePath, []byte("prod\nskip-auth-check: vpn-only-*\nskip-auth-check: server:*.corp.internal\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	for _, path := range []string{yamlPath, linePath} {
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", path, err)
+		}
+		if slices.Contains(cfg.Whitelist, "vpn-only-*") || slices.Contains(cfg.Whitelist, "skip-auth-check: vpn-only-*") {
+			t.Errorf("%s: expected skip-auth-check patterns not to be whitelisted, got %v", path, cfg.Whitelist)
+		}
+		tests := []struct {
+			ctx      ContextInfo
+			expected string
+		}{
+			{ContextInfo{Name: "vpn-only-eu"}, "vpn-only-*"},
+			{ContextInfo{Name: "build", Server: "https://build.corp.internal:6443"}, "server:*.corp.internal"},
+			{ContextInfo{Name: "prod"}, ""},
+		}
+		for _, tt := range tests {
+			pattern, ok := cfg.SkipsAuthCheck(tt.ctx)
+			if pattern != tt.expected || ok != (tt.expected != "") {
+				t.Errorf("%s: %s: expected skip-auth-check pattern %q, got %q (%v)", path, tt.ctx.Name, tt.expected, pattern, ok)
+			}
+		}
+	}
+}
+
+func TestRuleMatchingSettings(t *testing.T) {
+	cfg, err := Load(writeYAMLConfig(t, `rules:
+  - name: shared
+    match:
+      name: shared
+      namespace: CI
+    action: archive
+defaults:
+  ignoreCase: true
+  matchMode: prefix
+  matchAgainst: cluster
+`))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	rule, ok := cfg.MatchContext(ContextInfo{Name: "ci-1", Cluster: "Shared-EKS", Namespace: "ci-jobs"})
+	if !ok || rule.Name != "shared" {
+		t.Errorf("Expected the rule to match the cluster name by prefix regardless of case, got %+v", rule)
+	}
+}
+
+func TestAddCommandLinePatterns(t *testing.T) {
+	cfg, err := Load(writeYAMLConfig(t, `
+protect:
+  - prod-*
+rules:
+  - name: keep staging
+    match:
+      name: "staging-*"
+    action: keep
+`))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if err := cfg.AddCommandLinePatterns([]string{"dev-1"}, []string{"*", "server:*.example.com"}); err != nil {
+		t.Fatalf("Failed to add command-line patterns: %v", err)
+	}
+	tests := []struct {
+		ctx    ContextInfo
+		rule   string
+		action Action
+	}{
+		{ContextInfo{Name: "prod-eu"}, "protect: prod-*", ActionProtect},
+		{ContextInfo{Name: "dev-1"}, "--keep dev-1", ActionKeep},
+		{ContextInfo{Name: "staging-1"}, "--remove *", ActionRemove},
+	}
+	for _, tt := range tests {
+		rule, ok := cfg.MatchContext(tt.ctx)
+		if !ok || rule.Name != tt.rule || rule.Action != tt.action {
+			t.Errorf("%s: expected %s by '%s', got %+v, %v", tt.ctx.Name, tt.action, tt.rule, rule, ok)
+		}
+	}
+	if len(cfg.Rules) != 4 || cfg.Rules[2].Match.Server != "*.example.com" || cfg.Rules[3].Name != "keep staging" {
+		t.Errorf("Expected the command-line rules before the file's, got %+v", cfg.Rules)
+	}
+
+	if err := cfg.AddCommandLinePatterns(nil, []string{"regex:("}); !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern, got %v", err)
+	}
+}
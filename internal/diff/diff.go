@@ -0,0 +1,386 @@
+// Package diff renders line-level differences between two texts.
+// It backs every place kubectx-manager shows "what will change", such as dry-run and restore previews.
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// DefaultContext is the number of unchanged lines shown around each change
+	DefaultContext = 3
+)
+
+// ANSI escape sequences used when color output is enabled
+const (
+	colorReset     = "\x1b[0m"
+	colorRed       = "\x1b[31m"
+	colorGreen     = "\x1b[32m"
+	colorCyan      = "\x1b[36m"
+	colorBold      = "\x1b[1m"
+	colorRedBold   = "\x1b[1;41m"
+	colorGreenBold = "\x1b[1;42m"
+)
+
+// Op identifies the kind of change an Edit describes.
+type Op int
+
+const (
+	// Equal marks a line present in both texts
+	Equal Op = iota
+	// Delete marks a line present only in the old text
+	Delete
+	// Insert marks a line present only in the new text
+	Insert
+)
+
+// Edit is a single line of a diff together with the operation that produced it.
+type Edit struct {
+	Text string
+	Op   Op
+}
+
+// Options controls how a diff is rendered.
+type Options struct {
+	// OldName and NewName label the two sides in the diff header
+	OldName string
+	NewName string
+	// Context is the number of unchanged lines shown around each change
+	Context int
+	// Color enables ANSI colors
+	Color bool
+	// WordDiff highlights the changed words inside modified lines (requires Color)
+	WordDiff bool
+}
+
+// Lines computes the line edits that turn a into b.
+func Lines(a, b []string) []Edit {
+	return compute(a, b)
+}
+
+// HasChanges reports whether the edit script contains any insertions or deletions.
+func HasChanges(edits []Edit) bool {
+	for _, e := range edits {
+		if e.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Render writes a unified diff between oldText and newText to w.
+// Nothing is written when the texts are identical.
+func Render(w io.Writer, oldText, newText string, opts Options) error {
+	edits := Lines(splitLines(oldText), splitLines(newText))
+	if !HasChanges(edits) {
+		return nil
+	}
+
+	r := &renderer{w: w, opts: opts}
+	r.header()
+	for _, h := range hunks(edits, opts.Context) {
+		r.hunk(edits[h.start:h.end], h)
+	}
+	return r.err
+}
+
+// String returns the rendered diff between oldText and newText.
+func String(oldText, newText string, opts Options) string {
+	var sb strings.Builder
+	if err := Render(&sb, oldText, newText, opts); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// hunk describes a range of edits and the line numbers it starts at on each side.
+type hunk struct {
+	start, end       int
+	oldLine, newLine int
+	oldLen, newLen   int
+}
+
+// hunks groups edits into ranges of changes surrounded by at most context unchanged lines.
+// Changes separated by no more than twice the context are merged into a single hunk.
+func hunks(edits []Edit, context int) []hunk {
+	if context < 0 {
+		context = 0
+	}
+
+	var changed []int
+	for i, e := range edits {
+		if e.Op != Equal {
+			changed = append(changed, i)
+		}
+	}
+
+	var result []hunk
+	for i := 0; i < len(changed); {
+		first := changed[i]
+		last := first
+		for i < len(changed) && changed[i]-last <= 2*context+1 {
+			last = changed[i]
+			i++
+		}
+		start := first - context
+		if start < 0 {
+			start = 0
+		}
+		end := last + 1 + context
+		if end > len(edits) {
+			end = len(edits)
+		}
+		result = append(result, newHunk(edits, start, end))
+	}
+	return result
+}
+
+// newHunk computes the line numbers and lengths for edits[start:end].
+func newHunk(edits []Edit, start, end int) hunk {
+	h := hunk{start: start, end: end, oldLine: 1, newLine: 1}
+	for _, e := range edits[:start] {
+		if e.Op != Insert {
+			h.oldLine++
+		}
+		if e.Op != Delete {
+			h.newLine++
+		}
+	}
+	for _, e := range edits[start:end] {
+		if e.Op != Insert {
+			h.oldLen++
+		}
+		if e.Op != Delete {
+			h.newLen++
+		}
+	}
+	return h
+}
+
+type renderer struct {
+	w    io.Writer
+	err  error
+	opts Options
+}
+
+func (r *renderer) printf(format string, args ...interface{}) {
+	if r.err != nil {
+		return
+	}
+	_, r.err = fmt.Fprintf(r.w, format, args...)
+}
+
+func (r *renderer) paint(color, text string) string {
+	if !r.opts.Color {
+		return text
+	}
+	return color + text + colorReset
+}
+
+func (r *renderer) header() {
+	oldName, newName := r.opts.OldName, r.opts.NewName
+	if oldName == "" {
+		oldName = "a"
+	}
+	if newName == "" {
+		newName = "b"
+	}
+	r.printf("%s\n", r.paint(colorBold, "--- "+oldName))
+	r.printf("%s\n", r.paint(colorBold, "+++ "+newName))
+}
+
+func (r *renderer) hunk(edits []Edit, h hunk) {
+	r.printf("%s\n", r.paint(colorCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.oldLine, h.oldLen, h.newLine, h.newLen)))
+
+	for i := 0; i < len(edits); {
+		if edits[i].Op == Equal {
+			r.printf(" %s\n", edits[i].Text)
+			i++
+			continue
+		}
+
+		// Collect a block of deletions followed by insertions so modified lines can be paired
+		delStart := i
+		for i < len(edits) && edits[i].Op == Delete {
+			i++
+		}
+		insStart := i
+		for i < len(edits) && edits[i].Op == Insert {
+			i++
+		}
+		deleted := edits[delStart:insStart]
+		inserted := edits[insStart:i]
+
+		if r.opts.Color && r.opts.WordDiff && len(deleted) == len(inserted) {
+			highlighted := make([]string, len(inserted))
+			for j := range deleted {
+				oldLine, newLine := wordDiff(deleted[j].Text, inserted[j].Text)
+				r.printf("%s%s\n", r.paint(colorRed, "-"), oldLine)
+				highlighted[j] = newLine
+			}
+			for _, line := range highlighted {
+				r.printf("%s%s\n", r.paint(colorGreen, "+"), line)
+			}
+			continue
+		}
+
+		for _, e := range deleted {
+			r.printf("%s\n", r.paint(colorRed, "-"+e.Text))
+		}
+		for _, e := range inserted {
+			r.printf("%s\n", r.paint(colorGreen, "+"+e.Text))
+		}
+	}
+}
+
+// wordDiff returns both lines with the words that differ between them highlighted.
+func wordDiff(oldLine, newLine string) (highlightedOld, highlightedNew string) {
+	oldWords := tokenize(oldLine)
+	newWords := tokenize(newLine)
+
+	var oldSB, newSB strings.Builder
+	for _, e := range compute(oldWords, newWords) {
+		switch e.Op {
+		case Equal:
+			oldSB.WriteString(colorRed + e.Text + colorReset)
+			newSB.WriteString(colorGreen + e.Text + colorReset)
+		case Delete:
+			oldSB.WriteString(colorRedBold + e.Text + colorReset)
+		case Insert:
+			newSB.WriteString(colorGreenBold + e.Text + colorReset)
+		}
+	}
+	return oldSB.String(), newSB.String()
+}
+
+// tokenize splits a line into alternating runs of word and non-word characters.
+func tokenize(line string) []string {
+	var tokens []string
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i == len(line) || isWordChar(line[i]) != isWordChar(line[i-1]) {
+			tokens = append(tokens, line[start:i])
+			start = i
+		}
+	}
+	return tokens
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '-' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// compute runs the Myers O(ND) difference algorithm over a and b.
+func compute(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if maxD == 0 {
+		return nil
+	}
+
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		// Only diagonals -d-1..d+1 are consulted when backtracking step d
+		lo, hi := offset-d-1, offset+d+2
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(v) {
+			hi = len(v)
+		}
+		snapshot := make([]int, hi-lo)
+		copy(snapshot, v[lo:hi])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset, d)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the recorded Myers frontiers back from the end to build the edit script.
+func backtrack(trace [][]int, a, b []string, offset, depth int) []Edit {
+	x, y := len(a), len(b)
+	var edits []Edit
+
+	for d := depth; d > 0; d-- {
+		base := offset - d - 1
+		if base < 0 {
+			base = 0
+		}
+		v := func(k int) int { return trace[d][offset+k-base] }
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Op: Equal, Text: a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, Edit{Op: Insert, Text: b[y]})
+		} else {
+			x--
+			edits = append(edits, Edit{Op: Delete, Text: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, Edit{Op: Equal, Text: a[x]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
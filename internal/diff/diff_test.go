@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{"identical", []string{"a", "b"}, []string{"a", "b"}, "=a =b"},
+		{"both empty", nil, nil, ""},
+		{"insert only", nil, []string{"a"}, "+a"},
+		{"delete only", []string{"a"}, nil, "-a"},
+		{"middle change", []string{"a", "b", "c"}, []string{"a", "x", "c"}, "=a -b +x =c"},
+		{"append", []string{"a"}, []string{"a", "b"}, "=a +b"},
+		{"remove block", []string{"a", "b", "c", "d"}, []string{"a", "d"}, "=a -b -c =d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []string
+			for _, e := range Lines(tt.a, tt.b) {
+				prefix := map[Op]string{Equal: "=", Delete: "-", Insert: "+"}[e.Op]
+				parts = append(parts, prefix+e.Text)
+			}
+			if got := strings.Join(parts, " "); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	oldText := "apiVersion: v1\ncontexts:\n- name: dev\n- name: prod\nkind: Config\n"
+	newText := "apiVersion: v1\ncontexts:\n- name: prod\nkind: Config\n"
+
+	output := String(oldText, newText, Options{OldName: "current", NewName: "cleaned", Context: 1})
+
+	expected := `--- current
++++ cleaned
+@@ -2,3 +2,2 @@
+ contexts:
+-- name: dev
+ - name: prod
+`
+	if output != expected {
+		t.Errorf("Unexpected diff output:\n%s\nexpected:\n%s", output, expected)
+	}
+}
+
+func TestRenderIdentical(t *testing.T) {
+	if output := String("a\nb\n", "a\nb\n", Options{Context: DefaultContext}); output != "" {
+		t.Errorf("Expected no output for identical input, got %q", output)
+	}
+}
+
+func TestRenderSeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		oldLines = append(oldLines, line)
+		if i == 2 || i == 17 {
+			line += "-changed"
+		}
+		newLines = append(newLines, line)
+	}
+
+	output := String(strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), Options{Context: 2})
+
+	if count := strings.Count(output, "@@ -"); count != 2 {
+		t.Errorf("Expected 2 hunks, got %d:\n%s", count, output)
+	}
+	if !strings.Contains(output, "@@ -1,5 +1,5 @@") || !strings.Contains(output, "@@ -16,5 +16,5 @@") {
+		t.Errorf("Unexpected hunk headers:\n%s", output)
+	}
+}
+
+func TestRenderColorWordDiff(t *testing.T) {
+	output := String("server: https://old.example.com\n", "server: https://new.example.com\n",
+		Options{Color: true, WordDiff: true})
+
+	if !strings.Contains(output, colorRedBold+"old"+colorReset) {
+		t.Errorf("Expected changed word 'old' to be highlighted, got %q", output)
+	}
+	if !strings.Contains(output, colorGreenBold+"new"+colorReset) {
+		t.Errorf("Expected changed word 'new' to be highlighted, got %q", output)
+	}
+}
+
+func TestRenderNoColor(t *testing.T) {
+	output := String("a\n", "b\n", Options{WordDiff: true})
+	if strings.Contains(output, "\x1b[") {
+		t.Errorf("Expected no ANSI sequences without Color, got %q", output)
+	}
+}
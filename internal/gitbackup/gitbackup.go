@@ -0,0 +1,194 @@
+// Package gitbackup versions kubeconfig files in a git repository, one commit
+// per change, so their history can be audited and any version restored.
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package gitbackup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Identity used for commits when the repository has no user.email configured
+const (
+	defaultUserName  = "kubectx-manager"
+	defaultUserEmail = "kubectx-manager@localhost"
+)
+
+// fieldSeparator separates the fields of a commit in git log output
+const fieldSeparator = "\x1f"
+
+// Repo is a git repository holding kubeconfig versions.
+type Repo struct {
+	Dir string
+}
+
+// Commit describes a commit that changed a kubeconfig.
+type Commit struct {
+	Hash    string    `json:"hash" yaml:"hash"`
+	Time    time.Time `json:"time" yaml:"time"`
+	Subject string    `json:"subject" yaml:"subject"`
+}
+
+// ShortHash returns the abbreviated hash of the commit.
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// Open returns the repository at dir, creating and initializing it if needed.
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, errors.New("git backups need git installed")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create git backup repository: %w", err)
+	}
+	r := &Repo{Dir: dir}
+	if _, err := r.git("rev-parse", "--git-dir"); err != nil {
+		if _, err := r.git("init", "--quiet"); err != nil {
+			return nil, fmt.Errorf("failed to initialize git backup repository: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Commit writes data to file, relative to the repository, and commits it
+// alone with subject and body as message. It reports false, committing
+// nothing, if file already holds data.
+func (r *Repo) Commit(file string, data []byte, subject, body string) (bool, error) {
+	path := filepath.Join(r.Dir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return false, err
+	}
+	if _, err := r.git("add", "--", file); err != nil {
+		return false, err
+	}
+	// diff --quiet exits with 1 when the staged file differs from HEAD
+	if _, err := r.git("diff", "--cached", "--quiet", "--", file); err == nil {
+		return false, nil
+	}
+
+	args := []string{"commit", "--quiet", "-m", subject}
+	if body != "" {
+		args = append(args, "-m", body)
+	}
+	if email, _ := r.git("config", "user.email"); email == "" {
+		args = append([]string{"-c", "user.name=" + defaultUserName, "-c", "user.email=" + defaultUserEmail}, args...)
+	}
+	if _, err := r.git(append(args, "--", file)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Log lists the commits that changed file, newest first.
+func (r *Repo) Log(file string) ([]Commit, error) {
+	out, err := r.git("log", "--format=%H"+fieldSeparator+"%ct"+fieldSeparator+"%s", "--", file)
+	if err != nil {
+		// A repository without commits has no history
+		if _, headErr := r.git("rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var commits []Commit
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		commit, err := parseCommit(line)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// Resolve returns the commit a revision, such as a hash or HEAD~2, names.
+func (r *Repo) Resolve(rev string) (Commit, error) {
+	out, err := r.git("log", "-1", "--format=%H"+fieldSeparator+"%ct"+fieldSeparator+"%s", rev, "--")
+	if err != nil {
+		return Commit{}, fmt.Errorf("unknown revision %q: %w", rev, err)
+	}
+	return parseCommit(out)
+}
+
+// Show returns the contents of file at revision rev.
+func (r *Repo) Show(rev, file string) ([]byte, error) {
+	out, err := r.run("show", rev+":"+file)
+	if err != nil {
+		return nil, fmt.Errorf("no %s at revision %q: %w", file, rev, err)
+	}
+	return out, nil
+}
+
+// HasUpstream reports whether the current branch tracks a remote branch.
+func (r *Repo) HasUpstream() bool {
+	_, err := r.git("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	return err == nil
+}
+
+// Push pushes the current branch to its upstream.
+func (r *Repo) Push() error {
+	_, err := r.git("push", "--quiet")
+	return err
+}
+
+// parseCommit parses a line of git log output in the format Log requests
+func parseCommit(line string) (Commit, error) {
+	fields := strings.SplitN(line, fieldSeparator, 3)
+	if len(fields) != 3 {
+		return Commit{}, fmt.Errorf("unexpected git log output %q", line)
+	}
+	seconds, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return Commit{}, fmt.Errorf("unexpected git log output %q", line)
+	}
+	return Commit{Hash: fields[0], Time: time.Unix(seconds, 0), Subject: fields[2]}, nil
+}
+
+// git runs a git command in the repository and returns its trimmed output
+func (r *Repo) git(args ...string) (string, error) {
+	out, err := r.run(args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// run runs a git command in the repository, never prompting for credentials
+func (r *Repo) run(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.Dir}, args...)...) //nolint:gosec // Arguments are built by this package
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package gitbackup
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func openTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo, err := Open(filepath.Join(t.TempDir(), "history"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return repo
+}
+
+func TestCommitAndLog(t *testing.T) {
+	repo := openTestRepo(t)
+
+	if commits, err := repo.Log("config"); err != nil || len(commits) != 0 {
+		t.Fatalf("Expected no history in a new repository, got %v, %v", commits, err)
+	}
+
+	for i, version := range []string{"v1", "v2"} {
+		committed, err := repo.Commit("config", []byte(version), "Change "+version, "kubectx-manager remove "+version)
+		if err != nil || !committed {
+			t.Fatalf("Commit %d returned %v, %v", i, committed, err)
+		}
+	}
+	// Unchanged contents are not committed again
+	if committed, err := repo.Commit("config", []byte("v2"), "No change", ""); err != nil || committed {
+		t.Errorf("Expected nothing to commit, got %v, %v", committed, err)
+	}
+	if _, err := repo.Commit("other", []byte("x"), "Other file", ""); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	commits, err := repo.Log("config")
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "Change v2" || commits[1].Subject != "Change v1" {
+		t.Fatalf("Expected the two commits of config, newest first, got %+v", commits)
+	}
+
+	data, err := repo.Show(commits[1].ShortHash(), "config")
+	if err != nil || string(data) != "v1" {
+		t.Errorf("Show returned %q, %v", data, err)
+	}
+	commit, err := repo.Resolve("HEAD~1")
+	if err != nil || commit.Hash != commits[0].Hash {
+		t.Errorf("Resolve(HEAD~1) = %+v, %v; expected %s", commit, err, commits[0].Hash)
+	}
+	if _, err := repo.Resolve("no-such-revision"); err == nil {
+		t.Error("Expected an error for an unknown revision")
+	}
+	if _, err := repo.Show(commits[0].Hash, "missing"); err == nil {
+		t.Error("Expected an error for a file missing at the revision")
+	}
+}
+
+func TestPush(t *testing.T) {
+	repo := openTestRepo(t)
+	if repo.HasUpstream() {
+		t.Fatal("Expected a new repository to have no upstream")
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+	if _, err := repo.Commit("config", []byte("v1"), "Initial", ""); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	branch, _ := repo.git("rev-parse", "--abbrev-ref", "HEAD")
+	if _, err := repo.git("remote", "add", "origin", remote); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.git("push", "--quiet", "-u", "origin", branch); err != nil {
+		t.Fatal(err)
+	}
+	if !repo.HasUpstream() {
+		t.Fatal("Expected the branch to track origin")
+	}
+
+	if _, err := repo.Commit("config", []byte("v2"), "Second", ""); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := repo.Push(); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	out, err := exec.Command("git", "-C", remote, "log", "-1", "--format=%s", branch).Output()
+	if err != nil || string(out) != "Second\n" {
+		t.Errorf("Expected the remote to have the new commit, got %q, %v", out, err)
+	}
+}
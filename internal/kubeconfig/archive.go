@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+)
+
+// ArchiveSuffix is appended to the path of a kubeconfig to name its archive.
+const ArchiveSuffix = ".archive"
+
+// ArchivePath returns the path of the archive of the kubeconfig at path,
+// e.g. ~/.kube/config.archive.
+func ArchivePath(path string) string {
+	return path + ArchiveSuffix
+}
+
+// LoadArchive loads the archive of the kubeconfig at path. A missing
+// archive is returned empty.
+func LoadArchive(path string) (*Config, error) {
+	archive, err := Load(ArchivePath(path))
+	if errors.Is(err, ErrKubeconfigNotFound) {
+		archive = &Config{APIVersion: "v1", Kind: "Config"}
+		archive.buildInternalMaps()
+		return archive, nil
+	}
+	return archive, err
+}
+
+// ArchiveContexts adds the named contexts of config, with the clusters and
+// users they reference, to the archive of the kubeconfig at path. Archived
+// entries with the same names are replaced, so the archive keeps the most
+// recently removed version of each.
+func ArchiveContexts(config *Config, names []string, path string) error {
+	archive, err := LoadArchive(path)
+	if err != nil {
+		return err
+	}
+	if _, err := Merge(archive, ExtractContexts(config, names), DuplicateOverwrite); err != nil {
+		return err
+	}
+	// An archive has no current context, so it is never used by accident
+	archive.CurrentContext = ""
+	return Save(archive, ArchivePath(path))
+}
@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveContexts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	archive, err := LoadArchive(path)
+	if err != nil || len(archive.Contexts) != 0 {
+		t.Fatalf("Expected an empty archive before the first removal, got %+v, %v", archive, err)
+	}
+
+	config := &Config{
+		CurrentContext: "dev",
+		Clusters: []NamedCluster{
+			{Name: "dev-cluster", Cluster: &Cluster{Server: "https://dev.example.com"}},
+			{Name: "prod-cluster", Cluster: &Cluster{Server: "https://prod.example.com"}},
+		},
+		Users: []NamedUser{
+			{Name: "dev-user", User: &User{Token: "dev-token"}},
+			{Name: "prod-user", User: &User{Token: "prod-token"}},
+		},
+		Contexts: []NamedContext{
+			{Name: "dev", Context: &Context{Cluster: "dev-cluster", User: "dev-user"}},
+			{Name: "prod", Context: &Context{Cluster: "prod-cluster", User: "prod-user"}},
+		},
+	}
+	config.buildInternalMaps()
+
+	if err := ArchiveContexts(config, []string{"dev"}, path); err != nil {
+		t.Fatalf("ArchiveContexts failed: %v", err)
+	}
+	// A context removed again replaces its archived version
+	config.Clusters[0].Cluster = &Cluster{Server: "https://dev2.example.com"}
+	if err := ArchiveContexts(config, []string{"dev"}, path); err != nil {
+		t.Fatalf("ArchiveContexts failed: %v", err)
+	}
+
+	archive, err = Load(ArchivePath(path))
+	if err != nil {
+		t.Fatalf("Failed to load archive: %v", err)
+	}
+	if len(archive.Contexts) != 1 || archive.Contexts[0].Name != "dev" || len(archive.Clusters) != 1 || len(archive.Users) != 1 {
+		t.Fatalf("Expected only dev with its cluster and user, got %+v", archive)
+	}
+	if server := archive.GetCluster("dev-cluster").Server; server != "https://dev2.example.com" {
+		t.Errorf("Expected the latest version of dev-cluster, got %s", server)
+	}
+	if archive.CurrentContext != "" {
+		t.Errorf("Expected no current context in the archive, got %q", archive.CurrentContext)
+	}
+}
@@ -0,0 +1,322 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// BackupTimeFormat is the timestamp format used for backup file names
+	BackupTimeFormat = "20060102-150405"
+
+	// backupDirMode keeps backups, which hold credentials, private to the owner
+	backupDirMode = 0700
+	// sourceIDLength is the number of hex digits of the path hash in a backup subfolder name
+	sourceIDLength = 8
+)
+
+// DefaultBackupDir returns the directory backups are written to by default:
+// kubectx-manager/backups under $XDG_DATA_HOME, or ~/.local/share when it is
+// unset. It returns "" if the home directory cannot be determined, which
+// writes backups next to the kubeconfig.
+func DefaultBackupDir() string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "kubectx-manager", "backups")
+}
+
+// BackupFolder returns the folder holding the backups of the kubeconfig at
+// path: a subfolder of backupDir named after the kubeconfig and a hash of its
+// absolute path, so kubeconfigs with the same name do not mix. With an empty
+// backupDir it is the kubeconfig's own directory.
+func BackupFolder(path, backupDir string) string {
+	if backupDir == "" {
+		return filepath.Dir(path)
+	}
+	return filepath.Join(backupDir, BackupSource(path))
+}
+
+// BackupSource names the kubeconfig at path after its base name and a hash
+// of its absolute path, e.g. config-1a2b3c4d, telling apart the backups of
+// kubeconfigs with the same name.
+func BackupSource(path string) string {
+	sum := sha256.Sum256([]byte(absPath(path)))
+	return filepath.Base(path) + "-" + hex.EncodeToString(sum[:])[:sourceIDLength]
+}
+
+// Backup represents a kubeconfig backup file with metadata about when it was created.
+// It contains the file path, display name, and timestamp information for restore operations.
+type Backup struct {
+	Name    string
+	Path    string
+	Time    time.Time
+	TimeStr string
+	// Size is the size of the backup file in bytes
+	Size int64
+	// Sequence tells apart backups taken within the same second, whose
+	// names end in a counter: -1 for the second one, and so on
+	Sequence int
+	// Encrypted is set for backups named with AgeSuffix or PassphraseSuffix
+	Encrypted bool
+	// Remote is set for backups only stored on a backup remote; Path is
+	// where they are downloaded to
+	Remote bool
+}
+
+// After reports whether b was taken after other.
+func (b Backup) After(other Backup) bool {
+	if b.Time.Equal(other.Time) {
+		return b.Sequence > other.Sequence
+	}
+	return b.Time.After(other.Time)
+}
+
+// CreateBackupFolder creates the BackupFolder of the kubeconfig at path,
+// readable only by its owner, and returns it.
+func CreateBackupFolder(path, backupDir string) (string, error) {
+	folder := BackupFolder(path, backupDir)
+	if err := os.MkdirAll(folder, backupDirMode); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	return folder, nil
+}
+
+// CreateBackup copies the kubeconfig at path to a timestamped backup in its
+// BackupFolder under backupDir, creating the folder if needed.
+func CreateBackup(path, backupDir string) (string, error) {
+	return CreateEncryptedBackup(path, backupDir, Encryption{})
+}
+
+// CreateEncryptedBackup is CreateBackup that encrypts the backup as enc
+// selects. Encrypted backups are named with AgeSuffix or PassphraseSuffix.
+func CreateEncryptedBackup(path, backupDir string, enc Encryption) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // User-specified backup path is intentional
+	if err != nil {
+		return "", fmt.Errorf("failed to open source file: %w", err)
+	}
+	if data, err = enc.Encrypt(data); err != nil {
+		return "", fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+
+	folder, err := CreateBackupFolder(path, backupDir)
+	if err != nil {
+		return "", err
+	}
+	return WriteNewBackup(folder, filepath.Base(path)+".backup.", enc.Suffix(), data)
+}
+
+// WriteNewBackup writes data to a new file in folder named prefix, the
+// current time in BackupTimeFormat and suffix. A backup taken within the
+// same second gets a counter after the time, -1, -2 and so on, rather than
+// overwriting the earlier one.
+func WriteNewBackup(folder, prefix, suffix string, data []byte) (string, error) {
+	timestamp := time.Now().Format(BackupTimeFormat)
+	for sequence := 0; ; sequence++ {
+		name := prefix + timestamp
+		if sequence > 0 {
+			name += "-" + strconv.Itoa(sequence)
+		}
+		backupPath := filepath.Join(folder, name+suffix)
+		file, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, kubeconfigFileMode) //nolint:gosec // Backup folder derived from the kubeconfig path
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create backup file: %w", err)
+		}
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(backupPath)
+			return "", fmt.Errorf("failed to write backup file: %w", err)
+		}
+		return backupPath, nil
+	}
+}
+
+// FindBackups lists the backups of the kubeconfig at path, newest first.
+// It looks in the kubeconfig's BackupFolder under backupDir and, for backups
+// written before backups moved there, next to the kubeconfig. Files whose
+// suffix is not a backup timestamp are ignored.
+func FindBackups(kubeconfigPath, backupDir string) ([]Backup, error) {
+	backups, err := findBackupsIn(filepath.Dir(kubeconfigPath), filepath.Base(kubeconfigPath))
+	if err != nil {
+		return nil, err
+	}
+	if folder := BackupFolder(kubeconfigPath, backupDir); folder != filepath.Dir(kubeconfigPath) {
+		central, err := findBackupsIn(folder, filepath.Base(kubeconfigPath))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		backups = append(backups, central...)
+	}
+
+	// Sort backups by time (newest first)
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].After(backups[j])
+	})
+
+	return backups, nil
+}
+
+// findBackupsIn lists the backups of the kubeconfig named baseName in dir
+func findBackupsIn(dir, baseName string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	prefix := baseName + ".backup."
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		backupPath := filepath.Join(dir, entry.Name())
+
+		// Extract timestamp from filename
+		timestamp, sequence, encrypted, err := parseBackupSuffix(strings.TrimPrefix(entry.Name(), prefix))
+		if err != nil {
+			continue // Skip files that don't match our backup format
+		}
+
+		backup := Backup{
+			Name:      entry.Name(),
+			Path:      backupPath,
+			Time:      timestamp,
+			TimeStr:   timestamp.Format("2006-01-02 15:04:05"),
+			Sequence:  sequence,
+			Encrypted: encrypted,
+		}
+		if info, err := entry.Info(); err == nil {
+			backup.Size = info.Size()
+		}
+		backups = append(backups, backup)
+	}
+
+	return backups, nil
+}
+
+// parseBackupSuffix parses what follows ".backup." in the name of a backup:
+// its timestamp, the counter of backups taken within the same second, and
+// the suffix of encrypted backups.
+func parseBackupSuffix(suffix string) (timestamp time.Time, sequence int, encrypted bool, err error) {
+	for _, encryptedSuffix := range []string{AgeSuffix, PassphraseSuffix} {
+		if trimmed, ok := strings.CutSuffix(suffix, encryptedSuffix); ok {
+			suffix, encrypted = trimmed, true
+		}
+	}
+	if len(suffix) > len(BackupTimeFormat) {
+		counter, ok := strings.CutPrefix(suffix[len(BackupTimeFormat):], "-")
+		if sequence, err = strconv.Atoi(counter); !ok || err != nil || sequence < 1 {
+			return time.Time{}, 0, false, fmt.Errorf("invalid backup suffix %q", suffix)
+		}
+		suffix = suffix[:len(BackupTimeFormat)]
+	}
+	timestamp, err = time.ParseInLocation(BackupTimeFormat, suffix, time.Local)
+	return timestamp, sequence, encrypted, err
+}
+
+// RemoteBackup describes the backup of the kubeconfig at kubeconfigPath named
+// name on a backup remote, to be downloaded into its BackupFolder under
+// backupDir. Names that are not backups of the kubeconfig are rejected.
+func RemoteBackup(kubeconfigPath, backupDir, name string, size int64) (Backup, error) {
+	suffix, ok := strings.CutPrefix(name, filepath.Base(kubeconfigPath)+".backup.")
+	if !ok || name != filepath.Base(name) {
+		return Backup{}, fmt.Errorf("%s is not a backup of %s", name, kubeconfigPath)
+	}
+	timestamp, sequence, encrypted, err := parseBackupSuffix(suffix)
+	if err != nil {
+		return Backup{}, fmt.Errorf("%s is not a backup of %s", name, kubeconfigPath)
+	}
+	return Backup{
+		Name:      name,
+		Path:      filepath.Join(BackupFolder(kubeconfigPath, backupDir), name),
+		Time:      timestamp,
+		TimeStr:   timestamp.Format("2006-01-02 15:04:05"),
+		Size:      size,
+		Sequence:  sequence,
+		Encrypted: encrypted,
+		Remote:    true,
+	}, nil
+}
+
+// BackupAt describes the backup file at path, which need not be named or
+// placed as FindBackups expects. When its name holds no backup timestamp,
+// the file's modification time is used.
+func BackupAt(path string) (Backup, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Backup{}, err
+	}
+	if info.IsDir() {
+		return Backup{}, fmt.Errorf("%s is a directory", path)
+	}
+
+	name := filepath.Base(path)
+	backup := Backup{Name: name, Path: path, Time: info.ModTime(), Size: info.Size()}
+	i := strings.LastIndex(name, ".backup.")
+	if timestamp, sequence, encrypted, err := parseBackupSuffix(name[i+len(".backup."):]); i >= 0 && err == nil {
+		backup.Time, backup.Sequence, backup.Encrypted = timestamp, sequence, encrypted
+	} else {
+		backup.Encrypted = strings.HasSuffix(name, AgeSuffix) || strings.HasSuffix(name, PassphraseSuffix)
+	}
+	backup.TimeStr = backup.Time.Format("2006-01-02 15:04:05")
+	return backup, nil
+}
+
+// RestoreBackup replaces the kubeconfig at kubeconfigPath with the backup.
+// Encrypted backups fail with ErrBackupEncrypted; use RestoreEncryptedBackup.
+func RestoreBackup(backupPath, kubeconfigPath string) error {
+	return RestoreEncryptedBackup(backupPath, kubeconfigPath, Decryption{})
+}
+
+// RestoreEncryptedBackup is RestoreBackup that decrypts the backup with dec.
+func RestoreEncryptedBackup(backupPath, kubeconfigPath string, dec Decryption) error {
+	// Read backup file
+	data, err := ReadBackup(backupPath, dec)
+	if err != nil {
+		return fmt.Errorf("failed to read backup file: %w", err)
+	}
+
+	if err := checkShrink(kubeconfigPath, data); err != nil {
+		return err
+	}
+
+	// Replace the kubeconfig atomically so an interrupted restore cannot corrupt it
+	err = WriteFile(kubeconfigPath, data)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import "strings"
+
+// Changes lists the entries that differ between two versions of a
+// kubeconfig, as "kind/name", contexts first, then clusters, then users.
+type Changes struct {
+	Added    []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty" yaml:"modified,omitempty"`
+	// Unused lists the removed clusters and users that no context of the
+	// old version referenced
+	Unused []string `json:"unused,omitempty" yaml:"unused,omitempty"`
+	// CurrentContext is set when current-context changes
+	CurrentContext *CurrentContextChange `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+}
+
+// CurrentContextChange is a change of current-context.
+type CurrentContextChange struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// Empty reports whether nothing changed.
+func (c *Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0 && c.CurrentContext == nil
+}
+
+// Compare lists the changes from before to after.
+func Compare(before, after *Config) *Changes {
+	changes := &Changes{}
+	beforeContexts, beforeClusters, beforeUsers := entryNames(before)
+	afterContexts, afterClusters, afterUsers := entryNames(after)
+	compareEntries(changes, "context", beforeContexts, afterContexts, func(name string) bool {
+		return contextsEqual(before.GetContext(name), after.GetContext(name))
+	})
+	compareEntries(changes, "cluster", beforeClusters, afterClusters, func(name string) bool {
+		return clustersEqual(before.GetCluster(name), after.GetCluster(name))
+	})
+	compareEntries(changes, "user", beforeUsers, afterUsers, func(name string) bool {
+		return usersEqual(before.GetUser(name), after.GetUser(name))
+	})
+
+	usedClusters := make(map[string]bool, len(before.Contexts))
+	usedUsers := make(map[string]bool, len(before.Contexts))
+	for _, namedContext := range before.Contexts {
+		if namedContext.Context != nil {
+			usedClusters["cluster/"+namedContext.Context.Cluster] = true
+			usedUsers["user/"+namedContext.Context.User] = true
+		}
+	}
+	for _, key := range changes.Removed {
+		if !strings.HasPrefix(key, "context/") && !usedClusters[key] && !usedUsers[key] {
+			changes.Unused = append(changes.Unused, key)
+		}
+	}
+
+	if before.CurrentContext != after.CurrentContext {
+		changes.CurrentContext = &CurrentContextChange{From: before.CurrentContext, To: after.CurrentContext}
+	}
+	return changes
+}
+
+// compareEntries adds the entries of kind that were added, removed or, as
+// equal reports, modified between the before and after names.
+func compareEntries(changes *Changes, kind string, before, after []string, equal func(name string) bool) {
+	inAfter := make(map[string]bool, len(after))
+	for _, name := range after {
+		inAfter[name] = true
+	}
+	inBefore := make(map[string]bool, len(before))
+	for _, name := range before {
+		inBefore[name] = true
+		switch {
+		case !inAfter[name]:
+			changes.Removed = append(changes.Removed, kind+"/"+name)
+		case !equal(name):
+			changes.Modified = append(changes.Modified, kind+"/"+name)
+		}
+	}
+	for _, name := range after {
+		if !inBefore[name] {
+			changes.Added = append(changes.Added, kind+"/"+name)
+		}
+	}
+}
+
+// entryNames returns the names of the contexts, clusters and users of config, in order.
+func entryNames(config *Config) (contexts, clusters, users []string) {
+	for _, namedContext := range config.Contexts {
+		contexts = append(contexts, namedContext.Name)
+	}
+	for _, namedCluster := range config.Clusters {
+		clusters = append(clusters, namedCluster.Name)
+	}
+	for _, namedUser := range config.Users {
+		users = append(users, namedUser.Name)
+	}
+	return contexts, clusters, users
+}
+
+// PreviewRemoval returns the changes RemoveContexts would make to each of
+// the files, parallel to Paths, leaving them untouched.
+func (m *MultiConfig) PreviewRemoval(contextsToRemove []string) ([]*Changes, error) {
+	preview := &MultiConfig{Paths: m.Paths}
+	before := make([]*Config, len(m.Files))
+	for i, file := range m.Files {
+		data, err := Marshal(file)
+		if err != nil {
+			return nil, err
+		}
+		// Both sides are parsed from the same data, so that only the
+		// removal tells them apart
+		if before[i], err = Parse(data); err != nil {
+			return nil, err
+		}
+		after, err := Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		preview.Files = append(preview.Files, after)
+	}
+	preview.modified = make([]bool, len(preview.Files))
+	preview.merge()
+	if err := preview.RemoveContexts(contextsToRemove); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*Changes, len(before))
+	for i := range before {
+		changes[i] = Compare(before[i], preview.Files[i])
+	}
+	return changes, nil
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Clouds a cluster can be inferred to run in from its server URL
+const (
+	CloudAWS          = "aws"
+	CloudAzure        = "azure"
+	CloudGCP          = "gcp"
+	CloudDigitalOcean = "digitalocean"
+	CloudOracle       = "oracle"
+	CloudOpenShift    = "openshift"
+	CloudIBM          = "ibm"
+	CloudLocal        = "local"
+	CloudOther        = "other"
+)
+
+// cloudDomains maps the domains of managed Kubernetes API servers to their cloud
+var cloudDomains = []struct{ suffix, cloud string }{
+	{".eks.amazonaws.com", CloudAWS},
+	{".azmk8s.io", CloudAzure},
+	{".googleapis.com", CloudGCP},
+	{".k8s.ondigitalocean.com", CloudDigitalOcean},
+	{".oraclecloud.com", CloudOracle},
+	{".openshiftapps.com", CloudOpenShift},
+	{".cloud.ibm.com", CloudIBM},
+	{".appdomain.cloud", CloudIBM},
+}
+
+// Cloud infers where the cluster with the given server URL runs: one of the
+// Cloud constants. Servers on the local machine, as kind, minikube and
+// Docker Desktop set up, are CloudLocal; servers given by an IP address,
+// as GKE's are, and unknown domains are CloudOther.
+func Cloud(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.Hostname() == "" {
+		return CloudOther
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || host == "host.docker.internal" || host == "kubernetes.docker.internal" {
+		return CloudLocal
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() {
+			return CloudLocal
+		}
+		return CloudOther
+	}
+	for _, domain := range cloudDomains {
+		if strings.HasSuffix(host, domain.suffix) {
+			return domain.cloud
+		}
+	}
+	return CloudOther
+}
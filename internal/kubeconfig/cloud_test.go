@@ -0,0 +1,35 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import "testing"
+
+func TestCloud(t *testing.T) {
+	tests := map[string]string{
+		"https://ABC123.gr7.us-east-1.eks.amazonaws.com":                       CloudAWS,
+		"https://payments-dns-1a2b.hcp.westeurope.azmk8s.io:443":               CloudAzure,
+		"https://connectgateway.googleapis.com/v1/projects/p/gkeMemberships/m": CloudGCP,
+		"https://api.demo.abcd.p1.openshiftapps.com:6443":                      CloudOpenShift,
+		"https://127.0.0.1:6443":                                               CloudLocal,
+		"https://[::1]:6443":                                                   CloudLocal,
+		"https://kubernetes.docker.internal:6443":                              CloudLocal,
+		"https://34.118.1.2":                                                   CloudOther,
+		"https://k8s.corp.example.com":                                         CloudOther,
+		"":                                                                     CloudOther,
+	}
+	for server, expected := range tests {
+		if got := Cloud(server); got != expected {
+			t.Errorf("Cloud(%q) = %q, expected %q", server, got, expected)
+		}
+	}
+}
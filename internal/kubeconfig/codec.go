@@ -0,0 +1,28 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+//go:build !clientgo
+
+package kubeconfig
+
+// Backend names the implementation that reads and writes kubeconfig files
+const Backend = "builtin"
+
+// decode parses a kubeconfig with the built-in model
+func decode(data []byte) (*Config, error) {
+	return unmarshalYAML(data)
+}
+
+// encode serializes a kubeconfig with the built-in model
+func encode(config *Config) ([]byte, error) {
+	return marshalYAML(config)
+}
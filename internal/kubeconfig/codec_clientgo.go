@@ -0,0 +1,74 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+//go:build clientgo
+
+package kubeconfig
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Backend names the implementation that reads and writes kubeconfig files
+const Backend = "client-go"
+
+// decode parses a kubeconfig with the built-in model, keeping every key it
+// does not manage, and checks that client-go's clientcmd, and so kubectl,
+// can read it too.
+func decode(data []byte) (*Config, error) {
+	config, err := unmarshalYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateClientGo(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// encode serializes a kubeconfig with the built-in model, laid out as
+// kubectl writes it, once clientcmd has checked that kubectl can read it.
+func encode(config *Config) ([]byte, error) {
+	if err := validateClientGo(config); err != nil {
+		return nil, err
+	}
+	return marshalYAML(config)
+}
+
+// validateClientGo loads config with clientcmd. Repeated names, which
+// clientcmd rejects, are checked with the first definition, the one
+// kubectl uses; they are left to ResolveDuplicates as with the built-in
+// backend. config itself is not changed.
+func validateClientGo(config *Config) error {
+	data, err := marshalYAML(config)
+	if err != nil {
+		return err
+	}
+	if len(FindDuplicates(config)) > 0 {
+		deduplicated, err := unmarshalYAML(data)
+		if err != nil {
+			return err
+		}
+		if _, err := ResolveDuplicates(deduplicated, DuplicatesFirst); err != nil {
+			return err
+		}
+		if data, err = marshalYAML(deduplicated); err != nil {
+			return err
+		}
+	}
+	if _, err := clientcmd.Load(data); err != nil {
+		return fmt.Errorf("client-go cannot read the kubeconfig: %w", err)
+	}
+	return nil
+}
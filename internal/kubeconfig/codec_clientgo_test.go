@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+//go:build clientgo
+
+package kubeconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClientGoBackendRoundTrip(t *testing.T) {
+	content := `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+    extensions:
+    - name: vendor.example.com/meta
+      extension:
+        owner: team-a
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+users:
+- name: dev-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: aws
+      interactiveMode: Never
+      provideClusterInfo: true
+`
+	config, err := Load(writeTempKubeconfig(t, content))
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if user := config.GetUser("dev-user"); user == nil || user.Exec == nil || user.Exec.Command != "aws" {
+		t.Fatalf("Expected the exec user to be loaded, got %+v", user)
+	}
+
+	data, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{
+		"interactiveMode: Never",
+		"provideClusterInfo: true",
+		"name: vendor.example.com/meta",
+		"owner: team-a",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestClientGoBackendKeepsTheModel(t *testing.T) {
+	content := `apiVersion: v1
+clusters:
+- cluster:
+    server: https://b.example.com
+  name: b-cluster
+- cluster:
+    server: https://a.example.com
+  name: a-cluster
+contexts:
+- context:
+    cluster: b-cluster
+    user: b-user
+  name: b
+- context:
+    cluster: a-cluster
+    user: b-user
+    vendor-field: keep-me-too
+  name: a
+- context:
+    cluster: a-cluster
+    user: b-user
+  name: a
+current-context: b
+kind: Config
+preferences: {}
+users:
+- name: b-user
+  user:
+    token: secret
+vendor-setting: keep-me
+`
+	config, err := Load(writeTempKubeconfig(t, content))
+	if err != nil {
+		t.Fatalf("Expected repeated names to be loaded as with the built-in backend, got %v", err)
+	}
+	data, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("Expected the kubeconfig to be written back unchanged, got:\n%s", data)
+	}
+
+	invalid := strings.Replace(content, "    server: https://b.example.com\n",
+		"    server: https://b.example.com\n    certificate-authority-data: not base64\n", 1)
+	if _, err := Load(writeTempKubeconfig(t, invalid)); err == nil {
+		t.Error("Expected an error for a kubeconfig client-go cannot read")
+	}
+}
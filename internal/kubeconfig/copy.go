@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CopyContext adds a context named newName with the configuration of
+// oldName, with each non-empty field of overrides replacing the copied one.
+// The copy references the existing cluster and user entries instead of
+// duplicating them, so overriding clusters and users must already exist.
+func CopyContext(config *Config, oldName, newName string, overrides Context) error {
+	if err := checkRename("context", oldName, newName, config.hasContext); err != nil {
+		return err
+	}
+	if oldName == newName {
+		return fmt.Errorf("%w: context '%s' already exists", ErrConflict, newName)
+	}
+	if overrides.Cluster != "" && !config.hasCluster(overrides.Cluster) {
+		return fmt.Errorf("cluster '%s' not found", overrides.Cluster)
+	}
+	if overrides.User != "" && !config.hasUser(overrides.User) {
+		return fmt.Errorf("user '%s' not found", overrides.User)
+	}
+
+	copied := &Context{}
+	var extra map[string]yaml.Node
+	for _, namedContext := range config.Contexts {
+		if namedContext.Name != oldName {
+			continue
+		}
+		extra = copyExtra(namedContext.Extra)
+		if namedContext.Context != nil {
+			*copied = *namedContext.Context
+			copied.Extra = copyExtra(namedContext.Context.Extra)
+		}
+		break
+	}
+	if overrides.Cluster != "" {
+		copied.Cluster = overrides.Cluster
+	}
+	if overrides.User != "" {
+		copied.User = overrides.User
+	}
+	if overrides.Namespace != "" {
+		copied.Namespace = overrides.Namespace
+	}
+
+	config.Contexts = append(config.Contexts, NamedContext{Name: newName, Context: copied, Extra: extra})
+	config.buildInternalMaps()
+	return nil
+}
+
+// copyExtra returns a copy of the unknown fields of an entry, so the copy
+// and the original can be changed independently.
+func copyExtra(extra map[string]yaml.Node) map[string]yaml.Node {
+	if extra == nil {
+		return nil
+	}
+	copied := make(map[string]yaml.Node, len(extra))
+	for key, value := range extra {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCopyContext(t *testing.T) {
+	config := renameTestConfig()
+	config.GetContext("old").Namespace = "apps"
+	if err := CopyContext(config, "old", "old-viewer", Context{User: "viewer", Namespace: "kube-system"}); err != nil {
+		t.Fatalf("CopyContext failed: %v", err)
+	}
+
+	copied := config.GetContext("old-viewer")
+	if copied == nil || copied.Cluster != "shared" || copied.User != "viewer" || copied.Namespace != "kube-system" {
+		t.Fatalf("Expected a copy on cluster shared with the overrides, got %+v", copied)
+	}
+	if original := config.GetContext("old"); original.User != "admin" || original.Namespace != "apps" {
+		t.Errorf("Expected the original to be unchanged, got %+v", original)
+	}
+	if len(config.Clusters) != 1 || len(config.Users) != 2 || config.CurrentContext != "old" {
+		t.Error("Expected the copy to reuse the existing entries and leave current-context alone")
+	}
+
+	if err := CopyContext(config, "old", "other", Context{}); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict when copying onto an existing context, got %v", err)
+	}
+	if err := CopyContext(config, "old", "old", Context{}); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict when copying onto itself, got %v", err)
+	}
+	if err := CopyContext(config, "missing", "x", Context{}); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+	if err := CopyContext(config, "old", "x", Context{User: "nobody"}); err == nil || config.GetContext("x") != nil {
+		t.Error("Expected an error and no copy for an unknown user")
+	}
+}
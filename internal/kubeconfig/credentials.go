@@ -0,0 +1,226 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Authentication types reported by AuthType
+const (
+	AuthTypeToken             = "token"
+	AuthTypeClientCertificate = "client-certificate"
+	AuthTypeBasic             = "basic"
+	AuthTypeAuthProvider      = "auth-provider"
+	AuthTypeExec              = "exec"
+	AuthTypeNone              = "none"
+)
+
+// Number of dot-separated segments in a JWT
+const jwtSegments = 3
+
+// AuthType returns a short description of how the user authenticates.
+// Auth providers and exec plugins are suffixed with the provider name or command.
+func AuthType(user *User) string {
+	switch {
+	case user == nil:
+		return AuthTypeNone
+	case user.Token != "" || user.TokenFile != "":
+		return AuthTypeToken
+	case user.ClientCertificateData != "" || user.ClientCertificate != "":
+		return AuthTypeClientCertificate
+	case user.Username != "" && user.Password != "":
+		return AuthTypeBasic
+	case user.AuthProvider != nil:
+		return AuthTypeAuthProvider + ":" + user.AuthProvider.Name
+	case user.Exec != nil && user.Exec.Command != "":
+		return AuthTypeExec + ":" + user.Exec.Command
+	default:
+		return AuthTypeNone
+	}
+}
+
+// CredentialExpiry returns when the user's credentials expire, if that can be
+// determined locally from a client certificate or a JWT bearer token.
+func CredentialExpiry(user *User) (time.Time, bool) {
+	if user == nil {
+		return time.Time{}, false
+	}
+	if cert := ClientCertificate(user); cert != nil {
+		return cert.NotAfter, true
+	}
+	if token, err := bearerToken(user); err == nil && token != "" {
+		return TokenExpiry(token)
+	}
+	return time.Time{}, false
+}
+
+// ClientCertificate parses the user's client certificate from inline data or file.
+// It returns nil when the user has no certificate or it cannot be parsed.
+func ClientCertificate(user *User) *x509.Certificate {
+	data, err := credentialData(user.ClientCertificateData, resolvePath(user.dir, user.ClientCertificate))
+	if err != nil || data == nil {
+		return nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+// CheckCertificateAuthority verifies the cluster's CA material the way
+// kubectl will use it: certificate-authority-data must be base64 and
+// certificate-authority a readable file, holding PEM certificates that
+// parse and have not all expired. It returns nil for a cluster without a CA.
+func CheckCertificateAuthority(cluster *Cluster) error {
+	source := "certificate-authority-data"
+	if cluster.CertificateAuthorityData == "" {
+		source = "certificate-authority " + cluster.CertificateAuthority
+	}
+	data, err := credentialData(cluster.CertificateAuthorityData, resolvePath(cluster.dir, cluster.CertificateAuthority))
+	switch {
+	case err != nil && cluster.CertificateAuthorityData != "":
+		return fmt.Errorf("%s is not valid base64: %w", source, err)
+	case err != nil:
+		return fmt.Errorf("%s is not readable: %w", source, err)
+	case data == nil:
+		return nil
+	}
+
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("%s holds an invalid certificate: %w", source, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("%s holds no PEM certificate", source)
+	}
+	latest := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.After(latest.NotAfter) {
+			latest = cert
+		}
+	}
+	if time.Now().After(latest.NotAfter) {
+		return fmt.Errorf("%s expired on %s", source, latest.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// clientKeyPair loads the user's client certificate and key for TLS.
+// It returns nil when the user has no client certificate.
+func clientKeyPair(user *User) (*tls.Certificate, error) {
+	certPEM, err := credentialData(user.ClientCertificateData, resolvePath(user.dir, user.ClientCertificate))
+	if err != nil || certPEM == nil {
+		return nil, err
+	}
+	keyPEM, err := credentialData(user.ClientKeyData, resolvePath(user.dir, user.ClientKey))
+	if err != nil {
+		return nil, err
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &pair, nil
+}
+
+// credentialData returns base64 inline data, or else the contents of path.
+// It returns nil when both are empty.
+func credentialData(inline, path string) ([]byte, error) {
+	switch {
+	case inline != "":
+		return base64.StdEncoding.DecodeString(inline)
+	case path != "":
+		return os.ReadFile(path) //nolint:gosec // Credential path comes from the user's kubeconfig
+	default:
+		return nil, nil
+	}
+}
+
+// resolvePath returns path relative to dir unless it is absolute, empty,
+// or dir is unknown, such as for a kubeconfig that was not loaded from a file.
+func resolvePath(dir, path string) string {
+	if dir == "" || path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// bearerToken returns the user's token, read from tokenFile when no inline
+// token is set, as kubectl does. It returns "" when the user has neither.
+func bearerToken(user *User) (string, error) {
+	if user.Token != "" || user.TokenFile == "" {
+		return user.Token, nil
+	}
+	data, err := os.ReadFile(resolvePath(user.dir, user.TokenFile)) //nolint:gosec // Token path comes from the user's kubeconfig
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// idToken returns the OIDC id-token of the user's auth provider, or "".
+func idToken(user *User) string {
+	if user.AuthProvider == nil {
+		return ""
+	}
+	return user.AuthProvider.Config["id-token"]
+}
+
+// TokenExpiry reads the exp claim of a JWT without verifying its signature.
+// Opaque (non-JWT) tokens report no expiry.
+func TokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtSegments {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
@@ -0,0 +1,245 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateCertData returns a base64-encoded PEM certificate expiring at notAfter.
+func generateCertData(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-user"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// makeJWT returns an unsigned JWT carrying the given claims JSON.
+func makeJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return header + "." + payload + ".sig"
+}
+
+func TestAuthType(t *testing.T) {
+	tests := []struct {
+		user     *User
+		name     string
+		expected string
+	}{
+		{name: "nil user", user: nil, expected: AuthTypeNone},
+		{name: "empty user", user: &User{}, expected: AuthTypeNone},
+		{name: "token", user: &User{Token: "abc"}, expected: AuthTypeToken},
+		{name: "client cert", user: &User{ClientCertificate: "/tmp/cert"}, expected: AuthTypeClientCertificate},
+		{name: "basic", user: &User{Username: "u", Password: "p"}, expected: AuthTypeBasic},
+		{name: "auth provider", user: &User{AuthProvider: &AuthProvider{Name: "oidc"}}, expected: "auth-provider:oidc"},
+		{name: "exec", user: &User{Exec: &ExecConfig{Command: "aws"}}, expected: "exec:aws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AuthType(tt.user); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	expiry, ok := TokenExpiry(makeJWT(`{"sub":"user","exp":1700000000}`))
+	if !ok || !expiry.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected expiry 1700000000, got %v (%v)", expiry, ok)
+	}
+
+	for _, token := range []string{"opaque-token", makeJWT(`{"sub":"user"}`), "a.!!!.c"} {
+		if _, ok := TokenExpiry(token); ok {
+			t.Errorf("Expected no expiry for %q", token)
+		}
+	}
+}
+
+func TestCredentialExpiry(t *testing.T) {
+	notAfter := time.Now().Add(48 * time.Hour).Truncate(time.Second).UTC()
+	user := &User{ClientCertificateData: generateCertData(t, notAfter)}
+
+	expiry, ok := CredentialExpiry(user)
+	if !ok || !expiry.Equal(notAfter) {
+		t.Errorf("Expected certificate expiry %v, got %v (%v)", notAfter, expiry, ok)
+	}
+
+	if _, ok := CredentialExpiry(&User{ClientCertificateData: "bm90IGEgY2VydA=="}); ok {
+		t.Error("Expected no expiry for unparseable certificate data")
+	}
+	if _, ok := CredentialExpiry(&User{Token: "opaque"}); ok {
+		t.Error("Expected no expiry for opaque token")
+	}
+}
+
+func TestCheckCertificateAuthority(t *testing.T) {
+	valid := generateCertData(t, time.Now().Add(24*time.Hour))
+	expired := generateCertData(t, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+	validPEM, err := base64.StdEncoding.DecodeString(valid)
+	if err != nil {
+		t.Fatalf("Failed to decode certificate: %v", err)
+	}
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, validPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	expiredPEM, err := base64.StdEncoding.DecodeString(expired)
+	if err != nil {
+		t.Fatalf("Failed to decode certificate: %v", err)
+	}
+	bundle := base64.StdEncoding.EncodeToString(append(expiredPEM, validPEM...))
+
+	tests := []struct {
+		name    string
+		cluster Cluster
+		wantErr string
+	}{
+		{name: "no CA", cluster: Cluster{}},
+		{name: "valid data", cluster: Cluster{CertificateAuthorityData: valid}},
+		{name: "valid file", cluster: Cluster{CertificateAuthority: caFile}},
+		{name: "bundle with a rotated-out CA", cluster: Cluster{CertificateAuthorityData: bundle}},
+		{name: "not base64", cluster: Cluster{CertificateAuthorityData: "not base64!"}, wantErr: "not valid base64"},
+		{
+			name: "not PEM", cluster: Cluster{CertificateAuthorityData: base64.StdEncoding.EncodeToString([]byte("not a cert"))},
+			wantErr: "no PEM certificate",
+		},
+		{
+			name:    "corrupt certificate",
+			cluster: Cluster{CertificateAuthorityData: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))},
+			wantErr: "invalid certificate",
+		},
+		{name: "missing file", cluster: Cluster{CertificateAuthority: filepath.Join(t.TempDir(), "missing.crt")}, wantErr: "not readable"},
+		{name: "expired", cluster: Cluster{CertificateAuthorityData: expired}, wantErr: "expired on 2020-03-01"},
+	}
+	for _, tt := range tests {
+		err := CheckCertificateAuthority(&tt.cluster)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCheckAuthInvalidCA(t *testing.T) {
+	config := &Config{
+		Contexts: []NamedContext{{Name: "broken", Context: &Context{Cluster: "cluster", User: "user"}}},
+		Clusters: []NamedCluster{{Name: "cluster", Cluster: &Cluster{Server: "https://127.0.0.1:1", CertificateAuthority: "/does/not/exist.crt"}}},
+		Users:    []NamedUser{{Name: "user", User: &User{Token: "token"}}},
+	}
+	config.buildInternalMaps()
+
+	status := CheckAuth(config, "broken", AuthCheckOptions{Offline: true})
+	if status.Valid || status.State != AuthInvalidCA || !strings.Contains(status.Reason, "/does/not/exist.crt") {
+		t.Errorf("Expected a missing CA file to fail the check offline, got %+v", status)
+	}
+	if status := CheckAuth(config, "broken", AuthCheckOptions{Offline: true, RemoveOn: []AuthState{AuthExpired}}); !status.Valid {
+		t.Errorf("Expected --remove-on expired to keep a context with a broken CA, got %+v", status)
+	}
+}
+
+func TestLoadResolvesRelativePaths(t *testing.T) {
+	caPEM, err := base64.StdEncoding.DecodeString(generateCertData(t, time.Now().Add(24*time.Hour)))
+	if err != nil {
+		t.Fatalf("Failed to decode certificate: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), caPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+	path := filepath.Join(dir, "config")
+	content := `apiVersion: v1
+kind: Config
+current-context: relative
+contexts:
+- name: relative
+  context:
+    cluster: cluster
+    user: user
+clusters:
+- name: cluster
+  cluster:
+    server: https://127.0.0.1:1
+    certificate-authority: ca.crt
+users:
+- name: user
+  user:
+    tokenFile: token
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+
+	// Run from another directory, where ca.crt and token do not exist
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if status := CheckAuth(config, "relative", AuthCheckOptions{Offline: true}); !status.Valid {
+		t.Errorf("Expected paths relative to the kubeconfig to resolve, got %+v", status)
+	}
+
+	if err := Save(config, path); err != nil {
+		t.Fatalf("Failed to save kubeconfig: %v", err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read kubeconfig: %v", err)
+	}
+	if !strings.Contains(string(saved), "certificate-authority: ca.crt") || !strings.Contains(string(saved), "tokenFile: token") {
+		t.Errorf("Expected Save to keep relative paths, got:\n%s", saved)
+	}
+}
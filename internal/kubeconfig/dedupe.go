@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DedupeReport maps each removed duplicate to the canonical entry that replaced it
+type DedupeReport struct {
+	Clusters map[string]string `json:"clusters" yaml:"clusters"`
+	Users    map[string]string `json:"users" yaml:"users"`
+}
+
+// Empty reports whether no duplicates were found
+func (r *DedupeReport) Empty() bool {
+	return len(r.Clusters) == 0 && len(r.Users) == 0
+}
+
+// contextsEqual reports whether two contexts have identical configuration
+func contextsEqual(a, b *Context) bool {
+	return yamlEqual(a, b)
+}
+
+// clustersEqual reports whether two clusters have identical configuration
+func clustersEqual(a, b *Cluster) bool {
+	return yamlEqual(a, b)
+}
+
+// usersEqual reports whether two users have identical configuration
+func usersEqual(a, b *User) bool {
+	return yamlEqual(a, b)
+}
+
+// yamlEqual compares two values by their YAML encoding, so preserved unknown
+// fields are compared by content rather than by where they appeared in a file.
+func yamlEqual(a, b interface{}) bool {
+	encodedA, errA := yaml.Marshal(a)
+	encodedB, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// Dedupe collapses clusters and users with identical configuration into the
+// first such entry in file order, points every context at the canonical entry
+// and removes the duplicates.
+func Dedupe(config *Config) *DedupeReport {
+	report := &DedupeReport{
+		Clusters: make(map[string]string),
+		Users:    make(map[string]string),
+	}
+
+	clusters := make([]NamedCluster, 0, len(config.Clusters))
+	for _, candidate := range config.Clusters {
+		canonical := ""
+		for _, kept := range clusters {
+			if clustersEqual(kept.Cluster, candidate.Cluster) {
+				canonical = kept.Name
+				break
+			}
+		}
+		if canonical == "" {
+			clusters = append(clusters, candidate)
+			continue
+		}
+		report.Clusters[candidate.Name] = canonical
+	}
+
+	users := make([]NamedUser, 0, len(config.Users))
+	for _, candidate := range config.Users {
+		canonical := ""
+		for _, kept := range users {
+			if usersEqual(kept.User, candidate.User) {
+				canonical = kept.Name
+				break
+			}
+		}
+		if canonical == "" {
+			users = append(users, candidate)
+			continue
+		}
+		report.Users[candidate.Name] = canonical
+	}
+
+	if report.Empty() {
+		return report
+	}
+
+	for i := range config.Contexts {
+		ctx := config.Contexts[i].Context
+		if ctx == nil {
+			continue
+		}
+		if canonical, ok := report.Clusters[ctx.Cluster]; ok {
+			ctx.Cluster = canonical
+		}
+		if canonical, ok := report.Users[ctx.User]; ok {
+			ctx.User = canonical
+		}
+	}
+	config.Clusters = clusters
+	config.Users = users
+	config.buildInternalMaps()
+	return report
+}
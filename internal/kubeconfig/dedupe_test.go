@@ -0,0 +1,290 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDedupe(t *testing.T) {
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "a", Context: &Context{Cluster: "prod", User: "admin"}},
+			{Name: "b", Context: &Context{Cluster: "prod-copy", User: "admin-copy"}},
+			{Name: "c", Context: &Context{Cluster: "dev", User: "viewer"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "prod", Cluster: &Cluster{Server: "https://prod.example.com"}},
+			{Name: "prod-copy", Cluster: &Cluster{Server: "https://prod.example.com"}},
+			{Name: "dev", Cluster: &Cluster{Server: "https://dev.example.com"}},
+		},
+		Users: []NamedUser{
+			{Name: "admin", User: &User{Token: "secret"}},
+			{Name: "admin-copy", User: &User{Token: "secret"}},
+			{Name: "viewer", User: &User{Token: "other"}},
+		},
+	}
+	config.buildInternalMaps()
+
+	report := Dedupe(config)
+
+	if report.Clusters["prod-copy"] != "prod" || len(report.Clusters) != 1 {
+		t.Errorf("Expected prod-copy to collapse into prod, got %v", report.Clusters)
+	}
+	if report.Users["admin-copy"] != "admin" || len(report.Users) != 1 {
+		t.Errorf("Expected admin-copy to collapse into admin, got %v", report.Users)
+	}
+	if len(config.Clusters) != 2 || config.GetCluster("prod-copy") != nil {
+		t.Errorf("Expected duplicate cluster to be removed, got %+v", config.Clusters)
+	}
+	if len(config.Users) != 2 || config.GetUser("admin-copy") != nil {
+		t.Errorf("Expected duplicate user to be removed, got %+v", config.Users)
+	}
+	if ctx := config.GetContext("b"); ctx.Cluster != "prod" || ctx.User != "admin" {
+		t.Errorf("Expected context b to reference the canonical entries, got %+v", ctx)
+	}
+
+	if again := Dedupe(config); !again.Empty() {
+		t.Errorf("Expected a second pass to find nothing, got %+v", again)
+	}
+}
+
+func TestContextsEqual(t *testing.T) {
+	tests := []struct {
+		a        *Context
+		b        *Context
+		name     string
+		expected bool
+	}{
+		{
+			name:     "identical contexts",
+			a:        &Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
+			b:        &Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
+			expected: true,
+		},
+		{
+			name:     "different cluster",
+			a:        &Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
+			b:        &Context{Cluster: "c2", User: "u1", Namespace: "ns1"},
+			expected: false,
+		},
+		{
+			name:     "different user",
+			a:        &Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
+			b:        &Context{Cluster: "c1", User: "u2", Namespace: "ns1"},
+			expected: false,
+		},
+		{
+			name:     "different namespace",
+			a:        &Context{Cluster: "c1", User: "u1", Namespace: "ns1"},
+			b:        &Context{Cluster: "c1", User: "u1", Namespace: "ns2"},
+			expected: false,
+		},
+		{
+			name:     "empty namespace vs set namespace",
+			a:        &Context{Cluster: "c1", User: "u1", Namespace: ""},
+			b:        &Context{Cluster: "c1", User: "u1", Namespace: "default"},
+			expected: false,
+		},
+		{
+			name:     "different unknown field",
+			a:        &Context{Cluster: "c1", User: "u1", Extra: map[string]yaml.Node{"extensions": {Kind: yaml.ScalarNode, Value: "a"}}},
+			b:        &Context{Cluster: "c1", User: "u1"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := contextsEqual(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClustersEqual(t *testing.T) {
+	tests := []struct {
+		a        *Cluster
+		b        *Cluster
+		name     string
+		expected bool
+	}{
+		{
+			name: "identical clusters",
+			a: &Cluster{
+				Server:                   "https://api.example.com",
+				CertificateAuthorityData: "cert-data",
+				InsecureSkipTLSVerify:    false,
+			},
+			b: &Cluster{
+				Server:                   "https://api.example.com",
+				CertificateAuthorityData: "cert-data",
+				InsecureSkipTLSVerify:    false,
+			},
+			expected: true,
+		},
+		{
+			name: "different server",
+			a: &Cluster{
+				Server: "https://api1.example.com",
+			},
+			b: &Cluster{
+				Server: "https://api2.example.com",
+			},
+			expected: false,
+		},
+		{
+			name: "different certificate data",
+			a: &Cluster{
+				Server:                   "https://api.example.com",
+				CertificateAuthorityData: "cert-data-1",
+			},
+			b: &Cluster{
+				Server:                   "https://api.example.com",
+				CertificateAuthorityData: "cert-data-2",
+			},
+			expected: false,
+		},
+		{
+			name: "different insecure skip TLS",
+			a: &Cluster{
+				Server:                "https://api.example.com",
+				InsecureSkipTLSVerify: true,
+			},
+			b: &Cluster{
+				Server:                "https://api.example.com",
+				InsecureSkipTLSVerify: false,
+			},
+			expected: false,
+		},
+		{
+			name: "different proxy URL",
+			a: &Cluster{
+				Server:   "https://api.example.com",
+				ProxyURL: "http://proxy.example.com",
+			},
+			b: &Cluster{
+				Server: "https://api.example.com",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := clustersEqual(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUsersEqual(t *testing.T) {
+	tests := []struct {
+		a        *User
+		b        *User
+		name     string
+		expected bool
+	}{
+		{
+			name: "identical token users",
+			a: &User{
+				Token: "abc123",
+			},
+			b: &User{
+				Token: "abc123",
+			},
+			expected: true,
+		},
+		{
+			name: "different tokens",
+			a: &User{
+				Token: "abc123",
+			},
+			b: &User{
+				Token: "def456",
+			},
+			expected: false,
+		},
+		{
+			name: "identical cert users",
+			a: &User{
+				ClientCertificateData: "cert-data",
+				ClientKeyData:         "key-data",
+			},
+			b: &User{
+				ClientCertificateData: "cert-data",
+				ClientKeyData:         "key-data",
+			},
+			expected: true,
+		},
+		{
+			name: "different cert data",
+			a: &User{
+				ClientCertificateData: "cert-data-1",
+			},
+			b: &User{
+				ClientCertificateData: "cert-data-2",
+			},
+			expected: false,
+		},
+		{
+			name: "identical basic auth users",
+			a: &User{
+				Username: "admin",
+				Password: "secret",
+			},
+			b: &User{
+				Username: "admin",
+				Password: "secret",
+			},
+			expected: true,
+		},
+		{
+			name: "different passwords",
+			a: &User{
+				Username: "admin",
+				Password: "secret1",
+			},
+			b: &User{
+				Username: "admin",
+				Password: "secret2",
+			},
+			expected: false,
+		},
+		{
+			name: "different exec plugin",
+			a: &User{
+				Exec: &ExecConfig{Command: "aws"},
+			},
+			b: &User{
+				Exec: &ExecConfig{Command: "gcloud"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := usersEqual(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
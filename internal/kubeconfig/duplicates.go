@@ -0,0 +1,205 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicatePolicy decides what happens when a kubeconfig defines an entry
+// under the same name as an earlier entry of the same kind in that same file.
+// Unlike DuplicateStrategy, it is about a single file, such as a backup or a
+// file being merged, not about entries clashing between two files.
+type DuplicatePolicy string
+
+const (
+	// DuplicatesError rejects the file
+	DuplicatesError DuplicatePolicy = "error"
+	// DuplicatesFirst keeps the first definition, the one kubectl uses
+	DuplicatesFirst DuplicatePolicy = "first"
+	// DuplicatesLast keeps the last definition
+	DuplicatesLast DuplicatePolicy = "last"
+	// DuplicatesRename keeps every definition, renaming all but the first
+	DuplicatesRename DuplicatePolicy = "rename"
+)
+
+// DuplicatePolicies lists every supported policy
+var DuplicatePolicies = []DuplicatePolicy{DuplicatesError, DuplicatesFirst, DuplicatesLast, DuplicatesRename}
+
+// ParseDuplicatePolicy validates a policy name
+func ParseDuplicatePolicy(name string) (DuplicatePolicy, error) {
+	names := make([]string, len(DuplicatePolicies))
+	for i, policy := range DuplicatePolicies {
+		if string(policy) == name {
+			return policy, nil
+		}
+		names[i] = string(policy)
+	}
+	return "", fmt.Errorf("unknown duplicate policy %q (expected one of: %s)", name, strings.Join(names, ", "))
+}
+
+// DuplicateNameError is returned by ResolveDuplicates with DuplicatesError
+// when a file defines an entry twice with different configurations
+type DuplicateNameError struct {
+	Kind string
+	Name string
+}
+
+func (e *DuplicateNameError) Error() string {
+	return fmt.Sprintf("%s '%s' is defined more than once with different configurations", e.Kind, e.Name)
+}
+
+// Is reports DuplicateNameError as an ErrConflict
+func (e *DuplicateNameError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// FindDuplicates lists the names config defines more than once, each once:
+// contexts first, then clusters, then users, each in file order.
+func FindDuplicates(config *Config) []Conflict {
+	var duplicates []Conflict
+	add := func(kind string, names []string) {
+		seen := make(map[string]int, len(names))
+		for _, name := range names {
+			seen[name]++
+			if seen[name] == 2 {
+				duplicates = append(duplicates, Conflict{Kind: kind, Name: name})
+			}
+		}
+	}
+	add("context", contextNames(config.Contexts))
+	add("cluster", clusterNames(config.Clusters))
+	add("user", userNames(config.Users))
+	return duplicates
+}
+
+// ResolveDuplicates leaves config with a single entry of each name, as
+// policy says, and returns the duplicates it found. Repeated definitions
+// identical to the one kept are dropped whatever the policy. Contexts
+// referencing a duplicated cluster or user keep their reference, so they
+// use the definition kept under the original name. With DuplicatesError,
+// config is left unchanged and a DuplicateNameError returned.
+func ResolveDuplicates(config *Config, policy DuplicatePolicy) ([]Conflict, error) {
+	if _, err := ParseDuplicatePolicy(string(policy)); err != nil {
+		return nil, err
+	}
+	duplicates := FindDuplicates(config)
+	if len(duplicates) == 0 {
+		return nil, nil
+	}
+
+	contexts := config.Contexts
+	contextKeep, contextNames, err := resolveDuplicateNames("context", contextNames(contexts), policy,
+		func(i, j int) bool { return contextsEqual(contexts[i].Context, contexts[j].Context) })
+	if err != nil {
+		return nil, err
+	}
+	clusters := config.Clusters
+	clusterKeep, clusterNames, err := resolveDuplicateNames("cluster", clusterNames(clusters), policy,
+		func(i, j int) bool { return clustersEqual(clusters[i].Cluster, clusters[j].Cluster) })
+	if err != nil {
+		return nil, err
+	}
+	users := config.Users
+	userKeep, userNames, err := resolveDuplicateNames("user", userNames(users), policy,
+		func(i, j int) bool { return usersEqual(users[i].User, users[j].User) })
+	if err != nil {
+		return nil, err
+	}
+
+	config.Contexts = nil
+	for i, c := range contexts {
+		if contextKeep[i] {
+			c.Name = contextNames[i]
+			config.Contexts = append(config.Contexts, c)
+		}
+	}
+	config.Clusters = nil
+	for i, c := range clusters {
+		if clusterKeep[i] {
+			c.Name = clusterNames[i]
+			config.Clusters = append(config.Clusters, c)
+		}
+	}
+	config.Users = nil
+	for i, u := range users {
+		if userKeep[i] {
+			u.Name = userNames[i]
+			config.Users = append(config.Users, u)
+		}
+	}
+	config.buildInternalMaps()
+	return duplicates, nil
+}
+
+// resolveDuplicateNames decides which of the entries named names to keep,
+// and under which name, as policy says; equal reports whether two entries
+// are identical.
+func resolveDuplicateNames(kind string, names []string, policy DuplicatePolicy, equal func(i, j int) bool) ([]bool, []string, error) {
+	keep := make([]bool, len(names))
+	resolved := append([]string(nil), names...)
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[name] = true
+	}
+	// kept maps each name to the index of the entry currently kept under it
+	kept := make(map[string]int, len(names))
+	for i, name := range names {
+		j, seen := kept[name]
+		switch {
+		case !seen:
+			kept[name] = i
+			keep[i] = true
+		case equal(i, j):
+			// A repeated identical definition loses nothing
+		case policy == DuplicatesFirst:
+		case policy == DuplicatesLast:
+			keep[j] = false
+			keep[i] = true
+			kept[name] = i
+		case policy == DuplicatesRename:
+			renamed := uniqueName(name, "", func(candidate string) bool { return taken[candidate] })
+			taken[renamed] = true
+			resolved[i] = renamed
+			keep[i] = true
+		default:
+			return nil, nil, &DuplicateNameError{Kind: kind, Name: name}
+		}
+	}
+	return keep, resolved, nil
+}
+
+func contextNames(contexts []NamedContext) []string {
+	names := make([]string, len(contexts))
+	for i, c := range contexts {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func clusterNames(clusters []NamedCluster) []string {
+	names := make([]string, len(clusters))
+	for i, c := range clusters {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func userNames(users []NamedUser) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	return names
+}
@@ -0,0 +1,150 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newDuplicatesTestConfig returns a config defining cluster "shared" twice
+// with different servers, context "ctx" twice identically, and user "u" once.
+func newDuplicatesTestConfig() *Config {
+	cfg := &Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []NamedCluster{
+			{Name: "shared", Cluster: &Cluster{Server: "https://first.example.com"}},
+			{Name: "shared", Cluster: &Cluster{Server: "https://last.example.com"}},
+		},
+		Users: []NamedUser{{Name: "u", User: &User{Token: "token"}}},
+		Contexts: []NamedContext{
+			{Name: "ctx", Context: &Context{Cluster: "shared", User: "u"}},
+			{Name: "ctx", Context: &Context{Cluster: "shared", User: "u"}},
+		},
+	}
+	cfg.buildInternalMaps()
+	return cfg
+}
+
+func TestFindDuplicates(t *testing.T) {
+	got := FindDuplicates(newDuplicatesTestConfig())
+	want := []Conflict{{Kind: "context", Name: "ctx"}, {Kind: "cluster", Name: "shared"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := FindDuplicates(newMergeTestConfig("https://a.example.com", "token", "a", "b")); len(got) != 0 {
+		t.Errorf("Expected no duplicates, got %v", got)
+	}
+}
+
+func TestResolveDuplicates(t *testing.T) {
+	tests := []struct {
+		policy   DuplicatePolicy
+		clusters []string
+		servers  []string
+	}{
+		{DuplicatesFirst, []string{"shared"}, []string{"https://first.example.com"}},
+		{DuplicatesLast, []string{"shared"}, []string{"https://last.example.com"}},
+		{DuplicatesRename, []string{"shared", "shared-1"}, []string{"https://first.example.com", "https://last.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			cfg := newDuplicatesTestConfig()
+			duplicates, err := ResolveDuplicates(cfg, tt.policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(duplicates) != 2 {
+				t.Errorf("Expected the context and cluster to be reported, got %v", duplicates)
+			}
+
+			var clusters, servers []string
+			for _, c := range cfg.Clusters {
+				clusters = append(clusters, c.Name)
+				servers = append(servers, c.Cluster.Server)
+			}
+			if !reflect.DeepEqual(clusters, tt.clusters) || !reflect.DeepEqual(servers, tt.servers) {
+				t.Errorf("Expected clusters %v with servers %v, got %v with %v", tt.clusters, tt.servers, clusters, servers)
+			}
+			// The identical context is collapsed whatever the policy
+			if len(cfg.Contexts) != 1 {
+				t.Errorf("Expected the identical contexts to collapse, got %v", cfg.GetContextNames())
+			}
+			if got := cfg.GetCluster("shared").Server; got != tt.servers[0] {
+				t.Errorf("Expected GetCluster to see %q, got %q", tt.servers[0], got)
+			}
+		})
+	}
+}
+
+func TestResolveDuplicatesError(t *testing.T) {
+	cfg := newDuplicatesTestConfig()
+	_, err := ResolveDuplicates(cfg, DuplicatesError)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected a conflict error, got %v", err)
+	}
+	var duplicate *DuplicateNameError
+	if !errors.As(err, &duplicate) || duplicate.Kind != "cluster" || duplicate.Name != "shared" {
+		t.Errorf("Expected the duplicated cluster to be named, got %v", err)
+	}
+	if len(cfg.Clusters) != 2 || len(cfg.Contexts) != 2 {
+		t.Error("Expected the config to be left unchanged")
+	}
+
+	// Identical repeats alone are not an error
+	cfg.Clusters = cfg.Clusters[:1]
+	if _, err := ResolveDuplicates(cfg, DuplicatesError); err != nil {
+		t.Errorf("Expected identical repeats to be accepted, got %v", err)
+	}
+	if len(cfg.Contexts) != 1 {
+		t.Errorf("Expected the identical contexts to collapse, got %v", cfg.GetContextNames())
+	}
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	for _, policy := range DuplicatePolicies {
+		if got, err := ParseDuplicatePolicy(string(policy)); err != nil || got != policy {
+			t.Errorf("ParseDuplicatePolicy(%q) = %q, %v", policy, got, err)
+		}
+	}
+	if _, err := ParseDuplicatePolicy("skip"); err == nil {
+		t.Error("Expected an unknown policy to be rejected")
+	}
+}
+
+func TestMergeResolvesDuplicatesInSource(t *testing.T) {
+	dst := newMergeTestConfig("https://other.example.com", "token", "existing")
+	dst.Clusters[0].Name = "other"
+	src := newDuplicatesTestConfig()
+
+	if _, err := MergeWithOptions(dst, src, MergeOptions{Strategy: DuplicateFail, Duplicates: DuplicatesError}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected the error policy to reject the source, got %v", err)
+	}
+
+	report, err := MergeWithOptions(dst, src, MergeOptions{Strategy: DuplicateFail, Duplicates: DuplicatesLast})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := dst.GetCluster("shared").Server; got != "https://last.example.com" {
+		t.Errorf("Expected the last definition to be merged, got %q", got)
+	}
+	if want := []string{"context/ctx", "cluster/shared"}; !reflect.DeepEqual(report.Duplicates, want) {
+		t.Errorf("Expected duplicates %v, got %v", want, report.Duplicates)
+	}
+	if len(src.Clusters) != 2 {
+		t.Error("Expected the source to be left unchanged")
+	}
+}
@@ -0,0 +1,225 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Suffixes appended to the names of encrypted backups
+const (
+	// AgeSuffix marks a backup encrypted to age recipients
+	AgeSuffix = ".age"
+	// PassphraseSuffix marks a backup encrypted with a passphrase
+	PassphraseSuffix = ".enc"
+)
+
+// Passphrase encryption: an scrypt-derived key seals the backup with AES-256-GCM.
+// The file is passphraseHeader, the salt, the nonce and the sealed data.
+const (
+	passphraseHeader = "kubectx-manager-encrypted/v1\n"
+	saltSize         = 16
+	keySize          = 32
+	scryptN          = 1 << 15
+	scryptR          = 8
+	scryptP          = 1
+)
+
+// ErrBackupEncrypted is returned when reading an encrypted backup without the key to decrypt it.
+var ErrBackupEncrypted = errors.New("backup is encrypted")
+
+// Encryption selects how new backups are encrypted. With Recipients set,
+// backups are encrypted to those age public keys; otherwise with Passphrase.
+// The zero value leaves backups unencrypted.
+type Encryption struct {
+	// Recipients are age public keys ("age1...")
+	Recipients []string
+	Passphrase string
+}
+
+// IsZero reports whether backups are left unencrypted.
+func (e Encryption) IsZero() bool {
+	return len(e.Recipients) == 0 && e.Passphrase == ""
+}
+
+// Suffix returns the file name suffix of backups encrypted this way.
+func (e Encryption) Suffix() string {
+	switch {
+	case len(e.Recipients) > 0:
+		return AgeSuffix
+	case e.Passphrase != "":
+		return PassphraseSuffix
+	default:
+		return ""
+	}
+}
+
+// Encrypt seals data as selected by e; the zero value returns data unchanged.
+func (e Encryption) Encrypt(data []byte) ([]byte, error) {
+	if len(e.Recipients) > 0 {
+		return encryptAge(data, e.Recipients)
+	}
+	if e.Passphrase != "" {
+		return encryptPassphrase(data, e.Passphrase)
+	}
+	return data, nil
+}
+
+// Decryption holds the keys for reading encrypted backups.
+type Decryption struct {
+	// IdentityFile is an age identity file, as written by age-keygen
+	IdentityFile string
+	Passphrase   string
+}
+
+// ReadBackup returns the kubeconfig stored in the backup file at path,
+// decrypting it with dec if it is encrypted. ErrBackupEncrypted is returned
+// if dec holds no key for the backup's kind of encryption.
+func ReadBackup(path string, dec Decryption) ([]byte, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // User-selected backup file path is intentional
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasSuffix(path, AgeSuffix):
+		if dec.IdentityFile == "" {
+			return nil, fmt.Errorf("%w with age: an identity file is needed", ErrBackupEncrypted)
+		}
+		return decryptAge(data, dec.IdentityFile)
+	case strings.HasSuffix(path, PassphraseSuffix):
+		if dec.Passphrase == "" {
+			return nil, fmt.Errorf("%w with a passphrase", ErrBackupEncrypted)
+		}
+		return decryptPassphrase(data, dec.Passphrase)
+	default:
+		return data, nil
+	}
+}
+
+// LoadBackup reads the backup file at path as ReadBackup does and parses it.
+func LoadBackup(path string, dec Decryption) (*Config, error) {
+	data, err := ReadBackup(path, dec)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func encryptAge(data []byte, recipientKeys []string) ([]byte, error) {
+	recipients := make([]age.Recipient, 0, len(recipientKeys))
+	for _, key := range recipientKeys {
+		recipient, err := age.ParseX25519Recipient(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient %q: %w", key, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func decryptAge(data []byte, identityFile string) ([]byte, error) {
+	file, err := os.Open(identityFile) //nolint:gosec // User-specified identity file is intentional
+	if err != nil {
+		return nil, fmt.Errorf("failed to open age identity file: %w", err)
+	}
+	defer file.Close() //nolint:errcheck // Read-only file
+
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identity file: %w", err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup: %w", err)
+	}
+	return io.ReadAll(r)
+}
+
+func encryptPassphrase(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := passphraseAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(passphraseHeader)+saltSize+len(nonce)+len(data)+aead.Overhead())
+	out = append(out, passphraseHeader...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, []byte(passphraseHeader)), nil
+}
+
+func decryptPassphrase(data []byte, passphrase string) ([]byte, error) {
+	rest, ok := bytes.CutPrefix(data, []byte(passphraseHeader))
+	if !ok || len(rest) < saltSize {
+		return nil, errors.New("not a passphrase-encrypted backup")
+	}
+	salt, rest := rest[:saltSize], rest[saltSize:]
+	aead, err := passphraseAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, errors.New("not a passphrase-encrypted backup")
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, []byte(passphraseHeader))
+	if err != nil {
+		return nil, errors.New("failed to decrypt backup: wrong passphrase or corrupt file")
+	}
+	return plain, nil
+}
+
+// passphraseAEAD derives the AES-256-GCM cipher for passphrase and salt
+func passphraseAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+const encryptTestKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+users:
+- name: dev
+  user:
+    token: secret-token
+`
+
+func TestEncryptedBackupRoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate age identity: %v", err)
+	}
+	tmpDir := t.TempDir()
+	identityFile := filepath.Join(tmpDir, "key.txt")
+	if err := os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write identity: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		enc    Encryption
+		dec    Decryption
+		suffix string
+	}{
+		{
+			name:   "age",
+			enc:    Encryption{Recipients: []string{identity.Recipient().String()}},
+			dec:    Decryption{IdentityFile: identityFile},
+			suffix: AgeSuffix,
+		},
+		{
+			name:   "passphrase",
+			enc:    Encryption{Passphrase: "correct horse"},
+			dec:    Decryption{Passphrase: "correct horse"},
+			suffix: PassphraseSuffix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			kubeconfigPath := filepath.Join(dir, "config")
+			if err := os.WriteFile(kubeconfigPath, []byte(encryptTestKubeconfig), 0600); err != nil {
+				t.Fatalf("Failed to write kubeconfig: %v", err)
+			}
+
+			backupPath, err := CreateEncryptedBackup(kubeconfigPath, filepath.Join(dir, "backups"), tt.enc)
+			if err != nil {
+				t.Fatalf("Failed to create backup: %v", err)
+			}
+			if !strings.HasSuffix(backupPath, tt.suffix) {
+				t.Errorf("Expected backup name to end in %s, got %s", tt.suffix, backupPath)
+			}
+			raw, err := os.ReadFile(backupPath)
+			if err != nil {
+				t.Fatalf("Failed to read backup: %v", err)
+			}
+			if bytes.Contains(raw, []byte("secret-token")) {
+				t.Error("Expected the token not to be stored in plaintext")
+			}
+
+			if _, err := ReadBackup(backupPath, Decryption{}); !errors.Is(err, ErrBackupEncrypted) {
+				t.Errorf("Expected ErrBackupEncrypted without a key, got %v", err)
+			}
+			config, err := LoadBackup(backupPath, tt.dec)
+			if err != nil {
+				t.Fatalf("Failed to load backup: %v", err)
+			}
+			if config.CurrentContext != "dev" {
+				t.Errorf("Expected current context dev, got %q", config.CurrentContext)
+			}
+
+			backups, err := FindBackups(kubeconfigPath, filepath.Join(dir, "backups"))
+			if err != nil || len(backups) != 1 || !backups[0].Encrypted {
+				t.Fatalf("Expected one encrypted backup, got %+v (err %v)", backups, err)
+			}
+
+			if err := os.WriteFile(kubeconfigPath, []byte("changed"), 0600); err != nil {
+				t.Fatalf("Failed to overwrite kubeconfig: %v", err)
+			}
+			if err := RestoreEncryptedBackup(backupPath, kubeconfigPath, tt.dec); err != nil {
+				t.Fatalf("Failed to restore backup: %v", err)
+			}
+			if restored, _ := os.ReadFile(kubeconfigPath); string(restored) != encryptTestKubeconfig {
+				t.Errorf("Expected the original kubeconfig to be restored, got %q", restored)
+			}
+		})
+	}
+}
+
+func TestDecryptWrongPassphrase(t *testing.T) {
+	sealed, err := encryptPassphrase([]byte(encryptTestKubeconfig), "right")
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if _, err := decryptPassphrase(sealed, "wrong"); err == nil {
+		t.Error("Expected decryption with the wrong passphrase to fail")
+	}
+	sealed[len(sealed)-1] ^= 0xff
+	if _, err := decryptPassphrase(sealed, "right"); err == nil {
+		t.Error("Expected decryption of a tampered backup to fail")
+	}
+}
+
+func TestEncryptInvalidRecipient(t *testing.T) {
+	enc := Encryption{Recipients: []string{"not-a-key"}}
+	if _, err := enc.Encrypt([]byte("data")); err == nil {
+		t.Error("Expected an invalid age recipient to be rejected")
+	}
+}
@@ -13,8 +13,14 @@
 package kubeconfig
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -100,7 +106,7 @@ func TestIsClusterReachable(t *testing.T) {
 				Server: serverURL,
 			}
 
-			result := isClusterReachable(cluster, tt.user)
+			result := isClusterReachable(cluster, tt.user, AuthCheckOptions{})
 
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v for server %s", tt.expected, result, serverURL)
@@ -110,9 +116,17 @@ func TestIsClusterReachable(t *testing.T) {
 }
 
 func TestHasValidCredentials(t *testing.T) {
+	now := time.Now()
+	expiredJWT := makeJWT(fmt.Sprintf(`{"exp":%d}`, now.Add(-time.Hour).Unix()))
+	validJWT := makeJWT(fmt.Sprintf(`{"exp":%d}`, now.Add(time.Hour).Unix()))
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(expiredJWT+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
 	tests := []struct {
 		user     *User
 		name     string
+		window   time.Duration
 		expected bool
 	}{
 		{
@@ -129,6 +143,89 @@ func TestHasValidCredentials(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:     "unexpired JWT",
+			user:     &User{Token: validJWT},
+			expected: true,
+		},
+		{
+			name:     "expired JWT",
+			user:     &User{Token: expiredJWT},
+			expected: false,
+		},
+		{
+			name:     "token file with expired JWT",
+			user:     &User{TokenFile: tokenFile},
+			expected: false,
+		},
+		{
+			name:     "missing token file",
+			user:     &User{TokenFile: filepath.Join(t.TempDir(), "missing")},
+			expected: false,
+		},
+		{
+			name:     "inline token wins over token file",
+			user:     &User{Token: validJWT, TokenFile: tokenFile},
+			expected: true,
+		},
+		{
+			name: "expired OIDC id-token",
+			user: &User{
+				AuthProvider: &AuthProvider{
+					Name:   "oidc",
+					Config: map[string]string{"id-token": expiredJWT},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "expired OIDC id-token with refresh token",
+			user: &User{
+				AuthProvider: &AuthProvider{
+					Name:   "oidc",
+					Config: map[string]string{"id-token": expiredJWT, "refresh-token": "refresh"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "unexpired certificate",
+			user: &User{
+				ClientCertificateData: generateCertData(t, now.Add(30*24*time.Hour)),
+			},
+			expected: true,
+		},
+		{
+			name: "expired certificate",
+			user: &User{
+				ClientCertificateData: generateCertData(t, now.Add(-time.Hour)),
+			},
+			expected: false,
+		},
+		{
+			name: "expired certificate with token",
+			user: &User{
+				Token:                 "some-token",
+				ClientCertificateData: generateCertData(t, now.Add(-time.Hour)),
+			},
+			expected: false,
+		},
+		{
+			name: "certificate expiring within window",
+			user: &User{
+				ClientCertificateData: generateCertData(t, now.Add(24*time.Hour)),
+			},
+			window:   7 * 24 * time.Hour,
+			expected: false,
+		},
+		{
+			name: "certificate expiring after window",
+			user: &User{
+				ClientCertificateData: generateCertData(t, now.Add(30*24*time.Hour)),
+			},
+			window:   7 * 24 * time.Hour,
+			expected: true,
+		},
 		{
 			name: "valid basic auth",
 			user: &User{
@@ -174,10 +271,13 @@ func TestHasValidCredentials(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := hasValidCredentials(tt.user)
+			result, reason := hasValidCredentials(tt.user, AuthCheckOptions{CertExpiryWindow: tt.window})
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v for user %+v", tt.expected, result, tt.user)
 			}
+			if !result && reason == "" {
+				t.Error("Expected a reason for invalid credentials")
+			}
 		})
 	}
 }
@@ -279,6 +379,115 @@ func TestEnhancedIsAuthValid(t *testing.T) {
 	}
 }
 
+func TestCheckAuthReportsCertificateExpiry(t *testing.T) {
+	notAfter := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "expired", Context: &Context{Cluster: "cluster", User: "expired-user"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "cluster", Cluster: &Cluster{Server: "https://does-not-exist.invalid:443"}},
+		},
+		Users: []NamedUser{
+			{Name: "expired-user", User: &User{ClientCertificateData: generateCertData(t, notAfter)}},
+		},
+	}
+	config.buildInternalMaps()
+
+	status := CheckAuth(config, "expired", AuthCheckOptions{})
+	if status.Valid {
+		t.Fatal("Expected a context with an expired certificate to be invalid")
+	}
+	if !strings.Contains(status.Reason, "2020-03-01T12:00:00Z") {
+		t.Errorf("Expected the reason to report the expiry date, got %q", status.Reason)
+	}
+}
+
+func TestCheckAuthOffline(t *testing.T) {
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "valid", Context: &Context{Cluster: "cluster", User: "valid-user"}},
+			{Name: "expired", Context: &Context{Cluster: "cluster", User: "expired-user"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "cluster", Cluster: &Cluster{Server: "https://does-not-exist.invalid:443"}},
+		},
+		Users: []NamedUser{
+			{Name: "valid-user", User: &User{Token: makeJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix()))}},
+			{Name: "expired-user", User: &User{Token: makeJWT(`{"exp":1600000000}`)}},
+		},
+	}
+	config.buildInternalMaps()
+
+	opts := AuthCheckOptions{Offline: true}
+	if status := CheckAuth(config, "valid", opts); !status.Valid {
+		t.Errorf("Expected an unexpired token to be valid offline even though the cluster is unreachable, got %q", status.Reason)
+	}
+	status := CheckAuth(config, "expired", opts)
+	if status.Valid {
+		t.Fatal("Expected an expired token to be invalid offline")
+	}
+	if !strings.Contains(status.Reason, "token expired") {
+		t.Errorf("Expected the reason to report the token expiry, got %q", status.Reason)
+	}
+}
+
+func TestCheckAuthStates(t *testing.T) {
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "expired", Context: &Context{Cluster: "cluster", User: "expired-user"}},
+			{Name: "empty", Context: &Context{Cluster: "cluster", User: "empty-user"}},
+			{Name: "orphan", Context: &Context{Cluster: "cluster", User: "missing-user"}},
+			{Name: "clusterless", Context: &Context{Cluster: "missing-cluster", User: "expired-user"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "cluster", Cluster: &Cluster{Server: "https://does-not-exist.invalid:443"}},
+		},
+		Users: []NamedUser{
+			{Name: "expired-user", User: &User{Token: makeJWT(`{"exp":1600000000}`)}},
+			{Name: "empty-user", User: &User{}},
+		},
+	}
+	config.buildInternalMaps()
+
+	tests := []struct {
+		context   string
+		opts      AuthCheckOptions
+		wantValid bool
+		wantState AuthState
+	}{
+		{context: "expired", opts: AuthCheckOptions{Offline: true}, wantState: AuthExpired},
+		{context: "empty", opts: AuthCheckOptions{Offline: true}, wantState: AuthNoCredentials},
+		{context: "orphan", opts: AuthCheckOptions{Offline: true}, wantState: AuthNoCredentials},
+		{context: "clusterless", opts: AuthCheckOptions{Offline: true}, wantState: AuthUnreachable},
+		{context: "expired", opts: AuthCheckOptions{Offline: true, RemoveOn: []AuthState{AuthUnreachable}}, wantValid: true, wantState: AuthExpired},
+		{context: "empty", opts: AuthCheckOptions{Offline: true, RemoveOn: []AuthState{AuthExpired}}, wantValid: true, wantState: AuthNoCredentials},
+		{context: "expired", opts: AuthCheckOptions{Offline: true, RemoveOn: []AuthState{AuthExpired}}, wantState: AuthExpired},
+	}
+	for _, tt := range tests {
+		status := CheckAuth(config, tt.context, tt.opts)
+		if status.Valid != tt.wantValid || status.State != tt.wantState {
+			t.Errorf("%s with remove on %v: expected valid %v in state %q, got %v in %q (%s)",
+				tt.context, tt.opts.RemoveOn, tt.wantValid, tt.wantState, status.Valid, status.State, status.Reason)
+		}
+	}
+}
+
+func TestParseAuthStates(t *testing.T) {
+	states, err := ParseAuthStates("unauthorized, expired,unauthorized")
+	if err != nil {
+		t.Fatalf("ParseAuthStates failed: %v", err)
+	}
+	if want := []AuthState{AuthUnauthorized, AuthExpired}; !reflect.DeepEqual(states, want) {
+		t.Errorf("Expected %v, got %v", want, states)
+	}
+	for _, invalid := range []string{"", "ok", "offline", "expired,"} {
+		if _, err := ParseAuthStates(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
+
 // TestReachabilityTimeout ensures we don't hang on slow networks
 func TestReachabilityTimeout(t *testing.T) {
 	// Create a server that delays response beyond our timeout
@@ -296,7 +505,7 @@ func TestReachabilityTimeout(t *testing.T) {
 	}
 
 	start := time.Now()
-	result := isClusterReachable(cluster, user)
+	result := isClusterReachable(cluster, user, AuthCheckOptions{})
 	duration := time.Since(start)
 
 	// Should return false due to timeout
@@ -309,3 +518,52 @@ func TestReachabilityTimeout(t *testing.T) {
 		t.Errorf("Expected timeout around 10s, took %v", duration)
 	}
 }
+
+func TestIsClusterReachableRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Fail the first two probes, as a flaky VPN link would
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cluster := &Cluster{Server: server.URL}
+	user := &User{Token: "token"}
+
+	if isClusterReachable(cluster, user, AuthCheckOptions{Backoff: time.Millisecond}) {
+		t.Error("Expected a single failed probe to report the cluster unreachable")
+	}
+
+	requests.Store(0)
+	start := time.Now()
+	if !isClusterReachable(cluster, user, AuthCheckOptions{Retries: 2, Backoff: 10 * time.Millisecond}) {
+		t.Error("Expected the cluster to be reachable after retrying")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 probes, got %d", got)
+	}
+	// Backoff doubles: 10ms + 20ms
+	if waited := time.Since(start); waited < 30*time.Millisecond {
+		t.Errorf("Expected exponential backoff between retries, finished after %v", waited)
+	}
+}
+
+func TestIsClusterReachableTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(time.Second)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	if isClusterReachable(&Cluster{Server: server.URL}, &User{Token: "token"}, AuthCheckOptions{Timeout: 100 * time.Millisecond}) {
+		t.Error("Expected a probe exceeding the timeout to fail")
+	}
+	if waited := time.Since(start); waited > 900*time.Millisecond {
+		t.Errorf("Expected the configured timeout to apply, took %v", waited)
+	}
+}
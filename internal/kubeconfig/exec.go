@@ -0,0 +1,116 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultExecAPIVersion is sent to exec plugins whose config names no apiVersion
+const defaultExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// execInfoEnv passes the ExecCredential request to an exec plugin
+const execInfoEnv = "KUBERNETES_EXEC_INFO"
+
+// execCredential is the part of an ExecCredential the auth check reads
+type execCredential struct {
+	Status *struct {
+		ExpirationTimestamp   *time.Time `json:"expirationTimestamp,omitempty"`
+		Token                 string     `json:"token,omitempty"`
+		ClientCertificateData string     `json:"clientCertificateData,omitempty"`
+	} `json:"status,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// checkExecPlugin runs the exec credential plugin the way kubectl does,
+// non-interactively and bounded by timeout, and checks that it returns an
+// unexpired credential.
+func checkExecPlugin(ctx context.Context, execConfig *ExecConfig, timeout time.Duration) error {
+	apiVersion := execConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultExecAPIVersion
+	}
+	request, err := json.Marshal(map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]interface{}{"interactive": false},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	//nolint:gosec // The plugin command comes from the user's kubeconfig
+	cmd := exec.CommandContext(ctx, execConfig.Command, execConfig.Args...)
+	cmd.Env = append(os.Environ(), execInfoEnv+"="+string(request))
+	for _, env := range execConfig.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		if msg := firstLine(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+
+	var credential execCredential
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return fmt.Errorf("invalid ExecCredential response: %w", err)
+	}
+	if credential.Kind != "ExecCredential" || credential.Status == nil {
+		return errors.New("response is not an ExecCredential with a status")
+	}
+	status := credential.Status
+	if status.Token == "" && status.ClientCertificateData == "" {
+		return errors.New("returned no token or client certificate")
+	}
+	if status.ExpirationTimestamp != nil && time.Now().After(*status.ExpirationTimestamp) {
+		return fmt.Errorf("returned a credential that %w on %s", errCredentialExpired, status.ExpirationTimestamp.Format(time.RFC3339))
+	}
+	if expiry, ok := TokenExpiry(status.Token); ok && time.Now().After(expiry) {
+		return fmt.Errorf("returned a token that %w on %s", errCredentialExpired, expiry.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// errCredentialExpired is wrapped by the errors of exec plugins that
+// return an expired credential
+var errCredentialExpired = errors.New("expired")
+
+// firstLine returns the first non-empty line of s, trimmed
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePlugin writes a shell script exec plugin and returns its path.
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("exec plugin tests use shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "plugin")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	return path
+}
+
+func TestCheckExecPlugin(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{
+			name:   "valid token",
+			script: `echo '{"apiVersion":"client.authentication.k8s.io/v1beta1","kind":"ExecCredential","status":{"token":"abc","expirationTimestamp":"` + future + `"}}'`,
+		},
+		{
+			name:    "expired credential",
+			script:  `echo '{"kind":"ExecCredential","status":{"token":"abc","expirationTimestamp":"2020-01-01T00:00:00Z"}}'`,
+			wantErr: "expired on 2020-01-01T00:00:00Z",
+		},
+		{
+			name:    "plugin fails",
+			script:  "echo 'error: SSO session expired' >&2\nexit 1",
+			wantErr: "SSO session expired",
+		},
+		{
+			name:    "no credential",
+			script:  `echo '{"kind":"ExecCredential","status":{}}'`,
+			wantErr: "no token or client certificate",
+		},
+		{
+			name:    "not JSON",
+			script:  "echo 'hello'",
+			wantErr: "invalid ExecCredential",
+		},
+		{
+			name:    "timeout",
+			script:  "exec sleep 5",
+			wantErr: "timed out",
+		},
+		{
+			name:   "request and env are passed",
+			script: `[ "$MY_VAR" = "set" ] && echo "$KUBERNETES_EXEC_INFO" | grep -q '"interactive":false' && echo '{"kind":"ExecCredential","status":{"token":"abc"}}'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execConfig := &ExecConfig{
+				Command: writePlugin(t, tt.script),
+				Env:     []ExecEnvVar{{Name: "MY_VAR", Value: "set"}},
+			}
+			err := checkExecPlugin(context.Background(), execConfig, 500*time.Millisecond)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHasValidCredentialsRunExec(t *testing.T) {
+	user := &User{Exec: &ExecConfig{Command: writePlugin(t, "exit 1")}}
+
+	if ok, _ := hasValidCredentials(user, AuthCheckOptions{}); !ok {
+		t.Error("Expected an existing exec command to pass without RunExec")
+	}
+	ok, reason := hasValidCredentials(user, AuthCheckOptions{RunExec: true})
+	if ok {
+		t.Fatal("Expected a failing exec plugin to be invalid with RunExec")
+	}
+	if !strings.Contains(reason, "exec plugin") {
+		t.Errorf("Expected the reason to name the exec plugin, got %q", reason)
+	}
+
+	expired := &User{Exec: &ExecConfig{Command: writePlugin(t,
+		`echo '{"kind":"ExecCredential","status":{"token":"abc","expirationTimestamp":"2020-01-01T00:00:00Z"}}'`)}}
+	if state, _ := checkCredentials(context.Background(), user, AuthCheckOptions{RunExec: true}); state != AuthNoCredentials {
+		t.Errorf("Expected a failing exec plugin to leave no credentials, got %q", state)
+	}
+	if state, _ := checkCredentials(context.Background(), expired, AuthCheckOptions{RunExec: true}); state != AuthExpired {
+		t.Errorf("Expected an exec plugin returning an expired credential to be expired, got %q", state)
+	}
+}
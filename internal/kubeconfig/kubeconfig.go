@@ -15,138 +15,243 @@
 package kubeconfig
 
 import (
+	"bytes"
 	"context"
-	"crypto/tls"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+	kubectlyaml "sigs.k8s.io/yaml/goyaml.v3"
 )
 
 const (
 	// File permissions for kubeconfig files (readable/writable by owner only)
 	kubeconfigFileMode = 0600
-	// Timeout values for network operations
-	httpTimeout = 10 * time.Second
-	ctxTimeout  = 5 * time.Second
-	// HTTP status code threshold for success
-	httpSuccessThreshold = 500
+)
+
+// Sentinel errors callers can test for with errors.Is.
+var (
+	// ErrModifiedSinceLoad is returned by Save when the kubeconfig file changed on disk after it was loaded.
+	ErrModifiedSinceLoad = errors.New("kubeconfig was modified by another process since it was loaded")
+	// ErrKubeconfigNotFound is returned by Load when the kubeconfig file does not exist.
+	ErrKubeconfigNotFound = errors.New("kubeconfig not found")
+	// ErrBackupCorrupt marks a backup that cannot be read as a kubeconfig.
+	ErrBackupCorrupt = errors.New("backup is corrupt")
+	// ErrConflict marks an entry that clashes with an existing entry of the same name.
+	ErrConflict = errors.New("conflicting entry")
+	// ErrDrasticShrink is returned by Save and RestoreBackup when the new content is
+	// drastically smaller than the kubeconfig it would replace and ConfirmShrink did not allow it.
+	ErrDrasticShrink = errors.New("refusing to overwrite kubeconfig with drastically smaller content")
 )
 
 const (
-	// BackupTimeFormat is the timestamp format used for backup file names
-	BackupTimeFormat = "20060102-150405"
+	// DefaultAPIVersion is the apiVersion written when a kubeconfig does not declare one
+	DefaultAPIVersion = "v1"
+	// DefaultKind is the kind written when a kubeconfig does not declare one
+	DefaultKind = "Config"
 )
 
+// Every kubeconfig type carries an Extra map holding the keys it does not model
+// (preferences, extensions, disable-compression, vendor-specific keys, ...).
+// They are kept as parsed YAML nodes, so Save writes them back as they were
+// read, including their formatting and the order of their own keys.
+
 // Config represents the structure of a kubeconfig file
 type Config struct {
-	Preferences    map[string]interface{} `yaml:"preferences,omitempty"`
-	contextMap     map[string]*Context    `yaml:"-"`
-	clusterMap     map[string]*Cluster    `yaml:"-"`
-	userMap        map[string]*User       `yaml:"-"`
-	APIVersion     string                 `yaml:"apiVersion"`
-	Kind           string                 `yaml:"kind"`
-	CurrentContext string                 `yaml:"current-context"`
-	Contexts       []NamedContext         `yaml:"contexts"`
-	Clusters       []NamedCluster         `yaml:"clusters"`
-	Users          []NamedUser            `yaml:"users"`
+	Extra          map[string]yaml.Node `yaml:",inline"`
+	contextMap     map[string]*Context  `yaml:"-"`
+	clusterMap     map[string]*Cluster  `yaml:"-"`
+	userMap        map[string]*User     `yaml:"-"`
+	sourcePath     string               `yaml:"-"`
+	sourceHash     string               `yaml:"-"`
+	APIVersion     string               `yaml:"apiVersion"`
+	Kind           string               `yaml:"kind"`
+	CurrentContext string               `yaml:"current-context"`
+	Contexts       []NamedContext       `yaml:"contexts"`
+	Clusters       []NamedCluster       `yaml:"clusters"`
+	Users          []NamedUser          `yaml:"users"`
 }
 
 // NamedContext represents a Kubernetes context with its name.
 type NamedContext struct {
-	Context *Context `yaml:"context"`
-	Name    string   `yaml:"name"`
+	Extra   map[string]yaml.Node `yaml:",inline"`
+	Context *Context             `yaml:"context"`
+	Name    string               `yaml:"name"`
 }
 
 // Context represents a Kubernetes context configuration.
 type Context struct {
-	Cluster   string `yaml:"cluster"`
-	User      string `yaml:"user"`
-	Namespace string `yaml:"namespace,omitempty"`
+	Extra     map[string]yaml.Node `yaml:",inline"`
+	Cluster   string               `yaml:"cluster"`
+	User      string               `yaml:"user"`
+	Namespace string               `yaml:"namespace,omitempty"`
 }
 
 // NamedCluster represents a Kubernetes cluster configuration with its name.
 type NamedCluster struct {
-	Cluster *Cluster `yaml:"cluster"`
-	Name    string   `yaml:"name"`
+	Extra   map[string]yaml.Node `yaml:",inline"`
+	Cluster *Cluster             `yaml:"cluster"`
+	Name    string               `yaml:"name"`
 }
 
 // Cluster represents a Kubernetes cluster connection configuration.
 type Cluster struct {
-	Server                   string `yaml:"server"`
-	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
-	CertificateAuthority     string `yaml:"certificate-authority,omitempty"`
-	InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify,omitempty"`
+	Extra                    map[string]yaml.Node `yaml:",inline"`
+	Server                   string               `yaml:"server"`
+	CertificateAuthorityData string               `yaml:"certificate-authority-data,omitempty"`
+	CertificateAuthority     string               `yaml:"certificate-authority,omitempty"`
+	ProxyURL                 string               `yaml:"proxy-url,omitempty"`
+	TLSServerName            string               `yaml:"tls-server-name,omitempty"`
+	InsecureSkipTLSVerify    bool                 `yaml:"insecure-skip-tls-verify,omitempty"`
+	// dir is the directory relative certificate-authority paths resolve against
+	dir string
 }
 
 // NamedUser represents a Kubernetes user with its name.
 type NamedUser struct {
-	User *User  `yaml:"user"`
-	Name string `yaml:"name"`
+	Extra map[string]yaml.Node `yaml:",inline"`
+	User  *User                `yaml:"user"`
+	Name  string               `yaml:"name"`
 }
 
 // User represents a Kubernetes user authentication configuration.
 type User struct {
-	AuthProvider          *AuthProvider          `yaml:"auth-provider,omitempty"`
-	Exec                  *ExecConfig            `yaml:"exec,omitempty"`
-	Extensions            map[string]interface{} `yaml:",inline"`
-	ClientCertificateData string                 `yaml:"client-certificate-data,omitempty"`
-	ClientKeyData         string                 `yaml:"client-key-data,omitempty"`
-	ClientCertificate     string                 `yaml:"client-certificate,omitempty"`
-	ClientKey             string                 `yaml:"client-key,omitempty"`
-	Token                 string                 `yaml:"token,omitempty"`
-	Username              string                 `yaml:"username,omitempty"`
-	Password              string                 `yaml:"password,omitempty"`
+	AuthProvider          *AuthProvider        `yaml:"auth-provider,omitempty"`
+	Exec                  *ExecConfig          `yaml:"exec,omitempty"`
+	Extra                 map[string]yaml.Node `yaml:",inline"`
+	AsUserExtra           map[string][]string  `yaml:"as-user-extra,omitempty"`
+	ClientCertificateData string               `yaml:"client-certificate-data,omitempty"`
+	ClientKeyData         string               `yaml:"client-key-data,omitempty"`
+	ClientCertificate     string               `yaml:"client-certificate,omitempty"`
+	ClientKey             string               `yaml:"client-key,omitempty"`
+	Token                 string               `yaml:"token,omitempty"`
+	TokenFile             string               `yaml:"tokenFile,omitempty"`
+	Username              string               `yaml:"username,omitempty"`
+	Password              string               `yaml:"password,omitempty"`
+	As                    string               `yaml:"as,omitempty"`
+	AsGroups              []string             `yaml:"as-groups,omitempty"`
+	// dir is the directory relative credential file paths resolve against
+	dir string
 }
 
 // AuthProvider represents an authentication provider configuration.
 type AuthProvider struct {
-	Config map[string]string `yaml:"config,omitempty"`
-	Name   string            `yaml:"name"`
+	Extra  map[string]yaml.Node `yaml:",inline"`
+	Config map[string]string    `yaml:"config,omitempty"`
+	Name   string               `yaml:"name"`
 }
 
 // ExecConfig represents an exec-based authentication configuration.
 type ExecConfig struct {
-	APIVersion string       `yaml:"apiVersion"`
-	Command    string       `yaml:"command"`
-	Args       []string     `yaml:"args,omitempty"`
-	Env        []ExecEnvVar `yaml:"env,omitempty"`
+	Extra      map[string]yaml.Node `yaml:",inline"`
+	APIVersion string               `yaml:"apiVersion"`
+	Command    string               `yaml:"command"`
+	Args       []string             `yaml:"args,omitempty"`
+	Env        []ExecEnvVar         `yaml:"env,omitempty"`
 }
 
 // ExecEnvVar represents an environment variable used in exec-based authentication.
 // It contains a name-value pair that will be set when executing the auth command.
 type ExecEnvVar struct {
-	Name  string `yaml:"name"`
-	Value string `yaml:"value"`
+	Extra map[string]yaml.Node `yaml:",inline"`
+	Name  string               `yaml:"name"`
+	Value string               `yaml:"value"`
+}
+
+// modeledKeys describes, for each modeled mapping, which keys hold further
+// modeled structure. Marshal orders the keys of modeled mappings only and
+// leaves values kept in Extra exactly as they were parsed.
+type modeledKeys map[string]modeledKeys
+
+var kubeconfigSchema = modeledKeys{
+	"contexts": {"context": {}},
+	"clusters": {"cluster": {}},
+	"users": {"user": {
+		"auth-provider": {"config": {}},
+		"exec":          {"env": {}},
+	}},
 }
 
 // Load reads and parses a kubeconfig file
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // User-specified kubeconfig path is intentional
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %w", ErrKubeconfigNotFound, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read kubeconfig file: %w", err)
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	config, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remember what was loaded so Save can detect concurrent modification
+	config.sourcePath = absPath(path)
+	config.sourceHash = hashBytes(data)
+
+	// Relative credential paths are relative to the kubeconfig, as in kubectl
+	config.resolvePaths(filepath.Dir(config.sourcePath))
+
+	return config, nil
+}
+
+// resolvePaths makes the relative file paths of clusters and users resolve
+// against dir. The paths themselves are kept so Save writes them unchanged.
+func (c *Config) resolvePaths(dir string) {
+	for i := range c.Clusters {
+		if c.Clusters[i].Cluster != nil {
+			c.Clusters[i].Cluster.dir = dir
+		}
+	}
+	for i := range c.Users {
+		if c.Users[i].User != nil {
+			c.Users[i].User.dir = dir
+		}
+	}
+}
+
+// Parse parses kubeconfig data that was not loaded from a file, such as a backup
+func Parse(data []byte) (*Config, error) {
+	config, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	// Only check the header, Save writes it normalized
+	header := Config{APIVersion: config.APIVersion, Kind: config.Kind}
+	if err := header.normalizeHeader(); err != nil {
 		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
 	}
 
 	// Build internal maps for easy lookup
 	config.buildInternalMaps()
 
+	return config, nil
+}
+
+// unmarshalYAML parses kubeconfig YAML into the model
+func unmarshalYAML(data []byte) (*Config, error) {
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
 
 // buildInternalMaps creates internal maps for easy lookup
 func (c *Config) buildInternalMaps() {
-	c.contextMap = make(map[string]*Context)
-	c.clusterMap = make(map[string]*Cluster)
-	c.userMap = make(map[string]*User)
+	c.contextMap = make(map[string]*Context, len(c.Contexts))
+	c.clusterMap = make(map[string]*Cluster, len(c.Clusters))
+	c.userMap = make(map[string]*User, len(c.Users))
 
 	for _, namedContext := range c.Contexts {
 		if namedContext.Context != nil {
@@ -169,7 +274,7 @@ func (c *Config) buildInternalMaps() {
 
 // GetContextNames returns all context names
 func (c *Config) GetContextNames() []string {
-	var names []string
+	names := make([]string, 0, len(c.contextMap))
 	for name := range c.contextMap {
 		names = append(names, name)
 	}
@@ -186,63 +291,290 @@ func (c *Config) GetUser(name string) *User {
 	return c.userMap[name]
 }
 
-// Save writes the kubeconfig to a file
-func Save(config *Config, path string) error {
-	data, err := yaml.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal kubeconfig: %w", err)
+// Marshal serializes the kubeconfig to YAML exactly as Save would write it.
+// Mapping keys are emitted in alphabetical order at every level and indented
+// by two spaces, sequences level with their key, matching the layout kubectl
+// produces when it writes a kubeconfig.
+func Marshal(config *Config) ([]byte, error) {
+	return encode(config)
+}
+
+// marshalYAML serializes the model, leaving values kept in Extra as parsed
+func marshalYAML(config *Config) ([]byte, error) {
+	normalized := *config
+	if err := normalized.normalizeHeader(); err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(&normalized); err != nil {
+		return nil, fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	sortMappingKeys(&node, kubeconfigSchema)
+
+	// Indent like kubectl: two spaces, with sequences not indented under
+	// their key, which yaml.v3 cannot do but its fork in sigs.k8s.io/yaml can
+	var buf bytes.Buffer
+	encoder := kubectlyaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	encoder.CompactSeqIndent()
+	if err := encoder.Encode(toKubectlNode(&node, make(map[*yaml.Node]*kubectlyaml.Node))); err != nil {
+		return nil, fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// toKubectlNode copies node into the node type of the yaml.v3 fork that
+// marshalYAML writes with; copied maps each node already copied to its copy.
+func toKubectlNode(node *yaml.Node, copied map[*yaml.Node]*kubectlyaml.Node) *kubectlyaml.Node {
+	if node == nil {
+		return nil
+	}
+	if out, ok := copied[node]; ok {
+		return out
+	}
+	out := &kubectlyaml.Node{
+		Kind:        kubectlyaml.Kind(node.Kind),
+		Style:       kubectlyaml.Style(node.Style),
+		Tag:         node.Tag,
+		Value:       node.Value,
+		Anchor:      node.Anchor,
+		HeadComment: node.HeadComment,
+		LineComment: node.LineComment,
+		FootComment: node.FootComment,
+		Line:        node.Line,
+		Column:      node.Column,
 	}
+	copied[node] = out
+	out.Alias = toKubectlNode(node.Alias, copied)
+	for _, child := range node.Content {
+		out.Content = append(out.Content, toKubectlNode(child, copied))
+	}
+	return out
+}
 
-	return os.WriteFile(path, data, kubeconfigFileMode)
+// normalizeHeader fills in a missing apiVersion/kind and canonicalizes their spelling,
+// so files written by other tools or assembled in memory are always valid kubeconfigs.
+// Any other apiVersion or kind is an error, as kubectl could not read the file.
+func (c *Config) normalizeHeader() error {
+	c.APIVersion = strings.TrimSpace(c.APIVersion)
+	c.Kind = strings.TrimSpace(c.Kind)
+
+	if c.APIVersion == "" || strings.EqualFold(c.APIVersion, DefaultAPIVersion) {
+		c.APIVersion = DefaultAPIVersion
+	}
+	if c.Kind == "" || strings.EqualFold(c.Kind, DefaultKind) {
+		c.Kind = DefaultKind
+	}
+
+	if c.APIVersion != DefaultAPIVersion {
+		return fmt.Errorf("unsupported kubeconfig apiVersion %q (expected %s)", c.APIVersion, DefaultAPIVersion)
+	}
+	if c.Kind != DefaultKind {
+		return fmt.Errorf("unsupported kubeconfig kind %q (expected %s)", c.Kind, DefaultKind)
+	}
+	return nil
 }
 
-// CreateBackup creates a backup of the kubeconfig file
-func CreateBackup(path string) (string, error) {
-	timestamp := time.Now().Format(BackupTimeFormat)
-	backupPath := path + ".backup." + timestamp
+// sortMappingKeys orders the key/value pairs of node and of the modeled
+// mappings below it by key. Sequence items share their parent's schema.
+func sortMappingKeys(node *yaml.Node, schema modeledKeys) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			sortMappingKeys(child, schema)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
 
-	src, err := os.Open(path) //nolint:gosec // User-specified backup path is intentional
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i][0].Value < pairs[j][0].Value
+	})
+	for i, pair := range pairs {
+		node.Content[2*i] = pair[0]
+		node.Content[2*i+1] = pair[1]
+		if child, ok := schema[pair[0].Value]; ok {
+			sortMappingKeys(pair[1], child)
+		}
+	}
+}
+
+// Save writes the kubeconfig to a file.
+// The file is replaced atomically, so a crash never leaves it half-written.
+// Before overwriting, it verifies that the file has not been changed by another
+// process since the config was loaded, that the serialized output parses back
+// to the same number of contexts, clusters and users, and that it is not
+// drastically smaller than the file, as checked with ConfirmShrink. With
+// SortOnSave the entries of config are sorted first.
+func Save(config *Config, path string) error {
+	return save(config, path, true)
+}
+
+// SaveForce is Save without the check against a drastically smaller output.
+func SaveForce(config *Config, path string) error {
+	return save(config, path, false)
+}
+
+func save(config *Config, path string, guardShrink bool) error {
+	if err := config.checkUnchanged(path); err != nil {
+		return err
+	}
+	if SortOnSave {
+		Sort(config)
+	}
+
+	data, err := Marshal(config)
 	if err != nil {
-		return "", fmt.Errorf("failed to open source file: %w", err)
+		return err
 	}
-	defer func() {
-		if closeErr := src.Close(); closeErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close source file: %v\n", closeErr)
+	if err := verifyRoundTrip(config, data); err != nil {
+		return err
+	}
+	if guardShrink {
+		if err := checkShrink(path, data); err != nil {
+			return err
 		}
-	}()
+	}
 
-	dst, err := os.Create(backupPath) //nolint:gosec // Backup file creation is intentional
+	if err := WriteFile(path, data); err != nil {
+		return err
+	}
+
+	config.sourcePath = absPath(path)
+	config.sourceHash = hashBytes(data)
+	return nil
+}
+
+// WriteFile atomically replaces path with data by writing a temporary file in
+// the same directory, syncing it and renaming it over the target. An existing
+// file keeps its permissions, and a symlink is followed so the link survives.
+func WriteFile(path string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	mode := os.FileMode(kubeconfigFileMode)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
-		return "", fmt.Errorf("failed to create backup file: %w", err)
+		return fmt.Errorf("failed to create temporary file: %w", err)
 	}
+	tmpPath := tmp.Name()
+	committed := false
 	defer func() {
-		if closeErr := dst.Close(); closeErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close destination file: %v\n", closeErr)
+		if !committed {
+			_ = tmp.Close()
+			_ = os.Remove(tmpPath)
 		}
 	}()
 
-	_, err = io.Copy(dst, src)
+	if err := tmp.Chmod(mode); err != nil {
+		return fmt.Errorf("failed to set permissions on temporary file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace kubeconfig: %w", err)
+	}
+	committed = true
+
+	// Persist the rename itself; not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil { //nolint:gosec // Directory of the user-specified kubeconfig
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
+
+// checkUnchanged returns ErrModifiedSinceLoad if path is the file the config was
+// loaded from and its contents no longer match what was read.
+func (c *Config) checkUnchanged(path string) error {
+	if c.sourcePath == "" || c.sourcePath != absPath(path) {
+		return nil
+	}
+
+	current, err := os.ReadFile(path) //nolint:gosec // User-specified kubeconfig path is intentional
 	if err != nil {
-		return "", fmt.Errorf("failed to copy file: %w", err)
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s was removed", ErrModifiedSinceLoad, path)
+		}
+		return fmt.Errorf("failed to re-read kubeconfig before saving: %w", err)
+	}
+	if hashBytes(current) != c.sourceHash {
+		return fmt.Errorf("%w: %s", ErrModifiedSinceLoad, path)
+	}
+	return nil
+}
+
+// verifyRoundTrip parses data back and checks it describes the same entries as config
+func verifyRoundTrip(config *Config, data []byte) error {
+	var parsed Config
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("refusing to save kubeconfig: serialized output does not parse: %w", err)
 	}
+	if len(parsed.Contexts) != len(config.Contexts) ||
+		len(parsed.Clusters) != len(config.Clusters) ||
+		len(parsed.Users) != len(config.Users) {
+		return fmt.Errorf("refusing to save kubeconfig: serialized output lost entries "+
+			"(contexts %d/%d, clusters %d/%d, users %d/%d)",
+			len(parsed.Contexts), len(config.Contexts),
+			len(parsed.Clusters), len(config.Clusters),
+			len(parsed.Users), len(config.Users))
+	}
+	return nil
+}
 
-	return backupPath, nil
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
 }
 
 // RemoveContexts removes the specified contexts and cleans up orphaned entries
 func RemoveContexts(config *Config, contextsToRemove []string) error {
-	// Track which clusters and users are still in use
-	usedClusters := make(map[string]bool)
-	usedUsers := make(map[string]bool)
+	if config.contextMap == nil {
+		config.buildInternalMaps()
+	}
 
 	// Create a map for contexts to remove for quick lookup
-	toRemoveMap := make(map[string]bool)
+	toRemoveMap := make(map[string]bool, len(contextsToRemove))
 	for _, name := range contextsToRemove {
 		toRemoveMap[name] = true
 	}
 
+	// Track which clusters and users are still in use
+	usedClusters := make(map[string]bool, len(config.Clusters))
+	usedUsers := make(map[string]bool, len(config.Users))
+
 	// Filter out contexts to remove
-	var remainingContexts []NamedContext
+	remainingContexts := make([]NamedContext, 0, len(config.Contexts))
 	for _, namedContext := range config.Contexts {
 		if !toRemoveMap[namedContext.Name] {
 			remainingContexts = append(remainingContexts, namedContext)
@@ -250,7 +582,10 @@ func RemoveContexts(config *Config, contextsToRemove []string) error {
 				usedClusters[namedContext.Context.Cluster] = true
 				usedUsers[namedContext.Context.User] = true
 			}
-		} else if config.CurrentContext == namedContext.Name {
+			continue
+		}
+		delete(config.contextMap, namedContext.Name)
+		if config.CurrentContext == namedContext.Name {
 			// Update current-context if needed
 			config.CurrentContext = ""
 		}
@@ -258,19 +593,23 @@ func RemoveContexts(config *Config, contextsToRemove []string) error {
 	config.Contexts = remainingContexts
 
 	// Filter out orphaned clusters
-	var remainingClusters []NamedCluster
+	remainingClusters := make([]NamedCluster, 0, len(config.Clusters))
 	for _, namedCluster := range config.Clusters {
 		if usedClusters[namedCluster.Name] {
 			remainingClusters = append(remainingClusters, namedCluster)
+		} else {
+			delete(config.clusterMap, namedCluster.Name)
 		}
 	}
 	config.Clusters = remainingClusters
 
 	// Filter out orphaned users
-	var remainingUsers []NamedUser
+	remainingUsers := make([]NamedUser, 0, len(config.Users))
 	for _, namedUser := range config.Users {
 		if usedUsers[namedUser.Name] {
 			remainingUsers = append(remainingUsers, namedUser)
+		} else {
+			delete(config.userMap, namedUser.Name)
 		}
 	}
 	config.Users = remainingUsers
@@ -280,126 +619,370 @@ func RemoveContexts(config *Config, contextsToRemove []string) error {
 		config.CurrentContext = config.Contexts[0].Name
 	}
 
-	// Rebuild internal maps
-	config.buildInternalMaps()
-
 	return nil
 }
 
-// IsAuthValid checks if the authentication for a context is valid by:
-// 1. Verifying credentials exist
-// 2. Testing if the cluster API server is reachable
-// 3. Making a basic API call to validate authentication
-func IsAuthValid(config *Config, contextName string) bool {
-	ctx := config.GetContext(contextName)
-	if ctx == nil {
-		return false
+// ExtractContexts returns a new config holding only the named contexts of
+// config and the clusters and users they reference, in config's order.
+// Names that are not contexts of config are ignored.
+func ExtractContexts(config *Config, names []string) *Config {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
 	}
 
-	user := config.GetUser(ctx.User)
-	if user == nil {
-		return false
+	extracted := &Config{APIVersion: config.APIVersion, Kind: config.Kind}
+	usedClusters := make(map[string]bool)
+	usedUsers := make(map[string]bool)
+	for _, namedContext := range config.Contexts {
+		if !wanted[namedContext.Name] {
+			continue
+		}
+		extracted.Contexts = append(extracted.Contexts, namedContext)
+		if namedContext.Context != nil {
+			usedClusters[namedContext.Context.Cluster] = true
+			usedUsers[namedContext.Context.User] = true
+		}
+	}
+	for _, namedCluster := range config.Clusters {
+		if usedClusters[namedCluster.Name] {
+			extracted.Clusters = append(extracted.Clusters, namedCluster)
+		}
+	}
+	for _, namedUser := range config.Users {
+		if usedUsers[namedUser.Name] {
+			extracted.Users = append(extracted.Users, namedUser)
+		}
+	}
+	if wanted[config.CurrentContext] {
+		extracted.CurrentContext = config.CurrentContext
 	}
 
-	cluster := config.GetCluster(ctx.Cluster)
-	if cluster == nil {
-		return false
+	extracted.buildInternalMaps()
+	return extracted
+}
+
+const (
+	// DefaultAuthTimeout bounds each reachability probe of an auth check
+	DefaultAuthTimeout = 5 * time.Second
+	// DefaultAuthBackoff is the wait before the first retry of a failed probe
+	DefaultAuthBackoff = 500 * time.Millisecond
+	// DefaultDialTimeout bounds the DNS lookup and TCP connection of the TCP
+	// probe and of the pre-check
+	DefaultDialTimeout = time.Second
+)
+
+// AuthCheckOptions tunes the cluster reachability probe of an auth check.
+// Zero values select the defaults.
+type AuthCheckOptions struct {
+	// Timeout bounds each probe attempt
+	Timeout time.Duration
+	// Backoff is the wait before the first retry; it doubles after each retry
+	Backoff time.Duration
+	// Retries is how many more times an unreachable cluster is probed
+	Retries int
+	// CertExpiryWindow treats client certificates expiring within this
+	// window as already expired
+	CertExpiryWindow time.Duration
+	// Offline skips the reachability probe, relying solely on the local
+	// credential and expiry checks
+	Offline bool
+	// RunExec runs exec credential plugins, bounded by Timeout, instead of
+	// only checking that the command exists
+	RunExec bool
+	// Probe selects how clusters are probed: ProbeVersion (the default),
+	// ProbeAPI or ProbeTCP
+	Probe string
+	// PreCheck dials each cluster before requesting it, so clusters whose
+	// host does not resolve or whose port is closed are not requested
+	PreCheck bool
+	// DialTimeout bounds the dial of the TCP probe and of the pre-check
+	DialTimeout time.Duration
+	// RemoveOn lists the failed states that make a context invalid; a
+	// context failing the check in another state is kept. Nil means all of
+	// AuthFailureStates.
+	RemoveOn []AuthState
+	// KeepUnreachable keeps contexts whose cluster does not respond
+	KeepUnreachable bool
+	// KeepUnauthorized keeps contexts whose credentials the API probe saw rejected
+	KeepUnauthorized bool
+	// Cache, when set, reuses the results of recent probes instead of probing again
+	Cache *ProbeCache
+	// Outages, when set, records since when clusters have been unreachable
+	Outages *OutageLog
+	// UnreachableGrace keeps contexts whose cluster Outages has seen
+	// continuously unreachable for less than this long
+	UnreachableGrace time.Duration
+}
+
+// AuthState classifies the outcome of an auth check
+type AuthState string
+
+// States of an auth check
+const (
+	// AuthOK means the credentials are usable and, unless offline, the cluster responded
+	AuthOK AuthState = "ok"
+	// AuthUnauthorized means the cluster rejected the credentials
+	AuthUnauthorized AuthState = "unauthorized"
+	// AuthUnreachable means the cluster did not respond, or the context has no cluster
+	AuthUnreachable AuthState = "unreachable"
+	// AuthNoCredentials means the context has no user or no usable credentials
+	AuthNoCredentials AuthState = "no-credentials"
+	// AuthExpired means a certificate or token expired, or expires within the expiry window
+	AuthExpired AuthState = "expired"
+	// AuthInvalidCA means the cluster's certificate authority is missing, malformed or expired
+	AuthInvalidCA AuthState = "invalid-ca"
+)
+
+// AuthFailureStates are the states of a failed auth check, in the order
+// they are listed to users
+var AuthFailureStates = []AuthState{AuthUnauthorized, AuthExpired, AuthNoCredentials, AuthInvalidCA, AuthUnreachable}
+
+// ParseAuthStates parses a comma-separated list of failure states, such as
+// "unauthorized,expired".
+func ParseAuthStates(value string) ([]AuthState, error) {
+	states := []AuthState{}
+	for _, name := range strings.Split(value, ",") {
+		state := AuthState(strings.TrimSpace(name))
+		if !slices.Contains(AuthFailureStates, state) {
+			return nil, fmt.Errorf("unknown auth state '%s': expected %s", state, joinAuthStates(AuthFailureStates))
+		}
+		if !slices.Contains(states, state) {
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+// joinAuthStates lists states separated by commas
+func joinAuthStates(states []AuthState) string {
+	names := make([]string, len(states))
+	for i, state := range states {
+		names[i] = string(state)
+	}
+	return strings.Join(names, ", ")
+}
+
+// AuthStatus is the outcome of an auth check
+type AuthStatus struct {
+	// Valid is false when the context should be removed: it failed the check
+	// in a state that the RemoveOn and Keep options do not keep
+	Valid bool `json:"valid" yaml:"valid"`
+	// State says how the check ended; a valid status may have failed in a
+	// state the options keep
+	State AuthState `json:"state,omitempty" yaml:"state,omitempty"`
+	// Reason explains why the check failed. When Valid is set it is empty,
+	// or notes a failed probe that a keep policy overrode.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// withDefaults fills in unset options
+func (o AuthCheckOptions) withDefaults() AuthCheckOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultAuthTimeout
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = DefaultAuthBackoff
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = DefaultDialTimeout
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
 	}
+	return o
+}
 
-	// First check if we have any auth credentials
-	if !hasValidCredentials(user) {
+// removes reports whether a check failing in state makes a context invalid.
+func (o AuthCheckOptions) removes(state AuthState) bool {
+	switch {
+	case state == AuthUnreachable && o.KeepUnreachable, state == AuthUnauthorized && o.KeepUnauthorized:
 		return false
 	}
+	return o.RemoveOn == nil || slices.Contains(o.RemoveOn, state)
+}
+
+// failed returns the status of a check that failed in state for reason,
+// kept valid when the options do not remove contexts in that state.
+func (o AuthCheckOptions) failed(state AuthState, reason string) AuthStatus {
+	if o.removes(state) {
+		return AuthStatus{State: state, Reason: reason}
+	}
+	return AuthStatus{Valid: true, State: state, Reason: reason + ", kept by policy"}
+}
+
+// IsAuthValid checks if the authentication for a context is valid using the
+// default AuthCheckOptions.
+func IsAuthValid(config *Config, contextName string) bool {
+	return IsAuthValidWithOptions(config, contextName, AuthCheckOptions{})
+}
+
+// IsAuthValidWithOptions checks if the authentication for a context is valid.
+// See CheckAuth for the checks performed.
+func IsAuthValidWithOptions(config *Config, contextName string, opts AuthCheckOptions) bool {
+	return CheckAuth(config, contextName, opts).Valid
+}
 
-	// Then check if the cluster is reachable
-	return isClusterReachable(cluster, user)
+// CheckAuth checks if the authentication for a context is valid by:
+// 1. Verifying the cluster's certificate authority is usable, and that
+// credentials exist and have not expired, as far as client certificates and
+// JWT bearer tokens tell locally
+// 2. Unless opts.Offline is set, probing the cluster API server, retrying
+// with backoff. With opts.Probe set to ProbeAPI the probe is an authenticated
+// API call that tells rejected credentials apart from an unreachable cluster;
+// with ProbeTCP the server is only dialed.
+// The returned status gives the state the check ended in and says why it
+// failed; whether a failure makes the context invalid is up to opts.RemoveOn,
+// opts.KeepUnreachable and opts.KeepUnauthorized, and for an unreachable
+// cluster to opts.UnreachableGrace.
+func CheckAuth(config *Config, contextName string, opts AuthCheckOptions) AuthStatus {
+	return CheckAuthContext(context.Background(), config, contextName, opts)
 }
 
-// hasValidCredentials checks if the user has any authentication credentials
-func hasValidCredentials(user *User) bool {
-	// Check for token-based auth
-	if user.Token != "" {
-		return true
+// CheckAuthContext is CheckAuth that stops probing, and running exec
+// plugins, when ctx is done.
+func CheckAuthContext(ctx context.Context, config *Config, contextName string, opts AuthCheckOptions) AuthStatus {
+	kubeContext := config.GetContext(contextName)
+	if kubeContext == nil {
+		return opts.failed(AuthNoCredentials, "context not found")
 	}
 
-	// Check for certificate-based auth
-	if user.ClientCertificateData != "" || user.ClientCertificate != "" {
-		return true
+	user := config.GetUser(kubeContext.User)
+	if user == nil {
+		return opts.failed(AuthNoCredentials, fmt.Sprintf("user '%s' not found", kubeContext.User))
 	}
 
-	// Check for basic auth
-	if user.Username != "" && user.Password != "" {
-		return true
+	cluster := config.GetCluster(kubeContext.Cluster)
+	if cluster == nil {
+		return opts.failed(AuthUnreachable, fmt.Sprintf("cluster '%s' not found", kubeContext.Cluster))
 	}
 
-	// Check for auth provider (like OIDC, GCP, AWS, etc.)
-	if user.AuthProvider != nil {
-		return len(user.AuthProvider.Config) > 0
+	// kubectl fails on broken CA material before it sends any request
+	if err := CheckCertificateAuthority(cluster); err != nil {
+		return opts.failed(AuthInvalidCA, err.Error())
 	}
 
-	// Check for exec-based auth (like kubectl plugins)
-	if user.Exec != nil && user.Exec.Command != "" {
-		if _, err := os.Stat(user.Exec.Command); err == nil {
-			return true
-		}
-		// Also try to find it in PATH
-		if _, err := filepath.Abs(user.Exec.Command); err == nil {
-			return true
+	// First check if we have any usable auth credentials
+	if state, reason := checkCredentials(ctx, user, opts); state != AuthOK {
+		return opts.failed(state, reason)
+	}
+
+	if opts.Offline {
+		return AuthStatus{Valid: true, State: AuthOK}
+	}
+
+	// Then check if the cluster is reachable and, with the API probe, accepts the credentials
+	var state AuthState
+	var reason string
+	result, age := opts.Cache.probe(ctx, cluster, user, opts)
+	now := time.Now()
+	var since time.Time
+	if ctx.Err() == nil {
+		// A probe cut short says nothing about the cluster
+		since = opts.Outages.observe(cluster.Server, result != probeUnreachable, now)
+	}
+	switch result {
+	case probeUnreachable:
+		state, reason = AuthUnreachable, fmt.Sprintf("cluster %s is unreachable", cluster.Server)
+	case probeUnauthorized:
+		state, reason = AuthUnauthorized, fmt.Sprintf("cluster %s rejected the credentials", cluster.Server)
+	default:
+		return AuthStatus{Valid: true, State: AuthOK}
+	}
+	if age > 0 {
+		reason += fmt.Sprintf(" (cached %s ago)", age.Round(time.Second))
+	}
+	if state == AuthUnreachable && opts.Outages != nil && !since.IsZero() {
+		reason += " since " + since.Format(time.RFC3339)
+		if until := since.Add(opts.UnreachableGrace); now.Before(until) && opts.removes(state) {
+			return AuthStatus{Valid: true, State: state, Reason: reason + ", kept by the grace period until " + until.Format(time.RFC3339)}
 		}
 	}
+	return opts.failed(state, reason)
+}
 
-	return false
+// hasValidCredentials checks if the user has any authentication credentials.
+// A client certificate that has expired, or expires within
+// opts.CertExpiryWindow, is not valid, and neither is a JWT bearer token or
+// OIDC id-token whose exp claim has passed; the reason says when it expired.
+// With opts.RunExec, exec plugins are run and must return an unexpired credential.
+func hasValidCredentials(user *User, opts AuthCheckOptions) (bool, string) {
+	state, reason := checkCredentials(context.Background(), user, opts)
+	return state == AuthOK, reason
 }
 
-// isClusterReachable tests if the cluster API server is accessible
-// This solves the "dead cluster, live token" problem
-func isClusterReachable(cluster *Cluster, user *User) bool {
-	if cluster.Server == "" {
-		return false
+// checkCredentials is hasValidCredentials bounded by ctx that tells expired
+// credentials, AuthExpired, apart from missing or unusable ones, AuthNoCredentials.
+func checkCredentials(ctx context.Context, user *User, opts AuthCheckOptions) (AuthState, string) {
+	// Check for certificate-based auth first, an expired certificate fails
+	// the TLS handshake whatever else is configured
+	if user.ClientCertificateData != "" || user.ClientCertificate != "" {
+		if cert := ClientCertificate(user); cert != nil {
+			expiry := cert.NotAfter.Format(time.RFC3339)
+			now := time.Now()
+			if now.After(cert.NotAfter) {
+				return AuthExpired, "client certificate expired on " + expiry
+			}
+			if now.Add(opts.CertExpiryWindow).After(cert.NotAfter) {
+				return AuthExpired, fmt.Sprintf("client certificate expires on %s, within %s", expiry, opts.CertExpiryWindow)
+			}
+		}
+		return AuthOK, ""
 	}
 
-	// Create HTTP client with appropriate TLS settings
-	client := &http.Client{
-		Timeout: httpTimeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				//nolint:gosec // TLS verification controlled by kubeconfig setting
-				InsecureSkipVerify: cluster.InsecureSkipTLSVerify,
-			},
-		},
+	// Check for token-based auth; a tokenFile must be readable
+	if user.Token != "" || user.TokenFile != "" {
+		token, err := bearerToken(user)
+		if err != nil {
+			return AuthNoCredentials, fmt.Sprintf("tokenFile %s is not readable: %v", user.TokenFile, err)
+		}
+		if expiry, ok := TokenExpiry(token); ok && time.Now().After(expiry) {
+			return AuthExpired, "token expired on " + expiry.Format(time.RFC3339)
+		}
+		return AuthOK, ""
 	}
 
-	// Try to reach the /version endpoint (doesn't require auth)
-	versionURL := cluster.Server + "/version"
-
-	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", versionURL, http.NoBody)
-	if err != nil {
-		return false
+	// Check for basic auth
+	if user.Username != "" && user.Password != "" {
+		return AuthOK, ""
 	}
 
-	// Add authentication headers if we have a token
-	if user.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+user.Token)
+	// Check for auth provider (like OIDC, GCP, AWS, etc.)
+	if user.AuthProvider != nil {
+		if len(user.AuthProvider.Config) > 0 {
+			// Without a refresh token an expired id-token cannot be renewed
+			if token := idToken(user); token != "" && user.AuthProvider.Config["refresh-token"] == "" {
+				if expiry, ok := TokenExpiry(token); ok && time.Now().After(expiry) {
+					return AuthExpired, fmt.Sprintf("auth provider '%s' id-token expired on %s", user.AuthProvider.Name, expiry.Format(time.RFC3339))
+				}
+			}
+			return AuthOK, ""
+		}
+		return AuthNoCredentials, fmt.Sprintf("auth provider '%s' has no configuration", user.AuthProvider.Name)
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		// Network error, DNS resolution failure, connection refused, etc.
-		// This catches the "cluster is gone" scenario
-		return false
+	// Check for exec-based auth (like kubectl plugins)
+	if user.Exec != nil && user.Exec.Command != "" && opts.RunExec {
+		if err := checkExecPlugin(ctx, user.Exec, opts.withDefaults().Timeout); err != nil {
+			state := AuthNoCredentials
+			if errors.Is(err, errCredentialExpired) {
+				state = AuthExpired
+			}
+			return state, fmt.Sprintf("exec plugin '%s' failed: %v", user.Exec.Command, err)
+		}
+		return AuthOK, ""
 	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+	if user.Exec != nil && user.Exec.Command != "" {
+		if _, err := os.Stat(user.Exec.Command); err == nil {
+			return AuthOK, ""
 		}
-	}()
+		// Also try to find it in PATH
+		if _, err := filepath.Abs(user.Exec.Command); err == nil {
+			return AuthOK, ""
+		}
+	}
 
-	// If we get any response (even 401/403), the cluster is reachable
-	// Status codes in the 200-499 range indicate the server is responding
-	return resp.StatusCode < httpSuccessThreshold
+	return AuthNoCredentials, "no credentials"
 }
 
 // GetCluster returns a cluster by name (needed for the enhanced auth check)
@@ -409,3 +992,16 @@ func (c *Config) GetCluster(name string) *Cluster {
 	}
 	return c.clusterMap[name]
 }
+
+// GetServer returns the server URL of a context's cluster, or "" if the
+// context or its cluster is missing
+func (c *Config) GetServer(contextName string) string {
+	ctx := c.GetContext(contextName)
+	if ctx == nil {
+		return ""
+	}
+	if cluster := c.GetCluster(ctx.Cluster); cluster != nil {
+		return cluster.Server
+	}
+	return ""
+}
@@ -13,6 +13,8 @@
 package kubeconfig
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -245,6 +247,47 @@ func TestRemoveContexts(t *testing.T) {
 	}
 }
 
+func TestExtractContexts(t *testing.T) {
+	cfg := &Config{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentContext: "context2",
+		Contexts: []NamedContext{
+			{Name: "context1", Context: &Context{Cluster: "cluster1", User: "user1"}},
+			{Name: "context2", Context: &Context{Cluster: "cluster2", User: "user1"}},
+			{Name: "context3", Context: &Context{Cluster: "cluster3", User: "user3"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "cluster1", Cluster: &Cluster{Server: "https://cluster1.com"}},
+			{Name: "cluster2", Cluster: &Cluster{Server: "https://cluster2.com"}},
+			{Name: "cluster3", Cluster: &Cluster{Server: "https://cluster3.com"}},
+		},
+		Users: []NamedUser{
+			{Name: "user1", User: &User{Token: "token1"}},
+			{Name: "user3", User: &User{Token: "token3"}},
+		},
+	}
+	cfg.buildInternalMaps()
+
+	extracted := ExtractContexts(cfg, []string{"context2", "context1", "missing"})
+
+	if len(extracted.Contexts) != 2 || extracted.Contexts[0].Name != "context1" || extracted.Contexts[1].Name != "context2" {
+		t.Errorf("Expected context1 and context2 in config order, got %+v", extracted.Contexts)
+	}
+	if len(extracted.Clusters) != 2 || extracted.GetCluster("cluster3") != nil {
+		t.Errorf("Expected only the referenced clusters, got %+v", extracted.Clusters)
+	}
+	if len(extracted.Users) != 1 || extracted.GetUser("user1") == nil {
+		t.Errorf("Expected only user1, got %+v", extracted.Users)
+	}
+	if extracted.CurrentContext != "context2" {
+		t.Errorf("Expected current-context context2, got %q", extracted.CurrentContext)
+	}
+	if len(cfg.Contexts) != 3 {
+		t.Errorf("Expected the source config to be left alone, got %d contexts", len(cfg.Contexts))
+	}
+}
+
 func TestRemoveAllContexts(t *testing.T) {
 	cfg := &Config{
 		CurrentContext: "context1",
@@ -290,34 +333,105 @@ func TestCreateBackup(t *testing.T) {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	backupPath, err := CreateBackup(originalPath)
-	if err != nil {
-		t.Errorf("Unexpected error creating backup: %v", err)
+	for _, backupDir := range []string{"", filepath.Join(tmpDir, "backups")} {
+		backupPath, err := CreateBackup(originalPath, backupDir)
+		if err != nil {
+			t.Errorf("Unexpected error creating backup: %v", err)
+		}
+
+		// Check backup content matches original
+		backupContent, err := os.ReadFile(backupPath)
+		if err != nil {
+			t.Errorf("Failed to read backup file: %v", err)
+		}
+		if string(backupContent) != originalContent {
+			t.Errorf("Backup content doesn't match original")
+		}
+
+		// Check backup filename format and location
+		if !strings.HasPrefix(filepath.Base(backupPath), "config.backup.") {
+			t.Errorf("Backup filename doesn't contain expected pattern")
+		}
+		if filepath.Dir(backupPath) != BackupFolder(originalPath, backupDir) {
+			t.Errorf("Expected backup in %s, got %s", BackupFolder(originalPath, backupDir), backupPath)
+		}
 	}
+}
+
+func TestCreateBackupWithinTheSameSecond(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalPath := filepath.Join(tmpDir, "config")
+	backupDir := filepath.Join(tmpDir, "backups")
 
-	// Check backup file exists
-	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
-		t.Errorf("Backup file was not created")
+	// Back up three different contents faster than the timestamp changes
+	var paths []string
+	for _, content := range []string{"first", "second", "third"} {
+		if err := os.WriteFile(originalPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		backupPath, err := CreateBackup(originalPath, backupDir)
+		if err != nil {
+			t.Fatalf("Unexpected error creating backup: %v", err)
+		}
+		paths = append(paths, backupPath)
 	}
 
-	// Check backup content matches original
-	backupContent, err := os.ReadFile(backupPath)
+	backups, err := FindBackups(originalPath, backupDir)
 	if err != nil {
-		t.Errorf("Failed to read backup file: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(backups) != len(paths) {
+		t.Fatalf("Expected %d backups, got %d: %v", len(paths), len(backups), paths)
+	}
+	// Newest first, even when some were taken within the same second
+	for i, content := range []string{"third", "second", "first"} {
+		data, err := os.ReadFile(backups[i].Path)
+		if err != nil {
+			t.Fatalf("Failed to read backup: %v", err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected backup %d to hold %q, got %q", i, content, data)
+		}
+	}
+
+	for _, suffix := range []string{"20231124-143022-2", "20231124-143022-2" + AgeSuffix} {
+		timestamp, sequence, encrypted, err := parseBackupSuffix(suffix)
+		if err != nil || sequence != 2 || timestamp.IsZero() || encrypted != strings.HasSuffix(suffix, AgeSuffix) {
+			t.Errorf("parseBackupSuffix(%q) = %v, %d, %v, %v", suffix, timestamp, sequence, encrypted, err)
+		}
+	}
+	for _, suffix := range []string{"20231124-143022-0", "20231124-143022-x", "20231124-1430221"} {
+		if _, _, _, err := parseBackupSuffix(suffix); err == nil {
+			t.Errorf("Expected %q to be rejected", suffix)
+		}
+	}
+}
+
+func TestBackupFolder(t *testing.T) {
+	tmpDir := t.TempDir()
+	backupDir := filepath.Join(tmpDir, "backups")
+
+	if got := BackupFolder(filepath.Join(tmpDir, "config"), ""); got != tmpDir {
+		t.Errorf("Expected backups next to the kubeconfig without a backup dir, got %s", got)
 	}
-	if string(backupContent) != originalContent {
-		t.Errorf("Backup content doesn't match original")
+	a := BackupFolder(filepath.Join(tmpDir, "a", "config"), backupDir)
+	b := BackupFolder(filepath.Join(tmpDir, "b", "config"), backupDir)
+	if a == b || filepath.Dir(a) != backupDir || !strings.HasPrefix(filepath.Base(a), "config-") {
+		t.Errorf("Expected a separate subfolder per kubeconfig, got %s and %s", a, b)
 	}
+}
 
-	// Check backup filename format
-	if !strings.Contains(backupPath, ".backup.") {
-		t.Errorf("Backup filename doesn't contain expected pattern")
+func TestDefaultBackupDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	if got := DefaultBackupDir(); got != filepath.Join("/data", "kubectx-manager", "backups") {
+		t.Errorf("Expected the backup dir under $XDG_DATA_HOME, got %s", got)
 	}
 }
 
 func TestFindBackups(t *testing.T) {
 	tmpDir := t.TempDir()
 	kubeconfigPath := filepath.Join(tmpDir, "config")
+	backupDir := filepath.Join(tmpDir, "backups")
 
 	// Create original file
 	err := os.WriteFile(kubeconfigPath, []byte("original"), 0644)
@@ -325,14 +439,8 @@ func TestFindBackups(t *testing.T) {
 		t.Fatalf("Failed to create original file: %v", err)
 	}
 
-	// Create some backup files with proper timestamp format
-	timestamps := []string{
-		"20231201-120000",
-		"20231201-130000",
-		"20231201-140000",
-	}
-
-	for _, ts := range timestamps {
+	// Backups written next to the kubeconfig by older versions
+	for _, ts := range []string{"20231201-120000", "20231201-140000"} {
 		backupPath := kubeconfigPath + ".backup." + ts
 		err := os.WriteFile(backupPath, []byte("backup-"+ts), 0644)
 		if err != nil {
@@ -347,27 +455,85 @@ func TestFindBackups(t *testing.T) {
 		t.Fatalf("Failed to create wrong file: %v", err)
 	}
 
-	// Find backups using the function from restore.go
-	// We need to create a simple version here since restore.go is in cmd package
-	entries, err := os.ReadDir(tmpDir)
+	// A backup in the central directory
+	folder, err := CreateBackupFolder(kubeconfigPath, backupDir)
 	if err != nil {
-		t.Fatalf("Failed to read directory: %v", err)
+		t.Fatalf("Failed to create backup folder: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "config.backup.20231201-130000"), []byte("central"), 0600); err != nil {
+		t.Fatalf("Failed to create backup file: %v", err)
 	}
 
-	var backupCount int
-	prefix := "config.backup."
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
-			timestampStr := strings.TrimPrefix(entry.Name(), prefix)
-			_, err := time.Parse("20060102-150405", timestampStr)
-			if err == nil {
-				backupCount++
-			}
+	backups, err := FindBackups(kubeconfigPath, backupDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.TimeStr)
+	}
+	want := []string{"2023-12-01 14:00:00", "2023-12-01 13:00:00", "2023-12-01 12:00:00"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected backups from both locations newest first %v, got %v", want, names)
+	}
+
+	// A backup dir that does not exist yet only has the backups next to the kubeconfig
+	if backups, err := FindBackups(kubeconfigPath, filepath.Join(tmpDir, "missing")); err != nil || len(backups) != 2 {
+		t.Errorf("Expected the 2 backups next to the kubeconfig, got %d (%v)", len(backups), err)
+	}
+}
+
+func TestBackupAt(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	named := filepath.Join(tmpDir, "config.backup.20231124-143022"+PassphraseSuffix)
+	other := filepath.Join(tmpDir, "saved-config.yaml")
+	for _, path := range []string{named, other} {
+		if err := os.WriteFile(path, []byte("backup"), 0600); err != nil {
+			t.Fatalf("Failed to write backup: %v", err)
 		}
 	}
 
-	if backupCount != 3 {
-		t.Errorf("Expected 3 valid backups, found %d", backupCount)
+	backup, err := BackupAt(named)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !backup.Encrypted || backup.TimeStr != "2023-11-24 14:30:22" || backup.Size != int64(len("backup")) {
+		t.Errorf("Expected the timestamp and encryption from the name, got %+v", backup)
+	}
+
+	backup, err = BackupAt(other)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if backup.Encrypted || time.Since(backup.Time) > time.Minute {
+		t.Errorf("Expected an unencrypted backup dated by its modification time, got %+v", backup)
+	}
+
+	if _, err := BackupAt(filepath.Join(tmpDir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing file to be reported, got %v", err)
+	}
+}
+
+func TestRemoteBackup(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	backupDir := t.TempDir()
+
+	backup, err := RemoteBackup(kubeconfigPath, backupDir, "config.backup.20231124-143022"+AgeSuffix, 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !backup.Remote || !backup.Encrypted || backup.TimeStr != "2023-11-24 14:30:22" || backup.Size != 42 {
+		t.Errorf("Unexpected backup %+v", backup)
+	}
+	if expected := filepath.Join(BackupFolder(kubeconfigPath, backupDir), backup.Name); backup.Path != expected {
+		t.Errorf("Path = %q, expected %q", backup.Path, expected)
+	}
+
+	for _, name := range []string{"other.backup.20231124-143022", "config.backup.latest", "../config.backup.20231124-143022"} {
+		if _, err := RemoteBackup(kubeconfigPath, backupDir, name, 0); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
 	}
 }
 
@@ -530,3 +696,471 @@ func TestSave(t *testing.T) {
 		t.Errorf("Expected 1 context, got %d", len(loadedCfg.Contexts))
 	}
 }
+
+func TestSaveReplacesFileAtomically(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "real-config")
+	link := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(target, []byte("apiVersion: v1\nkind: Config\n"), 0640); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	if err := os.Chmod(target, 0640); err != nil {
+		t.Fatalf("Failed to set permissions: %v", err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	cfg, err := Load(link)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	cfg.CurrentContext = "changed"
+	if err := Save(cfg, link); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected %s to remain a symlink (err %v)", link, err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("Failed to stat target: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("Expected permissions 0640 to be preserved, got %o", info.Mode().Perm())
+	}
+	if saved, _ := Load(target); saved == nil || saved.CurrentContext != "changed" {
+		t.Error("Expected the symlink target to hold the saved kubeconfig")
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected no temporary files to be left behind, got %d entries", len(entries))
+	}
+}
+
+func TestMarshalMatchesKubectl(t *testing.T) {
+	// Written by kubectl's clientcmd.Write: keys in alphabetical order at
+	// every level, two-space indentation and sequences level with their key
+	golden, err := os.ReadFile(filepath.Join("testdata", "kubectl-config.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	cfg, err := Parse(golden)
+	if err != nil {
+		t.Fatalf("Failed to parse golden file: %v", err)
+	}
+
+	data, err := Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != string(golden) {
+		t.Errorf("Expected a kubeconfig written by kubectl to be written back unchanged, got:\n%s", data)
+	}
+}
+
+func TestSavePreservesUnknownFields(t *testing.T) {
+	skipUnlessBuiltinBackend(t)
+	content := `apiVersion: v1
+kind: Config
+current-context: keep
+preferences: {}
+extensions:
+  - name: vendor.example.com/meta
+    extension:
+      owner: team-a
+      b: 1
+      a: 2
+contexts:
+- name: keep
+  context:
+    cluster: keep-cluster
+    user: keep-user
+    extensions:
+    - name: x
+      extension: {z: 1, a: 2}
+- name: drop
+  context:
+    cluster: keep-cluster
+    user: keep-user
+clusters:
+- name: keep-cluster
+  cluster:
+    server: https://keep.example.com
+    proxy-url: socks5://localhost:1080
+    tls-server-name: keep.internal
+users:
+- name: keep-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: aws
+      interactiveMode: Never
+      provideClusterInfo: true
+`
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	config, err := Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if cluster := config.GetCluster("keep-cluster"); cluster.ProxyURL != "socks5://localhost:1080" || cluster.TLSServerName != "keep.internal" {
+		t.Errorf("Expected proxy-url and tls-server-name to be loaded, got %q and %q", cluster.ProxyURL, cluster.TLSServerName)
+	}
+	if err := RemoveContexts(config, []string{"drop"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+	if err := Save(config, kubeconfigPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to read saved kubeconfig: %v", err)
+	}
+	output := string(data)
+	for _, want := range []string{
+		"preferences: {}",
+		"name: vendor.example.com/meta",
+		// Unknown values keep the key order and style they were written with
+		"owner: team-a\n    b: 1\n    a: 2",
+		"extension: {z: 1, a: 2}",
+		"proxy-url: socks5://localhost:1080",
+		"tls-server-name: keep.internal",
+		"interactiveMode: Never",
+		"provideClusterInfo: true",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected saved kubeconfig to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "name: drop") {
+		t.Errorf("Expected context drop to be removed, got:\n%s", output)
+	}
+
+	// Saving again without changes must not alter the file
+	reloaded, err := Load(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to reload kubeconfig: %v", err)
+	}
+	again, err := Marshal(reloaded)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(again) != output {
+		t.Errorf("Expected a second save to be byte-for-byte identical:\n%s\nvs\n%s", output, again)
+	}
+}
+
+func TestEqualityIgnoresSourcePosition(t *testing.T) {
+	first, err := Load(writeTempKubeconfig(t, "clusters:\n- name: a\n  cluster:\n    server: https://x\n    proxy-url: http://p\n"))
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	second, err := Load(writeTempKubeconfig(t, "\n\nclusters:\n- cluster:\n    proxy-url: http://p\n    server: https://x\n  name: b\n"))
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	if !clustersEqual(first.Clusters[0].Cluster, second.Clusters[0].Cluster) {
+		t.Error("Expected clusters with the same unknown fields at different positions to be equal")
+	}
+
+	third := *first.Clusters[0].Cluster
+	third.TLSServerName = "x.internal"
+	if clustersEqual(first.Clusters[0].Cluster, &third) {
+		t.Error("Expected clusters with different tls-server-name to differ")
+	}
+}
+
+func TestUserImpersonationFields(t *testing.T) {
+	content := `users:
+- name: admin
+  user:
+    tokenFile: /var/run/secrets/token
+    as: jane
+    as-groups:
+    - devs
+    as-user-extra:
+      scopes:
+      - view
+`
+	config, err := Load(writeTempKubeconfig(t, content))
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	user := config.GetUser("admin")
+	if user.TokenFile != "/var/run/secrets/token" || user.As != "jane" || len(user.AsGroups) != 1 || user.AsUserExtra["scopes"][0] != "view" {
+		t.Errorf("Expected tokenFile and impersonation fields to be loaded, got %+v", user)
+	}
+
+	data, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{"tokenFile: /var/run/secrets/token", "as: jane", "- devs", "scopes:"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected saved kubeconfig to contain %q, got:\n%s", want, data)
+		}
+	}
+
+	other := *user
+	other.As = "john"
+	if usersEqual(user, &other) {
+		t.Error("Expected users impersonating different users to differ")
+	}
+}
+
+// skipUnlessBuiltinBackend skips tests of formatting only the built-in
+// backend produces; client-go writes kubectl's layout instead.
+func skipUnlessBuiltinBackend(t *testing.T) {
+	t.Helper()
+	if Backend != "builtin" {
+		t.Skipf("depends on the builtin backend's output, not %s", Backend)
+	}
+}
+
+func writeTempKubeconfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestMarshalNormalizesHeader(t *testing.T) {
+	skipUnlessBuiltinBackend(t)
+	tests := []struct {
+		name               string
+		apiVersion         string
+		kind               string
+		expectedAPIVersion string
+		expectedKind       string
+		expectedError      string
+	}{
+		{"missing", "", "", "v1", "Config", ""},
+		{"lowercase kind", "v1", "config", "v1", "Config", ""},
+		{"uppercase version with spaces", " V1 ", "Config", "v1", "Config", ""},
+		{"unknown apiVersion rejected", "v2alpha1", "Config", "", "", `unsupported kubeconfig apiVersion "v2alpha1"`},
+		{"unknown kind rejected", "v1", "Other", "", "", `unsupported kubeconfig kind "Other"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{APIVersion: tt.apiVersion, Kind: tt.kind}
+
+			data, err := Marshal(cfg)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error %q, got %v", tt.expectedError, err)
+				}
+				if err := Save(cfg, filepath.Join(t.TempDir(), "config")); err == nil {
+					t.Error("Expected Save to refuse the header")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			output := string(data)
+
+			if !strings.Contains(output, "apiVersion: "+tt.expectedAPIVersion+"\n") {
+				t.Errorf("Expected apiVersion %q in output:\n%s", tt.expectedAPIVersion, output)
+			}
+			if !strings.Contains(output, "kind: "+tt.expectedKind+"\n") {
+				t.Errorf("Expected kind %q in output:\n%s", tt.expectedKind, output)
+			}
+			if cfg.APIVersion != tt.apiVersion || cfg.Kind != tt.kind {
+				t.Error("Marshal must not modify the caller's config")
+			}
+		})
+	}
+}
+
+func TestLoadRejectsUnsupportedHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing", content: "contexts: []\n"},
+		{name: "differently cased", content: "apiVersion: V1\nkind: config\n"},
+		{name: "unknown apiVersion", content: "apiVersion: v2\nkind: Config\n", wantErr: `unsupported kubeconfig apiVersion "v2"`},
+		{name: "unknown kind", content: "apiVersion: v1\nkind: Pod\n", wantErr: `unsupported kubeconfig kind "Pod"`},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "config")
+		if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+			t.Fatalf("Failed to write kubeconfig: %v", err)
+		}
+		_, err := Load(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestSaveDetectsConcurrentModification(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	content := "apiVersion: v1\nkind: Config\ncontexts:\n- name: a\n  context:\n    cluster: c\n    user: u\n"
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create kubeconfig: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	// Unchanged file can be overwritten, repeatedly
+	if err := Save(cfg, configPath); err != nil {
+		t.Fatalf("Unexpected error saving unchanged kubeconfig: %v", err)
+	}
+	if err := Save(cfg, configPath); err != nil {
+		t.Fatalf("Unexpected error saving twice: %v", err)
+	}
+
+	// Another process edits the file
+	if err := os.WriteFile(configPath, []byte(content+"current-context: a\n"), 0600); err != nil {
+		t.Fatalf("Failed to modify kubeconfig: %v", err)
+	}
+	if err := Save(cfg, configPath); !errors.Is(err, ErrModifiedSinceLoad) {
+		t.Errorf("Expected ErrModifiedSinceLoad, got %v", err)
+	}
+
+	// Writing somewhere else is always allowed
+	if err := Save(cfg, filepath.Join(t.TempDir(), "other")); err != nil {
+		t.Errorf("Unexpected error saving to another path: %v", err)
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	config, err := Parse([]byte("apiVersion: v1\nkind: Config\ncontexts:\n- name: a\n  context:\n    cluster: c\n    user: u\n" +
+		"clusters:\n- name: c\n  cluster:\n    server: https://c.example.com\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse kubeconfig: %v", err)
+	}
+	data, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "faithful output", data: string(data)},
+		{name: "output that does not parse", data: "contexts: [", wantErr: "does not parse"},
+		{name: "output missing a cluster", data: "apiVersion: v1\nkind: Config\ncontexts:\n- name: a\n", wantErr: "contexts 1/1, clusters 0/1, users 0/0"},
+		{name: "output with an extra context", data: "contexts:\n- name: a\n- name: b\nclusters:\n- name: c\n", wantErr: "contexts 2/1"},
+	}
+	for _, tt := range tests {
+		err := verifyRoundTrip(config, []byte(tt.data))
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing"))
+	if !errors.Is(err, ErrKubeconfigNotFound) {
+		t.Errorf("Expected ErrKubeconfigNotFound, got %v", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the underlying not-exist error to be preserved, got %v", err)
+	}
+}
+
+// largeConfig builds a kubeconfig with n contexts, each with its own cluster and user.
+func largeConfig(n int) *Config {
+	config := &Config{APIVersion: "v1", Kind: "Config", CurrentContext: "context-0"}
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("%d", i)
+		config.Contexts = append(config.Contexts, NamedContext{
+			Name:    "context-" + name,
+			Context: &Context{Cluster: "cluster-" + name, User: "user-" + name},
+		})
+		config.Clusters = append(config.Clusters, NamedCluster{
+			Name:    "cluster-" + name,
+			Cluster: &Cluster{Server: "https://" + name + ".example.com"},
+		})
+		config.Users = append(config.Users, NamedUser{Name: "user-" + name, User: &User{Token: "token-" + name}})
+	}
+	config.buildInternalMaps()
+	return config
+}
+
+func TestRemoveContextsKeepsLookupsInSync(t *testing.T) {
+	config := largeConfig(10)
+	if err := RemoveContexts(config, []string{"context-0", "context-5"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+
+	if config.GetContext("context-5") != nil || config.GetCluster("cluster-5") != nil || config.GetUser("user-5") != nil {
+		t.Error("Expected removed context and its orphans to be gone from lookups")
+	}
+	if config.GetContext("context-1") == nil || config.GetCluster("cluster-1") == nil || config.GetUser("user-1") == nil {
+		t.Error("Expected remaining entries to stay in lookups")
+	}
+	if len(config.GetContextNames()) != 8 {
+		t.Errorf("Expected 8 contexts, got %d", len(config.GetContextNames()))
+	}
+	if config.CurrentContext != "context-1" {
+		t.Errorf("Expected current-context to move to context-1, got %q", config.CurrentContext)
+	}
+}
+
+func BenchmarkRemoveContexts(b *testing.B) {
+	const size = 10000
+	toRemove := make([]string, 0, size/2)
+	for i := 0; i < size; i += 2 {
+		toRemove = append(toRemove, fmt.Sprintf("context-%d", i))
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		config := largeConfig(size)
+		b.StartTimer()
+		if err := RemoveContexts(config, toRemove); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "config")
+	if err := Save(largeConfig(10000), path); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Load(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LabelsExtension names the context extension labels are kept in, so they
+// travel with the context and tools that preserve extensions keep them
+const LabelsExtension = "kubectx-manager"
+
+// extensionsKey is the context field holding its named extensions
+const extensionsKey = "extensions"
+
+// maxLabelLength is the longest a label name or value may be, as in Kubernetes
+const maxLabelLength = 63
+
+var (
+	// labelNamePattern matches label names and non-empty label values
+	labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	// labelPrefixPattern matches the optional DNS subdomain prefix of a label key
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// namedExtension is one entry of a context's extensions
+type namedExtension struct {
+	Name      string          `yaml:"name"`
+	Extension labelsExtension `yaml:"extension"`
+}
+
+// labelsExtension is the content of the LabelsExtension extension
+type labelsExtension struct {
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// Labels returns the labels of the context, or nil if it has none.
+func (c *Context) Labels() map[string]string {
+	node, ok := c.Extra[extensionsKey]
+	if !ok || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, item := range node.Content {
+		if extensionName(item) != LabelsExtension {
+			continue
+		}
+		var ext namedExtension
+		if err := item.Decode(&ext); err != nil {
+			return nil
+		}
+		return ext.Extension.Labels
+	}
+	return nil
+}
+
+// SetLabels replaces the labels of the context; no labels removes the
+// extension. Other extensions are kept as they are.
+func (c *Context) SetLabels(labels map[string]string) error {
+	var items []*yaml.Node
+	index := -1
+	if node, ok := c.Extra[extensionsKey]; ok {
+		if node.Kind != yaml.SequenceNode {
+			return fmt.Errorf("context %s is not a list", extensionsKey)
+		}
+		for _, item := range node.Content {
+			if extensionName(item) == LabelsExtension {
+				index = len(items)
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+
+	if len(labels) > 0 {
+		item := &yaml.Node{}
+		if err := item.Encode(namedExtension{Name: LabelsExtension, Extension: labelsExtension{Labels: labels}}); err != nil {
+			return fmt.Errorf("failed to encode labels: %w", err)
+		}
+		if index < 0 {
+			index = len(items)
+		}
+		items = slices.Insert(items, index, item)
+	}
+
+	if len(items) == 0 {
+		delete(c.Extra, extensionsKey)
+		return nil
+	}
+	if c.Extra == nil {
+		c.Extra = make(map[string]yaml.Node)
+	}
+	c.Extra[extensionsKey] = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items}
+	return nil
+}
+
+// extensionName returns the name of a named extension node, or "".
+func extensionName(item *yaml.Node) string {
+	if item.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		if item.Content[i].Value == "name" {
+			return item.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// ValidateLabel checks a label key and value against the Kubernetes label
+// syntax: an optional DNS subdomain prefix and a name of up to 63
+// alphanumerics, '-', '_' and '.', and a value of the same form or empty.
+func ValidateLabel(key, value string) error {
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("invalid label key '%s': the prefix must be a DNS subdomain", key)
+		}
+		name = rest
+	}
+	if len(name) > maxLabelLength || !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid label key '%s': must be at most %d letters, digits, '-', '_' and '.', "+
+			"starting and ending with a letter or digit", key, maxLabelLength)
+	}
+	if value != "" && (len(value) > maxLabelLength || !labelNamePattern.MatchString(value)) {
+		return fmt.Errorf("invalid label value '%s': must be empty or at most %d letters, digits, '-', '_' and '.', "+
+			"starting and ending with a letter or digit", value, maxLabelLength)
+	}
+	return nil
+}
+
+// FormatLabels returns labels as sorted key=value pairs joined by commas.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Selector selects contexts by their labels, like a kubectl label selector.
+type Selector struct {
+	text         string
+	requirements []labelRequirement
+}
+
+// labelRequirement is one comma-separated term of a selector
+type labelRequirement struct {
+	key, value string
+	// op is "=", "!=", "exists" or "!exists"
+	op string
+}
+
+// ParseSelector parses a comma-separated list of requirements: key=value
+// (or key==value), key!=value, key to require the label and !key to
+// forbid it. All of them must hold for a context to be selected.
+func ParseSelector(text string) (*Selector, error) {
+	selector := &Selector{text: text}
+	for _, term := range strings.Split(text, ",") {
+		term = strings.TrimSpace(term)
+		var req labelRequirement
+		switch {
+		case term == "":
+			return nil, fmt.Errorf("invalid selector '%s': empty requirement", text)
+		case strings.Contains(term, "!="):
+			req.key, req.value, _ = strings.Cut(term, "!=")
+			req.op = "!="
+		case strings.Contains(term, "="):
+			req.key, req.value, _ = strings.Cut(term, "=")
+			req.value = strings.TrimPrefix(req.value, "=")
+			req.op = "="
+		case strings.HasPrefix(term, "!"):
+			req.key, req.op = strings.TrimPrefix(term, "!"), "!exists"
+		default:
+			req.key, req.op = term, "exists"
+		}
+		req.key, req.value = strings.TrimSpace(req.key), strings.TrimSpace(req.value)
+		if err := ValidateLabel(req.key, req.value); err != nil {
+			return nil, fmt.Errorf("invalid selector '%s': %w", text, err)
+		}
+		selector.requirements = append(selector.requirements, req)
+	}
+	return selector, nil
+}
+
+// Matches reports whether labels satisfy every requirement of the selector.
+func (s *Selector) Matches(labels map[string]string) bool {
+	for _, req := range s.requirements {
+		value, ok := labels[req.key]
+		switch req.op {
+		case "=":
+			if !ok || value != req.value {
+				return false
+			}
+		case "!=":
+			if ok && value == req.value {
+				return false
+			}
+		case "exists":
+			if !ok {
+				return false
+			}
+		case "!exists":
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String returns the selector as it was given.
+func (s *Selector) String() string {
+	return s.text
+}
+
+// SelectContexts returns the names of the contexts of config whose labels
+// match the selector, in kubeconfig order.
+func SelectContexts(config *Config, selector *Selector) []string {
+	var names []string
+	for _, namedContext := range config.Contexts {
+		var labels map[string]string
+		if namedContext.Context != nil {
+			labels = namedContext.Context.Labels()
+		}
+		if selector.Matches(labels) {
+			names = append(names, namedContext.Name)
+		}
+	}
+	return names
+}
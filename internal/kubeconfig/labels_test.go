@@ -0,0 +1,107 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContextLabels(t *testing.T) {
+	config, err := Parse([]byte(`apiVersion: v1
+kind: Config
+contexts:
+- name: minikube
+  context:
+    cluster: minikube
+    user: minikube
+    extensions:
+    - name: context_info
+      extension:
+        provider: minikube.sigs.k8s.io
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	ctx := config.GetContext("minikube")
+	if ctx.Labels() != nil {
+		t.Errorf("Expected no labels, got %v", ctx.Labels())
+	}
+
+	if err := ctx.SetLabels(map[string]string{"env": "dev", "team": "payments"}); err != nil {
+		t.Fatalf("SetLabels failed: %v", err)
+	}
+	data, err := Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	reloaded, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	labels := reloaded.GetContext("minikube").Labels()
+	if !reflect.DeepEqual(labels, map[string]string{"env": "dev", "team": "payments"}) {
+		t.Errorf("Expected the labels to round-trip, got %v\n%s", labels, data)
+	}
+	if !strings.Contains(string(data), "provider: minikube.sigs.k8s.io") {
+		t.Errorf("Expected other extensions to be kept, got:\n%s", data)
+	}
+
+	if err := ctx.SetLabels(nil); err != nil {
+		t.Fatalf("SetLabels failed: %v", err)
+	}
+	if ctx.Labels() != nil || len(ctx.Extra["extensions"].Content) != 1 {
+		t.Errorf("Expected only the labels extension to be removed, got %v", ctx.Extra["extensions"].Content)
+	}
+}
+
+func TestValidateLabel(t *testing.T) {
+	for _, valid := range [][2]string{{"env", "prod"}, {"example.com/team", "payments"}, {"scratch", ""}, {"a_b.c-d", "X.1"}} {
+		if err := ValidateLabel(valid[0], valid[1]); err != nil {
+			t.Errorf("Expected %s=%s to be valid, got %v", valid[0], valid[1], err)
+		}
+	}
+	for _, invalid := range [][2]string{{"", "x"}, {"-env", "x"}, {"env", "a b"}, {"Example.com/team", "x"}, {strings.Repeat("a", 64), ""}} {
+		if err := ValidateLabel(invalid[0], invalid[1]); err == nil {
+			t.Errorf("Expected %q=%q to be invalid", invalid[0], invalid[1])
+		}
+	}
+}
+
+func TestSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "payments"}
+	for text, expected := range map[string]bool{
+		"env=prod":               true,
+		"env==prod,team":         true,
+		"env=dev":                false,
+		"env!=dev":               true,
+		"team!=payments":         false,
+		"!scratch":               true,
+		"!team":                  false,
+		"env=prod, team=billing": false,
+	} {
+		selector, err := ParseSelector(text)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q) failed: %v", text, err)
+		}
+		if got := selector.Matches(labels); got != expected {
+			t.Errorf("Expected %q to match %t, got %t", text, expected, got)
+		}
+	}
+	for _, invalid := range []string{"", "env=prod,", "env=a b"} {
+		if _, err := ParseSelector(invalid); err == nil {
+			t.Errorf("Expected selector %q to be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ErrLocked is returned by Lock when another process keeps a kubeconfig locked.
+var ErrLocked = errors.New("kubeconfig is locked by another process")
+
+// LockTimeout is how long Lock waits for another process to release a kubeconfig.
+var LockTimeout = 10 * time.Second
+
+const lockRetryInterval = 50 * time.Millisecond
+
+// LockSuffix is appended to the path of a kubeconfig to name its lock file.
+// It is not client-go's "<kubeconfig>.lock", which kubectl creates and
+// removes as a mutex of its own.
+const LockSuffix = ".kubectx-manager.lock"
+
+// FileLock is an advisory lock on a kubeconfig. The lock is held on a
+// "<kubeconfig>.kubectx-manager.lock" file next to it rather than on the kubeconfig itself,
+// because Save replaces the kubeconfig with a new file. A kubeconfig reached
+// through a symlink is locked next to the file the link points to, which is
+// the file Save writes.
+type FileLock struct {
+	file *os.File
+	path string
+}
+
+// Lock takes the lock for the kubeconfig at path, waiting up to LockTimeout
+// for another process to release it. Hold it across a load-modify-save cycle
+// and release it with Unlock.
+func Lock(path string) (*FileLock, error) {
+	return LockContext(context.Background(), path)
+}
+
+// LockContext is Lock that also stops waiting when ctx is done.
+func LockContext(ctx context.Context, path string) (*FileLock, error) {
+	lockPath := lockTarget(path) + LockSuffix
+	deadline := time.Now().Add(LockTimeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, kubeconfigFileMode) //nolint:gosec // Lock file next to the user-specified kubeconfig
+		if err != nil {
+			return nil, fmt.Errorf("failed to open lock file: %w", err)
+		}
+
+		locked, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			// The previous holder may have removed the file after we opened it;
+			// only a lock on the file currently at lockPath counts
+			if sameFile(file, lockPath) {
+				return &FileLock{file: file, path: lockPath}, nil
+			}
+			_ = unlockFile(file)
+		}
+		_ = file.Close()
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, path)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for lock on %s: %w", path, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// Unlock releases the lock and removes the lock file.
+func (l *FileLock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := releaseLockFile(l.file, l.path)
+	l.file = nil
+	return err
+}
+
+// Locks is a set of kubeconfig locks held together.
+type Locks []*FileLock
+
+// LockAll locks every kubeconfig in paths. Locks are always taken in the same
+// order, so two processes locking overlapping sets cannot deadlock.
+func LockAll(paths []string) (Locks, error) {
+	return LockAllContext(context.Background(), paths)
+}
+
+// LockAllContext is LockAll that also stops waiting when ctx is done.
+func LockAllContext(ctx context.Context, paths []string) (Locks, error) {
+	ordered := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if target := lockTarget(path); !seen[target] {
+			seen[target] = true
+			ordered = append(ordered, target)
+		}
+	}
+	sort.Strings(ordered)
+
+	locks := make(Locks, 0, len(ordered))
+	for _, path := range ordered {
+		lock, err := LockContext(ctx, path)
+		if err != nil {
+			return nil, errors.Join(err, locks.Unlock())
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+// Unlock releases all locks in reverse order, returning every failure.
+func (l Locks) Unlock() error {
+	var errs []error
+	for i := len(l) - 1; i >= 0; i-- {
+		if err := l[i].Unlock(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lockTarget returns the absolute path of the kubeconfig at path with
+// symlinks resolved, so every link to a kubeconfig shares one lock. A
+// kubeconfig that does not exist yet is locked by its absolute path.
+func lockTarget(path string) string {
+	abs := absPath(path)
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
+
+// sameFile reports whether file is still the file at path.
+func sameFile(file *os.File, path string) bool {
+	opened, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(opened, current)
+}
@@ -0,0 +1,38 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+//go:build !unix && !windows
+
+package kubeconfig
+
+import (
+	"os"
+)
+
+// tryLockFile always succeeds on platforms without advisory file locking.
+func tryLockFile(_ *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
+
+func releaseLockFile(file *os.File, path string) error {
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
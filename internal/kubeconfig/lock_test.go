@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockExcludesOtherHolders(t *testing.T) {
+	oldTimeout := LockTimeout
+	LockTimeout = 100 * time.Millisecond
+	defer func() { LockTimeout = oldTimeout }()
+
+	path := filepath.Join(t.TempDir(), "config")
+
+	lock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	if _, err := os.Stat(path + LockSuffix); err != nil {
+		t.Errorf("Expected lock file to exist while locked: %v", err)
+	}
+
+	// flock locks are per open file, so a second open in this process contends like another process would
+	if _, err := Lock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked while the lock is held, got %v", err)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if _, err := os.Stat(path + LockSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected lock file to be removed after unlock, got %v", err)
+	}
+
+	again, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Expected the lock to be free again, got %v", err)
+	}
+	if err := again.Unlock(); err != nil {
+		t.Errorf("Unlock failed: %v", err)
+	}
+}
+
+func TestLockWaitsForRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	held, err := LockAll([]string{path, path})
+	if err != nil {
+		t.Fatalf("LockAll failed: %v", err)
+	}
+	if len(held) != 1 {
+		t.Errorf("Expected duplicate paths to be locked once, got %d locks", len(held))
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		held.Unlock()
+	}()
+
+	start := time.Now()
+	lock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Expected Lock to wait for the holder, got %v", err)
+	}
+	defer lock.Unlock() //nolint:errcheck // Test cleanup
+	if waited := time.Since(start); waited < 50*time.Millisecond {
+		t.Errorf("Expected Lock to wait for release, returned after %v", waited)
+	}
+}
+
+func TestLockFollowsSymlinks(t *testing.T) {
+	oldTimeout := LockTimeout
+	LockTimeout = 100 * time.Millisecond
+	defer func() { LockTimeout = oldTimeout }()
+
+	target := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(target, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	link := filepath.Join(t.TempDir(), "config")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	lock, err := Lock(link)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	if _, err := os.Lstat(link + LockSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected no lock file next to the symlink, got %v", err)
+	}
+	// The kubeconfig locked through its link is locked under its own name too
+	if _, err := Lock(target); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked for the link's target, got %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	// Both names of one kubeconfig take a single lock instead of waiting on each other
+	locks, err := LockAll([]string{link, target})
+	if err != nil {
+		t.Fatalf("LockAll failed: %v", err)
+	}
+	if len(locks) != 1 {
+		t.Errorf("Expected a single lock for both names, got %d", len(locks))
+	}
+	if err := locks.Unlock(); err != nil {
+		t.Errorf("Unlock failed: %v", err)
+	}
+}
+
+func TestLockLeavesKubectlLockAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	// kubectl holds "<kubeconfig>.lock" while it writes the kubeconfig
+	kubectlLock := path + ".lock"
+	if err := os.WriteFile(kubectlLock, nil, 0600); err != nil {
+		t.Fatalf("Failed to create kubectl lock: %v", err)
+	}
+
+	lock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if _, err := os.Stat(kubectlLock); err != nil {
+		t.Errorf("Expected kubectl's lock file to be left alone, got %v", err)
+	}
+}
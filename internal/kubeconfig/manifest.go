@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ManifestSuffix is appended to the path of a backup to name its manifest
+const ManifestSuffix = ".json"
+
+// Manifest records why a backup was taken: the command that triggered it
+// and, for commands that remove contexts, what was removed and why.
+// It is stored in plaintext next to the backup, even when the backup is encrypted.
+type Manifest struct {
+	// Command is the full command path, e.g. "kubectx-manager remove"
+	Command string   `json:"command" yaml:"command"`
+	Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Flags holds the flags set on the command line, by name
+	Flags      map[string]string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	Kubeconfig string            `json:"kubeconfig" yaml:"kubeconfig"`
+	// RemovedContexts lists the contexts the command removed from the
+	// kubeconfig, or from the merged view of a kubeconfig list
+	RemovedContexts []string `json:"removedContexts,omitempty" yaml:"removedContexts,omitempty"`
+	// MatchedPatterns maps each pattern to the contexts it matched:
+	// whitelist patterns to the contexts they kept, remove patterns to those removed
+	MatchedPatterns map[string][]string `json:"matchedPatterns,omitempty" yaml:"matchedPatterns,omitempty"`
+	// AuthResults holds the outcome of --auth-check for each checked context
+	AuthResults map[string]AuthStatus `json:"authResults,omitempty" yaml:"authResults,omitempty"`
+}
+
+// ManifestPath returns the path of the manifest of the backup at backupPath.
+func ManifestPath(backupPath string) string {
+	return backupPath + ManifestSuffix
+}
+
+// WriteManifest writes m as the manifest of the backup at backupPath.
+func WriteManifest(backupPath string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ManifestPath(backupPath), append(data, '\n'), kubeconfigFileMode); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of the backup at backupPath. Backups
+// written before manifests were introduced have none; the error then
+// matches os.ErrNotExist.
+func ReadManifest(backupPath string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(backupPath)) //nolint:gosec // Manifest of a user-selected backup
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// RemoveBackup removes the backup at backupPath together with its manifest.
+func RemoveBackup(backupPath string) error {
+	if err := os.Remove(backupPath); err != nil {
+		return err
+	}
+	if err := os.Remove(ManifestPath(backupPath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := os.WriteFile(kubeconfigPath, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	backupDir := filepath.Join(tmpDir, "backups")
+	backupPath, err := CreateBackup(kubeconfigPath, backupDir)
+	if err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+
+	if _, err := ReadManifest(backupPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist before a manifest is written, got %v", err)
+	}
+
+	want := &Manifest{
+		Command:         "kubectx-manager",
+		Flags:           map[string]string{"auth-check": "true"},
+		Kubeconfig:      kubeconfigPath,
+		RemovedContexts: []string{"old"},
+		MatchedPatterns: map[string][]string{"prod-*": {"prod-east"}},
+		AuthResults:     map[string]AuthStatus{"old": {Reason: "token expired"}},
+	}
+	if err := WriteManifest(backupPath, want); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	got, err := ReadManifest(backupPath)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if got.Command != want.Command || got.Flags["auth-check"] != "true" || len(got.RemovedContexts) != 1 ||
+		got.MatchedPatterns["prod-*"][0] != "prod-east" || got.AuthResults["old"].Reason != "token expired" {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	// The manifest is not mistaken for a backup
+	backups, err := FindBackups(kubeconfigPath, backupDir)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Expected one backup, got %d (err %v)", len(backups), err)
+	}
+
+	if err := RemoveBackup(backupPath); err != nil {
+		t.Fatalf("Failed to remove backup: %v", err)
+	}
+	for _, path := range []string{backupPath, ManifestPath(backupPath)} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+}
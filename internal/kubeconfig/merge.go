@@ -0,0 +1,379 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DuplicateStrategy decides what happens when an entry being merged has the same
+// name as an existing entry but a different configuration.
+type DuplicateStrategy string
+
+const (
+	// DuplicateOverwrite replaces the existing entry with the incoming one
+	DuplicateOverwrite DuplicateStrategy = "overwrite"
+	// DuplicateKeep keeps the existing entry and drops the incoming one
+	DuplicateKeep DuplicateStrategy = "keep"
+	// DuplicateRename adds the incoming entry under a new, unused name
+	DuplicateRename DuplicateStrategy = "rename"
+	// DuplicateFail aborts the merge
+	DuplicateFail DuplicateStrategy = "fail"
+)
+
+// DuplicateStrategies lists every supported strategy
+var DuplicateStrategies = []DuplicateStrategy{DuplicateOverwrite, DuplicateKeep, DuplicateRename, DuplicateFail}
+
+// ParseDuplicateStrategy validates a strategy name
+func ParseDuplicateStrategy(name string) (DuplicateStrategy, error) {
+	for _, strategy := range DuplicateStrategies {
+		if string(strategy) == name {
+			return strategy, nil
+		}
+	}
+	names := make([]string, len(DuplicateStrategies))
+	for i, strategy := range DuplicateStrategies {
+		names[i] = string(strategy)
+	}
+	return "", fmt.Errorf("unknown duplicate strategy %q (expected one of: %s)", name, strings.Join(names, ", "))
+}
+
+// DuplicateError is returned by Merge with DuplicateFail when a conflicting entry is found
+type DuplicateError struct {
+	Kind string
+	Name string
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s '%s' already exists with a different configuration", e.Kind, e.Name)
+}
+
+// Is reports DuplicateError as an ErrConflict
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Conflict identifies an entry that exists in two configs under the same name
+// but with different configuration.
+type Conflict struct {
+	// Kind is "context", "cluster" or "user"
+	Kind string
+	Name string
+}
+
+// FindConflicts lists the entries of incoming that Merge would have to resolve
+// with a DuplicateStrategy because current holds a different entry of the same name.
+// Contexts are listed first, then clusters, then users, each in incoming's order.
+func FindConflicts(current, incoming *Config) []Conflict {
+	var conflicts []Conflict
+
+	contexts := make(map[string]*Context, len(current.Contexts))
+	for _, c := range current.Contexts {
+		contexts[c.Name] = c.Context
+	}
+	for _, c := range incoming.Contexts {
+		if existing, ok := contexts[c.Name]; ok && !contextsEqual(existing, c.Context) {
+			conflicts = append(conflicts, Conflict{Kind: "context", Name: c.Name})
+		}
+	}
+
+	clusters := make(map[string]*Cluster, len(current.Clusters))
+	for _, c := range current.Clusters {
+		clusters[c.Name] = c.Cluster
+	}
+	for _, c := range incoming.Clusters {
+		if existing, ok := clusters[c.Name]; ok && !clustersEqual(existing, c.Cluster) {
+			conflicts = append(conflicts, Conflict{Kind: "cluster", Name: c.Name})
+		}
+	}
+
+	users := make(map[string]*User, len(current.Users))
+	for _, u := range current.Users {
+		users[u.Name] = u.User
+	}
+	for _, u := range incoming.Users {
+		if existing, ok := users[u.Name]; ok && !usersEqual(existing, u.User) {
+			conflicts = append(conflicts, Conflict{Kind: "user", Name: u.Name})
+		}
+	}
+
+	return conflicts
+}
+
+// MergeReport describes what Merge did with each incoming entry
+type MergeReport struct {
+	// Renamed maps "kind/original-name" to the name the entry was added under
+	Renamed map[string]string `json:"renamed,omitempty" yaml:"renamed,omitempty"`
+	// Added, Overwritten, Kept and Identical list entries as "kind/name"
+	Added       []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Overwritten []string `json:"overwritten,omitempty" yaml:"overwritten,omitempty"`
+	Kept        []string `json:"kept,omitempty" yaml:"kept,omitempty"`
+	Identical   []string `json:"identical,omitempty" yaml:"identical,omitempty"`
+	// Duplicates lists entries src itself defined more than once, as
+	// "kind/name", resolved by MergeOptions.Duplicates before merging
+	Duplicates []string `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+}
+
+// MergeOptions controls how Merge resolves duplicate entries.
+type MergeOptions struct {
+	Strategy DuplicateStrategy
+	// Resolve, when set, picks the strategy for each conflicting entry
+	// instead of Strategy, e.g. by asking the user.
+	Resolve func(conflict Conflict) DuplicateStrategy
+	// RenameSuffix is appended to entries renamed by DuplicateRename.
+	// When empty, a numeric suffix is used.
+	RenameSuffix string
+	// Duplicates, when set, resolves entries src itself defines more than
+	// once before merging; see ResolveDuplicates. src is not modified.
+	// When empty, later definitions in src are merged like any other entry.
+	Duplicates DuplicatePolicy
+}
+
+// Merge adds the contexts, clusters and users from src into dst.
+// Entries that already exist with identical configuration are left alone; entries
+// that exist with a different configuration are handled according to strategy.
+// dst is only modified if Merge succeeds.
+func Merge(dst, src *Config, strategy DuplicateStrategy) (*MergeReport, error) {
+	return MergeWithOptions(dst, src, MergeOptions{Strategy: strategy})
+}
+
+// MergeWithOptions is Merge with control over how renamed entries are named.
+func MergeWithOptions(dst, src *Config, opts MergeOptions) (*MergeReport, error) {
+	if opts.Resolve == nil {
+		if _, err := ParseDuplicateStrategy(string(opts.Strategy)); err != nil {
+			return nil, err
+		}
+	}
+
+	var duplicates []Conflict
+	if opts.Duplicates != "" {
+		resolved := *src
+		var err error
+		if duplicates, err = ResolveDuplicates(&resolved, opts.Duplicates); err != nil {
+			return nil, err
+		}
+		src = &resolved
+	}
+
+	m := &merger{
+		report:   &MergeReport{Renamed: make(map[string]string)},
+		strategy: opts.Strategy,
+		choose:   opts.Resolve,
+		suffix:   opts.RenameSuffix,
+		clusters: append([]NamedCluster(nil), dst.Clusters...),
+		users:    append([]NamedUser(nil), dst.Users...),
+		contexts: append([]NamedContext(nil), dst.Contexts...),
+	}
+	for _, duplicate := range duplicates {
+		m.report.Duplicates = append(m.report.Duplicates, duplicate.Kind+"/"+duplicate.Name)
+	}
+
+	// Clusters and users first so renamed references can be applied to incoming contexts
+	clusterNames := make(map[string]string)
+	for _, incoming := range src.Clusters {
+		name, err := m.mergeCluster(incoming)
+		if err != nil {
+			return nil, err
+		}
+		clusterNames[incoming.Name] = name
+	}
+	userNames := make(map[string]string)
+	for _, incoming := range src.Users {
+		name, err := m.mergeUser(incoming)
+		if err != nil {
+			return nil, err
+		}
+		userNames[incoming.Name] = name
+	}
+	for _, incoming := range src.Contexts {
+		if incoming.Context != nil {
+			ctx := *incoming.Context
+			if renamed, ok := clusterNames[ctx.Cluster]; ok {
+				ctx.Cluster = renamed
+			}
+			if renamed, ok := userNames[ctx.User]; ok {
+				ctx.User = renamed
+			}
+			incoming.Context = &ctx
+		}
+		if err := m.mergeContext(incoming); err != nil {
+			return nil, err
+		}
+	}
+
+	dst.Clusters = m.clusters
+	dst.Users = m.users
+	dst.Contexts = m.contexts
+	if dst.CurrentContext == "" {
+		dst.CurrentContext = src.CurrentContext
+	}
+	dst.buildInternalMaps()
+
+	return m.report, nil
+}
+
+type merger struct {
+	report   *MergeReport
+	strategy DuplicateStrategy
+	choose   func(Conflict) DuplicateStrategy
+	suffix   string
+	clusters []NamedCluster
+	users    []NamedUser
+	contexts []NamedContext
+}
+
+func (m *merger) mergeCluster(incoming NamedCluster) (string, error) {
+	for i := range m.clusters {
+		if m.clusters[i].Name != incoming.Name {
+			continue
+		}
+		resolved, err := m.resolve("cluster", incoming.Name, clustersEqual(m.clusters[i].Cluster, incoming.Cluster),
+			func(name string) bool { return m.hasCluster(name) })
+		if err != nil || resolved == "" {
+			return incoming.Name, err
+		}
+		if resolved == incoming.Name {
+			m.clusters[i] = incoming
+			return incoming.Name, nil
+		}
+		incoming.Name = resolved
+		m.clusters = append(m.clusters, incoming)
+		return resolved, nil
+	}
+	m.clusters = append(m.clusters, incoming)
+	m.report.Added = append(m.report.Added, "cluster/"+incoming.Name)
+	return incoming.Name, nil
+}
+
+func (m *merger) mergeUser(incoming NamedUser) (string, error) {
+	for i := range m.users {
+		if m.users[i].Name != incoming.Name {
+			continue
+		}
+		resolved, err := m.resolve("user", incoming.Name, usersEqual(m.users[i].User, incoming.User),
+			func(name string) bool { return m.hasUser(name) })
+		if err != nil || resolved == "" {
+			return incoming.Name, err
+		}
+		if resolved == incoming.Name {
+			m.users[i] = incoming
+			return incoming.Name, nil
+		}
+		incoming.Name = resolved
+		m.users = append(m.users, incoming)
+		return resolved, nil
+	}
+	m.users = append(m.users, incoming)
+	m.report.Added = append(m.report.Added, "user/"+incoming.Name)
+	return incoming.Name, nil
+}
+
+func (m *merger) mergeContext(incoming NamedContext) error {
+	for i := range m.contexts {
+		if m.contexts[i].Name != incoming.Name {
+			continue
+		}
+		resolved, err := m.resolve("context", incoming.Name, contextsEqual(m.contexts[i].Context, incoming.Context),
+			func(name string) bool { return m.hasContext(name) })
+		if err != nil || resolved == "" {
+			return err
+		}
+		if resolved == incoming.Name {
+			m.contexts[i] = incoming
+			return nil
+		}
+		incoming.Name = resolved
+		m.contexts = append(m.contexts, incoming)
+		return nil
+	}
+	m.contexts = append(m.contexts, incoming)
+	m.report.Added = append(m.report.Added, "context/"+incoming.Name)
+	return nil
+}
+
+// resolve applies the strategy to a name collision. It returns the name the
+// incoming entry should be stored under, or "" if it should be dropped.
+func (m *merger) resolve(kind, name string, identical bool, exists func(string) bool) (string, error) {
+	key := kind + "/" + name
+	if identical {
+		m.report.Identical = append(m.report.Identical, key)
+		return "", nil
+	}
+
+	strategy := m.strategy
+	if m.choose != nil {
+		strategy = m.choose(Conflict{Kind: kind, Name: name})
+	}
+
+	switch strategy {
+	case DuplicateOverwrite:
+		m.report.Overwritten = append(m.report.Overwritten, key)
+		return name, nil
+	case DuplicateKeep:
+		m.report.Kept = append(m.report.Kept, key)
+		return "", nil
+	case DuplicateRename:
+		renamed := uniqueName(name, m.suffix, exists)
+		m.report.Renamed[key] = renamed
+		return renamed, nil
+	default:
+		return "", &DuplicateError{Kind: kind, Name: name}
+	}
+}
+
+func (m *merger) hasCluster(name string) bool {
+	for _, c := range m.clusters {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *merger) hasUser(name string) bool {
+	for _, u := range m.users {
+		if u.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *merger) hasContext(name string) bool {
+	for _, c := range m.contexts {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueName returns an unused name derived from name. With a suffix the first
+// candidate is name+suffix; otherwise, and on further collisions, the smallest
+// free numeric suffix is appended.
+func uniqueName(name, suffix string, exists func(string) bool) string {
+	base := name
+	if suffix != "" {
+		base = name + suffix
+		if !exists(base) {
+			return base
+		}
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", base, i)
+		if !exists(candidate) {
+			return candidate
+		}
+	}
+}
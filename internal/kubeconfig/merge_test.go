@@ -0,0 +1,150 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func newMergeTestConfig(server, token string, contexts ...string) *Config {
+	cfg := &Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters:   []NamedCluster{{Name: "shared", Cluster: &Cluster{Server: server}}},
+		Users:      []NamedUser{{Name: "shared-user", User: &User{Token: token}}},
+	}
+	for _, name := range contexts {
+		cfg.Contexts = append(cfg.Contexts, NamedContext{Name: name, Context: &Context{Cluster: "shared", User: "shared-user"}})
+	}
+	cfg.buildInternalMaps()
+	return cfg
+}
+
+func TestMergeAddsNewAndSkipsIdentical(t *testing.T) {
+	dst := newMergeTestConfig("https://a.example.com", "token", "ctx-a")
+	src := newMergeTestConfig("https://a.example.com", "token", "ctx-a", "ctx-b")
+
+	report, err := Merge(dst, src, DuplicateFail)
+	if err != nil {
+		t.Fatalf("Identical duplicates must not fail the merge: %v", err)
+	}
+	if len(dst.Contexts) != 2 || dst.GetContext("ctx-b") == nil {
+		t.Errorf("Expected ctx-b to be added, got %v", dst.GetContextNames())
+	}
+	if len(report.Added) != 1 || report.Added[0] != "context/ctx-b" {
+		t.Errorf("Unexpected added list: %v", report.Added)
+	}
+	if len(report.Identical) != 3 {
+		t.Errorf("Expected cluster, user and context to be identical, got %v", report.Identical)
+	}
+}
+
+func TestMergeStrategies(t *testing.T) {
+	tests := []struct {
+		strategy       DuplicateStrategy
+		expectedServer string
+		expectedUsers  int
+	}{
+		{DuplicateOverwrite, "https://new.example.com", 1},
+		{DuplicateKeep, "https://old.example.com", 1},
+		{DuplicateRename, "https://old.example.com", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			dst := newMergeTestConfig("https://old.example.com", "old-token", "ctx")
+			src := newMergeTestConfig("https://new.example.com", "new-token", "ctx")
+
+			report, err := Merge(dst, src, tt.strategy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got := dst.GetCluster("shared").Server; got != tt.expectedServer {
+				t.Errorf("Expected existing cluster server %q, got %q", tt.expectedServer, got)
+			}
+			if len(dst.Users) != tt.expectedUsers {
+				t.Errorf("Expected %d users, got %d", tt.expectedUsers, len(dst.Users))
+			}
+
+			if tt.strategy == DuplicateRename {
+				renamedCluster := report.Renamed["cluster/shared"]
+				if renamedCluster != "shared-1" || dst.GetCluster("shared-1").Server != "https://new.example.com" {
+					t.Errorf("Expected incoming cluster renamed to shared-1, got %v", report.Renamed)
+				}
+				// The incoming context now differs (it references renamed entries) and is renamed too
+				renamedCtx := dst.GetContext(report.Renamed["context/ctx"])
+				if renamedCtx == nil || renamedCtx.Cluster != "shared-1" || renamedCtx.User != "shared-user-1" {
+					t.Errorf("Expected renamed context to reference renamed cluster and user, got %+v", renamedCtx)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeFail(t *testing.T) {
+	dst := newMergeTestConfig("https://old.example.com", "token", "ctx")
+	src := newMergeTestConfig("https://new.example.com", "token", "ctx")
+
+	_, err := Merge(dst, src, DuplicateFail)
+	var dupErr *DuplicateError
+	if !errors.As(err, &dupErr) || dupErr.Kind != "cluster" || dupErr.Name != "shared" {
+		t.Fatalf("Expected DuplicateError for cluster 'shared', got %v", err)
+	}
+	if dst.GetCluster("shared").Server != "https://old.example.com" {
+		t.Error("Failed merge must leave the destination untouched")
+	}
+}
+
+func TestParseDuplicateStrategy(t *testing.T) {
+	if s, err := ParseDuplicateStrategy("rename"); err != nil || s != DuplicateRename {
+		t.Errorf("Expected rename, got %q (%v)", s, err)
+	}
+	if _, err := ParseDuplicateStrategy("merge"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
+
+func TestDuplicateErrorIsConflict(t *testing.T) {
+	var err error = &DuplicateError{Kind: "cluster", Name: "prod"}
+	if !errors.Is(err, ErrConflict) {
+		t.Error("Expected DuplicateError to match ErrConflict")
+	}
+}
+
+func TestMergeRenameSuffix(t *testing.T) {
+	dst := newMergeTestConfig("https://old.example.com", "old", "ctx")
+	src := newMergeTestConfig("https://new.example.com", "new", "ctx")
+
+	report, err := MergeWithOptions(dst, src, MergeOptions{Strategy: DuplicateRename, RenameSuffix: "-imported"})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if report.Renamed["cluster/shared"] != "shared-imported" {
+		t.Errorf("Expected cluster to be renamed with suffix, got %v", report.Renamed)
+	}
+	if dst.GetCluster("shared-imported") == nil || dst.GetUser("shared-user-imported") == nil {
+		t.Error("Expected renamed cluster and user to be added")
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	current := newMergeTestConfig("https://old.example.com", "token", "ctx")
+	incoming := newMergeTestConfig("https://new.example.com", "token", "ctx", "other")
+
+	conflicts := FindConflicts(current, incoming)
+	if len(conflicts) != 1 || conflicts[0] != (Conflict{Kind: "cluster", Name: "shared"}) {
+		t.Errorf("Expected only the cluster to conflict, got %v", conflicts)
+	}
+}
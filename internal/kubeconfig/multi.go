@@ -0,0 +1,280 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiConfig is the merged view of a list of kubeconfig files, built the way
+// kubectl merges a KUBECONFIG list: the first file that defines a context,
+// cluster or user name wins, and current-context comes from the first file
+// that sets it. Changes are written back to the file each entry came from.
+type MultiConfig struct {
+	// Merged is the combined view; its entries are shared with Files
+	Merged *Config
+	// Files holds the loaded files in list order, parallel to Paths
+	Files []*Config
+	Paths []string
+	// contextSource and currentSource index into Files
+	contextSource map[string]int
+	currentSource int
+	modified      []bool
+}
+
+// LoadMulti loads and merges the kubeconfig files at paths.
+// A single path behaves exactly like Load. In a list, files that do not
+// exist are skipped as kubectl does; ErrKubeconfigNotFound is returned if
+// none of them exist.
+func LoadMulti(paths []string) (*MultiConfig, error) {
+	m := &MultiConfig{}
+	for _, path := range paths {
+		config, err := Load(path)
+		if err != nil {
+			if len(paths) == 1 {
+				return nil, err
+			}
+			if errors.Is(err, ErrKubeconfigNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		m.Files = append(m.Files, config)
+		m.Paths = append(m.Paths, path)
+	}
+	if len(m.Files) == 0 {
+		return nil, fmt.Errorf("%w: none of %s exist", ErrKubeconfigNotFound, strings.Join(paths, ", "))
+	}
+	m.modified = make([]bool, len(m.Files))
+	m.merge()
+	return m, nil
+}
+
+// merge rebuilds the merged view from Files
+func (m *MultiConfig) merge() {
+	m.contextSource = make(map[string]int)
+	m.currentSource = 0
+
+	if len(m.Files) == 1 {
+		// A single file is its own merged view, so edits need no write-back
+		m.Merged = m.Files[0]
+		for _, namedContext := range m.Merged.Contexts {
+			m.contextSource[namedContext.Name] = 0
+		}
+		return
+	}
+
+	first := m.Files[0]
+	merged := &Config{APIVersion: first.APIVersion, Kind: first.Kind, Extra: first.Extra}
+	seenClusters := make(map[string]bool)
+	seenUsers := make(map[string]bool)
+	currentFound := false
+
+	for i, file := range m.Files {
+		if !currentFound && file.CurrentContext != "" {
+			merged.CurrentContext = file.CurrentContext
+			m.currentSource = i
+			currentFound = true
+		}
+		for _, namedContext := range file.Contexts {
+			if _, ok := m.contextSource[namedContext.Name]; !ok {
+				m.contextSource[namedContext.Name] = i
+				merged.Contexts = append(merged.Contexts, namedContext)
+			}
+		}
+		for _, namedCluster := range file.Clusters {
+			if !seenClusters[namedCluster.Name] {
+				seenClusters[namedCluster.Name] = true
+				merged.Clusters = append(merged.Clusters, namedCluster)
+			}
+		}
+		for _, namedUser := range file.Users {
+			if !seenUsers[namedUser.Name] {
+				seenUsers[namedUser.Name] = true
+				merged.Users = append(merged.Users, namedUser)
+			}
+		}
+	}
+	merged.buildInternalMaps()
+	m.Merged = merged
+}
+
+// SourceOf returns the path of the file that defines the named context in the merged view, or "".
+func (m *MultiConfig) SourceOf(contextName string) string {
+	if i, ok := m.contextSource[contextName]; ok {
+		return m.Paths[i]
+	}
+	return ""
+}
+
+// RemoveContexts removes the named contexts from every file that defines them,
+// so a shadowed definition in a later file does not take their place.
+// Clusters and users that only the removed contexts referenced are removed
+// from every file as well; entries referenced by a context in any remaining
+// file are kept. A single file is cleaned up exactly as RemoveContexts does.
+func (m *MultiConfig) RemoveContexts(contextsToRemove []string) error {
+	if len(m.Files) == 1 {
+		m.modified[0] = true
+		return RemoveContexts(m.Files[0], contextsToRemove)
+	}
+
+	toRemove := make(map[string]bool, len(contextsToRemove))
+	for _, name := range contextsToRemove {
+		toRemove[name] = true
+	}
+	current, currentSource := m.Merged.CurrentContext, m.currentSource
+
+	// Collect the clusters and users the removed contexts referenced
+	candidateClusters := make(map[string]bool)
+	candidateUsers := make(map[string]bool)
+	for i, file := range m.Files {
+		remaining := make([]NamedContext, 0, len(file.Contexts))
+		for _, namedContext := range file.Contexts {
+			if !toRemove[namedContext.Name] {
+				remaining = append(remaining, namedContext)
+				continue
+			}
+			if namedContext.Context != nil {
+				candidateClusters[namedContext.Context.Cluster] = true
+				candidateUsers[namedContext.Context.User] = true
+			}
+			m.modified[i] = true
+		}
+		file.Contexts = remaining
+	}
+
+	// Keep anything still referenced by a context in any file
+	for _, file := range m.Files {
+		for _, namedContext := range file.Contexts {
+			if namedContext.Context != nil {
+				delete(candidateClusters, namedContext.Context.Cluster)
+				delete(candidateUsers, namedContext.Context.User)
+			}
+		}
+	}
+
+	for i, file := range m.Files {
+		clusters := make([]NamedCluster, 0, len(file.Clusters))
+		for _, namedCluster := range file.Clusters {
+			if candidateClusters[namedCluster.Name] {
+				m.modified[i] = true
+				continue
+			}
+			clusters = append(clusters, namedCluster)
+		}
+		file.Clusters = clusters
+
+		users := make([]NamedUser, 0, len(file.Users))
+		for _, namedUser := range file.Users {
+			if candidateUsers[namedUser.Name] {
+				m.modified[i] = true
+				continue
+			}
+			users = append(users, namedUser)
+		}
+		file.Users = users
+
+		if toRemove[file.CurrentContext] {
+			file.CurrentContext = ""
+			m.modified[i] = true
+		}
+		file.buildInternalMaps()
+	}
+
+	m.merge()
+	// Pick a new current context in the file the removed one was read from
+	if toRemove[current] && len(m.Merged.Contexts) > 0 {
+		m.currentSource = currentSource
+		m.SetCurrentContext(m.Merged.Contexts[0].Name)
+	}
+	return nil
+}
+
+// SetCurrentContext sets current-context in the file that kubectl reads it
+// from: the first file that sets it, or the first file if none does.
+func (m *MultiConfig) SetCurrentContext(name string) {
+	file := m.Files[m.currentSource]
+	file.CurrentContext = name
+	m.modified[m.currentSource] = true
+	m.Merged.CurrentContext = name
+}
+
+// SetNamespace sets the namespace of the named context in the file that
+// defines it in the merged view.
+func (m *MultiConfig) SetNamespace(contextName, namespace string) error {
+	i, ok := m.contextSource[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found", contextName)
+	}
+	ctx := m.Files[i].GetContext(contextName)
+	if ctx == nil {
+		return fmt.Errorf("context '%s' has no configuration", contextName)
+	}
+	ctx.Namespace = namespace
+	m.modified[i] = true
+	return nil
+}
+
+// SetLabels replaces the labels of the named context in the file that
+// defines it in the merged view.
+func (m *MultiConfig) SetLabels(contextName string, labels map[string]string) error {
+	i, ok := m.contextSource[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found", contextName)
+	}
+	ctx := m.Files[i].GetContext(contextName)
+	if ctx == nil {
+		return fmt.Errorf("context '%s' has no configuration", contextName)
+	}
+	if err := ctx.SetLabels(labels); err != nil {
+		return err
+	}
+	m.modified[i] = true
+	return nil
+}
+
+// ModifiedPaths lists the files changed since loading, in list order.
+func (m *MultiConfig) ModifiedPaths() []string {
+	var paths []string
+	for i, path := range m.Paths {
+		if m.modified[i] {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Save writes every modified file back to where it was loaded from.
+func (m *MultiConfig) Save() error {
+	for i, file := range m.Files {
+		if !m.modified[i] {
+			continue
+		}
+		if err := Save(file, m.Paths[i]); err != nil {
+			return fmt.Errorf("%s: %w", m.Paths[i], err)
+		}
+		m.modified[i] = false
+	}
+	return nil
+}
+
+// Counts returns the number of clusters and users across all files.
+func (m *MultiConfig) Counts() (clusters, users int) {
+	for _, file := range m.Files {
+		clusters += len(file.Clusters)
+		users += len(file.Users)
+	}
+	return clusters, users
+}
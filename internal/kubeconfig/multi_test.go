@@ -0,0 +1,238 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const multiTestMain = `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: shared-user
+- name: staging
+  context:
+    cluster: staging-cluster
+    user: shared-user
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+users:
+- name: shared-user
+  user:
+    token: shared
+`
+
+const multiTestExtra = `apiVersion: v1
+kind: Config
+current-context: prod
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+- name: dev
+  context:
+    cluster: shadowed
+    user: shadowed
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com
+- name: staging-cluster
+  cluster:
+    server: https://staging.example.com
+users:
+- name: prod-user
+  user:
+    token: prod
+`
+
+func writeMultiTestFiles(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main")
+	extra := filepath.Join(dir, "extra")
+	if err := os.WriteFile(main, []byte(multiTestMain), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	if err := os.WriteFile(extra, []byte(multiTestExtra), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return main, extra
+}
+
+func TestLoadMultiMergesLikeKubectl(t *testing.T) {
+	main, extra := writeMultiTestFiles(t)
+
+	m, err := LoadMulti([]string{main, filepath.Join(filepath.Dir(main), "missing"), extra})
+	if err != nil {
+		t.Fatalf("LoadMulti failed: %v", err)
+	}
+	if len(m.Files) != 2 {
+		t.Fatalf("Expected missing file to be skipped, got %d files", len(m.Files))
+	}
+	if m.Merged.CurrentContext != "dev" {
+		t.Errorf("Expected current-context from the first file, got %q", m.Merged.CurrentContext)
+	}
+	if ctx := m.Merged.GetContext("dev"); ctx == nil || ctx.Cluster != "dev-cluster" {
+		t.Errorf("Expected the first definition of dev to win, got %+v", ctx)
+	}
+	if m.SourceOf("prod") != extra || m.SourceOf("staging") != main {
+		t.Errorf("Unexpected sources: prod=%q staging=%q", m.SourceOf("prod"), m.SourceOf("staging"))
+	}
+	if m.Merged.GetCluster("staging-cluster") == nil {
+		t.Error("Expected clusters from later files to be merged")
+	}
+
+	if _, err := LoadMulti([]string{filepath.Join(filepath.Dir(main), "a"), filepath.Join(filepath.Dir(main), "b")}); !errors.Is(err, ErrKubeconfigNotFound) {
+		t.Errorf("Expected ErrKubeconfigNotFound when no file exists, got %v", err)
+	}
+}
+
+func TestMultiConfigRemoveWritesBackToSources(t *testing.T) {
+	main, extra := writeMultiTestFiles(t)
+
+	m, err := LoadMulti([]string{main, extra})
+	if err != nil {
+		t.Fatalf("LoadMulti failed: %v", err)
+	}
+	if err := m.RemoveContexts([]string{"dev", "staging"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+	if got := m.ModifiedPaths(); len(got) != 2 {
+		t.Errorf("Expected both files to be modified, got %v", got)
+	}
+	// Removing both contexts of main leaves it without entries
+	ConfirmShrink = func(string, string) (bool, error) { return true, nil }
+	t.Cleanup(func() { ConfirmShrink = nil })
+	if err := m.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	mainConfig, err := Load(main)
+	if err != nil {
+		t.Fatalf("Failed to reload %s: %v", main, err)
+	}
+	if len(mainConfig.Contexts) != 0 || len(mainConfig.Clusters) != 0 || len(mainConfig.Users) != 0 {
+		t.Errorf("Expected main to be emptied, got %+v", mainConfig)
+	}
+	if mainConfig.CurrentContext != "prod" {
+		t.Errorf("Expected current-context to move to prod in main, got %q", mainConfig.CurrentContext)
+	}
+
+	extraConfig, err := Load(extra)
+	if err != nil {
+		t.Fatalf("Failed to reload %s: %v", extra, err)
+	}
+	if extraConfig.GetContext("dev") != nil {
+		t.Error("Expected the shadowed dev context to be removed too")
+	}
+	if extraConfig.GetCluster("staging-cluster") != nil {
+		t.Error("Expected staging-cluster to be removed from the file that defines it")
+	}
+	if extraConfig.GetContext("prod") == nil || extraConfig.GetCluster("prod-cluster") == nil || extraConfig.GetUser("prod-user") == nil {
+		t.Error("Expected prod and its entries to be kept")
+	}
+}
+
+func TestMultiConfigPreviewRemoval(t *testing.T) {
+	main, extra := writeMultiTestFiles(t)
+	multi, err := LoadMulti([]string{main, extra})
+	if err != nil {
+		t.Fatalf("LoadMulti failed: %v", err)
+	}
+
+	changes, err := multi.PreviewRemoval([]string{"dev", "staging"})
+	if err != nil {
+		t.Fatalf("PreviewRemoval failed: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Expected changes for both files, got %d", len(changes))
+	}
+	if got := strings.Join(changes[0].Removed, ","); got != "context/dev,context/staging,cluster/dev-cluster,user/shared-user" {
+		t.Errorf("Unexpected removals from the first file: %s", got)
+	}
+	if changes[0].CurrentContext == nil || changes[0].CurrentContext.From != "dev" {
+		t.Errorf("Expected current-context to change from dev, got %+v", changes[0].CurrentContext)
+	}
+	if got := strings.Join(changes[1].Removed, ","); got != "context/dev,cluster/staging-cluster" {
+		t.Errorf("Unexpected removals from the second file: %s", got)
+	}
+	if len(multi.Merged.Contexts) != 3 || len(multi.Files[0].Clusters) != 1 {
+		t.Error("Expected PreviewRemoval to leave the files untouched")
+	}
+}
+
+func TestCompareUnused(t *testing.T) {
+	before, err := Parse([]byte(multiTestExtra))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	after, err := Parse([]byte(multiTestExtra))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if err := RemoveContexts(after, []string{"dev"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+	after.Users[0].User.Token = "rotated"
+
+	changes := Compare(before, after)
+	if got := strings.Join(changes.Removed, ","); got != "context/dev,cluster/staging-cluster" {
+		t.Errorf("Unexpected removals: %s", got)
+	}
+	if got := strings.Join(changes.Unused, ","); got != "cluster/staging-cluster" {
+		t.Errorf("Expected staging-cluster to be reported as already unused, got %s", got)
+	}
+	if got := strings.Join(changes.Modified, ","); got != "user/prod-user" {
+		t.Errorf("Expected prod-user to be modified, got %s", got)
+	}
+	if changes.CurrentContext != nil || changes.Empty() {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+}
+
+func TestMultiConfigSetNamespace(t *testing.T) {
+	main, extra := writeMultiTestFiles(t)
+
+	m, err := LoadMulti([]string{main, extra})
+	if err != nil {
+		t.Fatalf("LoadMulti failed: %v", err)
+	}
+	// dev is defined in main, shadowing the dev of extra
+	if err := m.SetNamespace("dev", "team-a"); err != nil {
+		t.Fatalf("SetNamespace failed: %v", err)
+	}
+	if got := m.ModifiedPaths(); len(got) != 1 || got[0] != main {
+		t.Errorf("Expected only %s to be modified, got %v", main, got)
+	}
+	if ns := m.Merged.GetContext("dev").Namespace; ns != "team-a" {
+		t.Errorf("Expected the merged view to use namespace team-a, got %q", ns)
+	}
+	if ns := m.Files[1].GetContext("dev").Namespace; ns != "" {
+		t.Errorf("Expected the shadowed dev to be untouched, got %q", ns)
+	}
+	if err := m.SetNamespace("missing", "team-a"); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+}
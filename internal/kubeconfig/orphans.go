@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+// OrphanReport lists the clusters and users no context references, in file order
+type OrphanReport struct {
+	Clusters []string `json:"clusters" yaml:"clusters"`
+	Users    []string `json:"users" yaml:"users"`
+}
+
+// Empty reports whether no orphans were found
+func (r *OrphanReport) Empty() bool {
+	return len(r.Clusters) == 0 && len(r.Users) == 0
+}
+
+// PruneOrphans removes the clusters and users that no context references,
+// leaving the contexts untouched.
+func PruneOrphans(config *Config) *OrphanReport {
+	m := &MultiConfig{Files: []*Config{config}, modified: make([]bool, 1)}
+	return m.pruneOrphans()
+}
+
+// PruneOrphans removes the clusters and users that no context in any file
+// references from every file defining them, leaving the contexts untouched.
+// An entry only referenced from another file of the list is kept.
+func (m *MultiConfig) PruneOrphans() *OrphanReport {
+	report := m.pruneOrphans()
+	if !report.Empty() {
+		m.merge()
+	}
+	return report
+}
+
+func (m *MultiConfig) pruneOrphans() *OrphanReport {
+	usedClusters := make(map[string]bool)
+	usedUsers := make(map[string]bool)
+	for _, file := range m.Files {
+		for _, namedContext := range file.Contexts {
+			if namedContext.Context != nil {
+				usedClusters[namedContext.Context.Cluster] = true
+				usedUsers[namedContext.Context.User] = true
+			}
+		}
+	}
+
+	report := &OrphanReport{Clusters: []string{}, Users: []string{}}
+	prunedClusters := make(map[string]bool)
+	prunedUsers := make(map[string]bool)
+	for i, file := range m.Files {
+		clusters := make([]NamedCluster, 0, len(file.Clusters))
+		for _, namedCluster := range file.Clusters {
+			if usedClusters[namedCluster.Name] {
+				clusters = append(clusters, namedCluster)
+				continue
+			}
+			if !prunedClusters[namedCluster.Name] {
+				prunedClusters[namedCluster.Name] = true
+				report.Clusters = append(report.Clusters, namedCluster.Name)
+			}
+			m.modified[i] = true
+		}
+
+		users := make([]NamedUser, 0, len(file.Users))
+		for _, namedUser := range file.Users {
+			if usedUsers[namedUser.Name] {
+				users = append(users, namedUser)
+				continue
+			}
+			if !prunedUsers[namedUser.Name] {
+				prunedUsers[namedUser.Name] = true
+				report.Users = append(report.Users, namedUser.Name)
+			}
+			m.modified[i] = true
+		}
+
+		if m.modified[i] {
+			file.Clusters = clusters
+			file.Users = users
+			file.buildInternalMaps()
+		}
+	}
+	return report
+}
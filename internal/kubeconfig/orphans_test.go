@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPruneOrphans(t *testing.T) {
+	config := &Config{
+		CurrentContext: "a",
+		Contexts: []NamedContext{
+			{Name: "a", Context: &Context{Cluster: "prod", User: "admin"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "imported", Cluster: &Cluster{Server: "https://imported.example.com"}},
+			{Name: "prod", Cluster: &Cluster{Server: "https://prod.example.com"}},
+		},
+		Users: []NamedUser{
+			{Name: "admin", User: &User{Token: "secret"}},
+			{Name: "old-admin", User: &User{Token: "old"}},
+		},
+	}
+	config.buildInternalMaps()
+
+	report := PruneOrphans(config)
+
+	if fmt.Sprint(report.Clusters, report.Users) != "[imported] [old-admin]" {
+		t.Errorf("Expected imported and old-admin to be pruned, got %+v", report)
+	}
+	if len(config.Contexts) != 1 || config.CurrentContext != "a" {
+		t.Errorf("Expected the contexts to be untouched, got %+v", config.Contexts)
+	}
+	if config.GetCluster("imported") != nil || config.GetUser("old-admin") != nil || config.GetCluster("prod") == nil {
+		t.Errorf("Expected only the orphans to be removed, got %+v and %+v", config.Clusters, config.Users)
+	}
+	if again := PruneOrphans(config); !again.Empty() {
+		t.Errorf("Expected a second pass to find nothing, got %+v", again)
+	}
+}
+
+func TestMultiConfigPruneOrphans(t *testing.T) {
+	main, extra := writeMultiTestFiles(t)
+	m, err := LoadMulti([]string{main, extra})
+	if err != nil {
+		t.Fatalf("LoadMulti failed: %v", err)
+	}
+	m.Files[1].Users = append(m.Files[1].Users, NamedUser{Name: "stale", User: &User{Token: "stale"}})
+
+	// staging-cluster is only referenced from the other file
+	report := m.PruneOrphans()
+	if fmt.Sprint(report.Clusters, report.Users) != "[] [stale]" {
+		t.Errorf("Expected only the stale user to be pruned, got %+v", report)
+	}
+	if got := m.ModifiedPaths(); len(got) != 1 || got[0] != extra {
+		t.Errorf("Expected only %s to be modified, got %v", extra, got)
+	}
+	if m.Merged.GetUser("stale") != nil || m.Merged.GetCluster("staging-cluster") == nil {
+		t.Error("Expected the merged view to reflect the pruning")
+	}
+}
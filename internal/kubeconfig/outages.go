@@ -0,0 +1,120 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// File permissions for the outage log
+const (
+	outageFileMode = 0600
+	outageDirMode  = 0700
+)
+
+// OutageLog remembers since when each cluster has been unreachable, across
+// runs, so an auth check can give clusters a grace period before their
+// contexts are removed. Clusters are keyed by a hash of their server URL,
+// and forgotten as soon as they respond. An OutageLog is safe for
+// concurrent use.
+type OutageLog struct {
+	mu    sync.Mutex
+	path  string
+	since map[string]time.Time
+	dirty bool
+}
+
+// DefaultOutageLogPath returns the path of the outage log:
+// kubectx-manager/outages.json next to the probe cache.
+// It returns "" if the home directory cannot be determined.
+func DefaultOutageLogPath() string {
+	cachePath := DefaultProbeCachePath()
+	if cachePath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(cachePath), "outages.json")
+}
+
+// LoadOutageLog reads the outage log at path. A missing file yields an
+// empty log; an unreadable one is an error, as forgetting outages would
+// restart every grace period.
+func LoadOutageLog(path string) (*OutageLog, error) {
+	log := &OutageLog{path: path, since: make(map[string]time.Time)}
+	data, err := os.ReadFile(path) //nolint:gosec // The outage log path is derived from the state directory
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outage log: %w", err)
+	}
+	if err := json.Unmarshal(data, &log.since); err != nil {
+		return nil, fmt.Errorf("failed to parse outage log %s: %w", path, err)
+	}
+	if log.since == nil {
+		log.since = make(map[string]time.Time)
+	}
+	return log, nil
+}
+
+// Save writes the log back to its file if it changed since it was loaded.
+func (l *OutageLog) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(l.since, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal outage log: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), outageDirMode); err != nil {
+		return fmt.Errorf("failed to create outage log directory: %w", err)
+	}
+	if err := os.WriteFile(l.path, append(data, '\n'), outageFileMode); err != nil {
+		return fmt.Errorf("failed to write outage log: %w", err)
+	}
+	l.dirty = false
+	return nil
+}
+
+// observe records whether the cluster at server responded at now and
+// returns since when it has been continuously unreachable. A nil log
+// remembers nothing: an unreachable cluster is unreachable since now.
+func (l *OutageLog) observe(server string, reachable bool, now time.Time) time.Time {
+	if l == nil {
+		return now
+	}
+	sum := sha256.Sum256([]byte(server))
+	key := hex.EncodeToString(sum[:])
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	since, known := l.since[key]
+	switch {
+	case reachable && known:
+		delete(l.since, key)
+		l.dirty = true
+	case !reachable && !known:
+		since = now.UTC()
+		l.since[key] = since
+		l.dirty = true
+	}
+	return since
+}
@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutageLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "outages.json")
+	outages, err := LoadOutageLog(path)
+	if err != nil {
+		t.Fatalf("LoadOutageLog failed: %v", err)
+	}
+
+	start := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	if since := outages.observe("https://dead.example.com", false, start); !since.Equal(start) {
+		t.Errorf("Expected the first outage to start at %v, got %v", start, since)
+	}
+	if since := outages.observe("https://dead.example.com", false, start.Add(time.Hour)); !since.Equal(start) {
+		t.Errorf("Expected a continuing outage to keep its start, got %v", since)
+	}
+	if err := outages.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read outage log: %v", err)
+	}
+	if strings.Contains(string(data), "dead.example.com") {
+		t.Errorf("Expected servers to be stored hashed, got %s", data)
+	}
+
+	outages, err = LoadOutageLog(path)
+	if err != nil {
+		t.Fatalf("LoadOutageLog failed: %v", err)
+	}
+	if since := outages.observe("https://dead.example.com", false, start.Add(2*time.Hour)); !since.Equal(start) {
+		t.Errorf("Expected the outage to survive a reload, got %v", since)
+	}
+	outages.observe("https://dead.example.com", true, start.Add(3*time.Hour))
+	later := start.Add(4 * time.Hour)
+	if since := outages.observe("https://dead.example.com", false, later); !since.Equal(later) {
+		t.Errorf("Expected a response to end the outage, got %v", since)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("Failed to corrupt outage log: %v", err)
+	}
+	if _, err := LoadOutageLog(path); err == nil {
+		t.Error("Expected a corrupt outage log to be an error")
+	}
+}
+
+func TestCheckAuthUnreachableGrace(t *testing.T) {
+	config := &Config{
+		Contexts: []NamedContext{{Name: "dead", Context: &Context{Cluster: "cluster", User: "user"}}},
+		Clusters: []NamedCluster{{Name: "cluster", Cluster: &Cluster{Server: "https://127.0.0.1:1"}}},
+		Users:    []NamedUser{{Name: "user", User: &User{Token: "token"}}},
+	}
+	config.buildInternalMaps()
+	outages, err := LoadOutageLog(filepath.Join(t.TempDir(), "outages.json"))
+	if err != nil {
+		t.Fatalf("LoadOutageLog failed: %v", err)
+	}
+
+	opts := AuthCheckOptions{Outages: outages, UnreachableGrace: 7 * 24 * time.Hour}
+	status := CheckAuth(config, "dead", opts)
+	if !status.Valid || status.State != AuthUnreachable || !strings.Contains(status.Reason, "grace period") {
+		t.Errorf("Expected a new outage to be kept by the grace period, got %+v", status)
+	}
+
+	// Pretend the cluster went down eight days ago
+	for key := range outages.since {
+		outages.since[key] = time.Now().Add(-8 * 24 * time.Hour)
+	}
+	status = CheckAuth(config, "dead", opts)
+	if status.Valid || !strings.Contains(status.Reason, "unreachable since") {
+		t.Errorf("Expected an outage past the grace period to be removed, got %+v", status)
+	}
+
+	status = CheckAuth(config, "dead", AuthCheckOptions{Outages: outages, UnreachableGrace: 7 * 24 * time.Hour, KeepUnreachable: true})
+	if !status.Valid || !strings.Contains(status.Reason, "kept by policy") {
+		t.Errorf("Expected --keep-unreachable to keep the context, got %+v", status)
+	}
+}
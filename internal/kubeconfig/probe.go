@@ -0,0 +1,270 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Probe modes of an auth check
+const (
+	// ProbeVersion requests /version; any response below 500 counts as reachable
+	ProbeVersion = "version"
+	// ProbeAPI requests /api with the user's credentials; 401 and 403 count as rejected
+	ProbeAPI = "api"
+	// ProbeTCP only resolves the server's host and opens a TCP connection to its port
+	ProbeTCP = "tcp"
+)
+
+// HTTP status code threshold for success
+const httpSuccessThreshold = 500
+
+// probeResult is the outcome of probing a cluster's API server
+type probeResult int
+
+const (
+	// probeUnreachable means no response, or a server error
+	probeUnreachable probeResult = iota
+	// probeUnauthorized means the API probe's credentials were rejected
+	probeUnauthorized
+	// probeOK means the server responded and did not reject the credentials
+	probeOK
+)
+
+// isClusterReachable tests if the cluster API server is accessible
+// This solves the "dead cluster, live token" problem.
+func isClusterReachable(cluster *Cluster, user *User, opts AuthCheckOptions) bool {
+	return probeClusterWithRetries(context.Background(), cluster, user, opts) != probeUnreachable
+}
+
+// probeClusterWithRetries probes the cluster, probing an unreachable cluster
+// again up to opts.Retries times with exponential backoff, so a flaky link is
+// not mistaken for a dead cluster. Probing stops when ctx is done.
+func probeClusterWithRetries(ctx context.Context, cluster *Cluster, user *User, opts AuthCheckOptions) probeResult {
+	if cluster.Server == "" {
+		return probeUnreachable
+	}
+
+	opts = opts.withDefaults()
+	backoff := opts.Backoff
+	for attempt := 0; ; attempt++ {
+		result := probeCluster(ctx, cluster, user, opts)
+		if result != probeUnreachable || attempt >= opts.Retries {
+			return result
+		}
+		select {
+		case <-ctx.Done():
+			return result
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// probeCluster probes the cluster once. The TCP probe, and the pre-check
+// opts.PreCheck adds to the others, only dial the server; a cluster that
+// fails the pre-check is not requested. The version probe hits
+// /version, which doesn't require auth. The API probe hits /api with the
+// user's token, client certificate or basic auth; users with none of these
+// (exec plugins, auth providers without an id-token) fall back to the
+// version probe, since the server would reject them regardless.
+func probeCluster(ctx context.Context, cluster *Cluster, user *User, opts AuthCheckOptions) probeResult {
+	if opts.Probe == ProbeTCP || opts.PreCheck {
+		result, dialed := dialCluster(ctx, cluster, opts.DialTimeout)
+		if dialed && (result == probeUnreachable || opts.Probe == ProbeTCP) {
+			return result
+		}
+	}
+
+	authenticated := opts.Probe == ProbeAPI && hasRequestCredentials(user)
+
+	transport, err := newProbeTransport(cluster)
+	if err != nil {
+		// The cluster's CA or proxy is unusable, so no request can reach it
+		return probeUnreachable
+	}
+	tlsConfig := transport.TLSClientConfig
+	if authenticated {
+		pair, err := clientKeyPair(user)
+		if err != nil {
+			// The certificate or key cannot be loaded, so no request could authenticate
+			return probeUnauthorized
+		}
+		if pair != nil {
+			tlsConfig.Certificates = []tls.Certificate{*pair}
+		}
+	}
+
+	client := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: transport,
+	}
+
+	probeURL := cluster.Server + "/version"
+	if authenticated {
+		probeURL = cluster.Server + "/api"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", probeURL, http.NoBody)
+	if err != nil {
+		return probeUnreachable
+	}
+
+	// Add authentication headers if we have a token
+	token, err := bearerToken(user)
+	if err != nil && authenticated {
+		return probeUnauthorized
+	}
+	if token == "" && authenticated {
+		token = idToken(user)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if authenticated {
+		if user.Username != "" && user.Password != "" {
+			req.SetBasicAuth(user.Username, user.Password)
+		}
+		setImpersonationHeaders(req, user)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Network error, DNS resolution failure, connection refused, etc.
+		// This catches the "cluster is gone" scenario
+		return probeUnreachable
+	}
+	defer resp.Body.Close() //nolint:errcheck // Only the status code is read
+
+	switch {
+	case resp.StatusCode >= httpSuccessThreshold:
+		return probeUnreachable
+	case authenticated && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden):
+		return probeUnauthorized
+	default:
+		// Without credentials, any response (even 401/403) shows the server is responding
+		return probeOK
+	}
+}
+
+// dialCluster resolves the host of the cluster's server and opens a TCP
+// connection to its port, bounded by timeout, which fails fast for the dead
+// hostnames that would otherwise hold an HTTP probe for its whole timeout.
+// dialed is false when the cluster is reached through a proxy, which a
+// direct connection says nothing about.
+func dialCluster(ctx context.Context, cluster *Cluster, timeout time.Duration) (result probeResult, dialed bool) {
+	server, err := url.Parse(cluster.Server)
+	if err != nil || server.Hostname() == "" {
+		return probeUnreachable, true
+	}
+	if cluster.ProxyURL != "" {
+		return probeOK, false
+	}
+	if proxy, err := http.ProxyFromEnvironment(&http.Request{URL: server}); err != nil || proxy != nil {
+		return probeOK, false
+	}
+
+	port := server.Port()
+	if port == "" {
+		port = "443"
+		if server.Scheme == "http" {
+			port = "80"
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server.Hostname(), port))
+	if err != nil {
+		return probeUnreachable, true
+	}
+	_ = conn.Close()
+	return probeOK, true
+}
+
+// newProbeTransport builds an HTTP transport that connects to the cluster the
+// way kubectl does: TLS is verified against the cluster's own CA when it has
+// one, for the name in tls-server-name when it is set, and requests go
+// through its proxy-url, an http, https or socks5 URL, or else the proxy
+// named by HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+func newProbeTransport(cluster *Cluster) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		//nolint:gosec // TLS verification controlled by kubeconfig setting
+		InsecureSkipVerify: cluster.InsecureSkipTLSVerify,
+		ServerName:         cluster.TLSServerName,
+	}
+	caPEM, err := credentialData(cluster.CertificateAuthorityData, resolvePath(cluster.dir, cluster.CertificateAuthority))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+	}
+	if caPEM != nil && !cluster.InsecureSkipTLSVerify {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("certificate authority contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cluster.ProxyURL != "" {
+		proxyURL, err := url.Parse(cluster.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy-url: %w", err)
+		}
+		// The schemes kubectl accepts, and the transport can use
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported proxy-url scheme %q (expected http, https or socks5)", proxyURL.Scheme)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Transport{Proxy: proxy, TLSClientConfig: tlsConfig}, nil
+}
+
+// hasRequestCredentials reports whether the user has credentials the API
+// probe can send itself
+func hasRequestCredentials(user *User) bool {
+	return user.Token != "" || user.TokenFile != "" ||
+		user.ClientCertificateData != "" || user.ClientCertificate != "" ||
+		(user.Username != "" && user.Password != "") ||
+		idToken(user) != ""
+}
+
+// setImpersonationHeaders sends the user's as, as-groups and as-user-extra
+// settings the way kubectl does, so the probe is authorized as kubectl would be
+func setImpersonationHeaders(req *http.Request, user *User) {
+	if user.As != "" {
+		req.Header.Set("Impersonate-User", user.As)
+	}
+	for _, group := range user.AsGroups {
+		req.Header.Add("Impersonate-Group", group)
+	}
+	for key, values := range user.AsUserExtra {
+		for _, value := range values {
+			req.Header.Add("Impersonate-Extra-"+url.PathEscape(key), value)
+		}
+	}
+}
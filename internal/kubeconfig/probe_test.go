@@ -0,0 +1,302 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newAPIServer serves /version to anyone and /api only to the given token or basic auth user.
+func newAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/version":
+			w.WriteHeader(http.StatusOK)
+		case "/api":
+			username, password, basic := r.BasicAuth()
+			if r.Header.Get("Authorization") == "Bearer live-token" || (basic && username == "admin" && password == "secret") {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckAuthAPIProbe(t *testing.T) {
+	server := newAPIServer(t)
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "live", Context: &Context{Cluster: "cluster", User: "live-user"}},
+			{Name: "revoked", Context: &Context{Cluster: "cluster", User: "revoked-user"}},
+			{Name: "basic", Context: &Context{Cluster: "cluster", User: "basic-user"}},
+			{Name: "dead", Context: &Context{Cluster: "dead-cluster", User: "live-user"}},
+		},
+		Clusters: []NamedCluster{
+			{Name: "cluster", Cluster: &Cluster{Server: server.URL}},
+			{Name: "dead-cluster", Cluster: &Cluster{Server: "https://does-not-exist.invalid:443"}},
+		},
+		Users: []NamedUser{
+			{Name: "live-user", User: &User{Token: "live-token"}},
+			{Name: "revoked-user", User: &User{Token: "revoked-token"}},
+			{Name: "basic-user", User: &User{Username: "admin", Password: "secret"}},
+		},
+	}
+	config.buildInternalMaps()
+
+	tests := []struct {
+		name       string
+		context    string
+		opts       AuthCheckOptions
+		wantValid  bool
+		wantState  AuthState
+		wantReason string
+	}{
+		{name: "version probe keeps revoked token", context: "revoked", opts: AuthCheckOptions{}, wantValid: true, wantState: AuthOK},
+		{name: "API probe accepts live token", context: "live", opts: AuthCheckOptions{Probe: ProbeAPI}, wantValid: true, wantState: AuthOK},
+		{name: "API probe accepts basic auth", context: "basic", opts: AuthCheckOptions{Probe: ProbeAPI}, wantValid: true, wantState: AuthOK},
+		{
+			name: "API probe rejects revoked token", context: "revoked", opts: AuthCheckOptions{Probe: ProbeAPI},
+			wantState: AuthUnauthorized, wantReason: "rejected the credentials",
+		},
+		{
+			name: "keep unauthorized", context: "revoked", opts: AuthCheckOptions{Probe: ProbeAPI, KeepUnauthorized: true},
+			wantValid: true, wantState: AuthUnauthorized, wantReason: "kept by policy",
+		},
+		{
+			name: "keep unreachable", context: "dead", opts: AuthCheckOptions{Probe: ProbeAPI, KeepUnreachable: true},
+			wantValid: true, wantState: AuthUnreachable, wantReason: "unreachable",
+		},
+		{
+			name: "unreachable is removed", context: "dead", opts: AuthCheckOptions{Probe: ProbeAPI, KeepUnauthorized: true},
+			wantState: AuthUnreachable, wantReason: "unreachable",
+		},
+		{
+			name:    "remove on unauthorized keeps unreachable",
+			context: "dead", opts: AuthCheckOptions{Probe: ProbeAPI, RemoveOn: []AuthState{AuthUnauthorized, AuthExpired}},
+			wantValid: true, wantState: AuthUnreachable, wantReason: "kept by policy",
+		},
+		{
+			name:    "remove on unauthorized removes revoked token",
+			context: "revoked", opts: AuthCheckOptions{Probe: ProbeAPI, RemoveOn: []AuthState{AuthUnauthorized, AuthExpired}},
+			wantState: AuthUnauthorized, wantReason: "rejected the credentials",
+		},
+		{
+			name:    "remove on unreachable keeps revoked token",
+			context: "revoked", opts: AuthCheckOptions{Probe: ProbeAPI, RemoveOn: []AuthState{AuthUnreachable}},
+			wantValid: true, wantState: AuthUnauthorized, wantReason: "kept by policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := CheckAuth(config, tt.context, tt.opts)
+			if status.Valid != tt.wantValid {
+				t.Errorf("Expected valid %v, got %v (%s)", tt.wantValid, status.Valid, status.Reason)
+			}
+			if status.State != tt.wantState {
+				t.Errorf("Expected state %q, got %q", tt.wantState, status.State)
+			}
+			if !strings.Contains(status.Reason, tt.wantReason) {
+				t.Errorf("Expected reason containing %q, got %q", tt.wantReason, status.Reason)
+			}
+		})
+	}
+}
+
+func TestProbeClusterFallsBackWithoutRequestCredentials(t *testing.T) {
+	server := newAPIServer(t)
+	user := &User{Exec: &ExecConfig{Command: "kubelogin"}}
+
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL}, user, AuthCheckOptions{Probe: ProbeAPI}.withDefaults()); result != probeOK {
+		t.Errorf("Expected an exec user to fall back to the version probe, got %v", result)
+	}
+}
+
+func TestProbeClusterUsesCertificateAuthority(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caData := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	opts := AuthCheckOptions{}.withDefaults()
+
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL}, &User{}, opts); result != probeUnreachable {
+		t.Errorf("Expected a server signed by an unknown CA to be unreachable, got %v", result)
+	}
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL, CertificateAuthorityData: caData}, &User{}, opts); result != probeOK {
+		t.Errorf("Expected the cluster CA to be trusted, got %v", result)
+	}
+	// The test certificate is valid for example.com but not for other names
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL, CertificateAuthorityData: caData, TLSServerName: "example.com"}, &User{}, opts); result != probeOK {
+		t.Errorf("Expected tls-server-name matching the certificate to be trusted, got %v", result)
+	}
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL, CertificateAuthorityData: caData, TLSServerName: "other.invalid"}, &User{}, opts); result != probeUnreachable {
+		t.Errorf("Expected the certificate to be verified against tls-server-name, got %v", result)
+	}
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL, CertificateAuthorityData: "bm90IGEgY2VydA=="}, &User{}, opts); result != probeUnreachable {
+		t.Errorf("Expected an unusable CA to make the cluster unreachable, got %v", result)
+	}
+}
+
+func TestProbeClusterResolvesRelativeCertificateAuthority(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), caPEM, 0600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	path := filepath.Join(dir, "config")
+	content := "apiVersion: v1\nkind: Config\nclusters:\n- name: cluster\n  cluster:\n    server: " + server.URL +
+		"\n    certificate-authority: ca.crt\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+
+	// Run from another directory, where ca.crt does not exist
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	cluster := config.Clusters[0].Cluster
+	if result := probeCluster(context.Background(), cluster, &User{}, AuthCheckOptions{}.withDefaults()); result != probeOK {
+		t.Errorf("Expected the CA relative to the kubeconfig to be trusted, got %v", result)
+	}
+}
+
+func TestProbeClusterUsesProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	cluster := &Cluster{Server: "http://cluster.invalid", ProxyURL: proxy.URL}
+	if result := probeCluster(context.Background(), cluster, &User{}, AuthCheckOptions{}.withDefaults()); result != probeOK {
+		t.Fatalf("Expected the cluster to be reached through its proxy, got %v", result)
+	}
+	if proxied != "http://cluster.invalid/version" {
+		t.Errorf("Expected the proxy to receive the probe, got %q", proxied)
+	}
+
+	for _, proxyURL := range []string{"socks5://127.0.0.1:1080", "https://proxy.example.com:3128"} {
+		if _, err := newProbeTransport(&Cluster{Server: "https://cluster.invalid", ProxyURL: proxyURL}); err != nil {
+			t.Errorf("Expected proxy-url %s to be accepted, got %v", proxyURL, err)
+		}
+	}
+	if _, err := newProbeTransport(&Cluster{Server: "https://cluster.invalid", ProxyURL: "ftp://proxy.example.com"}); err == nil {
+		t.Error("Expected an ftp proxy-url to be rejected")
+	}
+}
+
+func TestProbeClusterTCP(t *testing.T) {
+	// A server that accepts connections but never answers HTTP
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer silent.Close()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	closedURL := "https://" + closed.Addr().String()
+	closed.Close()
+	silentURL := "https://" + silent.Addr().String()
+
+	tcp := AuthCheckOptions{Probe: ProbeTCP}.withDefaults()
+	if result := probeCluster(context.Background(), &Cluster{Server: silentURL}, &User{}, tcp); result != probeOK {
+		t.Errorf("Expected the TCP probe to accept a listening port, got %v", result)
+	}
+	for _, server := range []string{closedURL, "https://does-not-exist.invalid:6443", "not a url"} {
+		if result := probeCluster(context.Background(), &Cluster{Server: server}, &User{}, tcp); result != probeUnreachable {
+			t.Errorf("Expected the TCP probe to find %s unreachable, got %v", server, result)
+		}
+	}
+
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { requested.Store(true) }))
+	defer server.Close()
+	preCheck := AuthCheckOptions{PreCheck: true, Timeout: 100 * time.Millisecond}.withDefaults()
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL}, &User{}, preCheck); result != probeOK || !requested.Load() {
+		t.Errorf("Expected a cluster passing the pre-check to be requested, got %v (requested %v)", result, requested.Load())
+	}
+	if result := probeCluster(context.Background(), &Cluster{Server: silentURL}, &User{}, preCheck); result != probeUnreachable {
+		t.Errorf("Expected a server that does not answer HTTP to fail after the pre-check, got %v", result)
+	}
+
+	// Through a proxy the cluster host need not resolve
+	proxied := &Cluster{Server: "http://cluster.invalid", ProxyURL: server.URL}
+	if result := probeCluster(context.Background(), proxied, &User{}, preCheck); result != probeOK {
+		t.Errorf("Expected the pre-check to be skipped for a proxied cluster, got %v", result)
+	}
+}
+
+func TestProbeClusterSendsTokenFileAndImpersonation(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+	user := &User{
+		TokenFile:   tokenFile,
+		As:          "jane",
+		AsGroups:    []string{"devs", "ops"},
+		AsUserExtra: map[string][]string{"scopes": {"view"}},
+	}
+
+	if result := probeCluster(context.Background(), &Cluster{Server: server.URL}, user, AuthCheckOptions{Probe: ProbeAPI}.withDefaults()); result != probeOK {
+		t.Fatalf("Expected the probe to succeed, got %v", result)
+	}
+	if got := header.Get("Authorization"); got != "Bearer file-token" {
+		t.Errorf("Expected the token from tokenFile, got %q", got)
+	}
+	if header.Get("Impersonate-User") != "jane" || len(header.Values("Impersonate-Group")) != 2 || header.Get("Impersonate-Extra-Scopes") != "view" {
+		t.Errorf("Expected impersonation headers, got %v", header)
+	}
+}
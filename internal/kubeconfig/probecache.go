@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultProbeCacheTTL is how long a probe result is reused by default
+	DefaultProbeCacheTTL = 15 * time.Minute
+
+	// File permissions for the probe cache
+	probeCacheFileMode = 0600
+	probeCacheDirMode  = 0700
+)
+
+// probeResultNames are the names of probe results in the cache file
+var probeResultNames = map[probeResult]string{
+	probeUnreachable:  "unreachable",
+	probeUnauthorized: "unauthorized",
+	probeOK:           "ok",
+}
+
+// ProbeCache remembers the outcome of cluster probes for a while, so auth
+// checks run in quick succession, such as a dry run and the real run, do
+// not probe every cluster again. Results are keyed by a hash of the cluster
+// entry and, for the API probe, of the user entry, so no secret is stored.
+// A ProbeCache is safe for concurrent use.
+type ProbeCache struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]probeCacheEntry
+	dirty   bool
+}
+
+// probeCacheEntry is a cached probe result
+type probeCacheEntry struct {
+	Result    string    `json:"result"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// DefaultProbeCachePath returns the path of the probe cache:
+// kubectx-manager/auth-cache.json under $XDG_STATE_HOME, or ~/.local/state
+// when it is unset. It returns "" if the home directory cannot be determined.
+func DefaultProbeCachePath() string {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "kubectx-manager", "auth-cache.json")
+}
+
+// LoadProbeCache reads the probe cache at path, keeping the results younger
+// than ttl. A missing or unreadable cache yields an empty one: it only saves
+// probes.
+func LoadProbeCache(path string, ttl time.Duration) *ProbeCache {
+	cache := &ProbeCache{path: path, entries: make(map[string]probeCacheEntry)}
+	data, err := os.ReadFile(path) //nolint:gosec // The cache path is derived from the state directory
+	if err != nil {
+		return cache
+	}
+	var entries map[string]probeCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		cache.dirty = true
+		return cache
+	}
+	now := time.Now()
+	for key, entry := range entries {
+		if now.Sub(entry.CheckedAt) < ttl {
+			cache.entries[key] = entry
+		} else {
+			cache.dirty = true
+		}
+	}
+	return cache
+}
+
+// Save writes the cache back to its file if it changed since it was loaded.
+func (c *ProbeCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal probe cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), probeCacheDirMode); err != nil {
+		return fmt.Errorf("failed to create probe cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, probeCacheFileMode); err != nil {
+		return fmt.Errorf("failed to write probe cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// probe returns the cached result of probing the cluster as user, probing
+// it and caching the result when there is none. A nil cache always probes.
+// The age of a cached result is returned with it, zero for a fresh probe.
+func (c *ProbeCache) probe(ctx context.Context, cluster *Cluster, user *User, opts AuthCheckOptions) (probeResult, time.Duration) {
+	if c == nil {
+		return probeClusterWithRetries(ctx, cluster, user, opts), 0
+	}
+	key, err := probeCacheKey(cluster, user, opts)
+	if err != nil {
+		return probeClusterWithRetries(ctx, cluster, user, opts), 0
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		for result, name := range probeResultNames {
+			if name == entry.Result {
+				return result, time.Since(entry.CheckedAt)
+			}
+		}
+	}
+
+	result := probeClusterWithRetries(ctx, cluster, user, opts)
+	if ctx.Err() != nil {
+		// A probe cut short says nothing about the cluster
+		return result, 0
+	}
+	c.mu.Lock()
+	c.entries[key] = probeCacheEntry{Result: probeResultNames[result], CheckedAt: time.Now()}
+	c.dirty = true
+	c.mu.Unlock()
+	return result, 0
+}
+
+// probeCacheKey identifies a probe by its mode and a hash of the cluster
+// entry and, for the API probe, whose result depends on the credentials, of
+// the user entry.
+func probeCacheKey(cluster *Cluster, user *User, opts AuthCheckOptions) (string, error) {
+	probe := opts.Probe
+	if probe == "" {
+		probe = ProbeVersion
+	}
+	entries := []interface{}{cluster}
+	if probe == ProbeAPI {
+		entries = append(entries, user)
+	}
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash cluster: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return probe + ":" + hex.EncodeToString(sum[:]), nil
+}
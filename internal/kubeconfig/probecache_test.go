@@ -0,0 +1,96 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProbeCache(t *testing.T) {
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		if r.Header.Get("Authorization") != "Bearer live-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Contexts: []NamedContext{
+			{Name: "live", Context: &Context{Cluster: "cluster", User: "live-user"}},
+			{Name: "revoked", Context: &Context{Cluster: "cluster", User: "revoked-user"}},
+		},
+		Clusters: []NamedCluster{{Name: "cluster", Cluster: &Cluster{Server: server.URL}}},
+		Users: []NamedUser{
+			{Name: "live-user", User: &User{Token: "live-token"}},
+			{Name: "revoked-user", User: &User{Token: "revoked-token"}},
+		},
+	}
+	config.buildInternalMaps()
+	cachePath := filepath.Join(t.TempDir(), "auth-cache.json")
+
+	// The version probe is shared by every context of the cluster
+	opts := AuthCheckOptions{Cache: LoadProbeCache(cachePath, time.Hour)}
+	for _, name := range []string{"live", "revoked"} {
+		if status := CheckAuth(config, name, opts); !status.Valid {
+			t.Errorf("%s: unexpected status %+v", name, status)
+		}
+	}
+	if got := probes.Load(); got != 1 {
+		t.Errorf("Expected one probe, got %d", got)
+	}
+
+	// The API probe depends on the user
+	opts.Probe = ProbeAPI
+	if status := CheckAuth(config, "revoked", opts); status.Valid {
+		t.Errorf("Expected the revoked token to be rejected, got %+v", status)
+	}
+	if err := opts.Cache.Save(); err != nil {
+		t.Fatalf("Failed to save cache: %v", err)
+	}
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatalf("Failed to read cache: %v", err)
+	}
+	if strings.Contains(string(data), "token") || strings.Contains(string(data), server.URL) {
+		t.Errorf("Expected the cache to hold only hashes, got:\n%s", data)
+	}
+
+	// A later run reuses the results
+	probes.Store(0)
+	opts.Cache = LoadProbeCache(cachePath, time.Hour)
+	status := CheckAuth(config, "revoked", opts)
+	if status.Valid || !strings.Contains(status.Reason, "cached") {
+		t.Errorf("Expected the cached rejection, got %+v", status)
+	}
+	if got := probes.Load(); got != 0 {
+		t.Errorf("Expected no probe, got %d", got)
+	}
+
+	// Results older than the TTL are dropped
+	opts.Cache = LoadProbeCache(cachePath, time.Nanosecond)
+	if status := CheckAuth(config, "live", opts); !status.Valid {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	if got := probes.Load(); got != 1 {
+		t.Errorf("Expected a fresh probe, got %d", got)
+	}
+}
@@ -0,0 +1,111 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces secret values redacted by Redact
+const Redacted = "REDACTED"
+
+// secretKeyWords are the parts of a key or variable name that mark its value
+// as secret, such as id-token, client-secret or AWS_SECRET_ACCESS_KEY
+var secretKeyWords = []string{"token", "secret", "password", "passwd", "key", "credential"}
+
+// isSecretKey reports whether a value stored under name is likely a secret.
+func isSecretKey(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range secretKeyWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces the secrets of config with Redacted: user tokens,
+// passwords and client keys, secret-looking auth provider settings, exec
+// environment variables and unknown fields, and passwords in server and
+// proxy URLs. Certificates and paths to credential files are kept, as they
+// are not secret themselves. Entries shared with another config are
+// modified too, so redact a copy.
+func Redact(config *Config) {
+	for _, namedCluster := range config.Clusters {
+		cluster := namedCluster.Cluster
+		if cluster == nil {
+			continue
+		}
+		cluster.Server = redactURL(cluster.Server)
+		cluster.ProxyURL = redactURL(cluster.ProxyURL)
+		redactExtra(cluster.Extra)
+	}
+
+	for _, namedUser := range config.Users {
+		user := namedUser.User
+		if user == nil {
+			continue
+		}
+		user.Token = redactValue(user.Token)
+		user.Password = redactValue(user.Password)
+		user.ClientKeyData = redactValue(user.ClientKeyData)
+		if user.AuthProvider != nil {
+			for name, value := range user.AuthProvider.Config {
+				if isSecretKey(name) {
+					user.AuthProvider.Config[name] = redactValue(value)
+				}
+			}
+		}
+		if user.Exec != nil {
+			for i, env := range user.Exec.Env {
+				if isSecretKey(env.Name) {
+					user.Exec.Env[i].Value = redactValue(env.Value)
+				}
+			}
+		}
+		redactExtra(user.Extra)
+	}
+}
+
+// redactValue returns Redacted for a set value, keeping unset ones unset.
+func redactValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// redactURL redacts the password of a URL with credentials.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return value
+	}
+	u.User = url.UserPassword(u.User.Username(), Redacted)
+	return u.String()
+}
+
+// redactExtra redacts the unknown fields whose name marks them as secret.
+func redactExtra(extra map[string]yaml.Node) {
+	for name := range extra {
+		if isSecretKey(name) {
+			extra[name] = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: Redacted}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"fmt"
+)
+
+// RenameContext renames a context and updates current-context if it pointed at it.
+func RenameContext(config *Config, oldName, newName string) error {
+	if err := checkRename("context", oldName, newName, config.hasContext); err != nil {
+		return err
+	}
+	for i := range config.Contexts {
+		if config.Contexts[i].Name == oldName {
+			config.Contexts[i].Name = newName
+		}
+	}
+	if config.CurrentContext == oldName {
+		config.CurrentContext = newName
+	}
+	config.buildInternalMaps()
+	return nil
+}
+
+// RenameCluster renames a cluster and updates every context that references it.
+func RenameCluster(config *Config, oldName, newName string) error {
+	if err := checkRename("cluster", oldName, newName, config.hasCluster); err != nil {
+		return err
+	}
+	for i := range config.Clusters {
+		if config.Clusters[i].Name == oldName {
+			config.Clusters[i].Name = newName
+		}
+	}
+	for i := range config.Contexts {
+		if ctx := config.Contexts[i].Context; ctx != nil && ctx.Cluster == oldName {
+			ctx.Cluster = newName
+		}
+	}
+	config.buildInternalMaps()
+	return nil
+}
+
+// RenameUser renames a user and updates every context that references it.
+func RenameUser(config *Config, oldName, newName string) error {
+	if err := checkRename("user", oldName, newName, config.hasUser); err != nil {
+		return err
+	}
+	for i := range config.Users {
+		if config.Users[i].Name == oldName {
+			config.Users[i].Name = newName
+		}
+	}
+	for i := range config.Contexts {
+		if ctx := config.Contexts[i].Context; ctx != nil && ctx.User == oldName {
+			ctx.User = newName
+		}
+	}
+	config.buildInternalMaps()
+	return nil
+}
+
+// checkRename validates that oldName exists and newName is free
+func checkRename(kind, oldName, newName string, exists func(string) bool) error {
+	if newName == "" {
+		return fmt.Errorf("new %s name must not be empty", kind)
+	}
+	if !exists(oldName) {
+		return fmt.Errorf("%s '%s' not found", kind, oldName)
+	}
+	if oldName != newName && exists(newName) {
+		return fmt.Errorf("%w: %s '%s' already exists", ErrConflict, kind, newName)
+	}
+	return nil
+}
+
+func (c *Config) hasContext(name string) bool {
+	for _, namedContext := range c.Contexts {
+		if namedContext.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) hasCluster(name string) bool {
+	for _, namedCluster := range c.Clusters {
+		if namedCluster.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) hasUser(name string) bool {
+	for _, namedUser := range c.Users {
+		if namedUser.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func renameTestConfig() *Config {
+	config := &Config{
+		CurrentContext: "old",
+		Contexts: []NamedContext{
+			{Name: "old", Context: &Context{Cluster: "shared", User: "admin"}},
+			{Name: "other", Context: &Context{Cluster: "shared", User: "viewer"}},
+		},
+		Clusters: []NamedCluster{{Name: "shared", Cluster: &Cluster{Server: "https://example.com"}}},
+		Users: []NamedUser{
+			{Name: "admin", User: &User{Token: "a"}},
+			{Name: "viewer", User: &User{Token: "v"}},
+		},
+	}
+	config.buildInternalMaps()
+	return config
+}
+
+func TestRenameContext(t *testing.T) {
+	config := renameTestConfig()
+	if err := RenameContext(config, "old", "new"); err != nil {
+		t.Fatalf("RenameContext failed: %v", err)
+	}
+	if config.CurrentContext != "new" || config.GetContext("new") == nil || config.GetContext("old") != nil {
+		t.Errorf("Expected context and current-context to be renamed, got %+v", config.Contexts)
+	}
+
+	if err := RenameContext(config, "new", "other"); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict when renaming onto an existing context, got %v", err)
+	}
+	if err := RenameContext(config, "missing", "x"); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+}
+
+func TestRenameClusterAndUserUpdateReferences(t *testing.T) {
+	config := renameTestConfig()
+	if err := RenameCluster(config, "shared", "prod"); err != nil {
+		t.Fatalf("RenameCluster failed: %v", err)
+	}
+	if err := RenameUser(config, "admin", "prod-admin"); err != nil {
+		t.Fatalf("RenameUser failed: %v", err)
+	}
+
+	for _, namedContext := range config.Contexts {
+		if namedContext.Context.Cluster != "prod" {
+			t.Errorf("Expected context %s to reference cluster prod, got %s", namedContext.Name, namedContext.Context.Cluster)
+		}
+	}
+	if ctx := config.GetContext("old"); ctx.User != "prod-admin" {
+		t.Errorf("Expected context old to reference user prod-admin, got %s", ctx.User)
+	}
+	if ctx := config.GetContext("other"); ctx.User != "viewer" {
+		t.Errorf("Expected context other to keep user viewer, got %s", ctx.User)
+	}
+	if config.GetCluster("prod") == nil || config.GetUser("prod-admin") == nil {
+		t.Error("Expected renamed entries to be found by lookup")
+	}
+}
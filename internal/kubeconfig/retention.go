@@ -0,0 +1,117 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// day is the unit of the "d" suffix accepted in retention ages
+const day = 24 * time.Hour
+
+// RetentionPolicy says which backups of a kubeconfig to keep.
+// The zero policy keeps every backup.
+type RetentionPolicy struct {
+	// Count keeps only the newest Count backups; zero means no limit
+	Count int
+	// MaxAge keeps only backups younger than MaxAge; zero means no limit
+	MaxAge time.Duration
+}
+
+// ParseRetentionPolicy parses a comma-separated retention policy: a number
+// is the count of backups to keep, and a duration such as 30d or 72h the
+// age beyond which backups are removed, e.g. "10", "30d" or "10,30d".
+// An empty value keeps every backup.
+func ParseRetentionPolicy(value string) (RetentionPolicy, error) {
+	var policy RetentionPolicy
+	if strings.TrimSpace(value) == "" {
+		return policy, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if count, err := strconv.Atoi(part); err == nil {
+			if count <= 0 || policy.Count != 0 {
+				return RetentionPolicy{}, fmt.Errorf("invalid backup retention '%s': expected one positive count", value)
+			}
+			policy.Count = count
+			continue
+		}
+
+		age, err := ParseAge(part)
+		if err != nil || age <= 0 || policy.MaxAge != 0 {
+			return RetentionPolicy{}, fmt.Errorf("invalid backup retention '%s': expected a count such as 10, an age such as 30d, or both", value)
+		}
+		policy.MaxAge = age
+	}
+	return policy, nil
+}
+
+// ParseAge parses a duration, also accepting whole days such as 30d
+func ParseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * day, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// IsZero reports whether the policy keeps every backup.
+func (p RetentionPolicy) IsZero() bool {
+	return p.Count == 0 && p.MaxAge == 0
+}
+
+// Expired returns the backups the policy removes: those beyond the newest
+// Count and those older than MaxAge at now. backups must be sorted newest
+// first, as FindBackups returns them.
+func (p RetentionPolicy) Expired(backups []Backup, now time.Time) []Backup {
+	var expired []Backup
+	for i, backup := range backups {
+		if (p.Count > 0 && i >= p.Count) || (p.MaxAge > 0 && now.Sub(backup.Time) > p.MaxAge) {
+			expired = append(expired, backup)
+		}
+	}
+	return expired
+}
+
+// PruneBackups removes the backups of the kubeconfig at path that policy
+// does not keep, with their manifests, in both locations FindBackups searches, and returns them.
+// Backups that cannot be removed are reported in the error and left out of
+// the result.
+func PruneBackups(path, backupDir string, policy RetentionPolicy) ([]Backup, error) {
+	if policy.IsZero() {
+		return nil, nil
+	}
+	backups, err := FindBackups(path, backupDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []Backup
+	var errs []error
+	for _, backup := range policy.Expired(backups, time.Now()) {
+		if err := RemoveBackup(backup.Path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, backup)
+	}
+	return removed, errors.Join(errs...)
+}
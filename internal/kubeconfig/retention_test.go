@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    RetentionPolicy
+		wantErr bool
+	}{
+		{value: "", want: RetentionPolicy{}},
+		{value: "10", want: RetentionPolicy{Count: 10}},
+		{value: "30d", want: RetentionPolicy{MaxAge: 30 * day}},
+		{value: "72h", want: RetentionPolicy{MaxAge: 72 * time.Hour}},
+		{value: "10, 30d", want: RetentionPolicy{Count: 10, MaxAge: 30 * day}},
+		{value: "0", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "10,5", wantErr: true},
+		{value: "forever", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRetentionPolicy(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicyExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	var backups []Backup
+	for _, age := range []time.Duration{time.Hour, day, 10 * day, 40 * day} {
+		backups = append(backups, Backup{Name: age.String(), Time: now.Add(-age)})
+	}
+
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   int
+	}{
+		{name: "zero keeps all", policy: RetentionPolicy{}, want: 0},
+		{name: "count", policy: RetentionPolicy{Count: 3}, want: 1},
+		{name: "age", policy: RetentionPolicy{MaxAge: 7 * day}, want: 2},
+		{name: "count and age", policy: RetentionPolicy{Count: 1, MaxAge: 30 * day}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := tt.policy.Expired(backups, now)
+			if len(expired) != tt.want {
+				t.Fatalf("Expected %d expired backups, got %d", tt.want, len(expired))
+			}
+			// The oldest backups expire first
+			for i, backup := range expired {
+				if backup != backups[len(backups)-len(expired)+i] {
+					t.Errorf("Expected the oldest backups to expire, got %s", backup.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	backupDir := filepath.Join(tmpDir, "backups")
+	folder, err := CreateBackupFolder(kubeconfigPath, backupDir)
+	if err != nil {
+		t.Fatalf("Failed to create backup folder: %v", err)
+	}
+
+	// Two central backups and one written next to the kubeconfig by an older version
+	now := time.Now()
+	paths := []string{
+		filepath.Join(folder, "config.backup."+now.Format(BackupTimeFormat)),
+		filepath.Join(folder, "config.backup."+now.Add(-time.Hour).Format(BackupTimeFormat)),
+		kubeconfigPath + ".backup." + now.Add(-2*time.Hour).Format(BackupTimeFormat),
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte("backup"), 0600); err != nil {
+			t.Fatalf("Failed to write backup: %v", err)
+		}
+	}
+
+	removed, err := PruneBackups(kubeconfigPath, backupDir, RetentionPolicy{Count: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Expected 2 backups to be removed, got %d", len(removed))
+	}
+	if _, err := os.Stat(paths[0]); err != nil {
+		t.Errorf("Expected the newest backup to be kept: %v", err)
+	}
+	for _, path := range paths[1:] {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// A write is a drastic shrink when the new content keeps less than a tenth
+// of the entries or of the size of the file it replaces, once that file is
+// big enough for the comparison to mean something.
+const (
+	shrinkFactor     = 10
+	shrinkMinEntries = 10
+	shrinkMinSize    = 4096
+)
+
+// ConfirmShrink is asked before Save or RestoreBackup replace a kubeconfig
+// with drastically smaller content, such as what a bug or a bad merge would
+// produce; reason says how much smaller it is. The file is written only if
+// it returns true. When it is nil, the write fails with ErrDrasticShrink.
+var ConfirmShrink func(path, reason string) (bool, error)
+
+// checkShrink returns ErrDrasticShrink if data is drastically smaller than
+// the kubeconfig at path and ConfirmShrink does not allow it.
+func checkShrink(path string, data []byte) error {
+	previous, err := os.ReadFile(path) //nolint:gosec // User-specified kubeconfig path is intentional
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig before saving: %w", err)
+	}
+
+	reason := shrinkReason(previous, data)
+	if reason == "" {
+		return nil
+	}
+	if ConfirmShrink != nil {
+		ok, err := ConfirmShrink(path, reason)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrDrasticShrink, reason)
+}
+
+// shrinkReason describes how much smaller data is than previous, or returns
+// "" if it is not drastically smaller: it is empty or has no entries while
+// previous has some, or keeps less than a tenth of its entries or size.
+func shrinkReason(previous, data []byte) string {
+	if len(bytes.TrimSpace(previous)) == 0 {
+		return ""
+	}
+	before, after := countEntries(previous), countEntries(data)
+	switch {
+	case len(bytes.TrimSpace(data)) == 0:
+		return fmt.Sprintf("the new content is empty, the current file has %d bytes", len(previous))
+	case before > 0 && after == 0:
+		return fmt.Sprintf("the new content has no contexts, clusters or users, the current file has %d", before)
+	case before >= shrinkMinEntries && after*shrinkFactor < before:
+		return fmt.Sprintf("the new content has %d contexts, clusters and users, down from %d", after, before)
+	case len(previous) >= shrinkMinSize && len(data)*shrinkFactor < len(previous):
+		return fmt.Sprintf("the new content has %d bytes, down from %d", len(data), len(previous))
+	default:
+		return ""
+	}
+}
+
+// countEntries returns the number of contexts, clusters and users in
+// kubeconfig data, or 0 if it does not parse.
+func countEntries(data []byte) int {
+	config, err := unmarshalYAML(data)
+	if err != nil {
+		return 0
+	}
+	return len(config.Contexts) + len(config.Clusters) + len(config.Users)
+}
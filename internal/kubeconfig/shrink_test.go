@@ -0,0 +1,158 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeShrinkTestConfig writes a kubeconfig with count contexts, each with
+// its own cluster and user, and loads it.
+func writeShrinkTestConfig(t *testing.T, count int) (*Config, string) {
+	t.Helper()
+	var contexts, clusters, users strings.Builder
+	for i := range count {
+		fmt.Fprintf(&contexts, "- name: ctx-%d\n  context:\n    cluster: cluster-%d\n    user: user-%d\n", i, i, i)
+		fmt.Fprintf(&clusters, "- name: cluster-%d\n  cluster:\n    server: https://cluster-%d.example.com\n", i, i)
+		fmt.Fprintf(&users, "- name: user-%d\n  user:\n    token: token-%d\n", i, i)
+	}
+	content := "apiVersion: v1\nkind: Config\ncontexts:\n" + contexts.String() +
+		"clusters:\n" + clusters.String() + "users:\n" + users.String()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	return config, path
+}
+
+// useConfirmShrink makes Save ask confirm for the rest of the test.
+func useConfirmShrink(t *testing.T, confirm func(path, reason string) (bool, error)) {
+	t.Helper()
+	saved := ConfirmShrink
+	ConfirmShrink = confirm
+	t.Cleanup(func() { ConfirmShrink = saved })
+}
+
+func TestSaveRefusesEmptyOutput(t *testing.T) {
+	config, path := writeShrinkTestConfig(t, 1)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read kubeconfig: %v", err)
+	}
+	if err := RemoveContexts(config, []string{"ctx-0"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+
+	if err := Save(config, path); !errors.Is(err, ErrDrasticShrink) || !strings.Contains(err.Error(), "no contexts, clusters or users") {
+		t.Errorf("Expected emptying the kubeconfig to be refused, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(original) {
+		t.Error("Expected the refused save to leave the file unchanged")
+	}
+
+	var asked string
+	useConfirmShrink(t, func(_, reason string) (bool, error) {
+		asked = reason
+		return true, nil
+	})
+	if err := Save(config, path); err != nil {
+		t.Fatalf("Expected a confirmed save to succeed, got %v", err)
+	}
+	if !strings.Contains(asked, "the current file has 3") {
+		t.Errorf("Expected ConfirmShrink to be told what is lost, got %q", asked)
+	}
+}
+
+func TestSaveRefusesLargeShrink(t *testing.T) {
+	config, path := writeShrinkTestConfig(t, 12)
+	var names []string
+	for i := 1; i < 12; i++ {
+		names = append(names, fmt.Sprintf("ctx-%d", i))
+	}
+	if err := RemoveContexts(config, names); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+
+	useConfirmShrink(t, func(string, string) (bool, error) { return false, nil })
+	err := Save(config, path)
+	if !errors.Is(err, ErrDrasticShrink) || !strings.Contains(err.Error(), "has 3 contexts, clusters and users, down from 36") {
+		t.Errorf("Expected a declined large shrink to be refused, got %v", err)
+	}
+
+	useConfirmShrink(t, func(string, string) (bool, error) { return false, errors.New("no terminal") })
+	if err := Save(config, path); err == nil || err.Error() != "no terminal" {
+		t.Errorf("Expected the ConfirmShrink error, got %v", err)
+	}
+
+	if err := SaveForce(config, path); err != nil {
+		t.Fatalf("Expected SaveForce to skip the check, got %v", err)
+	}
+	if saved, err := Load(path); err != nil || len(saved.Contexts) != 1 {
+		t.Errorf("Expected one context to be saved, got %v", err)
+	}
+}
+
+func TestSaveAllowsModerateShrink(t *testing.T) {
+	config, path := writeShrinkTestConfig(t, 12)
+	if err := RemoveContexts(config, []string{"ctx-0", "ctx-1", "ctx-2", "ctx-3", "ctx-4", "ctx-5"}); err != nil {
+		t.Fatalf("RemoveContexts failed: %v", err)
+	}
+	if err := Save(config, path); err != nil {
+		t.Errorf("Expected removing half of the contexts to be saved without asking, got %v", err)
+	}
+}
+
+func TestShrinkReason(t *testing.T) {
+	small := "apiVersion: v1\nkind: Config\ncontexts:\n- name: a\n  context:\n    cluster: a\n"
+	large := small + "# " + strings.Repeat("x", shrinkMinSize) + "\n"
+	tests := []struct {
+		name     string
+		previous string
+		data     string
+		want     string
+	}{
+		{name: "new file", previous: "", data: small},
+		{name: "empty output", previous: small, data: "", want: "the new content is empty"},
+		{name: "no entries", previous: small, data: "apiVersion: v1\nkind: Config\n", want: "no contexts, clusters or users"},
+		{name: "same size", previous: small, data: small},
+		{name: "small file shrinking", previous: small, data: "apiVersion: v1\nkind: Config\ncontexts:\n- name: a\n"},
+		{name: "large file shrinking", previous: large, data: small, want: "bytes, down from"},
+		{name: "previous does not parse", previous: "{", data: small},
+	}
+	for _, tt := range tests {
+		got := shrinkReason([]byte(tt.previous), []byte(tt.data))
+		if (tt.want == "") != (got == "") || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: expected reason containing %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRestoreBackupRefusesEmptyBackup(t *testing.T) {
+	_, path := writeShrinkTestConfig(t, 1)
+	backup := filepath.Join(t.TempDir(), "config.backup.20240101-120000")
+	if err := os.WriteFile(backup, nil, 0600); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	if err := RestoreBackup(backup, path); !errors.Is(err, ErrDrasticShrink) {
+		t.Errorf("Expected restoring an empty backup to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"slices"
+	"strings"
+)
+
+// SortOnSave makes Save sort the entries of every kubeconfig it writes, as Sort does.
+var SortOnSave = false
+
+// Sort orders the contexts, clusters and users of config by name, so files
+// kept in version control produce readable diffs. It reports whether any
+// entry moved.
+func Sort(config *Config) bool {
+	moved := false
+	if !slices.IsSortedFunc(config.Contexts, compareContexts) {
+		slices.SortStableFunc(config.Contexts, compareContexts)
+		moved = true
+	}
+	if !slices.IsSortedFunc(config.Clusters, compareClusters) {
+		slices.SortStableFunc(config.Clusters, compareClusters)
+		moved = true
+	}
+	if !slices.IsSortedFunc(config.Users, compareUsers) {
+		slices.SortStableFunc(config.Users, compareUsers)
+		moved = true
+	}
+	return moved
+}
+
+func compareContexts(a, b NamedContext) int { return strings.Compare(a.Name, b.Name) }
+func compareClusters(a, b NamedCluster) int { return strings.Compare(a.Name, b.Name) }
+func compareUsers(a, b NamedUser) int       { return strings.Compare(a.Name, b.Name) }
@@ -0,0 +1,54 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package kubeconfig
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	config := renameTestConfig()
+	config.Users[0], config.Users[1] = config.Users[1], config.Users[0]
+	if !Sort(config) {
+		t.Fatal("Expected Sort to report moved entries")
+	}
+	if config.Contexts[0].Name != "old" || config.Users[0].Name != "admin" || config.Users[1].Name != "viewer" {
+		t.Errorf("Expected entries sorted by name, got %+v %+v", config.Contexts, config.Users)
+	}
+	if config.GetUser("viewer").Token != "v" {
+		t.Error("Expected lookups to still work after sorting")
+	}
+	if Sort(config) {
+		t.Error("Expected a sorted config to be left alone")
+	}
+}
+
+func TestSaveSortOnSave(t *testing.T) {
+	defer func(old bool) { SortOnSave = old }(SortOnSave)
+	SortOnSave = true
+
+	config := renameTestConfig()
+	config.Contexts[0], config.Contexts[1] = config.Contexts[1], config.Contexts[0]
+	path := filepath.Join(t.TempDir(), "config")
+	if err := Save(config, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	saved, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if saved.Contexts[0].Name != "old" || saved.Contexts[1].Name != "other" {
+		t.Errorf("Expected contexts saved in order, got %+v", saved.Contexts)
+	}
+}
@@ -16,52 +16,209 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 )
 
+// Color is an ANSI color to paint text with.
+type Color string
+
+// The colors: red for removals and errors, green for what is kept, yellow
+// for warnings
+const (
+	Red    Color = "\x1b[31m"
+	Green  Color = "\x1b[32m"
+	Yellow Color = "\x1b[33m"
+
+	colorReset = "\x1b[0m"
+)
+
+// ansiEscape matches the color sequences Paint adds, to strip them from log files
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Paint returns text in color c if enabled, and text unchanged otherwise.
+func Paint(enabled bool, c Color, text string) string {
+	if !enabled || text == "" {
+		return text
+	}
+	return string(c) + text + colorReset
+}
+
+// Level is the severity of a message. A logger shows the messages at its
+// level and above.
+type Level int
+
+// The levels, from the most verbose; the zero value is LevelInfo
+const (
+	LevelDebug Level = iota - 1
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{LevelDebug: "debug", LevelInfo: "info", LevelWarn: "warn", LevelError: "error"}
+
+// LevelNames lists the names ParseLevel accepts, from the most verbose.
+var LevelNames = []string{"debug", "info", "warn", "error"}
+
+// ParseLevel returns the level called name, in any case.
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level %q (expected one of: %s)", name, strings.Join(LevelNames, ", "))
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
 // Logger provides structured logging with different levels and output control.
-// It supports verbose mode for debug output and quiet mode for minimal output.
+// Messages below its level are dropped; the others go to the console and to
+// any outputs added with AddOutput.
 type Logger struct {
-	verbose bool
-	quiet   bool
+	level Level
+	out   io.Writer
+	err   io.Writer
+	// infoToStderr keeps stdout free for machine-readable output
+	infoToStderr bool
+	// color paints warnings, errors and text passed to Paint
+	color bool
+	// outputs also receive every message shown, with a timestamp
+	outputs []io.Writer
+	mu      sync.Mutex
 }
 
-// New creates a new Logger instance with the specified settings.
-// If verbose is true, debug messages will be shown.
-// If quiet is true, only error messages will be shown (quiet overrides verbose).
-func New(verbose, quiet bool) *Logger {
-	return &Logger{
-		verbose: verbose,
-		quiet:   quiet,
+// Options configures a Logger.
+type Options struct {
+	// Level is the least severe level shown, info by default
+	Level Level
+	// Out receives info messages; nil means os.Stdout
+	Out io.Writer
+	// Err receives debug, warning and error messages, and info messages
+	// after SetInfoToStderr; nil means os.Stderr
+	Err io.Writer
+}
+
+// New creates a Logger showing the messages at opts.Level and above.
+func New(opts Options) *Logger {
+	l := &Logger{level: opts.Level, out: opts.Out, err: opts.Err}
+	if l.out == nil {
+		l.out = os.Stdout
+	}
+	if l.err == nil {
+		l.err = os.Stderr
 	}
+	return l
 }
 
-// Debugf outputs debug-level messages when verbose mode is enabled.
-// Debug messages are only shown if verbose=true and quiet=false.
+// LevelFor returns the level of the --verbose and --quiet flags: debug
+// with verbose, error with quiet, which overrides verbose, and otherwise info.
+func LevelFor(verbose, quiet bool) Level {
+	switch {
+	case quiet:
+		return LevelError
+	case verbose:
+		return LevelDebug
+	default:
+		return LevelInfo
+	}
+}
+
+// Level returns the level of the logger.
+func (l *Logger) Level() Level {
+	return l.level
+}
+
+// SetColor enables colors: warnings are shown in yellow, errors in red, and
+// Paint colors text.
+func (l *Logger) SetColor(enabled bool) {
+	l.color = enabled
+}
+
+// ColorEnabled reports whether colors are enabled.
+func (l *Logger) ColorEnabled() bool {
+	return l.color
+}
+
+// Paint returns text in color c if colors are enabled.
+func (l *Logger) Paint(c Color, text string) string {
+	return Paint(l.color, c, text)
+}
+
+// AddOutput also writes the messages the logger shows to w, one line each
+// with a timestamp and the level and without colors, as in a log file.
+func (l *Logger) AddOutput(w io.Writer) {
+	l.outputs = append(l.outputs, w)
+}
+
+// Debugf outputs debug-level messages when the level is debug.
 func (l *Logger) Debugf(format string, args ...interface{}) {
-	if l.verbose && !l.quiet {
-		fmt.Fprintf(os.Stderr, "[DEBUG] "+format+"\n", args...)
+	if l.level <= LevelDebug {
+		l.log(LevelDebug, l.err, "[DEBUG] ", format, args)
 	}
 }
 
-// Infof outputs informational messages unless quiet mode is enabled.
-// Info messages are shown unless quiet=true.
+// SetInfoToStderr writes info messages to Err instead of Out, so a
+// command's machine-readable result is the only thing on stdout.
+func (l *Logger) SetInfoToStderr(toStderr bool) {
+	l.infoToStderr = toStderr
+}
+
+// Infof outputs informational messages when the level is info or below.
 func (l *Logger) Infof(format string, args ...interface{}) {
-	if !l.quiet {
-		fmt.Printf(format+"\n", args...)
+	if l.level > LevelInfo {
+		return
+	}
+	if l.infoToStderr {
+		l.log(LevelInfo, l.err, "", format, args)
+		return
 	}
+	l.log(LevelInfo, l.out, "", format, args)
 }
 
-// Warnf outputs warning messages unless quiet mode is enabled.
-// Warning messages are shown unless quiet=true.
+// Warnf outputs warning messages when the level is warn or below.
 func (l *Logger) Warnf(format string, args ...interface{}) {
-	if !l.quiet {
-		fmt.Fprintf(os.Stderr, "[WARN] "+format+"\n", args...)
+	if l.level <= LevelWarn {
+		l.log(LevelWarn, l.err, "[WARN] ", format, args)
 	}
 }
 
-// Errorf outputs error messages that are always shown regardless of quiet mode.
+// Errorf outputs error messages that are always shown regardless of the level.
 // Error messages cannot be suppressed as they indicate critical issues.
 func (l *Logger) Errorf(format string, args ...interface{}) {
-	fmt.Fprintf(os.Stderr, "[ERROR] "+format+"\n", args...)
+	l.log(LevelError, l.err, "[ERROR] ", format, args)
+}
+
+// log writes a message to console with prefix, painting warnings and
+// errors, and to the outputs.
+func (l *Logger) log(level Level, console io.Writer, prefix, format string, args []interface{}) {
+	message := fmt.Sprintf(format, args...)
+	switch level {
+	case LevelWarn:
+		fmt.Fprintln(console, l.Paint(Yellow, prefix+message))
+	case LevelError:
+		fmt.Fprintln(console, l.Paint(Red, prefix+message))
+	default:
+		fmt.Fprintln(console, prefix+message)
+	}
+	if len(l.outputs) == 0 {
+		return
+	}
+	message = ansiEscape.ReplaceAllString(message, "")
+	line := fmt.Sprintf("%s %-5s %s\n", time.Now().Format(time.RFC3339), strings.ToUpper(level.String()), message)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, w := range l.outputs {
+		_, _ = io.WriteString(w, line)
+	}
 }
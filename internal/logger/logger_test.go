@@ -17,31 +17,107 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
+// newTestLogger returns a logger at the level of verbose and quiet writing
+// to the returned stdout and stderr buffers.
+func newTestLogger(verbose, quiet bool) (logger *Logger, stdout, stderr *bytes.Buffer) {
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	return New(Options{Level: LevelFor(verbose, quiet), Out: stdout, Err: stderr}), stdout, stderr
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
-		name    string
-		verbose bool
-		quiet   bool
+		name     string
+		verbose  bool
+		quiet    bool
+		expected Level
 	}{
-		{"default", false, false},
-		{"verbose", true, false},
-		{"quiet", false, true},
-		{"verbose and quiet", true, true}, // quiet should override verbose
+		{"default", false, false, LevelInfo},
+		{"verbose", true, false, LevelDebug},
+		{"quiet", false, true, LevelError},
+		{"verbose and quiet", true, true, LevelError}, // quiet should override verbose
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			logger := New(tt.verbose, tt.quiet)
-			if logger.verbose != tt.verbose {
-				t.Errorf("Expected verbose=%v, got %v", tt.verbose, logger.verbose)
-			}
-			if logger.quiet != tt.quiet {
-				t.Errorf("Expected quiet=%v, got %v", tt.quiet, logger.quiet)
+			logger := New(Options{Level: LevelFor(tt.verbose, tt.quiet)})
+			if logger.Level() != tt.expected {
+				t.Errorf("Expected level %s, got %s", tt.expected, logger.Level())
 			}
 		})
 	}
+
+	// Without writers, the logger writes to the standard streams
+	logger := New(Options{})
+	if logger.out != os.Stdout || logger.err != os.Stderr {
+		t.Error("Expected stdout and stderr by default")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range LevelNames {
+		level, err := ParseLevel(strings.ToUpper(name))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+		if level.String() != name {
+			t.Errorf("Expected %s, got %s", name, level)
+		}
+	}
+	if _, err := ParseLevel("trace"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
+func TestAddOutput(t *testing.T) {
+	var file bytes.Buffer
+	logger := New(Options{Level: LevelWarn, Out: &bytes.Buffer{}, Err: &bytes.Buffer{}})
+	logger.SetInfoToStderr(true)
+	logger.AddOutput(&file)
+	logger.Debugf("hidden debug")
+	logger.Infof("hidden info")
+	logger.Warnf("shown %s", "warning")
+	logger.Errorf("shown error")
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines at warn and above, got %q", file.String())
+	}
+	if !strings.HasSuffix(lines[0], " WARN  shown warning") || !strings.HasSuffix(lines[1], " ERROR shown error") {
+		t.Errorf("Expected level-tagged lines, got %q", lines)
+	}
+	if _, err := time.Parse(time.RFC3339, strings.Fields(lines[0])[0]); err != nil {
+		t.Errorf("Expected lines to start with a timestamp: %v", err)
+	}
+}
+
+func TestColor(t *testing.T) {
+	if got := Paint(false, Red, "text"); got != "text" {
+		t.Errorf("Expected no colors when disabled, got %q", got)
+	}
+	if got := Paint(true, Green, "text"); got != "\x1b[32mtext\x1b[0m" {
+		t.Errorf("Expected green text, got %q", got)
+	}
+
+	var file bytes.Buffer
+	logger, _, console := newTestLogger(false, false)
+	logger.SetColor(true)
+	logger.AddOutput(&file)
+	logger.Warnf("careful with %s", logger.Paint(Red, "prod"))
+	logger.Errorf("failed")
+
+	if !logger.ColorEnabled() {
+		t.Error("Expected colors to be enabled")
+	}
+	if !strings.Contains(console.String(), "\x1b[33m[WARN] careful with \x1b[31mprod") ||
+		!strings.Contains(console.String(), "\x1b[31m[ERROR] failed\x1b[0m") {
+		t.Errorf("Expected a yellow warning and a red error, got %q", console.String())
+	}
+	if strings.Contains(file.String(), "\x1b[") || !strings.Contains(file.String(), "careful with prod") {
+		t.Errorf("Expected the log file to be free of colors, got %q", file.String())
+	}
 }
 
 func TestDebug(t *testing.T) {
@@ -60,20 +136,9 @@ func TestDebug(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Capture stderr
-			oldStderr := os.Stderr
-			r, w, _ := os.Pipe()
-			os.Stderr = w
-
-			logger := New(tt.verbose, tt.quiet)
+			logger, _, stderr := newTestLogger(tt.verbose, tt.quiet)
 			logger.Debugf("test message %s", "arg")
-
-			w.Close()
-			os.Stderr = oldStderr
-
-			var output bytes.Buffer
-			output.ReadFrom(r)
-			outputStr := output.String()
+			outputStr := stderr.String()
 
 			if tt.expectOutput {
 				if outputStr == "" {
@@ -107,20 +172,9 @@ func TestInfo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Capture stdout
-			oldStdout := os.Stdout
-			r, w, _ := os.Pipe()
-			os.Stdout = w
-
-			logger := New(tt.verbose, tt.quiet)
+			logger, stdout, _ := newTestLogger(tt.verbose, tt.quiet)
 			logger.Infof("test info %s", "message")
-
-			w.Close()
-			os.Stdout = oldStdout
-
-			var output bytes.Buffer
-			output.ReadFrom(r)
-			outputStr := output.String()
+			outputStr := stdout.String()
 
 			if tt.expectOutput {
 				if outputStr == "" {
@@ -136,6 +190,19 @@ func TestInfo(t *testing.T) {
 	}
 }
 
+func TestInfoToStderr(t *testing.T) {
+	logger, stdout, stderr := newTestLogger(false, false)
+	logger.SetInfoToStderr(true)
+	logger.Infof("test info %s", "message")
+
+	if stdout.Len() != 0 {
+		t.Errorf("Expected nothing on stdout, got %q", stdout.String())
+	}
+	if stderr.String() != "test info message\n" {
+		t.Errorf("Expected the message on stderr, got %q", stderr.String())
+	}
+}
+
 func TestWarn(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -152,20 +219,9 @@ func TestWarn(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Capture stderr
-			oldStderr := os.Stderr
-			r, w, _ := os.Pipe()
-			os.Stderr = w
-
-			logger := New(tt.verbose, tt.quiet)
+			logger, _, stderr := newTestLogger(tt.verbose, tt.quiet)
 			logger.Warnf("test warning %s", "message")
-
-			w.Close()
-			os.Stderr = oldStderr
-
-			var output bytes.Buffer
-			output.ReadFrom(r)
-			outputStr := output.String()
+			outputStr := stderr.String()
 
 			if tt.expectOutput {
 				if outputStr == "" {
@@ -200,20 +256,9 @@ func TestError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Capture stderr
-			oldStderr := os.Stderr
-			r, w, _ := os.Pipe()
-			os.Stderr = w
-
-			logger := New(tt.verbose, tt.quiet)
+			logger, _, stderr := newTestLogger(tt.verbose, tt.quiet)
 			logger.Errorf("test error %s", "message")
-
-			w.Close()
-			os.Stderr = oldStderr
-
-			var output bytes.Buffer
-			output.ReadFrom(r)
-			outputStr := output.String()
+			outputStr := stderr.String()
 
 			if tt.expectOutput {
 				if outputStr == "" {
@@ -267,17 +312,7 @@ func TestLoggerBehaviorMatrix(t *testing.T) {
 
 	for _, combo := range combinations {
 		t.Run(testName(combo.verbose, combo.quiet, combo.level), func(t *testing.T) {
-			logger := New(combo.verbose, combo.quiet)
-
-			// Capture both stdout and stderr
-			oldStdout := os.Stdout
-			oldStderr := os.Stderr
-
-			rOut, wOut, _ := os.Pipe()
-			rErr, wErr, _ := os.Pipe()
-
-			os.Stdout = wOut
-			os.Stderr = wErr
+			logger, stdout, stderr := newTestLogger(combo.verbose, combo.quiet)
 
 			// Call the appropriate log method
 			switch combo.level {
@@ -291,16 +326,7 @@ func TestLoggerBehaviorMatrix(t *testing.T) {
 				logger.Errorf("test")
 			}
 
-			wOut.Close()
-			wErr.Close()
-			os.Stdout = oldStdout
-			os.Stderr = oldStderr
-
-			var outputOut, outputErr bytes.Buffer
-			outputOut.ReadFrom(rOut)
-			outputErr.ReadFrom(rErr)
-
-			totalOutput := outputOut.String() + outputErr.String()
+			totalOutput := stdout.String() + stderr.String()
 			hasOutput := totalOutput != ""
 
 			if hasOutput != combo.expect {
@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "kubectx-manager.log")
+	readFile := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		return string(data)
+	}
+
+	file, err := OpenRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n", "a line longer than the maximum\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Failed to write %q: %v", line, err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Failed to close log file: %v", err)
+	}
+
+	// The oldest file, "one\ntwo\n", was dropped keeping two rotated files
+	expected := map[string]string{
+		path:        "a line longer than the maximum\n",
+		path + ".1": "four\n",
+		path + ".2": "three\n",
+	}
+	for name, content := range expected {
+		if got := readFile(name); got != content {
+			t.Errorf("Expected %s to hold %q, got %q", filepath.Base(name), content, got)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected no third rotated file, got %v", err)
+	}
+
+	// Reopening appends, counting what the file already holds
+	file, err = OpenRotatingFile(path, 32, 2)
+	if err != nil {
+		t.Fatalf("Failed to reopen log file: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.Write([]byte("more\n")); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if got := readFile(path + ".1"); got != "a line longer than the maximum\n" {
+		t.Errorf("Expected the full file to be rotated on reopening, got %q", got)
+	}
+}
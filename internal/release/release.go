@@ -0,0 +1,187 @@
+// Package release queries GitHub for published kubectx-manager releases.
+// It is used by the version and self-update commands to discover newer builds.
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAPIURL is the GitHub API endpoint for the kubectx-manager repository
+	DefaultAPIURL = "https://api.github.com/repos/che-incubator/kubectx-manager"
+	// Timeout for GitHub API requests
+	httpTimeout = 10 * time.Second
+	// Number of numeric components in a semantic version
+	versionParts = 3
+)
+
+// Release describes a published GitHub release.
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	HTMLURL    string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// FindAsset returns the asset with the given name, or nil if the release has none.
+func (r *Release) FindAsset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Client talks to the GitHub releases API.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+}
+
+// NewClient creates a client for the kubectx-manager repository.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: &http.Client{Timeout: httpTimeout},
+		BaseURL:    DefaultAPIURL,
+	}
+}
+
+// Latest returns the most recent stable release.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	var rel Release
+	if err := c.get(ctx, "/releases/latest", &rel); err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// List returns the published releases, newest first, including prereleases.
+func (c *Client) List(ctx context.Context) ([]Release, error) {
+	var releases []Release
+	if err := c.get(ctx, "/releases", &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.BaseURL, "/")+path, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query releases: unexpected status %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse release information: %w", err)
+	}
+	return nil
+}
+
+// CompareVersions compares two semantic versions such as "v1.2.3" or "1.3.0-rc.1".
+// It returns -1 if a < b, 0 if they are equal and 1 if a > b.
+func CompareVersions(a, b string) (int, error) {
+	aNums, aPre, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bNums, bPre, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < versionParts; i++ {
+		if aNums[i] != bNums[i] {
+			if aNums[i] < bNums[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+
+	// A version without a prerelease suffix ranks above one with a suffix
+	switch {
+	case aPre == bPre:
+		return 0, nil
+	case aPre == "":
+		return 1, nil
+	case bPre == "":
+		return -1, nil
+	case aPre < bPre:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// IsNewer reports whether latest is a newer version than current.
+// Unparseable versions, such as development builds, are never considered outdated.
+func IsNewer(current, latest string) bool {
+	cmp, err := CompareVersions(current, latest)
+	return err == nil && cmp < 0
+}
+
+func parseVersion(version string) (nums [versionParts]int, prerelease string, err error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		prerelease = v[i+1:]
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != versionParts {
+		return nums, "", fmt.Errorf("invalid version %q", version)
+	}
+	for i, part := range parts {
+		n, convErr := strconv.Atoi(part)
+		if convErr != nil || n < 0 {
+			return nums, "", fmt.Errorf("invalid version %q", version)
+		}
+		nums[i] = n
+	}
+	return nums, prerelease, nil
+}
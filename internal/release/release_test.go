@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.2.0", "v1.10.0", -1},
+		{"v2.0.0", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0", "v1.0.0-rc.1", 1},
+		{"v1.0.0-rc.1", "v1.0.0-rc.2", -1},
+		{"v1.0.0+build.5", "v1.0.0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("CompareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareVersionsInvalid(t *testing.T) {
+	for _, v := range []string{"dev", "1.0", "v1.x.0", ""} {
+		if _, err := CompareVersions(v, "v1.0.0"); err == nil {
+			t.Errorf("Expected error for invalid version %q", v)
+		}
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	if !IsNewer("v1.0.0", "v1.1.0") {
+		t.Error("Expected v1.1.0 to be newer than v1.0.0")
+	}
+	if IsNewer("v1.1.0", "v1.1.0") {
+		t.Error("Expected equal versions not to be newer")
+	}
+	if IsNewer("dev", "v1.1.0") {
+		t.Error("Development builds should never be reported as outdated")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"tag_name":"v1.4.0","html_url":"https://example.com/v1.4.0",
+			"assets":[{"name":"checksums.txt","browser_download_url":"https://example.com/checksums.txt"}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	rel, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rel.TagName != "v1.4.0" || rel.HTMLURL != "https://example.com/v1.4.0" {
+		t.Errorf("Unexpected release: %+v", rel)
+	}
+	if asset := rel.FindAsset("checksums.txt"); asset == nil || asset.DownloadURL != "https://example.com/checksums.txt" {
+		t.Errorf("Expected checksums.txt asset, got %+v", asset)
+	}
+	if rel.FindAsset("missing") != nil {
+		t.Error("Expected nil for missing asset")
+	}
+}
+
+func TestLatestHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	if _, err := client.Latest(context.Background()); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Minisign signature algorithms: Ed25519 over the file itself, or over its
+// BLAKE2b-512 hash, the default of minisign since 0.10
+const (
+	minisignLegacy    = "Ed"
+	minisignPrehashed = "ED"
+)
+
+const (
+	minisignKeyIDSize   = 8
+	minisignPublicSize  = 2 + minisignKeyIDSize + ed25519.PublicKeySize
+	minisignSigSize     = 2 + minisignKeyIDSize + ed25519.SignatureSize
+	trustedCommentLabel = "trusted comment: "
+)
+
+// ErrInvalidSignature is returned when a file does not match its signature.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// SignatureName returns the name of the minisign signature of the checksums
+// file published with a release.
+func SignatureName(version string) string {
+	return ChecksumsName(version) + ".minisig"
+}
+
+// VerifySignature checks data against a minisign signature made with the
+// key publicKey, given as the base64 line of a minisign public key file or
+// as the whole file. Both the signature of data and that of its trusted
+// comment must verify.
+func VerifySignature(data, signature []byte, publicKey string) error {
+	key, err := decodeMinisign(lastLine(publicKey), minisignPublicSize)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if string(key[:2]) != minisignLegacy {
+		return fmt.Errorf("invalid public key: unsupported algorithm %q", key[:2])
+	}
+	keyID, pub := key[2:2+minisignKeyIDSize], ed25519.PublicKey(key[2+minisignKeyIDSize:])
+
+	lines := strings.Split(strings.ReplaceAll(string(signature), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], trustedCommentLabel) {
+		return fmt.Errorf("%w: malformed signature file", ErrInvalidSignature)
+	}
+	sig, err := decodeMinisign(lines[1], minisignSigSize)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if !bytes.Equal(sig[2:2+minisignKeyIDSize], keyID) {
+		return fmt.Errorf("%w: signed with another key", ErrInvalidSignature)
+	}
+	message := data
+	switch string(sig[:2]) {
+	case minisignLegacy:
+	case minisignPrehashed:
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, sig[:2])
+	}
+	dataSig := sig[2+minisignKeyIDSize:]
+	if !ed25519.Verify(pub, message, dataSig) {
+		return ErrInvalidSignature
+	}
+
+	// The trusted comment is signed together with the signature of data
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed trusted comment signature", ErrInvalidSignature)
+	}
+	comment := strings.TrimPrefix(lines[2], trustedCommentLabel)
+	if !ed25519.Verify(pub, append(bytes.Clone(dataSig), comment...), globalSig) {
+		return fmt.Errorf("%w: trusted comment does not match", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// decodeMinisign decodes a base64 minisign key or signature of size bytes.
+func decodeMinisign(line string, size int) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(line))
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(decoded))
+	}
+	return decoded, nil
+}
+
+// lastLine returns the last non-empty line of s, skipping the untrusted
+// comment of a minisign public key file.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignKey returns a new key pair, the public key as minisign writes it.
+func minisignKey(t *testing.T, keyID string) (string, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	key := append([]byte(minisignLegacy+keyID), pub...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(key) + "\n", priv
+}
+
+// minisign signs data as minisign -S does, prehashed or not.
+func minisign(priv ed25519.PrivateKey, keyID string, data []byte, prehashed bool) []byte {
+	alg, message := minisignLegacy, data
+	if prehashed {
+		sum := blake2b.Sum512(data)
+		alg, message = minisignPrehashed, sum[:]
+	}
+	sig := ed25519.Sign(priv, message)
+	comment := "timestamp:1700000000\tfile:checksums.txt"
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), comment...))
+	return []byte(fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append([]byte(alg+keyID), sig...)), comment,
+		base64.StdEncoding.EncodeToString(globalSig)))
+}
+
+func TestVerifySignature(t *testing.T) {
+	publicKey, priv := minisignKey(t, "KEYID123")
+	otherKey, otherPriv := minisignKey(t, "OTHERKEY")
+	data := []byte("abc123  kubectx-manager_2.0.0_linux_amd64.tar.gz\n")
+
+	for _, prehashed := range []bool{true, false} {
+		signature := minisign(priv, "KEYID123", data, prehashed)
+		if err := VerifySignature(data, signature, publicKey); err != nil {
+			t.Errorf("prehashed=%v: unexpected error: %v", prehashed, err)
+		}
+		if err := VerifySignature(append(data, 'x'), signature, publicKey); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("prehashed=%v: expected ErrInvalidSignature for changed data, got %v", prehashed, err)
+		}
+	}
+
+	if err := VerifySignature(data, minisign(priv, "KEYID123", data, true), otherKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for another key, got %v", err)
+	}
+	forged := minisign(otherPriv, "KEYID123", data, true)
+	if err := VerifySignature(data, forged, publicKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a forged signature, got %v", err)
+	}
+	if err := VerifySignature(data, []byte("not a signature"), publicKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a malformed signature, got %v", err)
+	}
+	if err := VerifySignature(data, minisign(priv, "KEYID123", data, true), "RWQ="); err == nil {
+		t.Error("Expected an error for an invalid public key")
+	}
+}
@@ -0,0 +1,231 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ProjectName is the name used for release archives and the binary inside them
+	ProjectName = "kubectx-manager"
+	// Upper bound on downloaded and extracted file sizes
+	maxDownloadSize = 200 << 20
+	// File permissions for the installed executable
+	executableFileMode = 0755
+)
+
+// Release channels accepted by SelectRelease
+const (
+	ChannelStable     = "stable"
+	ChannelPrerelease = "prerelease"
+)
+
+// ErrNoRelease is returned when no release matches the requested channel.
+var ErrNoRelease = errors.New("no matching release found")
+
+// ArchiveName returns the goreleaser archive name for the given version and platform.
+func ArchiveName(version, goos, goarch string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.tar.gz", ProjectName, strings.TrimPrefix(version, "v"), goos, goarch)
+}
+
+// ChecksumsName returns the name of the checksums file published with a release.
+func ChecksumsName(version string) string {
+	return fmt.Sprintf("%s_%s_checksums.txt", ProjectName, strings.TrimPrefix(version, "v"))
+}
+
+// SelectRelease returns the newest release for the channel.
+// The stable channel only considers full releases; the prerelease channel considers both.
+func (c *Client) SelectRelease(ctx context.Context, channel string) (*Release, error) {
+	switch channel {
+	case ChannelStable, "":
+		return c.Latest(ctx)
+	case ChannelPrerelease:
+		releases, err := c.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var newest *Release
+		for i := range releases {
+			rel := &releases[i]
+			if rel.Draft {
+				continue
+			}
+			if newest == nil {
+				newest = rel
+				continue
+			}
+			if cmp, err := CompareVersions(rel.TagName, newest.TagName); err == nil && cmp > 0 {
+				newest = rel
+			}
+		}
+		if newest == nil {
+			return nil, ErrNoRelease
+		}
+		return newest, nil
+	default:
+		return nil, fmt.Errorf("unknown release channel %q (expected %s or %s)", channel, ChannelStable, ChannelPrerelease)
+	}
+}
+
+// Download fetches the contents of url.
+func (c *Client) Download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close response body: %v\n", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("download %s exceeds %d bytes", url, maxDownloadSize)
+	}
+	return data, nil
+}
+
+// VerifyChecksum checks data against the SHA-256 entry for name in a sha256sum-style checksums file.
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read checksums: %w", err)
+	}
+	return fmt.Errorf("no checksum found for %s", name)
+}
+
+// ExtractBinary returns the contents of the named file from a gzipped tar archive.
+func ExtractBinary(archive []byte, binaryName string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() {
+		if closeErr := gz.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close archive: %v\n", closeErr)
+		}
+	}()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", binaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != binaryName {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxDownloadSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", binaryName, err)
+		}
+		if len(data) > maxDownloadSize {
+			return nil, fmt.Errorf("%s exceeds %d bytes", binaryName, maxDownloadSize)
+		}
+		return data, nil
+	}
+}
+
+// ReplaceExecutable atomically replaces the file at path with data.
+// The previous executable is moved aside first so a running binary can be replaced on every platform;
+// an executable left aside by an earlier update is removed first, since Windows cannot rename over it.
+func ReplaceExecutable(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	cleanup := func() {
+		if removeErr := os.Remove(tmpPath); removeErr != nil && !os.IsNotExist(removeErr) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", tmpPath, removeErr)
+		}
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return fmt.Errorf("failed to write new executable: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to write new executable: %w", err)
+	}
+	if err := os.Chmod(tmpPath, executableFileMode); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to make new executable runnable: %w", err)
+	}
+
+	oldPath := path + ".old"
+	if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		cleanup()
+		return fmt.Errorf("failed to remove the executable left by an earlier update: %w", err)
+	}
+	if err := os.Rename(path, oldPath); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to move current executable aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		if restoreErr := os.Rename(oldPath, path); restoreErr != nil {
+			return fmt.Errorf("failed to install new executable (%w) and to restore the old one: %v", err, restoreErr)
+		}
+		cleanup()
+		return fmt.Errorf("failed to install new executable: %w", err)
+	}
+
+	// Removing the old binary may fail on Windows while it is still running; that is harmless
+	_ = os.Remove(oldPath)
+	return nil
+}
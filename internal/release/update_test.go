@@ -0,0 +1,155 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func makeArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write tar content: %v", err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestArchiveNames(t *testing.T) {
+	if got := ArchiveName("v1.2.3", "linux", "amd64"); got != "kubectx-manager_1.2.3_linux_amd64.tar.gz" {
+		t.Errorf("Unexpected archive name: %s", got)
+	}
+	if got := ChecksumsName("v1.2.3"); got != "kubectx-manager_1.2.3_checksums.txt" {
+		t.Errorf("Unexpected checksums name: %s", got)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("binary contents")
+	sum := sha256.Sum256(data)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  archive.tar.gz\n" +
+		"0000000000000000000000000000000000000000000000000000000000000000  other.tar.gz\n")
+
+	if err := VerifyChecksum(data, "archive.tar.gz", checksums); err != nil {
+		t.Errorf("Expected checksum to verify: %v", err)
+	}
+	if err := VerifyChecksum([]byte("tampered"), "archive.tar.gz", checksums); err == nil {
+		t.Error("Expected checksum mismatch for tampered data")
+	}
+	if err := VerifyChecksum(data, "missing.tar.gz", checksums); err == nil {
+		t.Error("Expected error when no checksum entry exists")
+	}
+}
+
+func TestExtractBinary(t *testing.T) {
+	archive := makeArchive(t, map[string]string{"README.md": "docs", "kubectx-manager": "new binary"})
+
+	data, err := ExtractBinary(archive, "kubectx-manager")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "new binary" {
+		t.Errorf("Unexpected binary contents: %q", data)
+	}
+
+	if _, err := ExtractBinary(archive, "missing"); err == nil {
+		t.Error("Expected error for missing binary")
+	}
+	if _, err := ExtractBinary([]byte("not an archive"), "kubectx-manager"); err == nil {
+		t.Error("Expected error for invalid archive")
+	}
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubectx-manager")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatalf("Failed to create executable: %v", err)
+	}
+
+	if err := ReplaceExecutable(path, []byte("new")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "new" {
+		t.Errorf("Expected executable to be replaced, got %q", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected replaced file to be executable, got %v", info.Mode())
+	}
+	if _, err := os.Stat(path + ".old"); !os.IsNotExist(err) {
+		t.Error("Expected previous executable to be removed")
+	}
+
+	// An executable left aside by an earlier update, which Windows cannot
+	// remove while it runs, does not block the next update
+	if err := os.WriteFile(path+".old", []byte("stale"), 0755); err != nil {
+		t.Fatalf("Failed to create stale executable: %v", err)
+	}
+	if err := ReplaceExecutable(path, []byte("newer")); err != nil {
+		t.Fatalf("Unexpected error with a stale executable: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "newer" {
+		t.Errorf("Expected executable to be replaced, got %q", data)
+	}
+}
+
+func TestSelectRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/latest":
+			w.Write([]byte(`{"tag_name":"v1.1.0"}`))
+		case "/releases":
+			w.Write([]byte(`[{"tag_name":"v1.3.0","draft":true},{"tag_name":"v1.2.0-rc.1","prerelease":true},{"tag_name":"v1.1.0"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+
+	stable, err := client.SelectRelease(context.Background(), ChannelStable)
+	if err != nil || stable.TagName != "v1.1.0" {
+		t.Errorf("Expected stable v1.1.0, got %+v (%v)", stable, err)
+	}
+
+	pre, err := client.SelectRelease(context.Background(), ChannelPrerelease)
+	if err != nil || pre.TagName != "v1.2.0-rc.1" {
+		t.Errorf("Expected prerelease v1.2.0-rc.1, got %+v (%v)", pre, err)
+	}
+
+	if _, err := client.SelectRelease(context.Background(), "nightly"); err == nil {
+		t.Error("Expected error for unknown channel")
+	}
+}
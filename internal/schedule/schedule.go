@@ -0,0 +1,261 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// Package schedule tells when a periodic job runs next, either at a fixed
+// interval or at the times a cron expression matches.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule tells when a periodic job runs next.
+type Schedule interface {
+	// Next returns the first time after t the job runs, or the zero time
+	// if it never does
+	Next(t time.Time) time.Time
+}
+
+// Every runs a job at a fixed interval.
+type Every time.Duration
+
+// Next returns t plus the interval.
+func (e Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+func (e Every) String() string {
+	return "every " + time.Duration(e).String()
+}
+
+// macros are the cron expressions the @ shorthands stand for.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes one of the five fields of a cron expression.
+type field struct {
+	name     string
+	min, max int
+	// names are the accepted names of the values, from min, if any
+	names []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// 7 is Sunday as well as 0
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Cron runs a job at the times a cron expression matches, in the local time
+// zone of the times it is given.
+type Cron struct {
+	spec                         string
+	minute, hour, dom, month     uint64
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+// ParseCron parses a standard five-field cron expression (minute, hour,
+// day of month, month and day of week) or one of @hourly, @daily, @weekly,
+// @monthly and @yearly. Fields accept *, values, ranges (1-5), steps (*/15
+// or 1-30/2) and comma-separated lists of them; months and days of the week
+// may be given by their first three letters. As in cron, when both the day
+// of month and the day of week are restricted, a day matching either runs
+// the job.
+func ParseCron(spec string) (*Cron, error) {
+	expr := strings.TrimSpace(spec)
+	if macro, ok := macros[strings.ToLower(expr)]; ok {
+		expr = macro
+	} else if strings.HasPrefix(expr, "@") {
+		return nil, fmt.Errorf("invalid cron expression %q: unknown shorthand", spec)
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", spec, len(fields))
+	}
+
+	c := &Cron{spec: spec}
+	var err error
+	if c.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+	if c.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+	if c.dom, err = parseField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+	if c.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+	if c.dow, err = parseField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domRestricted = !strings.HasPrefix(fields[2], "*")
+	c.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// parseField returns the values of a cron field as a bit set.
+func parseField(text string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepText, f.name)
+			}
+			step = n
+		}
+
+		var low, high int
+		switch lowText, highText, isRange := strings.Cut(rangeText, "-"); {
+		case rangeText == "*":
+			low, high = f.min, f.max
+		case isRange:
+			var err error
+			if low, err = f.value(lowText); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highText); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangeText, f.name)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeText); err != nil {
+				return 0, err
+			}
+			high = low
+			if hasStep {
+				// 5/15 means from 5 to the end, every 15
+				high = f.max
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field, by number or name.
+func (f field) value(text string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(text, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q (expected %d-%d)", f.name, text, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (c *Cron) String() string {
+	return c.spec
+}
+
+// Fields are the values a cron expression matches, for turning it into the
+// schedule of another scheduler. A field matching every value is nil.
+type Fields struct {
+	Minutes, Hours, Days, Months []int
+	// Weekdays run from 0 for Sunday to 6
+	Weekdays []int
+	// EitherDay is set when a day matching Days or Weekdays matches, rather
+	// than only one matching both
+	EitherDay bool
+}
+
+// Fields returns the values the expression matches.
+func (c *Cron) Fields() Fields {
+	return Fields{
+		Minutes:   values(c.minute, minuteField.min, minuteField.max),
+		Hours:     values(c.hour, hourField.min, hourField.max),
+		Days:      values(c.dom, domField.min, domField.max),
+		Months:    values(c.month, monthField.min, monthField.max),
+		Weekdays:  values(c.dow, 0, 6),
+		EitherDay: c.domRestricted && c.dowRestricted,
+	}
+}
+
+// values lists the values from low to high in bits, or returns nil if all are.
+func values(bits uint64, low, high int) []int {
+	var list []int
+	for v := low; v <= high; v++ {
+		if bits&(1<<uint(v)) != 0 {
+			list = append(list, v)
+		}
+	}
+	if len(list) == high-low+1 {
+		return nil
+	}
+	return list
+}
+
+// Next returns the first time after t the expression matches, to the
+// minute, or the zero time if it matches none within five years (such as
+// on February 30).
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		year, month, day := t.Date()
+		switch {
+		case c.month&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day of month and day
+// of week fields.
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domRestricted && c.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
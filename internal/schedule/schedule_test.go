@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package schedule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2025, 3, 5, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, 3, 5, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 5, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2025, 3, 6, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 5, 11, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2025, 3, 6, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1 jan,jul *", time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2025, 3, 5, 10, 45, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week
+		{"0 0 20 * fri", time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			c, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if next := c.Next(from); !next.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, next)
+			}
+		})
+	}
+
+	c, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if next := c.Next(from); !next.IsZero() {
+		t.Errorf("Expected February 30 never to come, got %s", next)
+	}
+}
+
+func TestCronFields(t *testing.T) {
+	c, err := ParseCron("*/20 9-11 * * 1-5")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	f := c.Fields()
+	if fmt.Sprint(f.Minutes, f.Hours, f.Weekdays) != "[0 20 40] [9 10 11] [1 2 3 4 5]" ||
+		f.Days != nil || f.Months != nil || f.EitherDay {
+		t.Errorf("Unexpected fields: %+v", f)
+	}
+
+	if c, err = ParseCron("0 0 1,15 * 7"); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if f := c.Fields(); fmt.Sprint(f.Days, f.Weekdays) != "[1 15] [0]" || !f.EitherDay {
+		t.Errorf("Expected the 1st, the 15th or Sundays, got %+v", f)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "5-1 * * * *", "*/0 * * * *", "* * * foo *", "@sometimes"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	from := time.Date(2025, 3, 5, 10, 30, 45, 0, time.UTC)
+	if next := Every(24 * time.Hour).Next(from); !next.Equal(from.Add(24 * time.Hour)) {
+		t.Errorf("Expected a day later, got %s", next)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores of a fuzzy match, modeled on fzf: every matched character scores,
+// more so at the start of a word or right after the previous match, and
+// every gap inside the match costs, more to open than to extend.
+const (
+	scoreMatch          = 16
+	bonusBoundary       = 8
+	bonusConsecutive    = 8
+	penaltyGapStart     = 3
+	penaltyGapExtension = 1
+)
+
+// Match reports whether the characters of pattern appear in text in order,
+// ignoring case, with a score that is higher for tighter matches and the
+// positions, in runes, of the matched characters. An empty pattern matches
+// everything with a score of zero.
+func Match(pattern, text string) (int, []int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	original := []rune(text)
+	t := []rune(strings.ToLower(text))
+	if len(t) != len(original) {
+		// A few runes change length when lowered; judge boundaries on t then
+		original = t
+	}
+
+	// Find the first position where the whole pattern matches...
+	end, pi := -1, 0
+	for i := 0; i < len(t) && pi < len(p); i++ {
+		if t[i] == p[pi] {
+			pi++
+			end = i
+		}
+	}
+	if pi < len(p) {
+		return 0, nil, false
+	}
+	// ...then walk back from its end for the shortest window holding it
+	positions := make([]int, len(p))
+	pi = len(p) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if t[i] == p[pi] {
+			positions[pi] = i
+			pi--
+		}
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += scoreMatch
+		if pos == 0 || isBoundary(original[pos-1], original[pos]) {
+			score += bonusBoundary
+		}
+		if i > 0 {
+			if pos == positions[i-1]+1 {
+				score += bonusConsecutive
+			} else {
+				score -= penaltyGapStart + penaltyGapExtension*(pos-positions[i-1]-2)
+			}
+		}
+	}
+	return score, positions, true
+}
+
+// isBoundary reports whether cur starts a word: it follows a separator or
+// is an upper-case letter after a lower-case one.
+func isBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// Ranked is an item that matched a fuzzy pattern.
+type Ranked struct {
+	// Index is the position of the item in the list given to Filter
+	Index     int
+	Score     int
+	Positions []int
+}
+
+// Filter returns the items matching pattern, best match first. Of items
+// that score the same the shorter comes first, as in fzf, then the earlier.
+// An empty pattern keeps every item in order.
+func Filter(pattern string, items []string) []Ranked {
+	var ranked []Ranked
+	for i, item := range items {
+		if score, positions, ok := Match(pattern, item); ok {
+			ranked = append(ranked, Ranked{Index: i, Score: score, Positions: positions})
+		}
+	}
+	if pattern == "" {
+		return ranked
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return len(items[ranked[i].Index]) < len(items[ranked[j].Index])
+	})
+	return ranked
+}
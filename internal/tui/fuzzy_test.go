@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		ok        bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"prd", "prod-cluster", true, []int{0, 1, 3}},
+		{"PC", "prod-cluster", true, []int{0, 5}},
+		{"clp", "prod-cluster", false, nil},
+		// The shortest window wins over the first occurrence of the first character
+		{"ab", "a-x-ab", true, []int{4, 5}},
+	}
+
+	for _, tt := range tests {
+		_, positions, ok := Match(tt.pattern, tt.text)
+		if ok != tt.ok || fmt.Sprint(positions) != fmt.Sprint(tt.positions) {
+			t.Errorf("Match(%q, %q) = %v, %v; expected %v, %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestMatchScoresTighterMatchesHigher(t *testing.T) {
+	consecutive, _, _ := Match("prod", "prod-eu")
+	scattered, _, _ := Match("prod", "p-r-o-d")
+	if consecutive <= scattered {
+		t.Errorf("Expected a consecutive match to score higher: %d <= %d", consecutive, scattered)
+	}
+
+	boundary, _, _ := Match("eu", "prod-eu")
+	inner, _, _ := Match("eu", "prodeu")
+	if boundary <= inner {
+		t.Errorf("Expected a match at a word start to score higher: %d <= %d", boundary, inner)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := []string{"staging-eu", "prod-us", "dev", "prod-eu"}
+
+	ranked := Filter("preu", items)
+	if len(ranked) != 1 || ranked[0].Index != 3 {
+		t.Errorf("Expected only prod-eu to match, got %+v", ranked)
+	}
+
+	ranked = Filter("eu", items)
+	if len(ranked) != 2 || ranked[0].Index != 3 || ranked[1].Index != 0 {
+		t.Errorf("Expected prod-eu before staging-eu, got %+v", ranked)
+	}
+
+	if ranked := Filter("", items); len(ranked) != len(items) || ranked[2].Index != 2 {
+		t.Errorf("Expected an empty pattern to keep every item in order, got %+v", ranked)
+	}
+}
@@ -0,0 +1,115 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"bufio"
+	"unicode"
+)
+
+// KeyCode identifies a key press.
+type KeyCode int
+
+const (
+	// KeyRune is a printable character, given in Key.Rune
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyPgUp
+	KeyPgDn
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyTab
+	KeyEsc
+	KeyBackspace
+	KeyCtrlC
+	// KeyUnknown is any other key or escape sequence
+	KeyUnknown
+)
+
+// Key is a single key press.
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// escapeSequences maps the CSI and SS3 sequences terminals send for
+// special keys, without the leading escape, to their key
+var escapeSequences = map[string]KeyCode{
+	"[A":  KeyUp,
+	"[B":  KeyDown,
+	"OA":  KeyUp,
+	"OB":  KeyDown,
+	"[5~": KeyPgUp,
+	"[6~": KeyPgDn,
+	"[H":  KeyHome,
+	"[F":  KeyEnd,
+	"OH":  KeyHome,
+	"OF":  KeyEnd,
+	"[1~": KeyHome,
+	"[4~": KeyEnd,
+}
+
+// ReadKey reads a single key press from a terminal in raw mode.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	ch, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+	switch ch {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case 0x7f, '\b':
+		return Key{Code: KeyBackspace}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 0x03:
+		return Key{Code: KeyCtrlC}, nil
+	case 0x10: // ctrl-p
+		return Key{Code: KeyUp}, nil
+	case 0x0e: // ctrl-n
+		return Key{Code: KeyDown}, nil
+	case 0x1b:
+		return readEscape(r)
+	}
+	if !unicode.IsPrint(ch) {
+		return Key{Code: KeyUnknown}, nil
+	}
+	return Key{Code: KeyRune, Rune: ch}, nil
+}
+
+// readEscape reads the rest of an escape sequence. Terminals send a whole
+// sequence at once, so an escape with nothing buffered after it is the
+// escape key itself.
+func readEscape(r *bufio.Reader) (Key, error) {
+	if r.Buffered() == 0 {
+		return Key{Code: KeyEsc}, nil
+	}
+	var seq []byte
+	for r.Buffered() > 0 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		seq = append(seq, b)
+		// A sequence ends with a letter or ~, after its introducer
+		if len(seq) > 1 && (b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b == '~') {
+			break
+		}
+	}
+	if code, ok := escapeSequences[string(seq)]; ok {
+		return Key{Code: code}, nil
+	}
+	return Key{Code: KeyUnknown}, nil
+}
@@ -0,0 +1,196 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// highlight marks the characters a fuzzy pattern matched
+const highlight = "\x1b[1;32m"
+
+// PickOptions configures a fuzzy picker.
+type PickOptions struct {
+	Prompt string
+	Items  []string
+	// Query is the initial filter
+	Query string
+	// Selected is the item the cursor starts on
+	Selected int
+	// Inspect lets the user press tab to ask for details of an item
+	Inspect bool
+}
+
+// Choice is the outcome of a fuzzy picker.
+type Choice struct {
+	// Query is the filter when the picker closed, to reopen it with
+	Query string
+	// Index is the chosen item, or -1 if the user canceled
+	Index int
+	// Inspect is set if the user asked for details of the item instead
+	Inspect bool
+}
+
+// picker is the state of a fuzzy picker.
+type picker struct {
+	opts    PickOptions
+	query   string
+	matches []Ranked
+	cursor  int
+	offset  int
+	choice  *Choice
+}
+
+func newPicker(opts PickOptions) *picker {
+	p := &picker{opts: opts, query: opts.Query}
+	p.refilter()
+	for i, match := range p.matches {
+		if match.Index == opts.Selected {
+			p.cursor = i
+		}
+	}
+	return p
+}
+
+// refilter ranks the items by the query, moving the cursor to the best match.
+func (p *picker) refilter() {
+	p.matches = Filter(p.query, p.opts.Items)
+	p.cursor, p.offset = 0, 0
+}
+
+func (p *picker) update(key Key) {
+	switch key.Code {
+	case KeyCtrlC, KeyEsc:
+		p.choice = &Choice{Query: p.query, Index: -1}
+	case KeyEnter:
+		if len(p.matches) > 0 {
+			p.choice = &Choice{Query: p.query, Index: p.matches[p.cursor].Index}
+		}
+	case KeyTab:
+		if p.opts.Inspect && len(p.matches) > 0 {
+			p.choice = &Choice{Query: p.query, Index: p.matches[p.cursor].Index, Inspect: true}
+		}
+	case KeyUp:
+		p.move(-1)
+	case KeyDown:
+		p.move(1)
+	case KeyPgUp:
+		p.move(-10)
+	case KeyPgDn:
+		p.move(10)
+	case KeyBackspace:
+		p.query = dropLastRune(p.query)
+		p.refilter()
+	case KeyRune:
+		p.query += string(key.Rune)
+		p.refilter()
+	}
+}
+
+func (p *picker) move(delta int) {
+	p.cursor += delta
+	if p.cursor >= len(p.matches) {
+		p.cursor = len(p.matches) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+// view renders the picker: the query, the match count and the matches,
+// best first, with the matched characters highlighted.
+func (p *picker) view(width, height int) string {
+	lines := []string{
+		truncate(p.opts.Prompt+" "+p.query, width),
+		fmt.Sprintf("  %d/%d", len(p.matches), len(p.opts.Items)),
+	}
+	listHeight := height - len(lines) - 1
+	if listHeight < 1 {
+		listHeight = 1
+	}
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+listHeight {
+		p.offset = p.cursor - listHeight + 1
+	}
+	for i := p.offset; i < len(p.matches) && i < p.offset+listHeight; i++ {
+		match := p.matches[i]
+		text := truncate(p.opts.Items[match.Index], width-2)
+		if i == p.cursor {
+			lines = append(lines, reverse+"> "+pad(text, width-2)+reset)
+			continue
+		}
+		lines = append(lines, "  "+highlightPositions(text, match.Positions))
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	help := "↑/↓ move  enter select  esc cancel"
+	if p.opts.Inspect {
+		help = "↑/↓ move  enter select  tab inspect  esc cancel"
+	}
+	lines = append(lines, truncate(help, width))
+	return strings.Join(lines, "\n")
+}
+
+// highlightPositions marks the runes of text at positions.
+func highlightPositions(text string, positions []int) string {
+	if len(positions) == 0 {
+		return text
+	}
+	marked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		marked[pos] = true
+	}
+	var b strings.Builder
+	for i, r := range []rune(text) {
+		if marked[i] {
+			b.WriteString(highlight + string(r) + reset)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Pick shows a type-to-filter list of items on the terminal attached to
+// in, drawing to out, until the user chooses one or cancels. Input that is
+// not a file, such as keys scripted by a test, is read as it is.
+func Pick(in io.Reader, out io.Writer, opts PickOptions) (Choice, error) {
+	size, restore, err := setupTerminal(in)
+	if err != nil {
+		return Choice{}, err
+	}
+	defer restore()
+
+	fmt.Fprint(out, altScreenOn)
+	defer fmt.Fprint(out, altScreenOff)
+
+	p := newPicker(opts)
+	reader := bufio.NewReader(in)
+	for p.choice == nil {
+		width, height := size()
+		fmt.Fprint(out, clearScreen+strings.ReplaceAll(p.view(width, height), "\n", "\r\n"))
+
+		key, err := ReadKey(reader)
+		if err != nil {
+			return Choice{}, err
+		}
+		p.update(key)
+	}
+	return *p.choice, nil
+}
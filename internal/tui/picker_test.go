@@ -0,0 +1,115 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+var pickerItems = []string{"dev", "prod-eu", "prod-us", "staging"}
+
+func TestPickerSelectsBestMatch(t *testing.T) {
+	p := newPicker(PickOptions{Items: pickerItems, Selected: 3})
+	if p.matches[p.cursor].Index != 3 {
+		t.Fatalf("Expected the cursor to start on the selected item")
+	}
+
+	typeQuery(p, "pus")
+	if len(p.matches) != 1 {
+		t.Errorf("Expected one match for pus, got %+v", p.matches)
+	}
+	p.update(Key{Code: KeyEnter})
+	if p.choice == nil || p.choice.Index != 2 || p.choice.Query != "pus" {
+		t.Errorf("Expected prod-us to be chosen, got %+v", p.choice)
+	}
+}
+
+func TestPickerCancel(t *testing.T) {
+	p := newPicker(PickOptions{Items: pickerItems})
+	typeQuery(p, "xyz")
+	// Enter does nothing without a match
+	p.update(Key{Code: KeyEnter})
+	if p.choice != nil {
+		t.Fatalf("Expected no choice without a match, got %+v", p.choice)
+	}
+	p.update(Key{Code: KeyEsc})
+	if p.choice == nil || p.choice.Index != -1 {
+		t.Errorf("Expected a canceled choice, got %+v", p.choice)
+	}
+}
+
+func TestPickerInspect(t *testing.T) {
+	p := newPicker(PickOptions{Items: pickerItems})
+	p.update(Key{Code: KeyTab})
+	if p.choice != nil {
+		t.Fatalf("Expected tab to do nothing unless inspecting is enabled")
+	}
+
+	p = newPicker(PickOptions{Items: pickerItems, Inspect: true})
+	p.update(Key{Code: KeyDown})
+	p.update(Key{Code: KeyTab})
+	if p.choice == nil || !p.choice.Inspect || p.choice.Index != 1 {
+		t.Errorf("Expected prod-eu to be inspected, got %+v", p.choice)
+	}
+}
+
+func TestPickerView(t *testing.T) {
+	p := newPicker(PickOptions{Prompt: "Pick>", Items: pickerItems})
+	typeQuery(p, "prod")
+
+	lines := strings.Split(p.view(40, 8), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("Expected the view to fill 8 lines, got %d", len(lines))
+	}
+	if lines[0] != "Pick> prod" || strings.TrimSpace(lines[1]) != "2/4" {
+		t.Errorf("Unexpected prompt and count %q, %q", lines[0], lines[1])
+	}
+	if !strings.Contains(lines[2], "> prod-eu") || !strings.Contains(lines[3], highlight+"p"+reset) {
+		t.Errorf("Expected the selected and highlighted matches, got %q, %q", lines[2], lines[3])
+	}
+}
+
+func TestPickNeedsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := Pick(f, os.Stdout, PickOptions{Items: pickerItems}); !errors.Is(err, ErrNotTerminal) {
+		t.Errorf("Expected ErrNotTerminal, got %v", err)
+	}
+}
+
+func TestPickFromReader(t *testing.T) {
+	var out strings.Builder
+	choice, err := Pick(strings.NewReader("pus\r"), &out, PickOptions{Items: pickerItems})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if choice.Index != 2 {
+		t.Errorf("Expected prod-us to be chosen, got %+v", choice)
+	}
+	if !strings.Contains(out.String(), "prod-us") {
+		t.Errorf("Expected the picker to be drawn to out, got %q", out.String())
+	}
+}
+
+func typeQuery(p *picker, query string) {
+	for _, r := range query {
+		p.update(Key{Code: KeyRune, Rune: r})
+	}
+}
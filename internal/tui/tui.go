@@ -0,0 +1,482 @@
+// Package tui implements the full-screen context browser of the tui command.
+// It draws on a raw terminal with ANSI escape sequences, so it needs no UI
+// framework; the Model holds all state and can be driven without a terminal.
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to draw the screen
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	hideCursor   = "\x1b[?25l"
+	showCursor   = "\x1b[?25h"
+	clearScreen  = "\x1b[H\x1b[2J"
+	reverse      = "\x1b[7m"
+	bold         = "\x1b[1m"
+	reset        = "\x1b[0m"
+)
+
+// Size used when the terminal does not report one
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// ErrNotTerminal is returned by Run and Pick when the input is a file that is not a terminal
+var ErrNotTerminal = errors.New("the context browser needs an interactive terminal")
+
+// Row is a context as shown in the browser.
+type Row struct {
+	Name      string
+	Cluster   string
+	Server    string
+	User      string
+	Namespace string
+	// Auth is the result of the last auth check, empty if none was run
+	Auth        string
+	Current     bool
+	Whitelisted bool
+}
+
+// Handler carries out the actions chosen in the browser. Each action
+// returns a status message for the user; afterwards the browser reloads
+// its rows with Rows.
+type Handler interface {
+	Rows() ([]Row, error)
+	Switch(name string) (string, error)
+	Rename(oldName, newName string) (string, error)
+	Delete(name string) (string, error)
+	Export(name, path string) (string, error)
+	CheckAuth(name string) (string, error)
+}
+
+// mode is what the browser's key presses currently edit
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeFilter
+	modeRename
+	modeExport
+	modeConfirmDelete
+)
+
+const helpLine = "↑/↓ move  enter switch  r rename  d delete  e export  a auth  / filter  q quit"
+
+// Model is the state of the browser.
+type Model struct {
+	handler Handler
+	title   string
+	rows    []Row
+	filter  string
+	input   string
+	status  string
+	cursor  int
+	offset  int
+	mode    mode
+	done    bool
+}
+
+// New returns a browser showing rows under title.
+func New(title string, rows []Row, handler Handler) *Model {
+	return &Model{title: title, rows: rows, handler: handler}
+}
+
+// Done reports whether the user quit the browser.
+func (m *Model) Done() bool {
+	return m.done
+}
+
+// Status returns the message shown below the list.
+func (m *Model) Status() string {
+	return m.status
+}
+
+// Visible returns the rows matching the filter, in display order.
+func (m *Model) Visible() []Row {
+	if m.filter == "" {
+		return m.rows
+	}
+	filter := strings.ToLower(m.filter)
+	var visible []Row
+	for _, row := range m.rows {
+		if strings.Contains(strings.ToLower(row.Name), filter) || strings.Contains(strings.ToLower(row.Server), filter) {
+			visible = append(visible, row)
+		}
+	}
+	return visible
+}
+
+// Selected returns the row under the cursor, or false if no row is visible.
+func (m *Model) Selected() (Row, bool) {
+	visible := m.Visible()
+	if len(visible) == 0 {
+		return Row{}, false
+	}
+	return visible[m.cursor], true
+}
+
+// Update applies a key press.
+func (m *Model) Update(key Key) {
+	if key.Code == KeyCtrlC {
+		m.done = true
+		return
+	}
+	switch m.mode {
+	case modeFilter:
+		m.updateFilter(key)
+	case modeRename, modeExport:
+		m.updateInput(key)
+	case modeConfirmDelete:
+		m.mode = modeBrowse
+		if row, ok := m.Selected(); ok && key.Code == KeyRune && (key.Rune == 'y' || key.Rune == 'Y') {
+			m.run(row.Name, func() (string, error) { return m.handler.Delete(row.Name) })
+			return
+		}
+		m.status = "Delete canceled"
+	default:
+		m.updateBrowse(key)
+	}
+}
+
+func (m *Model) updateBrowse(key Key) {
+	m.status = ""
+	switch key.Code {
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPgUp:
+		m.move(-10)
+	case KeyPgDn:
+		m.move(10)
+	case KeyHome:
+		m.move(-len(m.rows))
+	case KeyEnd:
+		m.move(len(m.rows))
+	case KeyEnter:
+		m.act(m.handler.Switch)
+	case KeyEsc:
+		m.filter = ""
+		m.clamp()
+	case KeyRune:
+		m.updateBrowseRune(key.Rune)
+	}
+}
+
+func (m *Model) updateBrowseRune(r rune) {
+	row, selected := m.Selected()
+	switch r {
+	case 'q':
+		m.done = true
+	case 'k':
+		m.move(-1)
+	case 'j':
+		m.move(1)
+	case 'g':
+		m.move(-len(m.rows))
+	case 'G':
+		m.move(len(m.rows))
+	case '/':
+		m.mode = modeFilter
+	case 's':
+		m.act(m.handler.Switch)
+	case 'a':
+		m.act(m.handler.CheckAuth)
+	case 'r':
+		if selected {
+			m.mode, m.input = modeRename, row.Name
+		}
+	case 'e':
+		if selected {
+			m.mode, m.input = modeExport, row.Name+".kubeconfig"
+		}
+	case 'd':
+		if selected {
+			m.mode = modeConfirmDelete
+		}
+	}
+}
+
+func (m *Model) updateFilter(key Key) {
+	switch key.Code {
+	case KeyEnter:
+		m.mode = modeBrowse
+	case KeyEsc:
+		m.mode, m.filter = modeBrowse, ""
+	case KeyBackspace:
+		m.filter = dropLastRune(m.filter)
+	case KeyRune:
+		m.filter += string(key.Rune)
+	}
+	m.clamp()
+}
+
+func (m *Model) updateInput(key Key) {
+	switch key.Code {
+	case KeyEsc:
+		m.mode, m.input, m.status = modeBrowse, "", ""
+	case KeyBackspace:
+		m.input = dropLastRune(m.input)
+	case KeyRune:
+		m.input += string(key.Rune)
+	case KeyEnter:
+		input, action := strings.TrimSpace(m.input), m.mode
+		m.mode, m.input = modeBrowse, ""
+		row, ok := m.Selected()
+		if !ok || input == "" {
+			return
+		}
+		if action == modeRename {
+			m.run(input, func() (string, error) { return m.handler.Rename(row.Name, input) })
+		} else {
+			m.run(row.Name, func() (string, error) { return m.handler.Export(row.Name, input) })
+		}
+	}
+}
+
+// act runs action on the selected context.
+func (m *Model) act(action func(name string) (string, error)) {
+	if row, ok := m.Selected(); ok {
+		m.run(row.Name, func() (string, error) { return action(row.Name) })
+	}
+}
+
+// run carries out an action, shows its outcome and reloads the rows,
+// moving the cursor to the context named follow if it still exists.
+func (m *Model) run(follow string, action func() (string, error)) {
+	status, err := action()
+	if err != nil {
+		status = "Error: " + err.Error()
+	}
+	m.status = status
+
+	rows, err := m.handler.Rows()
+	if err != nil {
+		m.status = "Error: " + err.Error()
+		return
+	}
+	m.rows = rows
+	m.clamp()
+	for i, row := range m.Visible() {
+		if row.Name == follow {
+			m.cursor = i
+		}
+	}
+}
+
+// move moves the cursor by delta rows, stopping at either end.
+func (m *Model) move(delta int) {
+	m.cursor += delta
+	m.clamp()
+}
+
+// clamp keeps the cursor on a visible row.
+func (m *Model) clamp() {
+	visible := len(m.Visible())
+	if m.cursor >= visible {
+		m.cursor = visible - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// View renders the screen for a terminal of the given size.
+func (m *Model) View(width, height int) string {
+	var lines []string
+	lines = append(lines, bold+truncate(m.title, width)+reset)
+
+	visible := m.Visible()
+	header := []string{"NAME", "CLUSTER", "SERVER", "NAMESPACE", "WHITELISTED", "AUTH"}
+	cells := make([][]string, len(visible))
+	for i, row := range visible {
+		cells[i] = []string{row.Name, row.Cluster, row.Server, row.Namespace, yesNo(row.Whitelisted), row.Auth}
+	}
+	widths := columnWidths(header, cells)
+	lines = append(lines, bold+truncate("  "+formatRow(header, widths), width)+reset)
+
+	// Title, header, status and help take four lines
+	listHeight := height - 4
+	if listHeight < 1 {
+		listHeight = 1
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+	for i := m.offset; i < len(visible) && i < m.offset+listHeight; i++ {
+		marker := "  "
+		if visible[i].Current {
+			marker = "* "
+		}
+		line := truncate(marker+formatRow(cells[i], widths), width)
+		if i == m.cursor {
+			line = reverse + pad(line, width) + reset
+		}
+		lines = append(lines, line)
+	}
+	if len(visible) == 0 {
+		lines = append(lines, "  No contexts")
+	}
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, truncate(m.statusLine(), width))
+	lines = append(lines, truncate(helpLine, width))
+	return strings.Join(lines, "\n")
+}
+
+// statusLine returns the prompt of the current mode, or the last status.
+func (m *Model) statusLine() string {
+	row, _ := m.Selected()
+	switch m.mode {
+	case modeFilter:
+		return "Filter: " + m.filter
+	case modeRename:
+		return fmt.Sprintf("Rename '%s' to: %s", row.Name, m.input)
+	case modeExport:
+		return fmt.Sprintf("Export '%s' to file: %s", row.Name, m.input)
+	case modeConfirmDelete:
+		return fmt.Sprintf("Delete context '%s'? (y/N)", row.Name)
+	}
+	if m.filter != "" && m.status == "" {
+		return fmt.Sprintf("Filter: %s (esc to clear)", m.filter)
+	}
+	return m.status
+}
+
+// Run shows the browser on the terminal attached to in, drawing to out,
+// until the user quits. Input that is not a file, such as keys scripted by
+// a test, is read as it is.
+func Run(in io.Reader, out io.Writer, m *Model) error {
+	size, restore, err := setupTerminal(in)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	fmt.Fprint(out, altScreenOn+hideCursor)
+	defer fmt.Fprint(out, showCursor+altScreenOff)
+
+	reader := bufio.NewReader(in)
+	for !m.Done() {
+		width, height := size()
+		// Raw mode does not translate newlines
+		fmt.Fprint(out, clearScreen+strings.ReplaceAll(m.View(width, height), "\n", "\r\n"))
+
+		key, err := ReadKey(reader)
+		if err != nil {
+			return err
+		}
+		m.Update(key)
+	}
+	return nil
+}
+
+// setupTerminal puts in into raw mode if it is a terminal, returning how to
+// read its size and how to restore it. A file that is not a terminal is
+// ErrNotTerminal; any other reader is used as it is, at the default size.
+func setupTerminal(in io.Reader) (size func() (int, int), restore func(), err error) {
+	file, ok := in.(interface{ Fd() uintptr })
+	if !ok {
+		return func() (int, int) { return defaultWidth, defaultHeight }, func() {}, nil
+	}
+	fd := int(file.Fd()) //nolint:gosec // File descriptors fit in an int
+	if !term.IsTerminal(fd) {
+		return nil, nil, ErrNotTerminal
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	size = func() (int, int) {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			return defaultWidth, defaultHeight
+		}
+		return width, height
+	}
+	return size, func() { _ = term.Restore(fd, state) }, nil
+}
+
+// columnWidths returns the width of each column of the table.
+func columnWidths(header []string, cells [][]string) []int {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range cells {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	return widths
+}
+
+// formatRow joins cells padded to the column widths.
+func formatRow(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = pad(cell, widths[i])
+	}
+	return strings.TrimRight(strings.Join(padded, "  "), " ")
+}
+
+// pad fills s with spaces to width runes.
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// truncate cuts s to width runes.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
+
+func dropLastRune(s string) string {
+	if s == "" {
+		return s
+	}
+	_, size := utf8.DecodeLastRuneInString(s)
+	return s[:len(s)-size]
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,250 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fakeHandler keeps the rows in memory and records the actions taken.
+type fakeHandler struct {
+	rows    []Row
+	actions []string
+}
+
+func newFakeHandler() *fakeHandler {
+	return &fakeHandler{rows: []Row{
+		{Name: "dev", Server: "https://dev.example.com"},
+		{Name: "prod", Server: "https://prod.example.com", Current: true, Whitelisted: true},
+		{Name: "staging", Server: "https://staging.example.com"},
+	}}
+}
+
+func (h *fakeHandler) Rows() ([]Row, error) {
+	rows := append([]Row(nil), h.rows...)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return rows, nil
+}
+
+func (h *fakeHandler) Switch(name string) (string, error) {
+	h.actions = append(h.actions, "switch "+name)
+	for i := range h.rows {
+		h.rows[i].Current = h.rows[i].Name == name
+	}
+	return "switched", nil
+}
+
+func (h *fakeHandler) Rename(oldName, newName string) (string, error) {
+	h.actions = append(h.actions, fmt.Sprintf("rename %s %s", oldName, newName))
+	for i := range h.rows {
+		if h.rows[i].Name == oldName {
+			h.rows[i].Name = newName
+		}
+	}
+	return "renamed", nil
+}
+
+func (h *fakeHandler) Delete(name string) (string, error) {
+	h.actions = append(h.actions, "delete "+name)
+	for i := range h.rows {
+		if h.rows[i].Name == name {
+			h.rows = append(h.rows[:i], h.rows[i+1:]...)
+			break
+		}
+	}
+	return "deleted", nil
+}
+
+func (h *fakeHandler) Export(name, path string) (string, error) {
+	h.actions = append(h.actions, fmt.Sprintf("export %s %s", name, path))
+	return "exported", nil
+}
+
+func (h *fakeHandler) CheckAuth(name string) (string, error) {
+	return "", errors.New("no credentials")
+}
+
+func newTestModel(t *testing.T) (*Model, *fakeHandler) {
+	t.Helper()
+	handler := newFakeHandler()
+	rows, _ := handler.Rows()
+	return New("test", rows, handler), handler
+}
+
+// typeKeys feeds each rune of s to the model as a key press.
+func typeKeys(m *Model, s string) {
+	for _, r := range s {
+		m.Update(Key{Code: KeyRune, Rune: r})
+	}
+}
+
+func TestModelNavigationAndSwitch(t *testing.T) {
+	m, handler := newTestModel(t)
+
+	m.Update(Key{Code: KeyDown})
+	m.Update(Key{Code: KeyDown})
+	m.Update(Key{Code: KeyDown})
+	if row, _ := m.Selected(); row.Name != "staging" {
+		t.Errorf("Expected the cursor to stop at staging, got %s", row.Name)
+	}
+	typeKeys(m, "k")
+	m.Update(Key{Code: KeyEnter})
+
+	if strings.Join(handler.actions, ",") != "switch prod" {
+		t.Errorf("Unexpected actions %v", handler.actions)
+	}
+	if m.Status() != "switched" {
+		t.Errorf("Expected the action's status, got %q", m.Status())
+	}
+}
+
+func TestModelFilter(t *testing.T) {
+	m, handler := newTestModel(t)
+
+	typeKeys(m, "/sTag")
+	if visible := m.Visible(); len(visible) != 1 || visible[0].Name != "staging" {
+		t.Errorf("Expected only staging to match, got %v", visible)
+	}
+	// Keys typed while filtering are not actions
+	if len(handler.actions) != 0 {
+		t.Errorf("Expected no actions while filtering, got %v", handler.actions)
+	}
+
+	m.Update(Key{Code: KeyBackspace})
+	m.Update(Key{Code: KeyEnter})
+	typeKeys(m, "q")
+	if !m.Done() {
+		t.Errorf("Expected q to quit after leaving the filter")
+	}
+}
+
+func TestModelRenameFollowsContext(t *testing.T) {
+	m, handler := newTestModel(t)
+
+	typeKeys(m, "r")
+	for range "dev" {
+		m.Update(Key{Code: KeyBackspace})
+	}
+	typeKeys(m, "zeta")
+	m.Update(Key{Code: KeyEnter})
+
+	if strings.Join(handler.actions, ",") != "rename dev zeta" {
+		t.Errorf("Unexpected actions %v", handler.actions)
+	}
+	if row, _ := m.Selected(); row.Name != "zeta" {
+		t.Errorf("Expected the cursor to follow the renamed context, got %s", row.Name)
+	}
+}
+
+func TestModelDeleteNeedsConfirmation(t *testing.T) {
+	m, handler := newTestModel(t)
+
+	typeKeys(m, "dn")
+	if len(handler.actions) != 0 || m.Status() != "Delete canceled" {
+		t.Errorf("Expected the delete to be canceled, got %v / %q", handler.actions, m.Status())
+	}
+
+	typeKeys(m, "dy")
+	if strings.Join(handler.actions, ",") != "delete dev" {
+		t.Errorf("Unexpected actions %v", handler.actions)
+	}
+	if visible := m.Visible(); len(visible) != 2 {
+		t.Errorf("Expected the rows to be reloaded, got %v", visible)
+	}
+}
+
+func TestModelExportAndErrors(t *testing.T) {
+	m, handler := newTestModel(t)
+
+	typeKeys(m, "e")
+	m.Update(Key{Code: KeyEnter})
+	if strings.Join(handler.actions, ",") != "export dev dev.kubeconfig" {
+		t.Errorf("Unexpected actions %v", handler.actions)
+	}
+
+	typeKeys(m, "a")
+	if m.Status() != "Error: no credentials" {
+		t.Errorf("Expected the error in the status line, got %q", m.Status())
+	}
+}
+
+func TestModelView(t *testing.T) {
+	m, _ := newTestModel(t)
+	typeKeys(m, "/prod")
+
+	view := m.View(100, 10)
+	lines := strings.Split(view, "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected the view to fill 10 lines, got %d:\n%s", len(lines), view)
+	}
+	if !strings.Contains(lines[1], "NAME") || !strings.Contains(lines[1], "WHITELISTED") {
+		t.Errorf("Expected the column header, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "* prod") || !strings.Contains(lines[2], "yes") {
+		t.Errorf("Expected the current, whitelisted prod row, got %q", lines[2])
+	}
+	if strings.Contains(view, "staging") {
+		t.Errorf("Expected staging to be filtered out:\n%s", view)
+	}
+	if lines[8] != "Filter: prod" {
+		t.Errorf("Expected the filter prompt, got %q", lines[8])
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		input string
+		key   Key
+	}{
+		{"j", Key{Code: KeyRune, Rune: 'j'}},
+		{"é", Key{Code: KeyRune, Rune: 'é'}},
+		{"\r", Key{Code: KeyEnter}},
+		{"\x7f", Key{Code: KeyBackspace}},
+		{"\t", Key{Code: KeyTab}},
+		{"\x03", Key{Code: KeyCtrlC}},
+		{"\x1b", Key{Code: KeyEsc}},
+		{"\x1b[A", Key{Code: KeyUp}},
+		{"\x1bOB", Key{Code: KeyDown}},
+		{"\x1b[6~", Key{Code: KeyPgDn}},
+		{"\x1b[15~", Key{Code: KeyUnknown}},
+	}
+
+	for _, tt := range tests {
+		key, err := ReadKey(bufio.NewReader(strings.NewReader(tt.input)))
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", tt.input, err)
+		}
+		if key != tt.key {
+			t.Errorf("%q: expected %+v, got %+v", tt.input, tt.key, key)
+		}
+	}
+}
+
+func TestRunNeedsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	m, _ := newTestModel(t)
+	if err := Run(f, os.Stdout, m); !errors.Is(err, ErrNotTerminal) {
+		t.Errorf("Expected ErrNotTerminal, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package usage records when each Kubernetes context was last used, and the
+// contexts switched away from. The data is collected by the track and switch
+// commands and read by commands that report or act on context age.
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+const (
+	// File permissions for the usage file
+	usageFileMode = 0600
+	usageDirMode  = 0700
+
+	// maxSwitches is how many switched-from contexts a Store keeps
+	maxSwitches = 10
+)
+
+// Store maps context names to the time they were last used.
+type Store struct {
+	LastUsed map[string]time.Time `json:"lastUsed"`
+	// Switches lists the contexts switched away from, most recent first
+	Switches []string `json:"switches,omitempty"`
+	path     string
+}
+
+// Load reads the usage file at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	store := &Store{LastUsed: make(map[string]time.Time), path: path}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Usage file path is derived from the user's home directory
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse usage file: %w", err)
+	}
+	if store.LastUsed == nil {
+		store.LastUsed = make(map[string]time.Time)
+	}
+	return store, nil
+}
+
+// Record marks the context as used at the given time.
+// Older timestamps never replace newer ones.
+func (s *Store) Record(contextName string, at time.Time) {
+	if previous, ok := s.LastUsed[contextName]; ok && previous.After(at) {
+		return
+	}
+	s.LastUsed[contextName] = at.UTC()
+}
+
+// Get returns when the context was last used, if it was ever recorded.
+func (s *Store) Get(contextName string) (time.Time, bool) {
+	t, ok := s.LastUsed[contextName]
+	return t, ok
+}
+
+// RecordSwitch records a switch away from the context from, making it the
+// context Previous returns. An empty name is ignored.
+func (s *Store) RecordSwitch(from string) {
+	if from == "" {
+		return
+	}
+	switches := []string{from}
+	for _, name := range s.Switches {
+		if name != from && len(switches) < maxSwitches {
+			switches = append(switches, name)
+		}
+	}
+	s.Switches = switches
+}
+
+// Previous returns the context last switched away from, or "" if none was recorded.
+func (s *Store) Previous() string {
+	if len(s.Switches) == 0 {
+		return ""
+	}
+	return s.Switches[0]
+}
+
+// Rename records that the context oldName is now called newName: its last
+// use moves to the new name, keeping the newer one if both were recorded,
+// and Previous returns the new name where it would have returned the old one.
+func (s *Store) Rename(oldName, newName string) {
+	if at, ok := s.LastUsed[oldName]; ok {
+		delete(s.LastUsed, oldName)
+		s.Record(newName, at)
+	}
+	switches := make([]string, 0, len(s.Switches))
+	for _, name := range s.Switches {
+		if name == oldName {
+			name = newName
+		}
+		if !slices.Contains(switches, name) {
+			switches = append(switches, name)
+		}
+	}
+	s.Switches = switches
+}
+
+// Save writes the store back to the file it was loaded from, replacing it
+// atomically so that a reader never sees it half written.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), usageDirMode); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	if err := kubeconfig.WriteFile(s.path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	return nil
+}
+
+// Update loads the store at path, lets update change it and saves it,
+// holding a lock on the file throughout, the same lock kubeconfigs are
+// changed under, so that concurrent processes never lose each other's
+// records.
+func Update(path string, update func(*Store)) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), usageDirMode); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	lock, err := kubeconfig.Lock(path)
+	if err != nil {
+		return fmt.Errorf("failed to lock usage file: %w", err)
+	}
+	defer func() {
+		if unlockErr := lock.Unlock(); unlockErr != nil && err == nil {
+			err = fmt.Errorf("failed to unlock usage file: %w", unlockErr)
+		}
+	}()
+
+	store, err := Load(path)
+	if err != nil {
+		return err
+	}
+	update(store)
+	return store.Save()
+}
@@ -0,0 +1,134 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "usage.json")
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of missing file failed: %v", err)
+	}
+	if _, ok := store.Get("prod"); ok {
+		t.Error("Expected no usage for an empty store")
+	}
+
+	newer := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	older := newer.Add(-time.Hour)
+	store.Record("prod", newer)
+	store.Record("prod", older)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	got, ok := reloaded.Get("prod")
+	if !ok || !got.Equal(newer) {
+		t.Errorf("Expected last use %v, got %v (recorded %v)", newer, got, ok)
+	}
+}
+
+func TestRecordSwitch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if store.Previous() != "" {
+		t.Error("Expected no previous context for an empty store")
+	}
+
+	store.RecordSwitch("dev")
+	store.RecordSwitch("prod")
+	store.RecordSwitch("dev")
+	store.RecordSwitch("")
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Previous() != "dev" || len(reloaded.Switches) != 2 || reloaded.Switches[1] != "prod" {
+		t.Errorf("Expected switches [dev prod], got %v", reloaded.Switches)
+	}
+
+	for i := 0; i < 2*maxSwitches; i++ {
+		reloaded.RecordSwitch(fmt.Sprintf("ctx-%d", i))
+	}
+	if len(reloaded.Switches) != maxSwitches {
+		t.Errorf("Expected at most %d switches, got %d", maxSwitches, len(reloaded.Switches))
+	}
+}
+
+func TestRename(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), "usage.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	used := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	store.Record("prod", used)
+	store.RecordSwitch("prod")
+	store.RecordSwitch("dev")
+	store.RecordSwitch("production")
+
+	store.Rename("prod", "production")
+	if len(store.Switches) != 2 || store.Previous() != "production" || store.Switches[1] != "dev" {
+		t.Errorf("Expected switches [production dev], got %v", store.Switches)
+	}
+	if _, ok := store.Get("prod"); ok {
+		t.Error("Expected no usage left under the old name")
+	}
+	if got, ok := store.Get("production"); !ok || !got.Equal(used) {
+		t.Errorf("Expected last use %v under the new name, got %v (recorded %v)", used, got, ok)
+	}
+}
+
+func TestUpdateConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	const writers = 20
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func(name string) {
+			errs <- Update(path, func(store *Store) {
+				store.Record(name, at)
+			})
+		}(fmt.Sprintf("ctx-%d", i))
+	}
+	for i := 0; i < writers; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Update failed: %v", err)
+		}
+	}
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(store.LastUsed) != writers {
+		t.Errorf("Expected every one of %d concurrent records to be kept, got %d", writers, len(store.LastUsed))
+	}
+}
@@ -13,7 +13,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/che-incubator/kubectx-manager/cmd"
@@ -21,7 +20,6 @@ import (
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cmd.ReportError(os.Stderr, err))
 	}
 }
@@ -0,0 +1,316 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// Package ctxmanager is the Go API of kubectx-manager. It loads, saves,
+// merges and backs up kubeconfig files and checks the authentication of
+// their contexts, the same way the kubectx-manager command does, so other
+// tools can embed it without shelling out.
+//
+// Nothing in this package reads os.Stdin or writes to os.Stdout or
+// os.Stderr; problems are returned as errors. Operations that wait, on a
+// lock or on a cluster, take a context.Context and stop when it is done.
+//
+// Writes go through the same safeguards as the command: files are replaced
+// atomically, Save fails with ErrModifiedSinceLoad if the file changed after
+// it was loaded and with ErrDrasticShrink if it would leave the file empty or
+// drastically smaller, and Update holds the kubeconfig lock shared with
+// concurrent kubectx-manager runs.
+package ctxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/che-incubator/kubectx-manager/internal/kubeconfig"
+)
+
+// Kubeconfig types
+type (
+	// Config is a parsed kubeconfig file
+	Config = kubeconfig.Config
+	// MultiConfig is the kubectl-style merged view of a KUBECONFIG list
+	MultiConfig = kubeconfig.MultiConfig
+	// NamedContext is a context entry of a kubeconfig
+	NamedContext = kubeconfig.NamedContext
+	// Context ties a cluster to a user and namespace
+	Context = kubeconfig.Context
+	// NamedCluster is a cluster entry of a kubeconfig
+	NamedCluster = kubeconfig.NamedCluster
+	// Cluster holds a cluster's server and TLS settings
+	Cluster = kubeconfig.Cluster
+	// NamedUser is a user entry of a kubeconfig
+	NamedUser = kubeconfig.NamedUser
+	// User holds a user's credentials
+	User = kubeconfig.User
+)
+
+// Operation types
+type (
+	// AuthCheckOptions tunes an auth check
+	AuthCheckOptions = kubeconfig.AuthCheckOptions
+	// AuthStatus is the outcome of an auth check
+	AuthStatus = kubeconfig.AuthStatus
+	// AuthState classifies the outcome of an auth check
+	AuthState = kubeconfig.AuthState
+	// MergeOptions controls how Merge resolves duplicate entries
+	MergeOptions = kubeconfig.MergeOptions
+	// MergeReport describes what Merge did with each incoming entry
+	MergeReport = kubeconfig.MergeReport
+	// Conflict is an incoming entry that differs from an existing one
+	Conflict = kubeconfig.Conflict
+	// DuplicateStrategy says what Merge does with a conflicting entry
+	DuplicateStrategy = kubeconfig.DuplicateStrategy
+	// DuplicateError is returned by Merge for conflicts under DuplicateFail
+	DuplicateError = kubeconfig.DuplicateError
+	// OrphanReport lists the clusters and users PruneOrphans removed
+	OrphanReport = kubeconfig.OrphanReport
+	// Backup is a timestamped backup of a kubeconfig
+	Backup = kubeconfig.Backup
+	// Locks is a set of kubeconfig locks held together
+	Locks = kubeconfig.Locks
+	// ProbeCache reuses recent probe results across auth checks
+	ProbeCache = kubeconfig.ProbeCache
+	// OutageLog records since when clusters have been unreachable
+	OutageLog = kubeconfig.OutageLog
+)
+
+// Duplicate strategies for Merge
+const (
+	DuplicateOverwrite = kubeconfig.DuplicateOverwrite
+	DuplicateKeep      = kubeconfig.DuplicateKeep
+	DuplicateRename    = kubeconfig.DuplicateRename
+	DuplicateFail      = kubeconfig.DuplicateFail
+)
+
+// Probe modes of an auth check
+const (
+	ProbeVersion = kubeconfig.ProbeVersion
+	ProbeAPI     = kubeconfig.ProbeAPI
+	ProbeTCP     = kubeconfig.ProbeTCP
+)
+
+// States of an auth check
+const (
+	AuthOK            = kubeconfig.AuthOK
+	AuthUnauthorized  = kubeconfig.AuthUnauthorized
+	AuthUnreachable   = kubeconfig.AuthUnreachable
+	AuthNoCredentials = kubeconfig.AuthNoCredentials
+	AuthExpired       = kubeconfig.AuthExpired
+	AuthInvalidCA     = kubeconfig.AuthInvalidCA
+)
+
+// Sentinel errors callers can test for with errors.Is.
+var (
+	ErrKubeconfigNotFound = kubeconfig.ErrKubeconfigNotFound
+	ErrModifiedSinceLoad  = kubeconfig.ErrModifiedSinceLoad
+	ErrBackupCorrupt      = kubeconfig.ErrBackupCorrupt
+	ErrConflict           = kubeconfig.ErrConflict
+	ErrLocked             = kubeconfig.ErrLocked
+	ErrDrasticShrink      = kubeconfig.ErrDrasticShrink
+)
+
+// Load reads and parses the kubeconfig at path.
+func Load(path string) (*Config, error) {
+	return kubeconfig.Load(path)
+}
+
+// LoadMulti loads the kubeconfig files at paths and merges them the way
+// kubectl merges a KUBECONFIG list.
+func LoadMulti(paths []string) (*MultiConfig, error) {
+	return kubeconfig.LoadMulti(paths)
+}
+
+// Marshal renders config as kubeconfig YAML.
+func Marshal(config *Config) ([]byte, error) {
+	return kubeconfig.Marshal(config)
+}
+
+// Save writes config to path atomically.
+func Save(config *Config, path string) error {
+	return kubeconfig.Save(config, path)
+}
+
+// SaveForce is Save that writes config even when the file would become
+// empty or drastically smaller.
+func SaveForce(config *Config, path string) error {
+	return kubeconfig.SaveForce(config, path)
+}
+
+// RemoveContexts removes the named contexts, along with clusters and users
+// no remaining context uses.
+func RemoveContexts(config *Config, names []string) error {
+	return kubeconfig.RemoveContexts(config, names)
+}
+
+// PruneOrphans removes the clusters and users no context uses, leaving the
+// contexts untouched.
+func PruneOrphans(config *Config) *OrphanReport {
+	return kubeconfig.PruneOrphans(config)
+}
+
+// Redact replaces the tokens, passwords, keys and other secrets of config
+// with REDACTED, for sharing it. Redact a copy: entries shared with another
+// config are modified too.
+func Redact(config *Config) {
+	kubeconfig.Redact(config)
+}
+
+// Sort orders the contexts, clusters and users of config by name, reporting
+// whether any entry moved.
+func Sort(config *Config) bool {
+	return kubeconfig.Sort(config)
+}
+
+// Merge adds the contexts, clusters and users of src to dst, resolving
+// entries that exist in both with different configuration as opts says.
+func Merge(dst, src *Config, opts MergeOptions) (*MergeReport, error) {
+	return kubeconfig.MergeWithOptions(dst, src, opts)
+}
+
+// ParseDuplicateStrategy validates a duplicate strategy name.
+func ParseDuplicateStrategy(name string) (DuplicateStrategy, error) {
+	return kubeconfig.ParseDuplicateStrategy(name)
+}
+
+// ParseAuthStates parses a comma-separated list of failed auth states, such
+// as "unauthorized,expired", for AuthCheckOptions.RemoveOn.
+func ParseAuthStates(value string) ([]AuthState, error) {
+	return kubeconfig.ParseAuthStates(value)
+}
+
+// CheckAuth checks the credentials of the named context and, unless
+// opts.Offline is set, probes its cluster. It stops when ctx is done.
+// The status gives the state the check ended in.
+func CheckAuth(ctx context.Context, config *Config, name string, opts AuthCheckOptions) AuthStatus {
+	return kubeconfig.CheckAuthContext(ctx, config, name, opts)
+}
+
+// LoadProbeCache reads the probe cache at path, keeping the results younger
+// than ttl, for AuthCheckOptions.Cache. Save it with ProbeCache.Save once
+// the checks are done.
+func LoadProbeCache(path string, ttl time.Duration) *ProbeCache {
+	return kubeconfig.LoadProbeCache(path, ttl)
+}
+
+// LoadOutageLog reads the outage log at path for AuthCheckOptions.Outages,
+// which AuthCheckOptions.UnreachableGrace needs. Save it with
+// OutageLog.Save once the checks are done.
+func LoadOutageLog(path string) (*OutageLog, error) {
+	return kubeconfig.LoadOutageLog(path)
+}
+
+// FindInvalidContexts checks every context of config and returns the status
+// of each one whose auth check failed, by context name. It returns ctx.Err()
+// if ctx is done before all contexts are checked.
+func FindInvalidContexts(ctx context.Context, config *Config, opts AuthCheckOptions) (map[string]AuthStatus, error) {
+	invalid := make(map[string]AuthStatus)
+	for _, name := range config.GetContextNames() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if status := kubeconfig.CheckAuthContext(ctx, config, name, opts); !status.Valid {
+			invalid[name] = status
+		}
+	}
+	// A probe cut short by ctx reports the cluster as unreachable
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return invalid, nil
+}
+
+// DefaultBackupDir returns the directory backups are written to by default,
+// kubectx-manager/backups under $XDG_DATA_HOME or ~/.local/share.
+func DefaultBackupDir() string {
+	return kubeconfig.DefaultBackupDir()
+}
+
+// CreateBackup copies the kubeconfig at path to a timestamped backup in a
+// subfolder of backupDir for that kubeconfig, or next to it when backupDir
+// is empty, and returns the backup's path.
+func CreateBackup(path, backupDir string) (string, error) {
+	return kubeconfig.CreateBackup(path, backupDir)
+}
+
+// FindBackups lists the backups of the kubeconfig at path, newest first,
+// both under backupDir and next to the kubeconfig.
+func FindBackups(path, backupDir string) ([]Backup, error) {
+	return kubeconfig.FindBackups(path, backupDir)
+}
+
+// RestoreBackup replaces the kubeconfig at path with the backup, holding
+// the kubeconfig lock while it does.
+func RestoreBackup(ctx context.Context, backupPath, path string) (err error) {
+	locks, err := Lock(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, locks.Unlock())
+	}()
+	return kubeconfig.RestoreBackup(backupPath, path)
+}
+
+// Lock takes the kubeconfig locks of paths, shared with concurrent
+// kubectx-manager runs, waiting until they are free or ctx is done.
+// Release them with Locks.Unlock.
+func Lock(ctx context.Context, paths ...string) (Locks, error) {
+	return kubeconfig.LockAllContext(ctx, paths)
+}
+
+// UpdateOptions controls Update.
+type UpdateOptions struct {
+	// BackupDir is where the backup is written, as for CreateBackup;
+	// set it to DefaultBackupDir() to share backups with the command
+	BackupDir string
+	// NoBackup skips the backup taken before the kubeconfig is written
+	NoBackup bool
+	// Force writes the result even when it leaves the kubeconfig empty or
+	// drastically smaller, which otherwise fails with ErrDrasticShrink
+	Force bool
+}
+
+// Update locks the kubeconfig at path, loads it, applies fn and saves the
+// result. Unless opts.NoBackup is set, a backup is taken before saving; its
+// path is returned. If fn fails, nothing is written.
+func Update(ctx context.Context, path string, opts UpdateOptions, fn func(*Config) error) (backupPath string, err error) {
+	locks, err := Lock(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, locks.Unlock())
+	}()
+
+	config, err := kubeconfig.Load(path)
+	if err != nil {
+		return "", err
+	}
+	if err := fn(config); err != nil {
+		return "", err
+	}
+	if !opts.NoBackup {
+		if backupPath, err = kubeconfig.CreateBackup(path, opts.BackupDir); err != nil {
+			return "", fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+	save := kubeconfig.Save
+	if opts.Force {
+		save = kubeconfig.SaveForce
+	}
+	if err := save(config, path); err != nil {
+		return backupPath, err
+	}
+	return backupPath, nil
+}
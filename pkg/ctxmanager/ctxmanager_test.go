@@ -0,0 +1,173 @@
+//
+// Copyright (c) 2025 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package ctxmanager_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/che-incubator/kubectx-manager/pkg/ctxmanager"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: keep
+contexts:
+- name: keep
+  context:
+    cluster: keep-cluster
+    user: keep-user
+- name: stale
+  context:
+    cluster: stale-cluster
+    user: stale-user
+clusters:
+- name: keep-cluster
+  cluster:
+    server: https://keep.example.com
+- name: stale-cluster
+  cluster:
+    server: https://stale.example.com
+users:
+- name: keep-user
+  user:
+    token: keep-token
+- name: stale-user
+  user: {}
+`
+
+func writeKubeconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestUpdateRemovesInvalidContexts(t *testing.T) {
+	path := writeKubeconfig(t)
+	ctx := context.Background()
+
+	backupPath, err := ctxmanager.Update(ctx, path, ctxmanager.UpdateOptions{}, func(config *ctxmanager.Config) error {
+		invalid, err := ctxmanager.FindInvalidContexts(ctx, config, ctxmanager.AuthCheckOptions{Offline: true})
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(invalid))
+		for name := range invalid {
+			names = append(names, name)
+		}
+		return ctxmanager.RemoveContexts(config, names)
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	config, err := ctxmanager.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to reload kubeconfig: %v", err)
+	}
+	if names := config.GetContextNames(); len(names) != 1 || names[0] != "keep" {
+		t.Errorf("Expected only the keep context to remain, got %v", names)
+	}
+
+	backups, err := ctxmanager.FindBackups(path, "")
+	if err != nil || len(backups) != 1 || backups[0].Path != backupPath {
+		t.Fatalf("Expected the backup %s to be found, got %v (%v)", backupPath, backups, err)
+	}
+	if err := ctxmanager.RestoreBackup(ctx, backupPath, path); err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if config, _ = ctxmanager.Load(path); len(config.GetContextNames()) != 2 {
+		t.Errorf("Expected the restored kubeconfig to have both contexts, got %v", config.GetContextNames())
+	}
+}
+
+func TestUpdateWritesNothingWhenFnFails(t *testing.T) {
+	path := writeKubeconfig(t)
+	wantErr := errors.New("abort")
+
+	backupPath, err := ctxmanager.Update(context.Background(), path, ctxmanager.UpdateOptions{}, func(config *ctxmanager.Config) error {
+		if err := ctxmanager.RemoveContexts(config, []string{"keep"}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Expected the callback's error, got %v", err)
+	}
+	if backupPath != "" {
+		t.Errorf("Expected no backup, got %s", backupPath)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != testKubeconfig {
+		t.Errorf("Expected the kubeconfig to be unchanged")
+	}
+}
+
+func TestUpdateRefusesEmptyingKubeconfig(t *testing.T) {
+	path := writeKubeconfig(t)
+	removeAll := func(config *ctxmanager.Config) error {
+		return ctxmanager.RemoveContexts(config, config.GetContextNames())
+	}
+
+	_, err := ctxmanager.Update(context.Background(), path, ctxmanager.UpdateOptions{NoBackup: true}, removeAll)
+	if !errors.Is(err, ctxmanager.ErrDrasticShrink) {
+		t.Fatalf("Expected ErrDrasticShrink, got %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != testKubeconfig {
+		t.Errorf("Expected the kubeconfig to be unchanged")
+	}
+
+	if _, err := ctxmanager.Update(context.Background(), path, ctxmanager.UpdateOptions{NoBackup: true, Force: true}, removeAll); err != nil {
+		t.Fatalf("Expected Force to empty the kubeconfig, got %v", err)
+	}
+	if config, err := ctxmanager.Load(path); err != nil || len(config.GetContextNames()) != 0 {
+		t.Errorf("Expected no context left (err %v)", err)
+	}
+}
+
+func TestLockHonorsContext(t *testing.T) {
+	path := writeKubeconfig(t)
+	locks, err := ctxmanager.Lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	defer locks.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ctxmanager.Update(ctx, path, ctxmanager.UpdateOptions{}, func(*ctxmanager.Config) error {
+		t.Error("Expected Update not to run while the kubeconfig is locked")
+		return nil
+	}); err == nil {
+		t.Error("Expected Update to fail once ctx is done")
+	}
+}
+
+func TestFindInvalidContextsHonorsContext(t *testing.T) {
+	config, err := ctxmanager.Load(writeKubeconfig(t))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ctxmanager.FindInvalidContexts(ctx, config, ctxmanager.AuthCheckOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}